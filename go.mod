@@ -11,6 +11,7 @@ require (
 	github.com/mattn/go-sixel v0.0.5
 	github.com/rivo/tview v0.0.0-20241227133733-17b7edb88c57
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/crypto v0.40.0
 	golang.org/x/image v0.21.0
 	golang.org/x/text v0.27.0
 	modernc.org/sqlite v1.38.2
@@ -38,7 +39,7 @@ require (
 	github.com/tetratelabs/wazero v1.8.1 // indirect
 	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
 	golang.org/x/sys v0.34.0 // indirect
-	golang.org/x/term v0.18.0 // indirect
+	golang.org/x/term v0.33.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	modernc.org/libc v1.66.3 // indirect
 	modernc.org/mathutil v1.7.1 // indirect
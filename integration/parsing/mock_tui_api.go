@@ -42,6 +42,15 @@ func (m *MockTuiAPI) OnConnectionError(err error) {
 	}
 }
 
+// OnConnectionStateChanged implements TuiAPI interface
+func (m *MockTuiAPI) OnConnectionStateChanged(state api.ConnectionState) {
+	call := fmt.Sprintf("OnConnectionStateChanged(state=%s)", state)
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
 // OnData implements TuiAPI interface - no-op for transcript tests
 func (m *MockTuiAPI) OnData(data []byte) {
 	// No-op - don't capture OnData calls to focus on other API calls
@@ -134,6 +143,51 @@ func (m *MockTuiAPI) OnSectorUpdated(sectorInfo api.SectorInfo) {
 	}
 }
 
+// OnSectorWarpsUpdated implements TuiAPI interface
+func (m *MockTuiAPI) OnSectorWarpsUpdated(sector int, warps [6]int) {
+	call := fmt.Sprintf("OnSectorWarpsUpdated(sector=%d, warps=%v)", sector, warps)
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
+// OnMapInvalidated implements TuiAPI interface
+func (m *MockTuiAPI) OnMapInvalidated(sectors []int) {
+	call := fmt.Sprintf("OnMapInvalidated(sectors=%v)", sectors)
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
+// OnCoursePlotted implements TuiAPI interface
+func (m *MockTuiAPI) OnCoursePlotted(sectors []int) {
+	call := fmt.Sprintf("OnCoursePlotted(sectors=%v)", sectors)
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
+// OnGameDetectionFailed implements TuiAPI interface
+func (m *MockTuiAPI) OnGameDetectionFailed(serverHost, serverPort string) {
+	call := fmt.Sprintf("OnGameDetectionFailed(host=%s, port=%s)", serverHost, serverPort)
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
+// OnGameSelectionMenu implements TuiAPI interface
+func (m *MockTuiAPI) OnGameSelectionMenu(menu api.GameMenuInfo) {
+	call := fmt.Sprintf("OnGameSelectionMenu(host=%s, port=%s, games=%d)", menu.ServerHost, menu.ServerPort, len(menu.Games))
+	m.calls = append(m.calls, call)
+	if m.t != nil {
+		m.t.Logf("MockTuiAPI: %s", call)
+	}
+}
+
 // GetCallsAsString returns all calls as a single string for easy validation
 func (m *MockTuiAPI) GetCallsAsString() string {
 	return strings.Join(m.calls, "\n")
@@ -53,6 +53,11 @@ func TestProbeDataParsing(t *testing.T) {
 	// The command prompt shows sector 190, so current sector should be 190
 	result.Assert.AssertCurrentSector(190)
 
+	// Verify probe warps were reported to the TUI for non-current sectors
+	if warps, ok := result.TuiAPI.SectorWarpsCalls[274]; !ok || warps[0] != 174 {
+		t.Errorf("Expected OnSectorWarpsUpdated for probed sector 274 with warp to 174, got %v (reported=%t)", warps, ok)
+	}
+
 	// Verify that player credits weren't zeroed out by the parsing
 	result.Assert.AssertPlayerCredits(testCredits)
 
@@ -59,6 +59,14 @@ func TestSectorMove(t *testing.T) {
 		t.Errorf("Expected at least 2 OnCurrentSectorChanged calls, got %d", len(sectorChangeCalls))
 	}
 
+	// A move is reported once: the sector display and the command prompt after it,
+	// and the re-display of 705, must not repeat the change event
+	for i := 1; i < len(sectorChangeCalls); i++ {
+		if sectorChangeCalls[i].Number == sectorChangeCalls[i-1].Number {
+			t.Errorf("Duplicate OnCurrentSectorChanged call for sector %d at %d", sectorChangeCalls[i].Number, i)
+		}
+	}
+
 	// Check that we got calls for both sectors in the right order
 	found705 := false
 	found279 := false
@@ -0,0 +1,28 @@
+package scripting
+
+import (
+	"reflect"
+	"testing"
+	"twist/internal/api"
+)
+
+// TestConnectionStateLifecycle verifies the proxy reports each step of a connection once,
+// even though disconnecting also ends the read loop with an error
+func TestConnectionStateLifecycle(t *testing.T) {
+	serverScript := `send "Command [TL=00:00:01]:[1] (?=Help)? : "`
+	clientScript := `expect "Command"`
+
+	result := Execute(t, serverScript, clientScript, nil)
+	defer result.Database.Close()
+
+	result.TuiAPI.ConnectionStatesMutex.Lock()
+	defer result.TuiAPI.ConnectionStatesMutex.Unlock()
+	expected := []api.ConnectionState{
+		api.ConnectionStateConnecting,
+		api.ConnectionStateConnected,
+		api.ConnectionStateDisconnected,
+	}
+	if !reflect.DeepEqual(result.TuiAPI.ConnectionStates, expected) {
+		t.Errorf("Expected connection states %v, got %v", expected, result.TuiAPI.ConnectionStates)
+	}
+}
@@ -1,8 +1,11 @@
 package scripting
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGotoCommand_RealIntegration(t *testing.T) {
@@ -929,6 +932,32 @@ func TestMacroPreprocessor_ErrorHandling_RealIntegration(t *testing.T) {
 	}
 }
 
+// TestMacroPreprocessor_WhileTimeout tests that a WHILE loop that never ends fails fast
+func TestMacroPreprocessor_WhileTimeout_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+	tester.SetTimeout(100 * time.Millisecond)
+
+	script := `
+		setVar $counter 1
+		while ($counter > 0)
+			add $counter 1
+		end
+		echo "Never reached"
+	`
+
+	start := time.Now()
+	result := tester.ExecuteScript(script)
+	if !errors.Is(result.Error, context.DeadlineExceeded) {
+		t.Fatalf("Expected the endless loop to hit the timeout, got %v", result.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the loop to stop soon after the timeout, took %v", elapsed)
+	}
+	if len(result.Output) != 0 {
+		t.Errorf("Expected no output past the loop, got %v", result.Output)
+	}
+}
+
 // TestMacroPreprocessor_SST_Pattern tests a pattern from the 1_SST.ts script
 func TestMacroPreprocessor_SST_Pattern_RealIntegration(t *testing.T) {
 	tester := NewIntegrationScriptTester(t)
@@ -497,6 +497,166 @@ func TestGetSectorCommand_NonExistentSector_RealIntegration(t *testing.T) {
 	}
 }
 
+// TestSectorQueryCommands_RealIntegration tests the single-value sector queries against a real database
+func TestSectorQueryCommands_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	if err := tester.setupData.DB.SaveSector(createTestSector(), 1234); err != nil {
+		t.Fatalf("Failed to save test sector: %v", err)
+	}
+	if err := tester.setupData.DB.SavePort(database.TPort{Name: "Sol Depot", ClassIndex: 1, UpDate: time.Now()}, 1234); err != nil {
+		t.Fatalf("Failed to save test port: %v", err)
+	}
+	if err := tester.setupData.DB.SaveSector(createTestSector(), 1235); err != nil {
+		t.Fatalf("Failed to save test sector: %v", err)
+	}
+
+	script := `
+		getSectorPort 1234 $class $name
+		if ($class = 1)
+			echo "Class 1 port: " $name
+		end
+		getSectorWarps 1234 $count $warps
+		echo "Warps: " $count " first " $warps[1] " last " $warps[$count]
+		getSectorDensity 1234 $density
+		echo "Density: " $density
+		getSectorPort 1235 $none
+		echo "No port: " $none
+		getSectorWarps 999 $unknown
+		getSectorDensity 999 $unknownDensity
+		echo "Unknown: " $unknown " " $unknownDensity
+	`
+
+	result := tester.ExecuteScript(script)
+	if result.Error != nil {
+		t.Errorf("Script execution failed: %v", result.Error)
+	}
+
+	expectedOutputs := []string{
+		"Class 1 port: Sol Depot",
+		"Warps: 3 first 2 last 4",
+		"Density: 45",
+		"No port: 0",
+		"Unknown: 0 -1",
+	}
+
+	if len(result.Output) != len(expectedOutputs) {
+		t.Errorf("Expected %d output lines, got %d: %v", len(expectedOutputs), len(result.Output), result.Output)
+	}
+	for i, expected := range expectedOutputs {
+		if i < len(result.Output) && result.Output[i] != expected {
+			t.Errorf("Output line %d: got %q, want %q", i, result.Output[i], expected)
+		}
+	}
+}
+
+// TestGetCourse_RealIntegration tests plotting courses through saved warps and the avoid list
+func TestGetCourse_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	// 1 -> 2 -> 3 -> 5 and 1 -> 4 -> 5, with sector 6 cut off
+	warps := map[int][6]int{1: {2, 4}, 2: {3}, 3: {5}, 4: {5}, 5: {1}, 6: {}}
+	for index, warp := range warps {
+		sector := createTestSector()
+		sector.Warp = warp
+		if err := tester.setupData.DB.SaveSector(sector, index); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", index, err)
+		}
+	}
+
+	script := `
+		getCourse 1 5 $hops $course $err
+		echo "Hops: " $hops " via " $course[2] " to " $course[3] " error '" $err "'"
+		getCourse 1 6 $hops $course $err
+		echo "Unreachable: " $hops " " $err
+		getCourse 3 3 $here $course
+		echo "Here: " $here " " $course[1]
+	`
+
+	result := tester.ExecuteScript(script)
+	if result.Error != nil {
+		t.Errorf("Script execution failed: %v", result.Error)
+	}
+
+	if err := tester.setupData.DB.AddAvoid(4); err != nil {
+		t.Fatalf("Failed to add avoid: %v", err)
+	}
+	avoided := tester.ExecuteScript(`
+		getCourse 1 5 $hops $course
+		echo "Avoiding 4: " $hops " via " $course[2] " " $course[3]
+		getCourse 1 4 $hops $course $err
+		echo "To avoided: " $hops " " $err
+	`)
+	if avoided.Error != nil {
+		t.Errorf("Script execution failed: %v", avoided.Error)
+	}
+
+	expectedOutputs := []string{
+		"Hops: 2 via 4 to 5 error ''",
+		"Unreachable: -1 no path",
+		"Here: 0 3",
+		"Avoiding 4: 3 via 2 3",
+		"To avoided: -1 no path (avoids block route)",
+	}
+	output := append(result.Output, avoided.Output...)
+	if len(output) != len(expectedOutputs) {
+		t.Errorf("Expected %d output lines, got %d: %v", len(expectedOutputs), len(output), output)
+	}
+	for i, expected := range expectedOutputs {
+		if i < len(output) && output[i] != expected {
+			t.Errorf("Output line %d: got %q, want %q", i, output[i], expected)
+		}
+	}
+}
+
+// TestIsSectorExplored_RealIntegration tests isSectorExplored against visited, density-scanned and unknown sectors
+func TestIsSectorExplored_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	visited := createTestSector()
+	visited.Explored = database.EtHolo
+	if err := tester.setupData.DB.SaveSector(visited, 1234); err != nil {
+		t.Fatalf("Failed to save test sector: %v", err)
+	}
+	if err := tester.setupData.DB.SaveSector(createTestSector(), 1235); err != nil {
+		t.Fatalf("Failed to save test sector: %v", err)
+	}
+
+	script := `
+		isSectorExplored 1234 $visited $visitedLevel
+		echo "Visited: " $visited " " $visitedLevel
+		isSectorExplored 1235 $scanned $scannedLevel
+		echo "Scanned: " $scanned " " $scannedLevel
+		isSectorExplored 999 $unknown $unknownLevel
+		echo "Unknown: " $unknown " " $unknownLevel
+		isSectorExplored 1235 $explored
+		if ($explored = 0)
+			echo "Worth a visit"
+		end
+	`
+
+	result := tester.ExecuteScript(script)
+	if result.Error != nil {
+		t.Errorf("Script execution failed: %v", result.Error)
+	}
+
+	expectedOutputs := []string{
+		"Visited: 1 YES",
+		"Scanned: 0 DENSITY",
+		"Unknown: 0 NO",
+		"Worth a visit",
+	}
+
+	if len(result.Output) != len(expectedOutputs) {
+		t.Errorf("Expected %d output lines, got %d: %v", len(expectedOutputs), len(result.Output), result.Output)
+	}
+	for i, expected := range expectedOutputs {
+		if i < len(result.Output) && result.Output[i] != expected {
+			t.Errorf("Output line %d: got %q, want %q", i, result.Output[i], expected)
+		}
+	}
+}
+
 // TestGetSectorCommand_ZeroIndex tests getSector with zero index (should be ignored)
 func TestGetSectorCommand_ZeroIndex_RealIntegration(t *testing.T) {
 	tester := NewIntegrationScriptTester(t)
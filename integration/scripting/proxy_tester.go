@@ -317,11 +317,11 @@ func Execute(t *testing.T, serverScript, clientScript string, connectOpts *api.C
 	}
 	address := fmt.Sprintf("localhost:%d", port)
 	
-	var proxyInstance api.ProxyAPI
-	
-	// Call factory.Connect in goroutine like real app would
+	// Call factory.Connect in goroutine like real app would; the connection callback fires
+	// before Connect returns, so the proxy is handed back over a channel
+	proxyReady := make(chan api.ProxyAPI, 1)
 	go func() {
-		proxyInstance = factory.Connect(address, trackingTuiAPI, connectOpts)
+		proxyReady <- factory.Connect(address, trackingTuiAPI, connectOpts)
 	}()
 
 	// Wait for connection callback (factory will call OnConnectionStatusChanged)
@@ -331,6 +331,12 @@ func Execute(t *testing.T, serverScript, clientScript string, connectOpts *api.C
 	case <-time.After(2 * time.Second):
 		t.Fatalf("Timeout waiting for proxy connection")
 	}
+	var proxyInstance api.ProxyAPI
+	select {
+	case proxyInstance = <-proxyReady:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timeout waiting for proxy connection")
+	}
 
 	// Set the input sender for client expect engine - this simulates user typing
 	clientExpectEngine.inputSender = func(input string) {
@@ -411,6 +417,7 @@ type TestTuiAPI struct {
 
 func (t *TestTuiAPI) OnConnectionStatusChanged(status api.ConnectionStatus, address string) {}
 func (t *TestTuiAPI) OnConnectionError(err error)                                           {}
+func (t *TestTuiAPI) OnConnectionStateChanged(state api.ConnectionState)                    {}
 func (t *TestTuiAPI) OnData(data []byte) {
 	if t.expectEngine != nil {
 		t.expectEngine.AddOutput(string(data))
@@ -429,6 +436,10 @@ type TrackingSectorChangeTuiAPI struct {
 	SectorChangeCalls     []api.SectorInfo
 	PlayerStatsCallsMutex sync.Mutex
 	PlayerStatsCalls      []api.PlayerStatsInfo
+	SectorWarpsCalls      map[int][6]int // Latest warps reported per sector
+	MapInvalidatedCalls   [][]int        // Sectors of each bulk change, in the order reported
+	ConnectionStatesMutex sync.Mutex
+	ConnectionStates      []api.ConnectionState // Connection lifecycle, in the order reported
 	ConnectionReady       chan bool
 	DisconnectionReady    chan bool
 }
@@ -449,6 +460,12 @@ func (t *TrackingSectorChangeTuiAPI) OnConnectionStatusChanged(status api.Connec
 	}
 }
 
+func (t *TrackingSectorChangeTuiAPI) OnConnectionStateChanged(state api.ConnectionState) {
+	t.ConnectionStatesMutex.Lock()
+	defer t.ConnectionStatesMutex.Unlock()
+	t.ConnectionStates = append(t.ConnectionStates, state)
+}
+
 func (t *TrackingSectorChangeTuiAPI) OnCurrentSectorChanged(sectorInfo api.SectorInfo) {
 	t.SectorChangeCalls = append(t.SectorChangeCalls, sectorInfo)
 }
@@ -467,6 +484,29 @@ func (t *TrackingSectorChangeTuiAPI) OnSectorUpdated(sectorInfo api.SectorInfo)
 	// Mock implementation - could store sector info if needed for tests
 }
 
+func (t *TrackingSectorChangeTuiAPI) OnSectorWarpsUpdated(sector int, warps [6]int) {
+	if t.SectorWarpsCalls == nil {
+		t.SectorWarpsCalls = make(map[int][6]int)
+	}
+	t.SectorWarpsCalls[sector] = warps
+}
+
+func (t *TrackingSectorChangeTuiAPI) OnMapInvalidated(sectors []int) {
+	t.MapInvalidatedCalls = append(t.MapInvalidatedCalls, sectors)
+}
+
+func (t *TrackingSectorChangeTuiAPI) OnCoursePlotted(sectors []int) {
+	// Mock implementation - the map is not tracked
+}
+
+func (t *TrackingSectorChangeTuiAPI) OnGameDetectionFailed(serverHost, serverPort string) {
+	// Mock implementation - tests connect with a forced database path
+}
+
+func (t *TrackingSectorChangeTuiAPI) OnGameSelectionMenu(menu api.GameMenuInfo) {
+	// Mock implementation - tests connect with a forced database path
+}
+
 // ExpectTelnetServer - Telnet server with server-side expect script support for black-box testing
 type ExpectTelnetServer struct {
 	t              *testing.T
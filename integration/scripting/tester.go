@@ -1,6 +1,7 @@
 package scripting
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -80,6 +81,10 @@ func (ts *MockScript) Stop() error {
 	return nil
 }
 
+// defaultScriptTimeout bounds each ExecuteScript/ContinueExecution call, so a script stuck
+// in a loop fails its own test quickly instead of hanging the whole suite
+const defaultScriptTimeout = 5 * time.Second
+
 // IntegrationScriptTester provides real integration testing for TWX scripts
 type IntegrationScriptTester struct {
 	setupData        *setup.IntegrationTestSetup
@@ -87,18 +92,45 @@ type IntegrationScriptTester struct {
 	currentScript    *scripting.Script
 	capturedOutput   []string
 	capturedCommands []string
+
+	// Each tester owns its context; it's cancelled when the test ends, stopping any
+	// async execution goroutine it started
+	ctx     context.Context
+	timeout time.Duration
 }
 
 // NewIntegrationScriptTester creates a new integration script tester with real components
 func NewIntegrationScriptTester(t *testing.T) *IntegrationScriptTester {
 	setupData := setup.SetupRealComponents(t)
 
+	return newIntegrationScriptTester(t, setupData)
+}
+
+// newIntegrationScriptTester creates a tester for setupData whose context ends with the test
+func newIntegrationScriptTester(t *testing.T, setupData *setup.IntegrationTestSetup) *IntegrationScriptTester {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
 	return &IntegrationScriptTester{
 		setupData: setupData,
 		t:         t,
+		ctx:       ctx,
+		timeout:   defaultScriptTimeout,
 	}
 }
 
+// SetTimeout changes how long a single execution may run before it fails
+func (tester *IntegrationScriptTester) SetTimeout(timeout time.Duration) {
+	tester.timeout = timeout
+}
+
+// execute runs the loaded script until it finishes, waits, or the timeout passes
+func (tester *IntegrationScriptTester) execute() error {
+	ctx, cancel := context.WithTimeout(tester.ctx, tester.timeout)
+	defer cancel()
+	return tester.setupData.VM.ExecuteContext(ctx)
+}
+
 // NewIntegrationScriptTesterWithSharedDB creates a tester that shares a database with another tester
 func NewIntegrationScriptTesterWithSharedDB(t *testing.T, sharedSetup *setup.IntegrationTestSetup) *IntegrationScriptTester {
 	// Create real game adapter using the shared database
@@ -114,15 +146,8 @@ func NewIntegrationScriptTesterWithSharedDB(t *testing.T, sharedSetup *setup.Int
 		DBPath:      sharedSetup.DBPath, // Share the same DB path
 	}
 
-	// Register cleanup for VM only (don't clean up shared DB)
-	t.Cleanup(func() {
-		// Only cleanup VM resources, not the shared database
-	})
-
-	return &IntegrationScriptTester{
-		setupData: newSetup,
-		t:         t,
-	}
+	// Cleanup only stops this tester's execution, not the shared database
+	return newIntegrationScriptTester(t, newSetup)
 }
 
 // ExecuteScript executes a TWX script and returns the results
@@ -175,7 +200,7 @@ func (tester *IntegrationScriptTester) ExecuteScript(script string) *Integration
 		}
 	}
 
-	if err := tester.setupData.VM.Execute(); err != nil {
+	if err := tester.execute(); err != nil {
 		return &IntegrationTestResult{
 			Output:   append([]string{}, tester.capturedOutput...),
 			Commands: append([]string{}, tester.capturedCommands...),
@@ -290,7 +315,7 @@ func (tester *IntegrationScriptTester) ContinueExecution() *IntegrationTestResul
 	tester.capturedCommands = []string{}
 
 	// Continue execution
-	err := tester.currentScript.VM.Execute()
+	err := tester.execute()
 
 	return &IntegrationTestResult{
 		Output:   append([]string{}, tester.capturedOutput...),
@@ -351,13 +376,13 @@ func (tester *IntegrationScriptTester) ExecuteScriptAsync(script string) (<-chan
 		}
 
 		// Execute until completion or waiting
-		if err := tester.setupData.VM.Execute(); err != nil {
+		if err := tester.execute(); err != nil {
 			return
 		}
 
 		// If we're waiting, the script will continue via ProcessIncomingText calls
-		// The goroutine will remain alive until the script completes or times out
-		for tester.setupData.VM.IsWaiting() {
+		// The goroutine will remain alive until the script completes or the test ends
+		for tester.setupData.VM.IsWaiting() && tester.ctx.Err() == nil {
 			// Keep the goroutine alive while waiting
 			// ProcessIncomingText will resume execution and eventually complete
 			time.Sleep(1 * time.Millisecond)
@@ -379,6 +404,21 @@ func (tester *IntegrationScriptTester) SimulateNetworkInput(text string) error {
 	return tester.setupData.VM.ProcessIncomingText(text)
 }
 
+// SimulateSectorComplete simulates the parser completing a sector for sector trigger processing
+func (tester *IntegrationScriptTester) SimulateSectorComplete(sector int) error {
+	return tester.setupData.VM.ProcessSectorComplete(sector)
+}
+
+// SimulateSectorArrival simulates the player arriving in a sector for arrival trigger processing
+func (tester *IntegrationScriptTester) SimulateSectorArrival(sector int) error {
+	return tester.setupData.VM.ProcessSectorArrival(sector)
+}
+
+// SimulateEvent simulates a program event, such as the parser sighting aliens, for event trigger processing
+func (tester *IntegrationScriptTester) SimulateEvent(eventName string) error {
+	return tester.setupData.VM.ProcessEvent(eventName)
+}
+
 // parseScriptWithPreprocessor parses script source code using the same pipeline as the engine
 // This mirrors the parseScriptWithBasePath method from the engine but without file path handling
 func (tester *IntegrationScriptTester) parseScriptWithPreprocessor(source string) (*parser.ASTNode, error) {
@@ -162,6 +162,125 @@ func TestSetEventTrigger_RealIntegration(t *testing.T) {
 	// In a real system, we would fire the event and verify the trigger response
 }
 
+// TestSetEventTrigger_FerrengiSighted tests that an event trigger jumps to its label when its event fires
+func TestSetEventTrigger_FerrengiSighted(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	script := `
+		setEventTrigger "Ferrengi sighted" "" :avoid
+		echo "Trigger set"
+		pause
+
+		:avoid
+		echo "Avoiding Ferrengi"
+		pause
+	`
+
+	result := tester.ExecuteScript(script)
+	tester.AssertNoError(result)
+	tester.AssertOutput(result, []string{"Trigger set"})
+
+	if err := tester.SimulateEvent("Aliens sighted"); err != nil {
+		t.Fatalf("Failed to simulate aliens event: %v", err)
+	}
+	if err := tester.SimulateEvent("Ferrengi sighted"); err != nil {
+		t.Fatalf("Failed to simulate Ferrengi event: %v", err)
+	}
+
+	expected := []string{"Trigger set", "Avoiding Ferrengi"}
+	if len(tester.capturedOutput) != len(expected) {
+		t.Fatalf("Expected output %q, got %q", expected, tester.capturedOutput)
+	}
+	for i := range expected {
+		if tester.capturedOutput[i] != expected[i] {
+			t.Errorf("Output line %d: got %q, want %q", i, tester.capturedOutput[i], expected[i])
+		}
+	}
+}
+
+// TestSetSectorTrigger_RealIntegration tests that SETSECTORTRIGGER passes each completed sector to the script
+func TestSetSectorTrigger_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	script := `
+		setSectorTrigger 1 :new_sector $sector
+		echo "Trigger set"
+		pause
+
+		:new_sector
+		echo "Completed " $sector
+		pause
+	`
+
+	result := tester.ExecuteScript(script)
+	tester.AssertNoError(result)
+	tester.AssertOutput(result, []string{"Trigger set"})
+
+	for _, sector := range []int{705, 279} {
+		if err := tester.SimulateSectorComplete(sector); err != nil {
+			t.Fatalf("Failed to simulate sector %d completing: %v", sector, err)
+		}
+	}
+
+	expected := []string{"Trigger set", "Completed 705", "Completed 279"}
+	if len(tester.capturedOutput) != len(expected) {
+		t.Fatalf("Expected output %q, got %q", expected, tester.capturedOutput)
+	}
+	for i := range expected {
+		if tester.capturedOutput[i] != expected[i] {
+			t.Errorf("Output line %d: got %q, want %q", i, tester.capturedOutput[i], expected[i])
+		}
+	}
+
+	// A killed sector trigger no longer fires
+	if err := tester.setupData.VM.KillTrigger("1"); err != nil {
+		t.Fatalf("Failed to kill trigger: %v", err)
+	}
+	if err := tester.SimulateSectorComplete(1); err != nil {
+		t.Fatalf("Failed to simulate sector completing: %v", err)
+	}
+	if len(tester.capturedOutput) != len(expected) {
+		t.Errorf("Expected no output after killTrigger, got %q", tester.capturedOutput[len(expected):])
+	}
+}
+
+// TestSetArrivalTrigger_RealIntegration tests that SETARRIVALTRIGGER only fires on arrival, not on completed sectors
+func TestSetArrivalTrigger_RealIntegration(t *testing.T) {
+	tester := NewIntegrationScriptTester(t)
+
+	script := `
+		setArrivalTrigger 1 :arrived $sector
+		echo "Trigger set"
+		pause
+
+		:arrived
+		echo "Arrived in " $sector
+		pause
+	`
+
+	result := tester.ExecuteScript(script)
+	tester.AssertNoError(result)
+	tester.AssertOutput(result, []string{"Trigger set"})
+
+	// A sector completing, e.g. from a probe, isn't an arrival
+	if err := tester.SimulateSectorComplete(40); err != nil {
+		t.Fatalf("Failed to simulate sector completing: %v", err)
+	}
+	if err := tester.SimulateSectorArrival(705); err != nil {
+		t.Fatalf("Failed to simulate arriving in sector: %v", err)
+	}
+
+	expected := []string{"Trigger set", "Arrived in 705"}
+	if len(tester.capturedOutput) != len(expected) {
+		t.Fatalf("Expected output %q, got %q", expected, tester.capturedOutput)
+	}
+	for i := range expected {
+		if tester.capturedOutput[i] != expected[i] {
+			t.Errorf("Output line %d: got %q, want %q", i, tester.capturedOutput[i], expected[i])
+		}
+	}
+}
+
 // TestKillTrigger_RealIntegration tests KILLTRIGGER command
 func TestKillTrigger_RealIntegration(t *testing.T) {
 	tester := NewIntegrationScriptTester(t)
@@ -0,0 +1,146 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ColorState tracks the colors and attributes set by SGR sequences (ESC [ ... m) in a stream,
+// so a piece cut out of the stream can be shown in the color it had. Like StreamingStripper,
+// it holds a sequence split across chunks until the rest arrives.
+type ColorState struct {
+	foreground string // SGR parameters of the foreground color, empty for the default
+	background string // SGR parameters of the background color, empty for the default
+	bold       bool
+	underline  bool
+	blink      bool
+	reverse    bool
+
+	pending string // Incomplete escape sequence at the end of the last chunk
+}
+
+// Write advances the state past a chunk of the stream
+func (c *ColorState) Write(text string) {
+	text = c.pending + text
+	c.pending = ""
+
+	for {
+		start := strings.IndexByte(text, '\x1b')
+		if start == -1 {
+			return
+		}
+		text = text[start:]
+		if len(text) < 2 {
+			c.pending = text
+			return
+		}
+		if text[1] != '[' {
+			text = text[1:]
+			continue
+		}
+
+		end := strings.IndexFunc(text[2:], func(r rune) bool {
+			return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		})
+		if end == -1 {
+			c.pending = text
+			return
+		}
+		end += 2
+		if text[end] == 'm' {
+			c.apply(text[2:end])
+		}
+		text = text[end+1:]
+	}
+}
+
+// apply updates the state with the parameters of one SGR sequence
+func (c *ColorState) apply(params string) {
+	parts := strings.Split(params, ";")
+	for i := 0; i < len(parts); i++ {
+		code, err := strconv.Atoi(parts[i])
+		if parts[i] == "" {
+			code, err = 0, nil
+		}
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			c.Reset()
+		case code == 1:
+			c.bold = true
+		case code == 4:
+			c.underline = true
+		case code == 5:
+			c.blink = true
+		case code == 7:
+			c.reverse = true
+		case code == 22:
+			c.bold = false
+		case code == 24:
+			c.underline = false
+		case code == 25:
+			c.blink = false
+		case code == 27:
+			c.reverse = false
+		case (code >= 30 && code <= 37) || (code >= 90 && code <= 97):
+			c.foreground = parts[i]
+		case (code >= 40 && code <= 47) || (code >= 100 && code <= 107):
+			c.background = parts[i]
+		case code == 39:
+			c.foreground = ""
+		case code == 49:
+			c.background = ""
+		case code == 38 || code == 48:
+			// Extended colors: 5;n for the 256 color palette, 2;r;g;b for true color
+			n := 0
+			if i+1 < len(parts) && parts[i+1] == "5" {
+				n = 2
+			} else if i+1 < len(parts) && parts[i+1] == "2" {
+				n = 4
+			}
+			if n == 0 || i+n >= len(parts) {
+				return
+			}
+			color := strings.Join(parts[i:i+n+1], ";")
+			if code == 38 {
+				c.foreground = color
+			} else {
+				c.background = color
+			}
+			i += n
+		}
+	}
+}
+
+// Prefix returns the SGR sequence that resets the terminal and then sets the current colors
+// and attributes, so text following it looks as it did in the stream
+func (c *ColorState) Prefix() string {
+	params := []string{"0"}
+	if c.bold {
+		params = append(params, "1")
+	}
+	if c.underline {
+		params = append(params, "4")
+	}
+	if c.blink {
+		params = append(params, "5")
+	}
+	if c.reverse {
+		params = append(params, "7")
+	}
+	if c.foreground != "" {
+		params = append(params, c.foreground)
+	}
+	if c.background != "" {
+		params = append(params, c.background)
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// Reset returns to the default colors, for a new connection
+func (c *ColorState) Reset() {
+	*c = ColorState{}
+}
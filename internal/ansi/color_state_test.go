@@ -0,0 +1,44 @@
+package ansi
+
+import "testing"
+
+func TestColorState_Prefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"no color", "Command [TL=00:00:00]", "\x1b[0m"},
+		{"foreground", "\x1b[32mSector  : 1", "\x1b[0;32m"},
+		{"bold and background", "\x1b[1;33;44mWarning", "\x1b[0;1;33;44m"},
+		{"later colors win", "\x1b[31mRed \x1b[36mCyan", "\x1b[0;36m"},
+		{"reset", "\x1b[1;31mRed\x1b[0m plain", "\x1b[0m"},
+		{"empty reset", "\x1b[1;31mRed\x1b[m plain", "\x1b[0m"},
+		{"bold off keeps color", "\x1b[1;35mBright\x1b[22m dim", "\x1b[0;35m"},
+		{"default foreground", "\x1b[31;44mRed\x1b[39m default", "\x1b[0;44m"},
+		{"256 colors", "\x1b[38;5;208;48;2;0;0;128mOrange", "\x1b[0;38;5;208;48;2;0;0;128m"},
+		{"cursor moves ignored", "\x1b[33m\x1b[2J\x1b[1;1HTop", "\x1b[0;33m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state ColorState
+			state.Write(tt.input)
+			if prefix := state.Prefix(); prefix != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, prefix)
+			}
+		})
+	}
+}
+
+func TestColorState_SequenceSplitAcrossChunks(t *testing.T) {
+	input := "plain \x1b[1;33mYellow\x1b[0;36mCyan"
+	for split := 1; split < len(input); split++ {
+		var state ColorState
+		state.Write(input[:split])
+		state.Write(input[split:])
+		if prefix := state.Prefix(); prefix != "\x1b[0;36m" {
+			t.Errorf("Split at %d: expected cyan, got %q", split, prefix)
+		}
+	}
+}
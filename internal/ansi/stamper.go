@@ -0,0 +1,69 @@
+package ansi
+
+import (
+	"strings"
+	"time"
+)
+
+// LineStamper prefixes each line of streaming text with the local time it started. Only
+// a line start after a newline is stamped, just before its first visible character, so a
+// carriage return redrawing the same line, cursor movement in game art and escape
+// sequences (even split across chunks) are left alone.
+type LineStamper struct {
+	format      string           // Time layout of the stamp, as for time.Format
+	now         func() time.Time // Clock the stamps are read from
+	atLineStart bool             // The next visible character starts a line
+	state       int              // 0=normal, 1=saw_esc, 2=in_sequence
+}
+
+// NewLineStamper creates a stamper that writes the time in format, followed by a space,
+// at the start of each line
+func NewLineStamper(format string) *LineStamper {
+	return &LineStamper{format: format, now: time.Now, atLineStart: true}
+}
+
+// StampChunk processes a chunk of text and returns it with the stamp inserted at each
+// line start
+func (s *LineStamper) StampChunk(text string) string {
+	var result strings.Builder
+
+	for _, char := range text {
+		switch s.state {
+		case 1: // Saw escape character
+			if char == '[' {
+				s.state = 2
+			} else {
+				s.state = 0
+			}
+			result.WriteRune(char)
+			continue
+
+		case 2: // In ANSI sequence
+			if (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') {
+				s.state = 0
+			}
+			result.WriteRune(char)
+			continue
+		}
+
+		switch {
+		case char == '\x1b':
+			s.state = 1
+		case char == '\n':
+			s.atLineStart = true
+		case char >= 32 && s.atLineStart:
+			result.WriteString(s.now().Format(s.format))
+			result.WriteByte(' ')
+			s.atLineStart = false
+		}
+		result.WriteRune(char)
+	}
+
+	return result.String()
+}
+
+// Reset resets the stamper to the start of a line (useful for new connections)
+func (s *LineStamper) Reset() {
+	s.atLineStart = true
+	s.state = 0
+}
@@ -0,0 +1,59 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestStamper creates a stamper whose clock always reads 09:05:07
+func newTestStamper(format string) *LineStamper {
+	stamper := NewLineStamper(format)
+	stamper.now = func() time.Time { return time.Date(2024, 1, 2, 9, 5, 7, 0, time.Local) }
+	return stamper
+}
+
+func TestLineStamper(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lines", "one\r\ntwo\r\n", "09:05:07 one\r\n09:05:07 two\r\n"},
+		{"blank lines are left blank", "one\r\n\r\ntwo", "09:05:07 one\r\n\r\n09:05:07 two"},
+		{"color before the text", "\x1b[1;32mSector\x1b[0m\r\n", "\x1b[1;32m09:05:07 Sector\x1b[0m\r\n"},
+		{"carriage return redraws the line", "Working |\rWorking /\r\n", "09:05:07 Working |\rWorking /\r\n"},
+		{"cursor movement", "\x1b[2J\x1b[5;10H*\x1b[6;10H*", "\x1b[2J\x1b[5;10H09:05:07 *\x1b[6;10H*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newTestStamper("15:04:05").StampChunk(tt.input); result != tt.expected {
+				t.Errorf("StampChunk(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLineStamper_SplitAcrossChunks(t *testing.T) {
+	stamper := newTestStamper("[15:04]")
+
+	// The line ends in one chunk and its sequence is split across the next two
+	result := stamper.StampChunk("one\r")
+	result += stamper.StampChunk("\n\x1b[3")
+	result += stamper.StampChunk("3mtwo")
+
+	expected := "[09:05] one\r\n\x1b[33m[09:05] two"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestLineStamper_Reset(t *testing.T) {
+	stamper := newTestStamper("15:04:05")
+	stamper.StampChunk("partial")
+	stamper.Reset()
+
+	if result := stamper.StampChunk("next"); result != "09:05:07 next" {
+		t.Errorf("Expected a reset stamper to start a new line, got %q", result)
+	}
+}
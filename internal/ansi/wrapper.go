@@ -0,0 +1,127 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// Wrapper breaks streaming text into lines no wider than a number of visible columns.
+// Escape sequences pass through whole and take up no columns, so a break never lands
+// inside one, even when a sequence is split across chunks, and colors carry over to the
+// wrapped line. It is the display counterpart to StreamingStripper.
+type Wrapper struct {
+	width    int    // Columns per line; zero or less disables wrapping
+	column   int    // Column the next visible character lands in
+	state    int    // 0=normal, 1=saw_esc, 2=in_sequence
+	sequence string // Parameters of the sequence being read
+}
+
+// NewWrapper creates a wrapper that breaks lines at width columns
+func NewWrapper(width int) *Wrapper {
+	return &Wrapper{width: width}
+}
+
+// SetWidth changes the columns per line; text already wrapped is not reflowed
+func (w *Wrapper) SetWidth(width int) {
+	w.width = width
+}
+
+// WrapChunk processes a chunk of text and returns it with a "\r\n" inserted wherever a
+// visible character would run past the line width. The break is only made once another
+// character arrives, so text exactly as wide as the line doesn't gain a blank line.
+func (w *Wrapper) WrapChunk(text string) string {
+	var result strings.Builder
+
+	for _, char := range text {
+		switch w.state {
+		case 1: // Saw escape character
+			result.WriteRune(char)
+			if char == '[' {
+				w.state = 2
+				w.sequence = ""
+				continue
+			}
+			// Not an ANSI escape; the character after ESC is shown as-is
+			w.state = 0
+			if char >= 32 {
+				w.column++
+			}
+			continue
+
+		case 2: // In ANSI sequence
+			result.WriteRune(char)
+			if (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') {
+				w.moveCursor(char)
+				w.state = 0
+			} else {
+				w.sequence += string(char)
+			}
+			continue
+		}
+
+		switch {
+		case char == '\x1b':
+			w.state = 1
+		case char == '\r':
+			w.column = 0
+		case char == '\b':
+			if w.column > 0 {
+				w.column--
+			}
+		case char == '\t':
+			w.column = (w.column/8 + 1) * 8
+		case char >= 32:
+			if w.width > 0 && w.column >= w.width {
+				result.WriteString("\r\n")
+				w.column = 0
+			}
+			w.column++
+		}
+		result.WriteRune(char)
+	}
+
+	return result.String()
+}
+
+// moveCursor tracks the column through cursor movement sequences
+func (w *Wrapper) moveCursor(command rune) {
+	params := strings.Split(w.sequence, ";")
+	count := max(1, parseNumber(params[0]))
+
+	switch command {
+	case 'H', 'f': // Cursor position: row;column
+		column := 1
+		if len(params) > 1 {
+			column = max(1, parseNumber(params[1]))
+		}
+		w.column = column - 1
+	case 'C': // Cursor right
+		w.column += count
+	case 'D': // Cursor left
+		w.column = max(0, w.column-count)
+	}
+}
+
+// Reset resets the wrapper to the start of a line (useful for new connections)
+func (w *Wrapper) Reset() {
+	w.column = 0
+	w.state = 0
+	w.sequence = ""
+}
+
+// WrapString is a convenience function for wrapping a complete string
+// This is equivalent to creating a new wrapper and calling WrapChunk once
+func WrapString(text string, width int) string {
+	return NewWrapper(width).WrapChunk(text)
+}
+
+// parseNumber parses the leading digits of a sequence parameter, 0 if there are none
+func parseNumber(s string) int {
+	result := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			break
+		}
+		result = result*10 + int(r-'0')
+	}
+	return result
+}
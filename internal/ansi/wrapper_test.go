@@ -0,0 +1,88 @@
+package ansi
+
+import (
+	"testing"
+)
+
+func TestWrapString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{
+			name:     "short line unchanged",
+			input:    "Hello\r\n",
+			width:    10,
+			expected: "Hello\r\n",
+		},
+		{
+			name:     "long line wrapped",
+			input:    "abcdefghij",
+			width:    4,
+			expected: "abcd\r\nefgh\r\nij",
+		},
+		{
+			name:     "exact width gets no blank line",
+			input:    "abcd\r\nef",
+			width:    4,
+			expected: "abcd\r\nef",
+		},
+		{
+			name:     "sequences take no columns",
+			input:    "\x1b[1;31mab\x1b[0mcd\x1b[32mef",
+			width:    4,
+			expected: "\x1b[1;31mab\x1b[0mcd\x1b[32m\r\nef",
+		},
+		{
+			name:     "cursor position sets the column",
+			input:    "\x1b[5;3Hab",
+			width:    3,
+			expected: "\x1b[5;3Ha\r\nb",
+		},
+		{
+			name:     "cursor left and right move the column",
+			input:    "ab\x1b[2Dcd\x1b[2Ce",
+			width:    4,
+			expected: "ab\x1b[2Dcd\x1b[2C\r\ne",
+		},
+		{
+			name:     "zero width disables wrapping",
+			input:    "abcdefghij",
+			width:    0,
+			expected: "abcdefghij",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := WrapString(tt.input, tt.width); result != tt.expected {
+				t.Errorf("WrapString(%q, %d) = %q, expected %q", tt.input, tt.width, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrapper_SequenceSplitAcrossChunks(t *testing.T) {
+	wrapper := NewWrapper(3)
+
+	// The break falls where the color sequence starts; it must come after the whole sequence
+	result := wrapper.WrapChunk("abc\x1b[3")
+	result += wrapper.WrapChunk("3mde")
+
+	expected := "abc\x1b[33m\r\nde"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestWrapper_Reset(t *testing.T) {
+	wrapper := NewWrapper(3)
+	wrapper.WrapChunk("ab")
+	wrapper.Reset()
+
+	if result := wrapper.WrapChunk("abc"); result != "abc" {
+		t.Errorf("Expected a reset wrapper to start a new line, got %q", result)
+	}
+}
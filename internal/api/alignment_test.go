@@ -0,0 +1,18 @@
+package api
+
+import "testing"
+
+func TestParseAlignment(t *testing.T) {
+	for alignment, want := range map[string]Alignment{
+		"Good":     AlignmentGood,
+		"neutral":  AlignmentNeutral,
+		"Evil":     AlignmentEvil,
+		"Outlaw":   AlignmentEvil,
+		"Criminal": AlignmentEvil,
+		"":         AlignmentUnknown,
+	} {
+		if got := ParseAlignment(alignment); got != want {
+			t.Errorf("ParseAlignment(%q) = %d, want %d", alignment, got, want)
+		}
+	}
+}
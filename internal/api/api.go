@@ -1,6 +1,9 @@
 package api
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Enums for type safety
 type ProductType int
@@ -95,6 +98,17 @@ type PortInfo struct {
 	Dead       bool          `json:"dead"`
 }
 
+// DestroyedPortLabel replaces the trade pattern of a destroyed port
+const DestroyedPortLabel = "DEAD"
+
+// Label returns the port's trade pattern such as "BBS", or DestroyedPortLabel once the port is destroyed
+func (pi PortInfo) Label() string {
+	if pi.Dead {
+		return DestroyedPortLabel
+	}
+	return pi.ClassType.String()
+}
+
 type ProductInfo struct {
 	Type       ProductType   `json:"type"`
 	Status     ProductStatus `json:"status"`
@@ -119,6 +133,16 @@ type ProxyAPI interface {
 	// Game State Management (Phase 4)
 	GetCurrentSector() (int, error)
 	GetSectorInfo(sectorNum int) (SectorInfo, error)
+	GetSectorWarps(sectorNum int) ([6]int, error)    // Warp slots only, 0 for empty
+	GetInferredWarps(sectorNum int) ([]int, error)   // Warps only assumed because the destination warps back
+	SetSectorNote(sectorNum int, note string) error  // Empty note removes it
+	GetRecentSectors() ([]int, error)                // Sectors the player was last in, most recent (current) first
+	IsAtCommandPrompt() bool                         // Whether the game is waiting at the Command prompt
+	GetExplorationStats() (ExplorationStats, error)  // How much of the universe is mapped
+	GetSessionStats() (SessionStats, error)          // What the player has done this session
+	GetPlayerStatsDelta() (PlayerStatsDelta, error)  // The latest player stats and the ones before, to show what changed
+	GetRecentANSILines() ([]string, error)           // Last lines from the server in their ANSI colors, oldest first
+	GetRecentParsedLines() ([]ParsedLineInfo, error) // Last lines the parser handled, oldest first, for debugging
 	GetPlayerInfo() (PlayerInfo, error)
 
 	// Port Information (Phase 2)
@@ -130,6 +154,26 @@ type ProxyAPI interface {
 	// Script Menu Operations
 	GetScriptList() ([]ScriptInfo, error)    // Lists all loaded scripts with status
 	SendBurstCommand(burstText string) error // Sends burst command to server
+
+	// Game Database Fallback
+	StartServerDatabase() error // Starts a game database for this server when game detection failed
+
+	// Active Game
+	GetActiveGame() DatabaseStateInfo // Identity of the game whose database is loaded; IsLoaded is false when none
+
+	// Game Selection
+	SelectGame(letter string) error // Picks a game at the server's game selection prompt; its database loads when the game starts
+
+	// Game Databases
+	ListGameDatabases() ([]GameDatabaseInfo, error) // Game databases on disk for the connected server
+	OpenGameDatabase(name string) error             // Makes a listed database the active game database
+	CreateGameDatabase(gameName string) error       // Creates a new game database for the connected server and makes it active
+
+	// Terminal Display
+	ShowCurrentSector() error // Writes the current sector's stored data to the terminal
+
+	// Database Durability
+	CloseDatabase() error // Finishes parsing the server output in flight and closes the game database, for shutdown
 }
 
 // TuiAPI defines notifications from Proxy to TUI
@@ -141,6 +185,7 @@ type TuiAPI interface {
 	// Connection Events - single callback for all status changes
 	OnConnectionStatusChanged(status ConnectionStatus, address string)
 	OnConnectionError(err error)
+	OnConnectionStateChanged(state ConnectionState) // Proxy connection lifecycle: connecting, connected, disconnected or lost to an error
 
 	// Data Events - must return immediately (high frequency calls)
 	OnData(data []byte)
@@ -164,6 +209,21 @@ type TuiAPI interface {
 
 	// Sector Events - called when sector data is updated (e.g. from etherprobe)
 	OnSectorUpdated(sectorInfo SectorInfo) // Sector information updated from parsing or probe data
+
+	// Warp Events - called when only the warp list of a sector changes (probe, sector display)
+	OnSectorWarpsUpdated(sector int, warps [6]int) // Warp destinations for a (possibly non-current) sector
+
+	// Map Events - called once when a burst of sector changes ends, such as a CIM download
+	OnMapInvalidated(sectors []int) // Sectors changed in bulk; reload them and redraw the map once
+
+	// Course Events - called when the game plots a course, for a move or the computer
+	OnCoursePlotted(sectors []int) // Sectors of the course in order, from the start to the destination
+
+	// Game Detection Events - called when no known login/game prompt was seen in time after connecting
+	OnGameDetectionFailed(serverHost, serverPort string) // Game database was not initialized; offer StartServerDatabase
+
+	// Game Selection Events - called when the server waits at its game selection prompt
+	OnGameSelectionMenu(menu GameMenuInfo) // The games on offer; answer with SelectGame
 }
 
 // ConnectionStatus represents the current connection state
@@ -188,6 +248,35 @@ func (cs ConnectionStatus) String() string {
 	}
 }
 
+// ConnectionState is a step in the proxy's connection lifecycle, reported to the TUI as it
+// happens so a dropped server shows up instead of a frozen screen
+type ConnectionState int
+
+const (
+	ConnectionStateDisconnected ConnectionState = iota
+	ConnectionStateConnecting
+	ConnectionStateConnected
+	ConnectionStateError        // The connection failed or was lost to a network error
+	ConnectionStateReconnecting // Waiting to redial the server after losing the connection
+)
+
+func (cs ConnectionState) String() string {
+	switch cs {
+	case ConnectionStateDisconnected:
+		return "disconnected"
+	case ConnectionStateConnecting:
+		return "connecting"
+	case ConnectionStateConnected:
+		return "connected"
+	case ConnectionStateError:
+		return "error"
+	case ConnectionStateReconnecting:
+		return "reconnecting"
+	default:
+		return "unknown"
+	}
+}
+
 // ScriptStatusInfo provides basic script information for Phase 3
 type ScriptStatusInfo struct {
 	ActiveCount int      `json:"active_count"` // Number of running scripts
@@ -203,25 +292,171 @@ type PlayerInfo struct {
 
 // SectorInfo provides basic sector information for panel display
 type SectorInfo struct {
-	Number        int    `json:"number"`             // Sector number
-	NavHaz        int    `json:"nav_haz"`            // Navigation hazard level
-	HasTraders    int    `json:"has_traders"`        // Number of traders present
-	Constellation string `json:"constellation"`      // Constellation name
-	Beacon        string `json:"beacon"`             // Beacon text
-	Warps         []int  `json:"warps"`              // Warp connections to other sectors
-	HasPort       bool   `json:"has_port,omitempty"` // True if sector has a port
-	Visited       bool   `json:"visited"`            // True only if sector has been actually visited (EtHolo)
+	Number        int       `json:"number"`                   // Sector number
+	NavHaz        int       `json:"nav_haz"`                  // Navigation hazard level
+	HasTraders    int       `json:"has_traders"`              // Number of traders present
+	Constellation string    `json:"constellation"`            // Constellation name
+	Beacon        string    `json:"beacon"`                   // Beacon text
+	Warps         []int     `json:"warps"`                    // Warp connections to other sectors
+	InferredWarps []int     `json:"inferred_warps,omitempty"` // Warps only assumed because the destination warps back
+	HasPort       bool      `json:"has_port,omitempty"`       // True if sector has a port
+	Visited       bool      `json:"visited"`                  // True only if sector has been actually visited (EtHolo)
+	Explored      Explored  `json:"explored"`                 // How much is known about the sector
+	Avoided       bool      `json:"avoided,omitempty"`        // True if sector is on the avoid list
+	Note          string    `json:"note,omitempty"`           // Player note flagging the sector, empty if none
+	LastSeen      time.Time `json:"last_seen,omitempty"`      // When the sector's data was last recorded, zero if never
+
+	// Alignment of the most hostile trader present, unknown if there are none or no
+	// trader's alignment was shown
+	TraderAlignment Alignment `json:"trader_alignment,omitempty"`
+
+	// True if the sector was first explored during this session, so explorers can see
+	// their progress
+	NewThisSession bool `json:"new_this_session,omitempty"`
+}
+
+// Explored is how much is known about a sector, matching TWX's exploration types
+type Explored int
+
+const (
+	ExploredNo      Explored = iota // Only known as a warp destination
+	ExploredCalc                    // Warps known from a course plot or port report
+	ExploredDensity                 // Seen by a density scan
+	ExploredHolo                    // Visited or holo scanned, so its contents are known
+)
+
+// ExplorationStats counts the universe's sectors by how much is known about them
+type ExplorationStats struct {
+	TotalSectors int `json:"total_sectors"` // Sectors in the universe, as far as the database knows
+	Known        int `json:"known"`         // Sectors the database has stored, whatever their status
+	Unexplored   int `json:"unexplored"`    // Including sectors never seen at all
+	Calculated   int `json:"calculated"`
+	Density      int `json:"density"`
+	Holo         int `json:"holo"`
+}
+
+// PercentHolo returns the share of the universe visited or holo scanned, from 0 to 100
+func (s ExplorationStats) PercentHolo() float64 {
+	if s.TotalSectors == 0 {
+		return 0
+	}
+	return float64(s.Holo) * 100 / float64(s.TotalSectors)
+}
+
+// SessionStats sums up what the player has done since connecting or switching games
+type SessionStats struct {
+	TurnsSpent      int `json:"turns_spent"`      // Turns used since the first player stats seen
+	CreditsChange   int `json:"credits_change"`   // Credits gained since then, negative if lost
+	SectorsExplored int `json:"sectors_explored"` // Sectors first explored this session
+	PortsDiscovered int `json:"ports_discovered"` // Ports the database didn't know at the start
+}
+
+// PlayerStatsDelta holds the latest player stats and the ones seen before them, so the TUI
+// can show what an OnPlayerStatsUpdated snapshot changed
+type PlayerStatsDelta struct {
+	Previous    PlayerStatsInfo `json:"previous"`     // Zero until a second update is seen
+	Current     PlayerStatsInfo `json:"current"`      // As last sent with OnPlayerStatsUpdated
+	HasPrevious bool            `json:"has_previous"` // False for the first update of a session
+}
+
+// TurnsSpent returns the turns used since the previous update, 0 if there was none
+func (d PlayerStatsDelta) TurnsSpent() int {
+	if !d.HasPrevious {
+		return 0
+	}
+	return d.Previous.Turns - d.Current.Turns
+}
+
+// CreditsChange returns the credits gained since the previous update, negative if lost and
+// 0 if there was none
+func (d PlayerStatsDelta) CreditsChange() int {
+	if !d.HasPrevious {
+		return 0
+	}
+	return d.Current.Credits - d.Previous.Credits
+}
+
+// Alignment is how hostile a trader is, ordered so the most hostile compares greatest
+type Alignment int
+
+const (
+	AlignmentUnknown Alignment = iota // Not shown in the sector display
+	AlignmentGood
+	AlignmentNeutral
+	AlignmentEvil // Evil, outlaw and criminal traders
+)
+
+// ParseAlignment returns the alignment for a trader's alignment as the sector display
+// shows it, such as "Good" or "Outlaw"
+func ParseAlignment(alignment string) Alignment {
+	switch strings.ToLower(strings.TrimSpace(alignment)) {
+	case "good":
+		return AlignmentGood
+	case "neutral":
+		return AlignmentNeutral
+	case "evil", "outlaw", "criminal":
+		return AlignmentEvil
+	}
+	return AlignmentUnknown
+}
+
+// Age returns how long ago the sector's data was recorded, or 0 if it never was
+func (si SectorInfo) Age(now time.Time) time.Duration {
+	if si.LastSeen.IsZero() {
+		return 0
+	}
+	return now.Sub(si.LastSeen)
+}
+
+// ParsedLineInfo is a line from the server as the parser saw it
+type ParsedLineInfo struct {
+	Raw      string `json:"raw"`      // As received, with ANSI codes
+	Stripped string `json:"stripped"` // As matched by the parsers
+	Display  string `json:"display"`  // Display the parser was in after the line, e.g. "Sector" or "None"
+}
+
+// TerminalMenuInfo describes one of the proxy's terminal menus, for help shown in the TUI
+type TerminalMenuInfo struct {
+	Title string                 `json:"title"`
+	Keys  string                 `json:"keys"` // Keys typed to open the menu, e.g. "$ V"
+	Items []TerminalMenuItemInfo `json:"items"`
+}
+
+// TerminalMenuItemInfo is an item of a terminal menu
+type TerminalMenuItemInfo struct {
+	Hotkey      string `json:"hotkey"`
+	Description string `json:"description"`
 }
 
 // DatabaseStateInfo provides information about database loading/unloading
 type DatabaseStateInfo struct {
 	GameName     string `json:"game_name"`     // Name of the game (e.g., "Trade Wars 2002")
+	GameLetter   string `json:"game_letter"`   // Letter the game was selected with on the server menu, empty if not selected from a menu
 	ServerHost   string `json:"server_host"`   // Server host (e.g., "twgs.geekm0nkey.com")
 	ServerPort   string `json:"server_port"`   // Server port (e.g., "23")
 	DatabaseName string `json:"database_name"` // Database filename
 	IsLoaded     bool   `json:"is_loaded"`     // true when database is loaded, false when unloaded
 }
 
+// GameDatabaseInfo describes a game database file that can be opened for the connected server
+type GameDatabaseInfo struct {
+	Name     string `json:"name"`      // Database filename, as passed to OpenGameDatabase
+	IsActive bool   `json:"is_active"` // true for the currently loaded database
+}
+
+// GameMenuInfo describes the game selection menu the server is waiting at
+type GameMenuInfo struct {
+	ServerHost string           `json:"server_host"` // Server host (e.g., "twgs.geekm0nkey.com")
+	ServerPort string           `json:"server_port"` // Server port (e.g., "23")
+	Games      []GameMenuOption `json:"games"`       // Games listed on the menu, by letter
+}
+
+// GameMenuOption is one game listed on the server's game selection menu
+type GameMenuOption struct {
+	Letter string `json:"letter"` // Letter that selects the game, as passed to SelectGame
+	Name   string `json:"name"`   // Name of the game as the menu lists it
+}
+
 // TraderInfo represents trader information for TUI API
 type TraderInfo struct {
 	Name      string `json:"name"`      // Trader name
@@ -242,6 +477,7 @@ type PlayerStatsInfo struct {
 	OrgHolds      int    `json:"org_holds"`      // Organics holds
 	EquHolds      int    `json:"equ_holds"`      // Equipment holds
 	ColHolds      int    `json:"col_holds"`      // Colonists holds
+	EmptyHolds    int    `json:"empty_holds"`    // Holds not carrying cargo or colonists
 	Photons       int    `json:"photons"`        // Photon torpedoes
 	Armids        int    `json:"armids"`         // Armid mines
 	Limpets       int    `json:"limpets"`        // Limpet mines
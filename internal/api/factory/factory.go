@@ -2,10 +2,11 @@ package factory
 
 import (
 	"fmt"
-	"net"
 	"strings"
 	"twist/internal/api"
 	"twist/internal/proxy"
+	"twist/internal/proxy/menu"
+	"twist/internal/proxy/transport"
 )
 
 // Connect creates a new proxy instance and returns a connected ProxyAPI
@@ -32,9 +33,11 @@ func Connect(address string, tuiAPI api.TuiAPI, options ...*api.ConnectOptions)
 	}
 
 	// Establish network connection (blocking)
-	conn, err := net.Dial("tcp", address)
+	tuiAPI.OnConnectionStateChanged(api.ConnectionStateConnecting)
+	conn, err := transport.Dial(address, opts.Transport)
 	if err != nil {
 		// Connection failed - notify TUI and panic
+		tuiAPI.OnConnectionStateChanged(api.ConnectionStateError)
 		tuiAPI.OnConnectionError(fmt.Errorf("failed to connect to %s: %w", address, err))
 		panic(fmt.Errorf("failed to connect to %s: %w", address, err))
 	}
@@ -48,3 +51,8 @@ func Connect(address string, tuiAPI api.TuiAPI, options ...*api.ConnectOptions)
 	// Return connected proxy instance
 	return proxyInstance
 }
+
+// TerminalMenus lists the proxy's built-in terminal menus, for help shown before connecting
+func TerminalMenus() []api.TerminalMenuInfo {
+	return menu.TerminalMenus()
+}
@@ -0,0 +1,15 @@
+package api
+
+import "testing"
+
+func TestPortInfoLabel(t *testing.T) {
+	port := PortInfo{ClassType: PortClassBBS}
+	if got := port.Label(); got != "BBS" {
+		t.Errorf("Expected BBS, got %q", got)
+	}
+
+	port.Dead = true
+	if got := port.Label(); got != DestroyedPortLabel {
+		t.Errorf("Expected %q for a destroyed port, got %q", DestroyedPortLabel, got)
+	}
+}
@@ -1,7 +1,100 @@
 package api
 
+import "time"
+
 // ConnectOptions holds optional parameters for Connect
 type ConnectOptions struct {
 	DatabasePath string
 	ScriptName   string
+
+	// GameDetectionTimeout is how long to wait for a known login/game prompt before
+	// OnGameDetectionFailed fires. Zero uses the default; negative disables it.
+	GameDetectionTimeout time.Duration
+
+	// DetectionPatternsPath is a JSON file of extra prompt or regex -> game detection patterns
+	// for servers with custom menus. Empty reads twist_detection_patterns.json if present.
+	DetectionPatternsPath string
+
+	// ServerDatabaseFallback starts one game database for the server when the game command
+	// prompt appears before any game was detected, for servers whose menus aren't recognized.
+	// Off by default, since every game on the server then shares that database.
+	ServerDatabaseFallback bool
+
+	// PortClassesPath is a JSON file of trade pattern -> port class for servers with custom
+	// port classes. Empty reads twist_port_classes.json if present.
+	PortClassesPath string
+
+	// TradePricingPath is a JSON file of base prices and margin for servers with a tweaked
+	// economy, used for trade profit estimates. Empty reads twist_trade_pricing.json if present.
+	TradePricingPath string
+
+	// UnrecognizedPromptsPath is a file prompts no parser handler or game detection pattern
+	// recognized are appended to, as samples for supporting more servers. Empty doesn't log them.
+	UnrecognizedPromptsPath string
+
+	// DisplayWatchdogLines is how many lines the parser lets a screen such as a CIM report
+	// run without saving anything before it stops parsing lines as part of that screen.
+	// Zero uses the default; negative disables it.
+	DisplayWatchdogLines int
+
+	// CheckpointInterval is how often the game database's write-ahead log is copied into
+	// the database file. Zero uses the default; negative disables it.
+	CheckpointInterval time.Duration
+
+	// GameName opens this game's database for the server as soon as the connection is made,
+	// instead of waiting for game detection. Empty detects the game from the server's prompts.
+	GameName string
+
+	// GameLetter selects this game the first time the server's game selection prompt lists
+	// it, logging straight into the game. Empty leaves the choice to the user.
+	GameLetter string
+
+	// StaleSectorAge is how old a sector's data must be before the terminal menu's sector
+	// display shows its age. Zero uses the default; negative disables it.
+	StaleSectorAge time.Duration
+
+	// PruneVolatileAfter clears traders, ships and other players' fighters from sectors last
+	// seen longer ago than this whenever a game database is loaded. Zero keeps them.
+	PruneVolatileAfter time.Duration
+
+	// Transport is how the connection to the server is made; the zero value is plain TCP
+	Transport TransportOptions
+
+	// Reconnect redials the server when the connection drops; the zero value doesn't
+	Reconnect ReconnectOptions
+}
+
+// ReconnectOptions configures reconnecting after the server drops the connection. Each
+// attempt waits twice as long as the one before, up to a minute, and a successful one
+// runs ScriptName again to log back in.
+type ReconnectOptions struct {
+	MaxAttempts int           // Attempts before giving up; zero never reconnects
+	Interval    time.Duration // Wait before the first attempt. Zero uses the default.
+}
+
+// Transports a connection can be made over
+const (
+	TransportTCP = "tcp"
+	TransportTLS = "tls"
+	TransportSSH = "ssh"
+)
+
+// TransportOptions selects and configures the connection's transport
+type TransportOptions struct {
+	Kind       string // TransportTCP (or empty), TransportTLS or TransportSSH
+	SkipVerify bool   // Accept any TLS certificate or SSH host key
+
+	// SSH login: a password, a private key file, or both (the password then unlocks the key)
+	User     string
+	Password string
+	KeyFile  string
+
+	// KnownHostsFile holds the SSH host keys to trust. Empty uses ~/.ssh/known_hosts.
+	KnownHostsFile string
+}
+
+// UsesTelnet returns true if the server end speaks telnet. TLS fronts a telnet server, while
+// SSH opens a terminal session of its own.
+func (t TransportOptions) UsesTelnet() bool {
+	return t.Kind != TransportSSH
 }
@@ -34,8 +34,12 @@ func (sm *StateManager) convertSectorToSectorInfo(sector database.TSector, secto
 		}
 	}
 
-	// Count traders
+	// Count traders and find the most hostile
 	hasTraders := len(sector.Traders)
+	traderAlignment := api.AlignmentUnknown
+	for _, trader := range sector.Traders {
+		traderAlignment = max(traderAlignment, api.ParseAlignment(trader.Alignment))
+	}
 
 	return api.SectorInfo{
 		Number:        sectorNum,
@@ -44,6 +48,10 @@ func (sm *StateManager) convertSectorToSectorInfo(sector database.TSector, secto
 		Constellation: sector.Constellation,
 		Beacon:        sector.Beacon,
 		Warps:         warps,
+		Visited:       sector.Explored == database.EtHolo,
+		Explored:      api.Explored(sector.Explored),
+
+		TraderAlignment: traderAlignment,
 	}
 }
 
@@ -0,0 +1,64 @@
+package proxy
+
+import (
+	"errors"
+	"time"
+
+	"twist/internal/log"
+)
+
+// DefaultCheckpointInterval is how often the game database's write-ahead log is copied into
+// the database file when ConnectOptions doesn't say, so a copy of the file alone is current
+const DefaultCheckpointInterval = 5 * time.Second
+
+// closeDrainTimeout is how long CloseDatabase waits for the server output being parsed
+const closeDrainTimeout = 2 * time.Second
+
+// startCheckpoints checkpoints the game database every interval until the proxy
+// disconnects. Zero uses DefaultCheckpointInterval; negative turns checkpoints off.
+func (p *Proxy) startCheckpoints(interval time.Duration) {
+	if interval < 0 {
+		log.Info("Proxy: database checkpoints disabled")
+		return
+	}
+	if interval == 0 {
+		interval = DefaultCheckpointInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if !p.IsConnected() {
+				if p.mayReconnect() {
+					continue // Nothing new to write until the connection is back
+				}
+				return
+			}
+			db := p.db
+			if db == nil {
+				continue
+			}
+			if err := db.Checkpoint(); err != nil {
+				log.Debug("Proxy: database checkpoint skipped", "error", err)
+			}
+		}
+	}()
+}
+
+// CloseDatabase finishes parsing the server output in flight, so its chunk transaction
+// commits, then closes the game database. It's for shutdowns that can't disconnect
+// cleanly: nothing the server sends afterwards is parsed.
+func (p *Proxy) CloseDatabase() error {
+	if connectedState, ok := p.getState().(*ConnectedState); ok && connectedState.pipeline != nil {
+		if !connectedState.pipeline.Drain(closeDrainTimeout) {
+			log.Warn("Proxy: closing the game database while server output is still being parsed")
+		}
+	}
+
+	db := p.db
+	if db == nil {
+		return errors.New("no game database loaded")
+	}
+	return db.CloseDatabase()
+}
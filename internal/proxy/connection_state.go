@@ -0,0 +1,28 @@
+package proxy
+
+import (
+	"twist/internal/api"
+	"twist/internal/log"
+)
+
+// endConnection switches to the disconnected state and tells the TUI how the connection
+// ended. Only the call that leaves the connected state reports it, so a user disconnect and
+// the read error it causes in handleOutput don't both fire. Returns true if this call ended it.
+func (p *Proxy) endConnection(state api.ConnectionState) bool {
+	p.stateMu.Lock()
+	oldState := p.state
+	p.state = NewDisconnectedState()
+	p.stateMu.Unlock()
+
+	if oldState == nil {
+		return false
+	}
+	oldState.Close()
+	if _, wasConnected := oldState.(*ConnectedState); !wasConnected {
+		return false
+	}
+
+	log.Info("Proxy: connection ended", "address", p.currentAddress, "state", state)
+	p.tuiAPI.OnConnectionStateChanged(state)
+	return true
+}
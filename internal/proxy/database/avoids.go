@@ -0,0 +1,189 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"twist/internal/log"
+)
+
+// ErrNoPath is returned by GetCourse when the target cannot be reached from the known warps
+var ErrNoPath = errors.New("no path")
+
+// ErrNoPathAvoids is returned by GetCourse when a route exists but every one passes through an avoided sector
+var ErrNoPathAvoids = errors.New("no path (avoids block route)")
+
+// AddAvoid adds a sector to the avoid list (TWX Avoid)
+func (d *SQLiteDatabase) AddAvoid(sectorIndex int) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+	if sectorIndex <= 0 {
+		return fmt.Errorf("invalid sector %d", sectorIndex)
+	}
+
+	if _, err := d.conn().Exec(`INSERT OR IGNORE INTO avoids (sector_index) VALUES (?)`, sectorIndex); err != nil {
+		return fmt.Errorf("failed to add avoid for sector %d: %w", sectorIndex, err)
+	}
+
+	log.Info("Sector added to avoid list", "sector", sectorIndex)
+	return nil
+}
+
+// RemoveAvoid removes a sector from the avoid list (TWX ClearAvoid)
+func (d *SQLiteDatabase) RemoveAvoid(sectorIndex int) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	if _, err := d.conn().Exec(`DELETE FROM avoids WHERE sector_index = ?`, sectorIndex); err != nil {
+		return fmt.Errorf("failed to remove avoid for sector %d: %w", sectorIndex, err)
+	}
+
+	log.Info("Sector removed from avoid list", "sector", sectorIndex)
+	return nil
+}
+
+// ListAvoids returns all avoided sectors in ascending order (TWX ListAvoids)
+func (d *SQLiteDatabase) ListAvoids() ([]int, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT sector_index FROM avoids ORDER BY sector_index`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list avoids: %w", err)
+	}
+	defer rows.Close()
+
+	avoids := make([]int, 0)
+	for rows.Next() {
+		var sectorIndex int
+		if err := rows.Scan(&sectorIndex); err != nil {
+			return nil, fmt.Errorf("failed to scan avoid: %w", err)
+		}
+		avoids = append(avoids, sectorIndex)
+	}
+
+	return avoids, rows.Err()
+}
+
+// IsAvoided returns true if the sector is on the avoid list
+func (d *SQLiteDatabase) IsAvoided(sectorIndex int) bool {
+	if !d.dbOpen {
+		return false
+	}
+
+	var count int
+	if err := d.conn().QueryRow(`SELECT COUNT(*) FROM avoids WHERE sector_index = ?`, sectorIndex).Scan(&count); err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// GetCourse finds the shortest warp path between two sectors, skipping avoided sectors.
+// The returned path includes both the start and target sectors.
+func (d *SQLiteDatabase) GetCourse(from, to int) ([]int, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+	if from <= 0 || to <= 0 {
+		return nil, fmt.Errorf("invalid course %d to %d", from, to)
+	}
+	if from == to {
+		return []int{from}, nil
+	}
+
+	warps, err := d.loadWarpMap()
+	if err != nil {
+		return nil, err
+	}
+
+	avoided, err := d.loadAvoidedSet()
+	if err != nil {
+		return nil, err
+	}
+
+	if path := shortestPath(warps, from, to, avoided); path != nil {
+		return path, nil
+	}
+
+	// Distinguish between an unknown route and one that only the avoid list blocks
+	if len(avoided) > 0 && shortestPath(warps, from, to, nil) != nil {
+		log.Debug("Course blocked by avoided sectors", "from", from, "to", to, "avoids", len(avoided))
+		return nil, ErrNoPathAvoids
+	}
+	return nil, ErrNoPath
+}
+
+// loadWarpMap reads the outgoing warps of every known sector
+func (d *SQLiteDatabase) loadWarpMap() (map[int][]int, error) {
+	rows, err := d.conn().Query(`SELECT sector_index, COALESCE(warp1, 0), COALESCE(warp2, 0), COALESCE(warp3, 0),
+		COALESCE(warp4, 0), COALESCE(warp5, 0), COALESCE(warp6, 0) FROM sectors`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load warps: %w", err)
+	}
+	defer rows.Close()
+
+	warpMap := make(map[int][]int)
+	for rows.Next() {
+		var sectorIndex int
+		var warps [6]int
+		if err := rows.Scan(&sectorIndex, &warps[0], &warps[1], &warps[2], &warps[3], &warps[4], &warps[5]); err != nil {
+			return nil, fmt.Errorf("failed to scan warps: %w", err)
+		}
+		for _, warp := range warps {
+			if warp > 0 {
+				warpMap[sectorIndex] = append(warpMap[sectorIndex], warp)
+			}
+		}
+	}
+
+	return warpMap, rows.Err()
+}
+
+// loadAvoidedSet reads the avoid list as a set of sectors
+func (d *SQLiteDatabase) loadAvoidedSet() (map[int]bool, error) {
+	avoidList, err := d.ListAvoids()
+	if err != nil {
+		return nil, err
+	}
+	avoided := make(map[int]bool, len(avoidList))
+	for _, sector := range avoidList {
+		avoided[sector] = true
+	}
+	return avoided, nil
+}
+
+// shortestPath runs a breadth-first search over the warp map. Avoided sectors are never
+// entered, including the target. Returns nil if no path exists.
+func shortestPath(warpMap map[int][]int, from, to int, avoided map[int]bool) []int {
+	if avoided[to] {
+		return nil
+	}
+
+	previous := map[int]int{from: 0}
+	queue := []int{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range warpMap[current] {
+			if _, seen := previous[next]; seen || avoided[next] {
+				continue
+			}
+			previous[next] = current
+
+			if next == to {
+				path := []int{to}
+				for sector := current; sector != 0; sector = previous[sector] {
+					path = append([]int{sector}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
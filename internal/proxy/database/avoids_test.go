@@ -0,0 +1,99 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// createCourseTestDatabase builds two routes from 1 to 5: 1-2-5 (short) and 1-3-4-5 (long)
+func createCourseTestDatabase(t *testing.T) *SQLiteDatabase {
+	t.Helper()
+
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	warps := map[int][]int{
+		1: {2, 3},
+		2: {1, 5},
+		3: {1, 4},
+		4: {3, 5},
+		5: {2, 4},
+	}
+	for index, targets := range warps {
+		sector := NULLSector()
+		for i, target := range targets {
+			sector.Warp[i] = target
+		}
+		if err := db.SaveSector(sector, index); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", index, err)
+		}
+	}
+
+	return db
+}
+
+func TestGetCourseHonorsAvoids(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	course, err := db.GetCourse(1, 5)
+	if err != nil {
+		t.Fatalf("GetCourse failed: %v", err)
+	}
+	if !reflect.DeepEqual(course, []int{1, 2, 5}) {
+		t.Errorf("Expected shortest course [1 2 5], got %v", course)
+	}
+
+	if err := db.AddAvoid(2); err != nil {
+		t.Fatalf("AddAvoid failed: %v", err)
+	}
+	if !db.IsAvoided(2) {
+		t.Error("Expected sector 2 to be avoided")
+	}
+
+	course, err = db.GetCourse(1, 5)
+	if err != nil {
+		t.Fatalf("GetCourse with avoid failed: %v", err)
+	}
+	if !reflect.DeepEqual(course, []int{1, 3, 4, 5}) {
+		t.Errorf("Expected course around avoid [1 3 4 5], got %v", course)
+	}
+
+	info, err := db.GetSectorInfo(2)
+	if err != nil {
+		t.Fatalf("GetSectorInfo failed: %v", err)
+	}
+	if !info.Avoided {
+		t.Error("Expected SectorInfo.Avoided for sector 2")
+	}
+
+	if err := db.AddAvoid(4); err != nil {
+		t.Fatalf("AddAvoid failed: %v", err)
+	}
+	if _, err := db.GetCourse(1, 5); !errors.Is(err, ErrNoPathAvoids) {
+		t.Errorf("Expected ErrNoPathAvoids, got %v", err)
+	}
+
+	if err := db.RemoveAvoid(2); err != nil {
+		t.Fatalf("RemoveAvoid failed: %v", err)
+	}
+	avoids, err := db.ListAvoids()
+	if err != nil {
+		t.Fatalf("ListAvoids failed: %v", err)
+	}
+	if !reflect.DeepEqual(avoids, []int{4}) {
+		t.Errorf("Expected avoids [4], got %v", avoids)
+	}
+}
+
+func TestGetCourseUnknownRoute(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	if _, err := db.GetCourse(1, 99); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"twist/internal/log"
+)
+
+// MaxBurstHistory is how many recently sent bursts are kept
+const MaxBurstHistory = 20
+
+// Burst is a burst command saved under a name, with '*' standing for ENTER
+type Burst struct {
+	Name string
+	Text string
+}
+
+// SaveBurst stores a burst under a name, replacing any burst already saved with that name.
+// Names are matched without regard to case.
+func (d *SQLiteDatabase) SaveBurst(name, text string) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	name, text = strings.TrimSpace(name), strings.TrimSpace(text)
+	if name == "" {
+		return fmt.Errorf("burst name is empty")
+	}
+	if text == "" {
+		return fmt.Errorf("burst %q is empty", name)
+	}
+
+	_, err := d.conn().Exec(`
+		INSERT INTO bursts (name, burst, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(name) DO UPDATE SET burst = excluded.burst, updated_at = excluded.updated_at`,
+		name, text)
+	if err != nil {
+		return fmt.Errorf("failed to save burst %q: %w", name, err)
+	}
+
+	log.Info("Burst saved", "name", name)
+	return nil
+}
+
+// GetBurst returns the burst saved under a name, or "" if there is none
+func (d *SQLiteDatabase) GetBurst(name string) (string, error) {
+	if !d.dbOpen {
+		return "", fmt.Errorf("database not open")
+	}
+
+	var text string
+	err := d.conn().QueryRow(`SELECT burst FROM bursts WHERE name = ?`, strings.TrimSpace(name)).Scan(&text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get burst %q: %w", name, err)
+	}
+	return text, nil
+}
+
+// DeleteBurst removes the burst saved under a name
+func (d *SQLiteDatabase) DeleteBurst(name string) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	if _, err := d.conn().Exec(`DELETE FROM bursts WHERE name = ?`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("failed to delete burst %q: %w", name, err)
+	}
+
+	log.Info("Burst deleted", "name", name)
+	return nil
+}
+
+// ListBursts returns the saved bursts in name order
+func (d *SQLiteDatabase) ListBursts() ([]Burst, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT name, burst FROM bursts ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bursts: %w", err)
+	}
+	defer rows.Close()
+
+	bursts := make([]Burst, 0)
+	for rows.Next() {
+		var burst Burst
+		if err := rows.Scan(&burst.Name, &burst.Text); err != nil {
+			return nil, fmt.Errorf("failed to scan burst: %w", err)
+		}
+		bursts = append(bursts, burst)
+	}
+
+	return bursts, rows.Err()
+}
+
+// AddBurstHistory records a sent burst as the most recent one. Sending a burst already in
+// the history moves it to the front; only the newest MaxBurstHistory are kept.
+func (d *SQLiteDatabase) AddBurstHistory(text string) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	if text = strings.TrimSpace(text); text == "" {
+		return fmt.Errorf("burst is empty")
+	}
+
+	if _, err := d.conn().Exec(`DELETE FROM burst_history WHERE burst = ?`, text); err != nil {
+		return fmt.Errorf("failed to record burst history: %w", err)
+	}
+	if _, err := d.conn().Exec(`INSERT INTO burst_history (burst, sent_at) VALUES (?, CURRENT_TIMESTAMP)`, text); err != nil {
+		return fmt.Errorf("failed to record burst history: %w", err)
+	}
+	_, err := d.conn().Exec(`
+		DELETE FROM burst_history
+		WHERE id NOT IN (SELECT id FROM burst_history ORDER BY id DESC LIMIT ?)`, MaxBurstHistory)
+	if err != nil {
+		return fmt.Errorf("failed to trim burst history: %w", err)
+	}
+	return nil
+}
+
+// ListBurstHistory returns up to limit recently sent bursts, newest first
+func (d *SQLiteDatabase) ListBurstHistory(limit int) ([]string, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT burst FROM burst_history ORDER BY id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list burst history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]string, 0)
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, fmt.Errorf("failed to scan burst history: %w", err)
+		}
+		history = append(history, text)
+	}
+
+	return history, rows.Err()
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBursts(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	if err := db.SaveBurst(" trade ", "pt*s*"); err != nil {
+		t.Fatalf("SaveBurst failed: %v", err)
+	}
+	if err := db.SaveBurst("dock", "p*t*"); err != nil {
+		t.Fatalf("SaveBurst failed: %v", err)
+	}
+
+	// Names match without regard to case, and saving again replaces the burst
+	if err := db.SaveBurst("TRADE", "pt*1*"); err != nil {
+		t.Fatalf("SaveBurst replace failed: %v", err)
+	}
+	if text, err := db.GetBurst("Trade"); err != nil || text != "pt*1*" {
+		t.Errorf("Expected replaced burst 'pt*1*', got %q (%v)", text, err)
+	}
+
+	bursts, err := db.ListBursts()
+	if err != nil {
+		t.Fatalf("ListBursts failed: %v", err)
+	}
+	if len(bursts) != 2 || bursts[0].Name != "dock" || bursts[1].Name != "trade" {
+		t.Errorf("Expected bursts dock and trade in order, got %v", bursts)
+	}
+
+	if err := db.DeleteBurst("dock"); err != nil {
+		t.Fatalf("DeleteBurst failed: %v", err)
+	}
+	if text, err := db.GetBurst("dock"); err != nil || text != "" {
+		t.Errorf("Expected deleted burst to be gone, got %q (%v)", text, err)
+	}
+
+	if err := db.SaveBurst("", "pt*"); err == nil {
+		t.Error("Expected an empty name to be rejected")
+	}
+	if err := db.SaveBurst("empty", " "); err == nil {
+		t.Error("Expected an empty burst to be rejected")
+	}
+}
+
+func TestBurstHistory(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	for _, text := range []string{"pt*", "m 12*", "pt*"} {
+		if err := db.AddBurstHistory(text); err != nil {
+			t.Fatalf("AddBurstHistory failed: %v", err)
+		}
+	}
+
+	// Sending a burst again moves it to the front rather than repeating it
+	history, err := db.ListBurstHistory(10)
+	if err != nil {
+		t.Fatalf("ListBurstHistory failed: %v", err)
+	}
+	if len(history) != 2 || history[0] != "pt*" || history[1] != "m 12*" {
+		t.Errorf("Expected [pt* m 12*], got %v", history)
+	}
+
+	// Only the newest bursts are kept
+	for i := 0; i < MaxBurstHistory+5; i++ {
+		db.AddBurstHistory(fmt.Sprintf("m %d*", i))
+	}
+	history, _ = db.ListBurstHistory(100)
+	if len(history) != MaxBurstHistory {
+		t.Errorf("Expected %d bursts kept, got %d", MaxBurstHistory, len(history))
+	}
+	if want := fmt.Sprintf("m %d*", MaxBurstHistory+4); history[0] != want {
+		t.Errorf("Expected newest burst %q first, got %q", want, history[0])
+	}
+
+	if err := db.AddBurstHistory(" "); err == nil {
+		t.Error("Expected an empty burst to be rejected")
+	}
+}
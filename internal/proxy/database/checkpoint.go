@@ -0,0 +1,23 @@
+package database
+
+import (
+	"fmt"
+	"twist/internal/log"
+)
+
+// Checkpoint copies committed writes from the write-ahead log into the database file, so the
+// file alone is current. Committed writes are already safe in the log; this only moves them.
+// It is passive, so it never waits on the parser's writes: frames still being read or written
+// are left for the next checkpoint. It runs on its own connection, outside any transaction.
+func (d *SQLiteDatabase) Checkpoint() error {
+	if !d.dbOpen || d.db == nil {
+		return fmt.Errorf("database not open")
+	}
+
+	var busy, logFrames, checkpointed int
+	if err := d.db.QueryRow("PRAGMA wal_checkpoint(PASSIVE)").Scan(&busy, &logFrames, &checkpointed); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	log.Debug("Database checkpoint", "file", d.filename, "wal_frames", logFrames, "checkpointed", checkpointed)
+	return nil
+}
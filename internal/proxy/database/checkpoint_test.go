@@ -0,0 +1,54 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointWritesDatabaseFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game.db")
+	db := NewDatabase()
+	if err := db.CreateDatabase(path); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	sector := NULLSector()
+	sector.Warp[0] = 2
+	sector.Beacon = "checkpointed"
+	if err := db.SaveSector(sector, 1); err != nil {
+		t.Fatalf("SaveSector failed: %v", err)
+	}
+	if err := db.Checkpoint(); err != nil {
+		t.Fatalf("Checkpoint failed: %v", err)
+	}
+
+	// The database file alone, without its write-ahead log, has the sector
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read database file: %v", err)
+	}
+	copyPath := filepath.Join(dir, "copy.db")
+	if err := os.WriteFile(copyPath, data, 0644); err != nil {
+		t.Fatalf("Failed to copy database file: %v", err)
+	}
+	copied := NewDatabase()
+	if err := copied.OpenDatabase(copyPath); err != nil {
+		t.Fatalf("Failed to open copied database: %v", err)
+	}
+	defer copied.CloseDatabase()
+	loaded, err := copied.LoadSector(1)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if loaded.Beacon != "checkpointed" {
+		t.Errorf("Expected the checkpointed sector in the database file, got beacon %q", loaded.Beacon)
+	}
+
+	closed := NewDatabase()
+	if err := closed.Checkpoint(); err == nil {
+		t.Error("Expected checkpointing a closed database to fail")
+	}
+}
@@ -1,9 +1,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 	"twist/internal/api"
 	"twist/internal/log"
@@ -21,6 +23,11 @@ type Database interface {
 	// Sector operations (matching TWX methods)
 	SaveSector(sector TSector, index int) error
 	LoadSector(index int) (TSector, error)
+	GetWarps(sector int) ([6]int, error)
+	LoadExploredStatus(sectors []int) (map[int]TSectorExploredType, error)
+	CountSectors() (int, error)
+	CountSectorsByExplored() (map[TSectorExploredType]int, error)
+	GetExplorationStats() (api.ExplorationStats, error)
 
 	// Enhanced SaveSector with collections (Pascal-compliant signature)
 	SaveSectorWithCollections(sector TSector, index int, ships []TShip, traders []TTrader, planets []TPlanet) error
@@ -29,6 +36,7 @@ type Database interface {
 	SavePort(port TPort, sectorIndex int) error
 	LoadPort(sectorIndex int) (TPort, error)
 	DeletePort(sectorIndex int) error
+	CountPorts() (int, error)
 	FindPortsByClass(classIndex int) ([]TPort, error)
 	FindPortsBuying(product TProductType) ([]TPort, error)
 
@@ -51,14 +59,47 @@ type Database interface {
 
 	// Fighter management
 	ResetPersonalCorpFighters() error
+	ResetPersonalCorpFightersExcept(stardock int) (int64, error)
+	PruneVolatileData(olderThan time.Duration) (int, error)
+
+	// Avoid list and course plotting
+	AddAvoid(sectorIndex int) error
+	RemoveAvoid(sectorIndex int) error
+	ListAvoids() ([]int, error)
+	IsAvoided(sectorIndex int) bool
+	GetCourse(from, to int) ([]int, error)
+	MarkWarpInferred(fromSector, toSector int) error
+	ConfirmWarp(fromSector, toSector int) error
+	ConfirmSectorWarps(sectorIndex int) error
+	GetInferredWarps(sectorIndex int) ([]int, error)
+	VerifyWarps() ([]WarpIssue, error)
+	RepairWarps() (int, error)
+	FindTradePairs(maxHops int) ([]TradePair, error)
+	NearestPorts(from int, class int, maxHops int, limit int) ([]PortDistance, error)
+
+	// Sector notes
+	SetSectorNote(sectorIndex int, note string) error
+	GetSectorNote(sectorIndex int) (string, error)
+
+	// Named burst commands
+	SaveBurst(name, text string) error
+	GetBurst(name string) (string, error)
+	DeleteBurst(name string) error
+	ListBursts() ([]Burst, error)
+	AddBurstHistory(text string) error
+	ListBurstHistory(limit int) ([]string, error)
 
 	// Modern additions
 	BeginTransaction() error
 	CommitTransaction() error
 	RollbackTransaction() error
+	InTransaction() bool
+	Session() Database
+	Checkpoint() error // Copies the write-ahead log into the database file
 
 	// Internal access for advanced operations
 	GetDB() *sql.DB
+	GetExecutor() Executor
 }
 
 // SQLiteDatabase implements Database interface using SQLite
@@ -67,7 +108,9 @@ type SQLiteDatabase struct {
 	dbOpen   bool
 	filename string
 	sectors  int
-	tx       *sql.Tx // Current transaction
+	tx       *sql.Conn    // Connection holding the current transaction
+	txMu     sync.RWMutex // Guards tx, which the parser opens and closes while other goroutines read
+	session  bool         // Set on views returned by Session, which don't own the connections
 
 	// Prepared statements for performance
 	loadSectorStmt *sql.Stmt
@@ -191,9 +234,12 @@ func (d *SQLiteDatabase) CloseDatabase() error {
 	}
 
 	// Close any active transaction
-	if d.tx != nil {
-		d.tx.Rollback() // Rollback any uncommitted transaction
-		d.tx = nil
+	if d.InTransaction() {
+		d.RollbackTransaction() // Rollback any uncommitted transaction
+	}
+	if d.session {
+		d.dbOpen = false
+		return nil
 	}
 
 	// Close prepared statements
@@ -235,7 +281,7 @@ func (d *SQLiteDatabase) LoadSector(index int) (TSector, error) {
 	// Load main sector data (Phase 2: port data removed from sectors table)
 	// Add timing debug to check if busy timeout is working
 	startTime := time.Now()
-	row := d.loadSectorStmt.QueryRow(index)
+	row := d.loadSectorRow(index)
 
 	var upDate sql.NullTime
 
@@ -248,6 +294,7 @@ func (d *SQLiteDatabase) LoadSector(index int) (TSector, error) {
 		&sector.Figs.Quantity, &sector.Figs.Owner, &sector.Figs.FigType,
 		&sector.MinesArmid.Quantity, &sector.MinesArmid.Owner,
 		&sector.MinesLimpet.Quantity, &sector.MinesLimpet.Owner,
+		&sector.AlienCount, &sector.AlienType,
 	)
 
 	// Log timing for database lock analysis
@@ -317,7 +364,7 @@ func (d *SQLiteDatabase) SaveSector(sector TSector, index int) error {
 
 	// Test a simple query to ensure the connection works
 	var tableCount int
-	if err := d.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='sectors'").Scan(&tableCount); err != nil {
+	if err := d.conn().QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='sectors'").Scan(&tableCount); err != nil {
 		return fmt.Errorf("failed to query sqlite_master: %w", err)
 	}
 
@@ -327,7 +374,7 @@ func (d *SQLiteDatabase) SaveSector(sector TSector, index int) error {
 
 	// Start transaction if not already in one
 	shouldCommit := false
-	if d.tx == nil {
+	if !d.InTransaction() {
 		if err := d.BeginTransaction(); err != nil {
 			return err
 		}
@@ -358,12 +405,13 @@ func (d *SQLiteDatabase) SaveSector(sector TSector, index int) error {
 		constellation, beacon, nav_haz, density, anomaly, warps, explored, update_time,
 		figs_quantity, figs_owner, figs_type,
 		mines_armid_quantity, mines_armid_owner,
-		mines_limpet_quantity, mines_limpet_owner
+		mines_limpet_quantity, mines_limpet_owner,
+		aliens_count, aliens_type
 	) VALUES (
-		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 	);`
 
-	_, err := d.tx.Exec(saveQuery,
+	_, err := d.conn().Exec(saveQuery,
 		index,
 		sector.Warp[0], sector.Warp[1], sector.Warp[2],
 		sector.Warp[3], sector.Warp[4], sector.Warp[5],
@@ -373,6 +421,7 @@ func (d *SQLiteDatabase) SaveSector(sector TSector, index int) error {
 		sector.Figs.Quantity, sector.Figs.Owner, int(sector.Figs.FigType),
 		sector.MinesArmid.Quantity, sector.MinesArmid.Owner,
 		sector.MinesLimpet.Quantity, sector.MinesLimpet.Owner,
+		sector.AlienCount, sector.AlienType,
 	)
 
 	if err != nil {
@@ -410,7 +459,7 @@ func (d *SQLiteDatabase) SaveSectorWithCollections(sector TSector, index int, sh
 
 	// Start transaction for atomic operation
 	shouldCommit := false
-	if d.tx == nil {
+	if !d.InTransaction() {
 		if err := d.BeginTransaction(); err != nil {
 			return err
 		}
@@ -441,12 +490,13 @@ func (d *SQLiteDatabase) SaveSectorWithCollections(sector TSector, index int, sh
 		constellation, beacon, nav_haz, density, anomaly, warps, explored, update_time,
 		figs_quantity, figs_owner, figs_type,
 		mines_armid_quantity, mines_armid_owner,
-		mines_limpet_quantity, mines_limpet_owner
+		mines_limpet_quantity, mines_limpet_owner,
+		aliens_count, aliens_type
 	) VALUES (
-		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 	);`
 
-	_, err := d.tx.Exec(saveQuery,
+	_, err := d.conn().Exec(saveQuery,
 		index,
 		sector.Warp[0], sector.Warp[1], sector.Warp[2],
 		sector.Warp[3], sector.Warp[4], sector.Warp[5],
@@ -456,6 +506,7 @@ func (d *SQLiteDatabase) SaveSectorWithCollections(sector TSector, index int, sh
 		sector.Figs.Quantity, sector.Figs.Owner, int(sector.Figs.FigType),
 		sector.MinesArmid.Quantity, sector.MinesArmid.Owner,
 		sector.MinesLimpet.Quantity, sector.MinesLimpet.Owner,
+		sector.AlienCount, sector.AlienType,
 	)
 
 	if err != nil {
@@ -496,32 +547,52 @@ func (d *SQLiteDatabase) GetDB() *sql.DB {
 
 // Transaction methods
 func (d *SQLiteDatabase) BeginTransaction() error {
+	d.txMu.Lock()
+	defer d.txMu.Unlock()
+
 	if d.tx != nil {
 		return fmt.Errorf("transaction already active")
 	}
 
-	var err error
-	d.tx, err = d.db.Begin()
-	return err
+	conn, err := d.db.Conn(context.Background())
+	if err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(context.Background(), "BEGIN"); err != nil {
+		conn.Close()
+		return err
+	}
+	d.tx = conn
+	return nil
 }
 
 func (d *SQLiteDatabase) CommitTransaction() error {
-	if d.tx == nil {
-		return fmt.Errorf("no active transaction")
-	}
-
-	err := d.tx.Commit()
-	d.tx = nil
-	return err
+	return d.endTransaction("COMMIT")
 }
 
 func (d *SQLiteDatabase) RollbackTransaction() error {
-	if d.tx == nil {
+	return d.endTransaction("ROLLBACK")
+}
+
+// endTransaction commits or rolls back the transaction and returns its connection to the
+// pool. Closing the connection waits for rows other goroutines still have open on it, so
+// it happens after unlocking.
+func (d *SQLiteDatabase) endTransaction(statement string) error {
+	d.txMu.Lock()
+	conn := d.tx
+	if conn == nil {
+		d.txMu.Unlock()
 		return fmt.Errorf("no active transaction")
 	}
 
-	err := d.tx.Rollback()
+	_, err := conn.ExecContext(context.Background(), statement)
+	if err != nil && statement != "ROLLBACK" {
+		conn.ExecContext(context.Background(), "ROLLBACK") // Don't return the connection mid-transaction
+	}
 	d.tx = nil
+	d.txMu.Unlock()
+
+	conn.Close()
 	return err
 }
 
@@ -567,7 +638,7 @@ func (d *SQLiteDatabase) SaveScriptVariable(name string, value interface{}) erro
 	INSERT OR REPLACE INTO script_vars (var_name, var_type, string_value, number_value, updated_at)
 	VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP);`
 
-	_, err := d.db.Exec(query, name, varType, stringValue, numberValue)
+	_, err := d.conn().Exec(query, name, varType, stringValue, numberValue)
 	if err != nil {
 		return fmt.Errorf("failed to save script variable %s: %w", name, err)
 	}
@@ -590,7 +661,7 @@ func (d *SQLiteDatabase) LoadScriptVariable(name string) (interface{}, error) {
 	var stringValue string
 	var numberValue float64
 
-	err := d.db.QueryRow(query, name).Scan(&varType, &stringValue, &numberValue)
+	err := d.conn().QueryRow(query, name).Scan(&varType, &stringValue, &numberValue)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Variable doesn't exist, return nil/empty value
@@ -630,7 +701,7 @@ func (d *SQLiteDatabase) SavePlayerStats(stats TPlayerStats) error {
 		1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP
 	);`
 
-	_, err := d.db.Exec(query,
+	_, err := d.conn().Exec(query,
 		stats.Turns, stats.Credits, stats.Fighters, stats.Shields, stats.TotalHolds,
 		stats.OreHolds, stats.OrgHolds, stats.EquHolds, stats.ColHolds, stats.Photons,
 		stats.Armids, stats.Limpets, stats.GenTorps, stats.TwarpType, stats.Cloaks,
@@ -660,7 +731,7 @@ func (d *SQLiteDatabase) LoadPlayerStats() (TPlayerStats, error) {
 	FROM player_stats WHERE id = 1;`
 
 	var stats TPlayerStats
-	err := d.db.QueryRow(query).Scan(
+	err := d.conn().QueryRow(query).Scan(
 		&stats.Turns, &stats.Credits, &stats.Fighters, &stats.Shields, &stats.TotalHolds,
 		&stats.OreHolds, &stats.OrgHolds, &stats.EquHolds, &stats.ColHolds, &stats.Photons,
 		&stats.Armids, &stats.Limpets, &stats.GenTorps, &stats.TwarpType, &stats.Cloaks,
@@ -689,7 +760,7 @@ func (d *SQLiteDatabase) AddMessageToHistory(message TMessageHistory) error {
 	INSERT INTO message_history (message_type, timestamp, content, sender, channel)
 	VALUES (?, ?, ?, ?, ?);`
 
-	_, err := d.db.Exec(query, int(message.Type), message.Timestamp, message.Content, message.Sender, message.Channel)
+	_, err := d.conn().Exec(query, int(message.Type), message.Timestamp, message.Content, message.Sender, message.Channel)
 	if err != nil {
 		return fmt.Errorf("failed to add message to history: %w", err)
 	}
@@ -713,7 +784,7 @@ func (d *SQLiteDatabase) GetMessageHistory(limit int) ([]TMessageHistory, error)
 	ORDER BY timestamp DESC
 	LIMIT ?;`
 
-	rows, err := d.db.Query(query, limit)
+	rows, err := d.conn().Query(query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get message history: %w", err)
 	}
@@ -747,7 +818,7 @@ func (d *SQLiteDatabase) ResetPersonalCorpFighters() error {
 	SET figs_quantity = 0, figs_owner = '', figs_type = 3
 	WHERE figs_owner IN ('yours', 'belong to your Corp');`
 
-	_, err := d.db.Exec(query)
+	_, err := d.conn().Exec(query)
 	if err != nil {
 		return fmt.Errorf("failed to reset personal/corp fighters: %w", err)
 	}
@@ -755,6 +826,30 @@ func (d *SQLiteDatabase) ResetPersonalCorpFighters() error {
 	return nil
 }
 
+// ResetPersonalCorpFightersExcept clears personal and corp fighters outside FedSpace
+// (sectors 1-10) in a single statement, leaving the Stardock sector untouched (mirrors
+// the TWX Pascal ResetFigDatabase loop). Pass a stardock <= 0 if it is unknown.
+// Owners match case-insensitively, including corp variants like "your corporation".
+// Returns the number of sectors reset.
+func (d *SQLiteDatabase) ResetPersonalCorpFightersExcept(stardock int) (int64, error) {
+	if !d.dbOpen {
+		return 0, fmt.Errorf("database not open")
+	}
+
+	query := `
+	UPDATE sectors
+	SET figs_quantity = 0, figs_owner = '', figs_type = 3
+	WHERE sector_index > 10 AND sector_index <> ?
+		AND (figs_owner LIKE 'yours' OR figs_owner LIKE '%your corp%');`
+
+	result, err := d.conn().Exec(query, stardock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset personal/corp fighters: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 // Port operations (Phase 2: Database Schema Optimization)
 
 // SavePort saves port information to the dedicated ports table
@@ -776,21 +871,11 @@ func (d *SQLiteDatabase) SavePort(port TPort, sectorIndex int) error {
 		updated_at
 	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP);`
 
-	// Use transaction if active, otherwise use direct connection
-	var err error
-	if d.tx != nil {
-		_, err = d.tx.Exec(query,
-			sectorIndex, port.Name, port.ClassIndex, port.Dead, port.BuildTime,
-			port.BuyProduct[PtFuelOre], port.BuyProduct[PtOrganics], port.BuyProduct[PtEquipment],
-			port.ProductPercent[PtFuelOre], port.ProductPercent[PtOrganics], port.ProductPercent[PtEquipment],
-			port.ProductAmount[PtFuelOre], port.ProductAmount[PtOrganics], port.ProductAmount[PtEquipment])
-	} else {
-		_, err = d.db.Exec(query,
-			sectorIndex, port.Name, port.ClassIndex, port.Dead, port.BuildTime,
-			port.BuyProduct[PtFuelOre], port.BuyProduct[PtOrganics], port.BuyProduct[PtEquipment],
-			port.ProductPercent[PtFuelOre], port.ProductPercent[PtOrganics], port.ProductPercent[PtEquipment],
-			port.ProductAmount[PtFuelOre], port.ProductAmount[PtOrganics], port.ProductAmount[PtEquipment])
-	}
+	_, err := d.conn().Exec(query,
+		sectorIndex, port.Name, port.ClassIndex, port.Dead, port.BuildTime,
+		port.BuyProduct[PtFuelOre], port.BuyProduct[PtOrganics], port.BuyProduct[PtEquipment],
+		port.ProductPercent[PtFuelOre], port.ProductPercent[PtOrganics], port.ProductPercent[PtEquipment],
+		port.ProductAmount[PtFuelOre], port.ProductAmount[PtOrganics], port.ProductAmount[PtEquipment])
 
 	if err != nil {
 		return fmt.Errorf("failed to save port for sector %d: %w", sectorIndex, err)
@@ -820,24 +905,12 @@ func (d *SQLiteDatabase) LoadPort(sectorIndex int) (TPort, error) {
 	FROM ports WHERE sector_index = ?;`
 
 	var updateTime time.Time
-	var err error
-
-	// Use transaction if active, otherwise use direct connection
-	if d.tx != nil {
-		err = d.tx.QueryRow(query, sectorIndex).Scan(
-			&port.Name, &port.ClassIndex, &port.Dead, &port.BuildTime,
-			&port.BuyProduct[PtFuelOre], &port.BuyProduct[PtOrganics], &port.BuyProduct[PtEquipment],
-			&port.ProductPercent[PtFuelOre], &port.ProductPercent[PtOrganics], &port.ProductPercent[PtEquipment],
-			&port.ProductAmount[PtFuelOre], &port.ProductAmount[PtOrganics], &port.ProductAmount[PtEquipment],
-			&updateTime)
-	} else {
-		err = d.db.QueryRow(query, sectorIndex).Scan(
-			&port.Name, &port.ClassIndex, &port.Dead, &port.BuildTime,
-			&port.BuyProduct[PtFuelOre], &port.BuyProduct[PtOrganics], &port.BuyProduct[PtEquipment],
-			&port.ProductPercent[PtFuelOre], &port.ProductPercent[PtOrganics], &port.ProductPercent[PtEquipment],
-			&port.ProductAmount[PtFuelOre], &port.ProductAmount[PtOrganics], &port.ProductAmount[PtEquipment],
-			&updateTime)
-	}
+	err := d.conn().QueryRow(query, sectorIndex).Scan(
+		&port.Name, &port.ClassIndex, &port.Dead, &port.BuildTime,
+		&port.BuyProduct[PtFuelOre], &port.BuyProduct[PtOrganics], &port.BuyProduct[PtEquipment],
+		&port.ProductPercent[PtFuelOre], &port.ProductPercent[PtOrganics], &port.ProductPercent[PtEquipment],
+		&port.ProductAmount[PtFuelOre], &port.ProductAmount[PtOrganics], &port.ProductAmount[PtEquipment],
+		&updateTime)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -863,13 +936,7 @@ func (d *SQLiteDatabase) DeletePort(sectorIndex int) error {
 
 	query := `DELETE FROM ports WHERE sector_index = ?;`
 
-	// Use transaction if active, otherwise use direct connection (consistent with SavePort)
-	var err error
-	if d.tx != nil {
-		_, err = d.tx.Exec(query, sectorIndex)
-	} else {
-		_, err = d.db.Exec(query, sectorIndex)
-	}
+	_, err := d.conn().Exec(query, sectorIndex)
 
 	if err != nil {
 		return fmt.Errorf("failed to delete port for sector %d: %w", sectorIndex, err)
@@ -878,6 +945,32 @@ func (d *SQLiteDatabase) DeletePort(sectorIndex int) error {
 	return nil
 }
 
+// CountSectors returns how many sectors the database has data for
+func (d *SQLiteDatabase) CountSectors() (int, error) {
+	if !d.dbOpen {
+		return 0, fmt.Errorf("database not open")
+	}
+
+	var count int
+	if err := d.conn().QueryRow(`SELECT COUNT(*) FROM sectors WHERE sector_index > 0`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count sectors: %w", err)
+	}
+	return count, nil
+}
+
+// CountPorts returns how many sectors have a known port
+func (d *SQLiteDatabase) CountPorts() (int, error) {
+	if !d.dbOpen {
+		return 0, fmt.Errorf("database not open")
+	}
+
+	var count int
+	if err := d.conn().QueryRow(`SELECT COUNT(*) FROM ports`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count ports: %w", err)
+	}
+	return count, nil
+}
+
 // FindPortsByClass finds all ports with a specific class
 func (d *SQLiteDatabase) FindPortsByClass(classIndex int) ([]TPort, error) {
 	if !d.dbOpen {
@@ -892,7 +985,7 @@ func (d *SQLiteDatabase) FindPortsByClass(classIndex int) ([]TPort, error) {
 		   updated_at
 	FROM ports WHERE class_index = ? ORDER BY name;`
 
-	rows, err := d.db.Query(query, classIndex)
+	rows, err := d.conn().Query(query, classIndex)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find ports by class %d: %w", classIndex, err)
 	}
@@ -946,7 +1039,7 @@ func (d *SQLiteDatabase) FindPortsBuying(product TProductType) ([]TPort, error)
 		   updated_at
 	FROM ports WHERE %s = TRUE ORDER BY name;`, column)
 
-	rows, err := d.db.Query(query)
+	rows, err := d.conn().Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find ports buying %v: %w", product, err)
 	}
@@ -993,7 +1086,7 @@ func (d *SQLiteDatabase) GetPlayerStatsInfo() (api.PlayerStatsInfo, error) {
 		       ship_class, current_sector, player_name
 		FROM player_stats WHERE id = 1`
 
-	row := d.db.QueryRow(query)
+	row := d.conn().QueryRow(query)
 	err := row.Scan(
 		&info.Turns, &info.Credits, &info.Fighters, &info.Shields, &info.TotalHolds,
 		&info.OreHolds, &info.OrgHolds, &info.EquHolds, &info.ColHolds, &info.Photons,
@@ -1013,6 +1106,7 @@ func (d *SQLiteDatabase) GetPlayerStatsInfo() (api.PlayerStatsInfo, error) {
 		return info, fmt.Errorf("failed to get player stats info: %w", err)
 	}
 
+	info.EmptyHolds = max(info.TotalHolds-info.OreHolds-info.OrgHolds-info.EquHolds-info.ColHolds, 0)
 	return info, nil
 }
 
@@ -1029,20 +1123,21 @@ func (d *SQLiteDatabase) GetSectorInfo(sectorIndex int) (api.SectorInfo, error)
 	query := `
 		SELECT constellation, beacon, nav_haz, 
 		       warp1, warp2, warp3, warp4, warp5, warp6,
-		       density, anomaly, explored
+		       density, anomaly, explored, update_time
 		FROM sectors WHERE sector_index = ?`
 
-	row := d.db.QueryRow(query, sectorIndex)
+	row := d.conn().QueryRow(query, sectorIndex)
 
 	var constellation, beacon sql.NullString
 	var navHaz, density sql.NullInt64
 	var warps [6]sql.NullInt64
 	var anomaly sql.NullBool
 	var explored sql.NullInt64
+	var updateTime sql.NullTime
 
 	err := row.Scan(&constellation, &beacon, &navHaz,
 		&warps[0], &warps[1], &warps[2], &warps[3], &warps[4], &warps[5],
-		&density, &anomaly, &explored)
+		&density, &anomaly, &explored, &updateTime)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -1062,6 +1157,9 @@ func (d *SQLiteDatabase) GetSectorInfo(sectorIndex int) (api.SectorInfo, error)
 	if navHaz.Valid {
 		info.NavHaz = int(navHaz.Int64)
 	}
+	if updateTime.Valid {
+		info.LastSeen = updateTime.Time
+	}
 
 	// Build warps array from non-zero values
 	warpList := make([]int, 0, 6)
@@ -1071,24 +1169,39 @@ func (d *SQLiteDatabase) GetSectorInfo(sectorIndex int) (api.SectorInfo, error)
 		}
 	}
 	info.Warps = warpList
+	if inferred, err := d.GetInferredWarps(sectorIndex); err == nil && len(inferred) > 0 {
+		info.InferredWarps = inferred
+	}
 
 	// Check for port presence
 	portQuery := `SELECT COUNT(*) FROM ports WHERE sector_index = ?`
 	var portCount int
-	if err := d.db.QueryRow(portQuery, sectorIndex).Scan(&portCount); err == nil {
+	if err := d.conn().QueryRow(portQuery, sectorIndex).Scan(&portCount); err == nil {
 		info.HasPort = portCount > 0
 	}
 
-	// Count traders
-	tradersQuery := `SELECT COUNT(*) FROM traders WHERE sector_index = ?`
-	var traderCount int
-	if err := d.db.QueryRow(tradersQuery, sectorIndex).Scan(&traderCount); err == nil {
-		info.HasTraders = traderCount
+	// Count traders and find the most hostile
+	if rows, err := d.conn().Query(`SELECT alignment FROM traders WHERE sector_index = ?`, sectorIndex); err == nil {
+		for rows.Next() {
+			var alignment sql.NullString
+			if rows.Scan(&alignment) != nil {
+				continue
+			}
+			info.HasTraders++
+			info.TraderAlignment = max(info.TraderAlignment, api.ParseAlignment(alignment.String))
+		}
+		rows.Close()
 	}
 
-	// Set visited based on explored status (simplified logic for now)
+	// Only a visit or holo scan shows what is in a sector
 	if explored.Valid {
-		info.Visited = explored.Int64 > 0
+		info.Explored = api.Explored(explored.Int64)
+		info.Visited = info.Explored == api.ExploredHolo
+	}
+
+	info.Avoided = d.IsAvoided(sectorIndex)
+	if note, err := d.GetSectorNote(sectorIndex); err == nil {
+		info.Note = note
 	}
 
 	return info, nil
@@ -1110,7 +1223,7 @@ func (d *SQLiteDatabase) GetPortInfo(sectorIndex int) (*api.PortInfo, error) {
 		       updated_at
 		FROM ports WHERE sector_index = ?`
 
-	row := d.db.QueryRow(query, sectorIndex)
+	row := d.conn().QueryRow(query, sectorIndex)
 
 	var name sql.NullString
 	var dead sql.NullBool
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs statements against either the database or the active transaction.
+// Both *sql.DB and *sql.Tx satisfy it.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// GetExecutor returns the active transaction if one is open, otherwise the database.
+// Writes made through it join the caller's transaction instead of contending for the
+// write lock, and reads see data not yet committed.
+func (d *SQLiteDatabase) GetExecutor() Executor {
+	return d.conn()
+}
+
+// InTransaction returns true if a transaction is active
+func (d *SQLiteDatabase) InTransaction() bool {
+	d.txMu.RLock()
+	defer d.txMu.RUnlock()
+	return d.tx != nil
+}
+
+// conn returns the active transaction if one is open, otherwise the database
+func (d *SQLiteDatabase) conn() Executor {
+	d.txMu.RLock()
+	defer d.txMu.RUnlock()
+
+	if d.tx != nil {
+		return txExecutor{d: d}
+	}
+	if d.db == nil {
+		return nil
+	}
+	return d.db
+}
+
+// txExecutor runs each statement on the transaction if it's still open, otherwise on the
+// database. It holds the transaction lock while the statement starts, so the transaction
+// can't end between choosing it and using it; rows keep working after a commit because
+// the transaction runs on a plain connection rather than a *sql.Tx.
+type txExecutor struct {
+	d *SQLiteDatabase
+}
+
+func (e txExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	e.d.txMu.RLock()
+	defer e.d.txMu.RUnlock()
+
+	if e.d.tx != nil {
+		return e.d.tx.ExecContext(context.Background(), query, args...)
+	}
+	return e.d.db.Exec(query, args...)
+}
+
+func (e txExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	e.d.txMu.RLock()
+	defer e.d.txMu.RUnlock()
+
+	if e.d.tx != nil {
+		return e.d.tx.QueryContext(context.Background(), query, args...)
+	}
+	return e.d.db.Query(query, args...)
+}
+
+func (e txExecutor) QueryRow(query string, args ...any) *sql.Row {
+	e.d.txMu.RLock()
+	defer e.d.txMu.RUnlock()
+
+	if e.d.tx != nil {
+		return e.d.tx.QueryRowContext(context.Background(), query, args...)
+	}
+	return e.d.db.QueryRow(query, args...)
+}
+
+// loadSectorRow runs the prepared load statement, or the same query on the active transaction if one is open
+func (d *SQLiteDatabase) loadSectorRow(index int) *sql.Row {
+	d.txMu.RLock()
+	defer d.txMu.RUnlock()
+
+	if d.tx != nil {
+		return d.tx.QueryRowContext(context.Background(), loadSectorQuery, index)
+	}
+	return d.loadSectorStmt.QueryRow(index)
+}
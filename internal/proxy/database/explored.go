@@ -0,0 +1,101 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"twist/internal/api"
+)
+
+// LoadExploredStatus returns the exploration status of each requested sector in one
+// query. Sectors not in the database are reported as EtNo.
+func (d *SQLiteDatabase) LoadExploredStatus(sectors []int) (map[int]TSectorExploredType, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	explored := make(map[int]TSectorExploredType, len(sectors))
+	if len(sectors) == 0 {
+		return explored, nil
+	}
+
+	args := make([]interface{}, len(sectors))
+	for i, sector := range sectors {
+		args[i] = sector
+		explored[sector] = EtNo
+	}
+
+	query := `SELECT sector_index, explored FROM sectors WHERE sector_index IN (?` + strings.Repeat(", ?", len(sectors)-1) + `)`
+	rows, err := d.conn().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load explored status: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sectorIndex int
+		var status TSectorExploredType
+		if err := rows.Scan(&sectorIndex, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan explored status: %w", err)
+		}
+		explored[sectorIndex] = status
+	}
+
+	return explored, rows.Err()
+}
+
+// CountSectorsByExplored counts the sectors in the database by exploration status in one
+// query. Sectors the database has no row for aren't counted.
+func (d *SQLiteDatabase) CountSectorsByExplored() (map[TSectorExploredType]int, error) {
+	counts, _, err := d.countSectorsByExplored()
+	return counts, err
+}
+
+// countSectorsByExplored counts sectors by exploration status and finds the highest sector
+// number stored, in one GROUP BY query
+func (d *SQLiteDatabase) countSectorsByExplored() (map[TSectorExploredType]int, int, error) {
+	if !d.dbOpen {
+		return nil, 0, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT explored, COUNT(*), MAX(sector_index) FROM sectors WHERE sector_index > 0 GROUP BY explored`)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count sectors by explored status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[TSectorExploredType]int)
+	highest := 0
+	for rows.Next() {
+		var status TSectorExploredType
+		var count, groupHighest int
+		if err := rows.Scan(&status, &count, &groupHighest); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan explored count: %w", err)
+		}
+		counts[status] = count
+		highest = max(highest, groupHighest)
+	}
+
+	return counts, highest, rows.Err()
+}
+
+// GetExplorationStats summarizes CountSectorsByExplored. The universe is at least as big as
+// when the database was opened and as its highest known sector; sectors missing from the
+// table count as unexplored.
+func (d *SQLiteDatabase) GetExplorationStats() (api.ExplorationStats, error) {
+	counts, highest, err := d.countSectorsByExplored()
+	if err != nil {
+		return api.ExplorationStats{}, err
+	}
+
+	stats := api.ExplorationStats{
+		Calculated: counts[EtCalc],
+		Density:    counts[EtDensity],
+		Holo:       counts[EtHolo],
+	}
+	for _, count := range counts {
+		stats.Known += count
+	}
+	stats.TotalSectors = max(d.sectors, highest)
+	stats.Unexplored = stats.TotalSectors - stats.Calculated - stats.Density - stats.Holo
+	return stats, nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import "testing"
+
+func TestLoadExploredStatus(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	holo := NULLSector()
+	holo.Explored = EtHolo
+	if err := db.SaveSector(holo, 10); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+	calc := NULLSector()
+	calc.Explored = EtCalc
+	if err := db.SaveSector(calc, 20); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	explored, err := db.LoadExploredStatus([]int{10, 20, 30})
+	if err != nil {
+		t.Fatalf("LoadExploredStatus failed: %v", err)
+	}
+
+	expected := map[int]TSectorExploredType{10: EtHolo, 20: EtCalc, 30: EtNo}
+	if len(explored) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, explored)
+	}
+	for sector, status := range expected {
+		if explored[sector] != status {
+			t.Errorf("Sector %d: expected %v, got %v", sector, status, explored[sector])
+		}
+	}
+}
+
+func TestCountSectorsByExplored(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	statuses := []TSectorExploredType{EtHolo, EtHolo, EtHolo, EtDensity, EtDensity, EtCalc, EtNo}
+	for i, explored := range statuses {
+		s := NULLSector()
+		s.Explored = explored
+		if err := db.SaveSector(s, i+1); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", i+1, err)
+		}
+	}
+
+	counts, err := db.CountSectorsByExplored()
+	if err != nil {
+		t.Fatalf("CountSectorsByExplored failed: %v", err)
+	}
+
+	expected := map[TSectorExploredType]int{EtHolo: 3, EtDensity: 2, EtCalc: 1, EtNo: 1}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, counts)
+	}
+	for status, count := range expected {
+		if counts[status] != count {
+			t.Errorf("Status %v: expected %d, got %d", status, count, counts[status])
+		}
+	}
+}
+
+func TestGetExplorationStats(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	for sector, explored := range map[int]TSectorExploredType{1: EtHolo, 2: EtHolo, 3: EtDensity, 4: EtCalc, 5: EtNo, 10: EtHolo} {
+		s := NULLSector()
+		s.Explored = explored
+		if err := db.SaveSector(s, sector); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", sector, err)
+		}
+	}
+
+	stats, err := db.GetExplorationStats()
+	if err != nil {
+		t.Fatalf("GetExplorationStats failed: %v", err)
+	}
+
+	// Sectors 6-9 aren't in the table but still count as unexplored
+	if stats.TotalSectors != 10 || stats.Holo != 3 || stats.Density != 1 || stats.Calculated != 1 || stats.Unexplored != 5 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if stats.Known != 6 {
+		t.Errorf("Expected 6 stored sectors, got %d", stats.Known)
+	}
+	if stats.PercentHolo() != 30 {
+		t.Errorf("Expected 30%% holo explored, got %v", stats.PercentHolo())
+	}
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fmt"
+)
+
+// A warp is inferred when the parser adds it only because its destination was seen to warp
+// back, as TWX's AddWarp does to keep courses plottable. The game has one-way warps, so an
+// inferred warp may not exist; it stays inferred until the sector's own warp list is seen.
+
+// MarkWarpInferred records that the warp from fromSector to toSector was only inferred
+func (d *SQLiteDatabase) MarkWarpInferred(fromSector, toSector int) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	_, err := d.conn().Exec(`INSERT OR IGNORE INTO inferred_warps (from_sector, to_sector) VALUES (?, ?)`, fromSector, toSector)
+	if err != nil {
+		return fmt.Errorf("failed to mark warp %d -> %d inferred: %w", fromSector, toSector, err)
+	}
+	return nil
+}
+
+// ConfirmWarp records that the warp from fromSector to toSector was seen to exist
+func (d *SQLiteDatabase) ConfirmWarp(fromSector, toSector int) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	_, err := d.conn().Exec(`DELETE FROM inferred_warps WHERE from_sector = ? AND to_sector = ?`, fromSector, toSector)
+	if err != nil {
+		return fmt.Errorf("failed to confirm warp %d -> %d: %w", fromSector, toSector, err)
+	}
+	return nil
+}
+
+// ConfirmSectorWarps records that the sector's whole warp list was seen, so none of its
+// warps are inferred
+func (d *SQLiteDatabase) ConfirmSectorWarps(sectorIndex int) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+
+	if _, err := d.conn().Exec(`DELETE FROM inferred_warps WHERE from_sector = ?`, sectorIndex); err != nil {
+		return fmt.Errorf("failed to confirm warps of sector %d: %w", sectorIndex, err)
+	}
+	return nil
+}
+
+// GetInferredWarps returns the sector's inferred warp destinations in ascending order
+func (d *SQLiteDatabase) GetInferredWarps(sectorIndex int) ([]int, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT to_sector FROM inferred_warps WHERE from_sector = ? ORDER BY to_sector`, sectorIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inferred warps of sector %d: %w", sectorIndex, err)
+	}
+	defer rows.Close()
+
+	var warps []int
+	for rows.Next() {
+		var warp int
+		if err := rows.Scan(&warp); err != nil {
+			return nil, fmt.Errorf("failed to scan inferred warp: %w", err)
+		}
+		warps = append(warps, warp)
+	}
+	return warps, rows.Err()
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestInferredWarps(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	db.MarkWarpInferred(5, 9)
+	db.MarkWarpInferred(5, 2)
+	db.MarkWarpInferred(5, 9)
+	db.MarkWarpInferred(6, 5)
+
+	if inferred, err := db.GetInferredWarps(5); err != nil || len(inferred) != 2 || inferred[0] != 2 || inferred[1] != 9 {
+		t.Errorf("Expected 2 and 9 inferred from sector 5, got %v (%v)", inferred, err)
+	}
+
+	db.ConfirmWarp(5, 9)
+	if inferred, _ := db.GetInferredWarps(5); len(inferred) != 1 || inferred[0] != 2 {
+		t.Errorf("Expected only 2 left after confirming 9, got %v", inferred)
+	}
+
+	db.ConfirmSectorWarps(5)
+	if inferred, _ := db.GetInferredWarps(5); len(inferred) != 0 {
+		t.Errorf("Expected no inferred warps from sector 5, got %v", inferred)
+	}
+	if inferred, _ := db.GetInferredWarps(6); len(inferred) != 1 {
+		t.Errorf("Expected sector 6 untouched, got %v", inferred)
+	}
+}
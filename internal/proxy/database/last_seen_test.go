@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSectorInfoLastSeen(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	info, err := db.GetSectorInfo(1)
+	if err != nil {
+		t.Fatalf("GetSectorInfo failed: %v", err)
+	}
+	if info.LastSeen.IsZero() || time.Since(info.LastSeen) > time.Minute {
+		t.Errorf("Expected a saved sector to be seen just now, got %v", info.LastSeen)
+	}
+
+	// Parser trackers stamp sectors with SQLite's CURRENT_TIMESTAMP
+	if _, err := db.conn().Exec("UPDATE sectors SET update_time = '2026-01-02 03:04:05' WHERE sector_index = 2"); err != nil {
+		t.Fatalf("Failed to age sector: %v", err)
+	}
+	info, err = db.GetSectorInfo(2)
+	if err != nil {
+		t.Fatalf("GetSectorInfo failed: %v", err)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !info.LastSeen.Equal(want) {
+		t.Errorf("Expected last seen %v, got %v", want, info.LastSeen)
+	}
+	if age := info.Age(time.Date(2026, 1, 3, 3, 4, 5, 0, time.UTC)); age != 24*time.Hour {
+		t.Errorf("Expected an age of one day, got %v", age)
+	}
+
+	if _, err := db.conn().Exec("UPDATE sectors SET update_time = NULL WHERE sector_index = 3"); err != nil {
+		t.Fatalf("Failed to clear sector time: %v", err)
+	}
+	if info, err = db.GetSectorInfo(3); err != nil || !info.LastSeen.IsZero() || info.Age(time.Now()) != 0 {
+		t.Errorf("Expected a never seen sector, got %v (err %v)", info.LastSeen, err)
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PortDistance is a port and how many warps away it is
+type PortDistance struct {
+	Sector int
+	Class  int
+	Hops   int
+}
+
+// NearestPorts finds up to limit live ports of the given class within maxHops warps of
+// from, nearest first and by sector within a distance. A port in from itself is 0 hops
+// away. Avoided sectors are never entered, as when plotting a course.
+func (d *SQLiteDatabase) NearestPorts(from int, class int, maxHops int, limit int) ([]PortDistance, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+	if from <= 0 {
+		return nil, fmt.Errorf("invalid sector %d", from)
+	}
+	if class < 0 || class > 9 {
+		return nil, fmt.Errorf("invalid port class %d", class)
+	}
+	if maxHops < 0 {
+		return nil, fmt.Errorf("invalid hop distance %d", maxHops)
+	}
+	if limit < 1 {
+		return nil, fmt.Errorf("invalid port limit %d", limit)
+	}
+
+	ports, err := d.loadPortSectorsOfClass(class)
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == 0 {
+		return []PortDistance{}, nil
+	}
+
+	warps, err := d.loadWarpMap()
+	if err != nil {
+		return nil, err
+	}
+	avoided, err := d.loadAvoidedSet()
+	if err != nil {
+		return nil, err
+	}
+
+	distances := warpDistances(warps, from, maxHops, avoided)
+	distances[from] = 0
+
+	nearest := make([]PortDistance, 0)
+	for sector, hops := range distances {
+		if ports[sector] {
+			nearest = append(nearest, PortDistance{Sector: sector, Class: class, Hops: hops})
+		}
+	}
+
+	sort.Slice(nearest, func(i, j int) bool {
+		if nearest[i].Hops != nearest[j].Hops {
+			return nearest[i].Hops < nearest[j].Hops
+		}
+		return nearest[i].Sector < nearest[j].Sector
+	})
+	if len(nearest) > limit {
+		nearest = nearest[:limit]
+	}
+
+	return nearest, nil
+}
+
+// loadPortSectorsOfClass reads the sectors with a live port of the class
+func (d *SQLiteDatabase) loadPortSectorsOfClass(class int) (map[int]bool, error) {
+	rows, err := d.conn().Query(`SELECT sector_index FROM ports WHERE class_index = ? AND NOT COALESCE(dead, FALSE)`, class)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ports: %w", err)
+	}
+	defer rows.Close()
+
+	sectors := make(map[int]bool)
+	for rows.Next() {
+		var sectorIndex int
+		if err := rows.Scan(&sectorIndex); err != nil {
+			return nil, fmt.Errorf("failed to scan port: %w", err)
+		}
+		sectors[sectorIndex] = true
+	}
+
+	return sectors, rows.Err()
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNearestPorts(t *testing.T) {
+	// Routes from 1 to 5: 1-2-5 (2 hops) and 1-3-4-5
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	savePortForTest(t, db, 1, 1, "BBS", 50)
+	savePortForTest(t, db, 2, 2, "BSB", 50)
+	savePortForTest(t, db, 4, 1, "BBS", 50)
+	savePortForTest(t, db, 5, 1, "BBS", 50)
+	if err := db.SavePort(TPort{Name: "Dead", ClassIndex: 1, Dead: true}, 3); err != nil {
+		t.Fatalf("Failed to save port 3: %v", err)
+	}
+
+	tests := []struct {
+		name                     string
+		from, class, hops, limit int
+		expected                 []PortDistance
+	}{
+		{"own sector first", 1, 1, 5, 10, []PortDistance{{1, 1, 0}, {4, 1, 2}, {5, 1, 2}}},
+		{"limited", 1, 1, 5, 2, []PortDistance{{1, 1, 0}, {4, 1, 2}}},
+		{"within hops, dead port skipped", 3, 1, 1, 10, []PortDistance{{1, 1, 1}, {4, 1, 1}}},
+		{"other class", 5, 2, 3, 10, []PortDistance{{2, 2, 1}}},
+		{"none in range", 4, 2, 1, 10, []PortDistance{}},
+	}
+	for _, test := range tests {
+		ports, err := db.NearestPorts(test.from, test.class, test.hops, test.limit)
+		if err != nil {
+			t.Fatalf("%s: NearestPorts failed: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(ports, test.expected) {
+			t.Errorf("%s: got %+v, want %+v", test.name, ports, test.expected)
+		}
+	}
+
+	// Avoiding 2 leaves only the long way round to 5
+	if err := db.AddAvoid(2); err != nil {
+		t.Fatalf("AddAvoid failed: %v", err)
+	}
+	ports, err := db.NearestPorts(1, 1, 2, 10)
+	if err != nil {
+		t.Fatalf("NearestPorts failed: %v", err)
+	}
+	if expected := []PortDistance{{1, 1, 0}, {4, 1, 2}}; !reflect.DeepEqual(ports, expected) {
+		t.Errorf("with 2 avoided got %+v, want %+v", ports, expected)
+	}
+
+	for _, bad := range [][4]int{{0, 1, 1, 1}, {1, 10, 1, 1}, {1, 1, -1, 1}, {1, 1, 1, 0}} {
+		if _, err := db.NearestPorts(bad[0], bad[1], bad[2], bad[3]); err == nil {
+			t.Errorf("NearestPorts%v should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"twist/internal/log"
+)
+
+// SetSectorNote stores a player note for a sector. An empty note removes it.
+func (d *SQLiteDatabase) SetSectorNote(sectorIndex int, note string) error {
+	if !d.dbOpen {
+		return fmt.Errorf("database not open")
+	}
+	if sectorIndex <= 0 {
+		return fmt.Errorf("invalid sector %d", sectorIndex)
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" {
+		if _, err := d.conn().Exec(`DELETE FROM sector_notes WHERE sector_index = ?`, sectorIndex); err != nil {
+			return fmt.Errorf("failed to remove note for sector %d: %w", sectorIndex, err)
+		}
+		log.Info("Sector note removed", "sector", sectorIndex)
+		return nil
+	}
+
+	_, err := d.conn().Exec(`
+		INSERT INTO sector_notes (sector_index, note, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(sector_index) DO UPDATE SET note = excluded.note, updated_at = excluded.updated_at`,
+		sectorIndex, note)
+	if err != nil {
+		return fmt.Errorf("failed to set note for sector %d: %w", sectorIndex, err)
+	}
+
+	log.Info("Sector note set", "sector", sectorIndex)
+	return nil
+}
+
+// GetSectorNote returns the player note for a sector, or "" if it has none
+func (d *SQLiteDatabase) GetSectorNote(sectorIndex int) (string, error) {
+	if !d.dbOpen {
+		return "", fmt.Errorf("database not open")
+	}
+
+	var note string
+	err := d.conn().QueryRow(`SELECT note FROM sector_notes WHERE sector_index = ?`, sectorIndex).Scan(&note)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get note for sector %d: %w", sectorIndex, err)
+	}
+	return note, nil
+}
@@ -0,0 +1,37 @@
+package database
+
+import "testing"
+
+func TestSectorNotes(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	if err := db.SetSectorNote(2, "  enemy planet  "); err != nil {
+		t.Fatalf("SetSectorNote failed: %v", err)
+	}
+	if note, err := db.GetSectorNote(2); err != nil || note != "enemy planet" {
+		t.Errorf("Expected trimmed note, got %q (%v)", note, err)
+	}
+
+	if err := db.SetSectorNote(2, "safe port"); err != nil {
+		t.Fatalf("SetSectorNote update failed: %v", err)
+	}
+	info, err := db.GetSectorInfo(2)
+	if err != nil {
+		t.Fatalf("GetSectorInfo failed: %v", err)
+	}
+	if info.Note != "safe port" {
+		t.Errorf("Expected sector info note 'safe port', got %q", info.Note)
+	}
+
+	if err := db.SetSectorNote(2, ""); err != nil {
+		t.Fatalf("SetSectorNote removal failed: %v", err)
+	}
+	if note, err := db.GetSectorNote(2); err != nil || note != "" {
+		t.Errorf("Expected note removed, got %q (%v)", note, err)
+	}
+
+	if err := db.SetSectorNote(0, "nowhere"); err == nil {
+		t.Error("Expected invalid sector to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pruneBatchSize bounds how many sectors go into one statement's IN list
+const pruneBatchSize = 500
+
+// PruneVolatileData clears what moves around from sectors last seen longer than olderThan
+// ago: traders, ships, aliens and other players' fighters. Warps, ports, planets, mines and
+// the player's own or corp fighters are kept, as is when the sector was last seen. Returns
+// the number of sectors that had something cleared.
+func (d *SQLiteDatabase) PruneVolatileData(olderThan time.Duration) (int, error) {
+	if !d.dbOpen {
+		return 0, fmt.Errorf("database not open")
+	}
+	if olderThan <= 0 {
+		return 0, fmt.Errorf("prune age must be positive")
+	}
+
+	// Update times are stored in Go's time format, which SQLite can't compare, so the cutoff
+	// is applied here
+	rows, err := d.conn().Query(`SELECT sector_index, update_time FROM sectors WHERE update_time IS NOT NULL`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load sector update times: %w", err)
+	}
+	cutoff := time.Now().Add(-olderThan)
+	var stale []int
+	for rows.Next() {
+		var sectorIndex int
+		var upDate sql.NullTime
+		if err := rows.Scan(&sectorIndex, &upDate); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan sector update time: %w", err)
+		}
+		if upDate.Valid && upDate.Time.Before(cutoff) {
+			stale = append(stale, sectorIndex)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to load sector update times: %w", err)
+	}
+
+	pruned := make(map[int]bool)
+	for start := 0; start < len(stale); start += pruneBatchSize {
+		if err := d.pruneSectors(stale[start:min(start+pruneBatchSize, len(stale))], pruned); err != nil {
+			return len(pruned), err
+		}
+	}
+	return len(pruned), nil
+}
+
+// pruneSectors clears the volatile data of the given sectors, adding each one that had any
+// to pruned
+func (d *SQLiteDatabase) pruneSectors(sectors []int, pruned map[int]bool) error {
+	args := make([]interface{}, len(sectors))
+	for i, sector := range sectors {
+		args[i] = sector
+	}
+	in := `sector_index IN (?` + strings.Repeat(", ?", len(sectors)-1) + `)`
+
+	statements := []string{
+		`DELETE FROM traders WHERE ` + in + ` RETURNING sector_index`,
+		`DELETE FROM ships WHERE ` + in + ` RETURNING sector_index`,
+		`UPDATE sectors SET aliens_count = 0, aliens_type = '' WHERE ` + in + ` AND aliens_count > 0 RETURNING sector_index`,
+		fmt.Sprintf(`UPDATE sectors SET figs_quantity = 0, figs_owner = '', figs_type = %d WHERE `, FtNone) + in + ` AND figs_quantity > 0
+			AND NOT (figs_owner LIKE 'yours' OR figs_owner LIKE '%your corp%') RETURNING sector_index`,
+	}
+	for _, statement := range statements {
+		rows, err := d.conn().Query(statement, args...)
+		if err != nil {
+			return fmt.Errorf("failed to prune sectors: %w", err)
+		}
+		for rows.Next() {
+			var sectorIndex int
+			if err := rows.Scan(&sectorIndex); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan pruned sector: %w", err)
+			}
+			pruned[sectorIndex] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to prune sectors: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneVolatileData(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	save := func(index int, figsOwner string) {
+		sector := NULLSector()
+		sector.Warp[0] = 1
+		sector.Explored = EtHolo
+		sector.Figs = TSpaceObject{Quantity: 50, Owner: figsOwner, FigType: FtDefensive}
+		sector.AlienCount = 2
+		sector.AlienType = AlienTypeFerrengi
+		ships := []TShip{{Name: "Drifter", Owner: "Bob", ShipType: "Merchant Cruiser"}}
+		traders := []TTrader{{Name: "Alice", ShipName: "Runner", ShipType: "Scout Marauder", Figs: 10}}
+		planets := []TPlanet{{Name: "Terra"}}
+		if err := db.SaveSectorWithCollections(sector, index, ships, traders, planets); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", index, err)
+		}
+	}
+	save(2, "Bob")
+	save(3, "yours")
+	save(4, "Bob")
+
+	// Sectors 2 and 3 were last seen two days ago; 4 was just seen
+	old := time.Now().Add(-48 * time.Hour)
+	if _, err := db.GetDB().Exec(`UPDATE sectors SET update_time = ? WHERE sector_index IN (2, 3)`, old); err != nil {
+		t.Fatalf("Failed to age sectors: %v", err)
+	}
+
+	pruned, err := db.PruneVolatileData(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("PruneVolatileData failed: %v", err)
+	}
+	if pruned != 2 {
+		t.Errorf("Expected 2 sectors pruned, got %d", pruned)
+	}
+
+	sector, err := db.LoadSector(2)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if len(sector.Traders) != 0 || len(sector.Ships) != 0 || sector.AlienCount != 0 || sector.Figs.Quantity != 0 {
+		t.Errorf("Expected sector 2's volatile data cleared, got %+v", sector)
+	}
+	if sector.Warp[0] != 1 || len(sector.Planets) != 1 || sector.Explored != EtHolo {
+		t.Errorf("Expected sector 2's warps and planets kept, got %+v", sector)
+	}
+	if sector.UpDate.After(old.Add(time.Second)) {
+		t.Errorf("Expected sector 2's last seen time kept, got %v", sector.UpDate)
+	}
+
+	if sector, _ := db.LoadSector(3); sector.Figs.Quantity != 50 || len(sector.Traders) != 0 {
+		t.Errorf("Expected the player's own fighters kept in sector 3, got %+v", sector)
+	}
+	if sector, _ := db.LoadSector(4); len(sector.Traders) != 1 || sector.Figs.Quantity != 50 {
+		t.Errorf("Expected recently seen sector 4 untouched, got %+v", sector)
+	}
+
+	if pruned, err := db.PruneVolatileData(24 * time.Hour); err != nil || pruned != 0 {
+		t.Errorf("Expected nothing left to prune, got %d (%v)", pruned, err)
+	}
+}
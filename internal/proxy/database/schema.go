@@ -57,7 +57,11 @@ func (d *SQLiteDatabase) createSchema() error {
 		mines_armid_owner TEXT DEFAULT '',
 		
 		mines_limpet_quantity INTEGER DEFAULT 0,
-		mines_limpet_owner TEXT DEFAULT ''
+		mines_limpet_owner TEXT DEFAULT '',
+		
+		-- Alien occupants (Ferrengi and other NPC races)
+		aliens_count INTEGER DEFAULT 0,
+		aliens_type TEXT DEFAULT ''
 	);`
 
 	// Ships table (dynamic list)
@@ -81,6 +85,7 @@ func (d *SQLiteDatabase) createSchema() error {
 		ship_type TEXT DEFAULT '',
 		ship_name TEXT DEFAULT '',
 		fighters INTEGER DEFAULT 0,
+		alignment TEXT DEFAULT '',
 		FOREIGN KEY (sector_index) REFERENCES sectors(sector_index) ON DELETE CASCADE
 	);`
 
@@ -262,6 +267,46 @@ func (d *SQLiteDatabase) createSchema() error {
 		FOREIGN KEY (sector_index) REFERENCES sectors(sector_index) ON DELETE CASCADE
 	);`
 
+	// Avoided sectors table (TWX avoid list - sectors skipped when plotting courses)
+	avoidsTable := `
+	CREATE TABLE IF NOT EXISTS avoids (
+		sector_index INTEGER PRIMARY KEY,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Sector notes table (free-form player notes flagging a sector)
+	sectorNotesTable := `
+	CREATE TABLE IF NOT EXISTS sector_notes (
+		sector_index INTEGER PRIMARY KEY,
+		note TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Named burst commands table (saved from the burst menu)
+	burstsTable := `
+	CREATE TABLE IF NOT EXISTS bursts (
+		name TEXT PRIMARY KEY COLLATE NOCASE,
+		burst TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Warps added only because their destination warps back (TWX AddWarp), until the
+	// sector's own warp list confirms them
+	inferredWarpsTable := `
+	CREATE TABLE IF NOT EXISTS inferred_warps (
+		from_sector INTEGER NOT NULL,
+		to_sector INTEGER NOT NULL,
+		PRIMARY KEY (from_sector, to_sector)
+	);`
+
+	// Recently sent bursts, newest last, so the last burst survives a restart
+	burstHistoryTable := `
+	CREATE TABLE IF NOT EXISTS burst_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		burst TEXT NOT NULL,
+		sent_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
 	// Create indexes for performance
 	indexes := []string{
 		`CREATE INDEX IF NOT EXISTS idx_sectors_constellation ON sectors(constellation);`,
@@ -300,7 +345,7 @@ func (d *SQLiteDatabase) createSchema() error {
 	}
 
 	// Execute all DDL statements
-	statements := []string{sectorsTable, shipsTable, tradersTable, planetsTable, sectorVarsTable, scriptVarsTable, scriptVariablesTable, scriptsTable, scriptTriggersTable, scriptCallStackTable, messageHistoryTable, playerStatsTable, portsTable}
+	statements := []string{sectorsTable, shipsTable, tradersTable, planetsTable, sectorVarsTable, scriptVarsTable, scriptVariablesTable, scriptsTable, scriptTriggersTable, scriptCallStackTable, messageHistoryTable, playerStatsTable, portsTable, avoidsTable, sectorNotesTable, burstsTable, burstHistoryTable, inferredWarpsTable}
 	statements = append(statements, indexes...)
 
 	for _, stmt := range statements {
@@ -309,6 +354,37 @@ func (d *SQLiteDatabase) createSchema() error {
 		}
 	}
 
+	// Columns added to existing tables, which CREATE TABLE IF NOT EXISTS leaves out of
+	// databases created before them
+	addedColumns := []struct {
+		table, name, definition string
+	}{
+		{"traders", "alignment", "TEXT DEFAULT ''"},
+		{"sectors", "aliens_count", "INTEGER DEFAULT 0"},
+		{"sectors", "aliens_type", "TEXT DEFAULT ''"},
+	}
+	for _, col := range addedColumns {
+		if err := d.addColumnIfMissing(col.table, col.name, col.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addColumnIfMissing adds a column to a table unless the table already has it
+func (d *SQLiteDatabase) addColumnIfMissing(table, column, definition string) error {
+	var count int
+	query := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?;`
+	if err := d.db.QueryRow(query, table, column).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check for %s.%s column: %w", table, column, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if _, err := d.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s;`, table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add %s.%s column: %w", table, column, err)
+	}
 	return nil
 }
 
@@ -345,20 +421,21 @@ func (d *SQLiteDatabase) getSectorCount() (int, error) {
 	return count, nil
 }
 
-// prepareStatements creates prepared statements for performance
-func (d *SQLiteDatabase) prepareStatements() error {
-	// Load sector statement (Phase 2: port data removed from sectors)
-	loadQuery := `
+// loadSectorQuery loads the main sector data (Phase 2: port data removed from sectors)
+const loadSectorQuery = `
 	SELECT 
 		warp1, warp2, warp3, warp4, warp5, warp6,
 		constellation, beacon, nav_haz, density, anomaly, warps, explored, update_time,
 		figs_quantity, figs_owner, figs_type,
 		mines_armid_quantity, mines_armid_owner,
-		mines_limpet_quantity, mines_limpet_owner
+		mines_limpet_quantity, mines_limpet_owner,
+		aliens_count, aliens_type
 	FROM sectors WHERE sector_index = ?;`
 
+// prepareStatements creates prepared statements for performance
+func (d *SQLiteDatabase) prepareStatements() error {
 	var err error
-	d.loadSectorStmt, err = d.db.Prepare(loadQuery)
+	d.loadSectorStmt, err = d.db.Prepare(loadSectorQuery)
 	if err != nil {
 		return fmt.Errorf("failed to prepare load sector statement: %w", err)
 	}
@@ -371,9 +448,10 @@ func (d *SQLiteDatabase) prepareStatements() error {
 		constellation, beacon, nav_haz, density, anomaly, warps, explored, update_time,
 		figs_quantity, figs_owner, figs_type,
 		mines_armid_quantity, mines_armid_owner,
-		mines_limpet_quantity, mines_limpet_owner
+		mines_limpet_quantity, mines_limpet_owner,
+		aliens_count, aliens_type
 	) VALUES (
-		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 	);`
 
 	d.saveSectorStmt, err = d.db.Prepare(saveQuery)
@@ -388,7 +466,7 @@ func (d *SQLiteDatabase) prepareStatements() error {
 func (d *SQLiteDatabase) loadSectorRelatedData(sectorIndex int, sector *TSector) error {
 	// Load ships
 	shipsQuery := `SELECT name, owner, ship_type, fighters FROM ships WHERE sector_index = ?;`
-	rows, err := d.db.Query(shipsQuery, sectorIndex)
+	rows, err := d.conn().Query(shipsQuery, sectorIndex)
 	if err != nil {
 		return fmt.Errorf("failed to load ships: %w", err)
 	}
@@ -403,8 +481,8 @@ func (d *SQLiteDatabase) loadSectorRelatedData(sectorIndex int, sector *TSector)
 	}
 
 	// Load traders
-	tradersQuery := `SELECT name, ship_type, ship_name, fighters FROM traders WHERE sector_index = ?;`
-	rows, err = d.db.Query(tradersQuery, sectorIndex)
+	tradersQuery := `SELECT name, ship_type, ship_name, fighters, alignment FROM traders WHERE sector_index = ?;`
+	rows, err = d.conn().Query(tradersQuery, sectorIndex)
 	if err != nil {
 		return fmt.Errorf("failed to load traders: %w", err)
 	}
@@ -412,7 +490,7 @@ func (d *SQLiteDatabase) loadSectorRelatedData(sectorIndex int, sector *TSector)
 
 	for rows.Next() {
 		var trader TTrader
-		if err := rows.Scan(&trader.Name, &trader.ShipType, &trader.ShipName, &trader.Figs); err != nil {
+		if err := rows.Scan(&trader.Name, &trader.ShipType, &trader.ShipName, &trader.Figs, &trader.Alignment); err != nil {
 			return fmt.Errorf("failed to scan trader: %w", err)
 		}
 		sector.Traders = append(sector.Traders, trader)
@@ -420,7 +498,7 @@ func (d *SQLiteDatabase) loadSectorRelatedData(sectorIndex int, sector *TSector)
 
 	// Load planets
 	planetsQuery := `SELECT name, owner, fighters, citadel, stardock FROM planets WHERE sector_index = ?;`
-	rows, err = d.db.Query(planetsQuery, sectorIndex)
+	rows, err = d.conn().Query(planetsQuery, sectorIndex)
 	if err != nil {
 		return fmt.Errorf("failed to load planets: %w", err)
 	}
@@ -436,7 +514,7 @@ func (d *SQLiteDatabase) loadSectorRelatedData(sectorIndex int, sector *TSector)
 
 	// Load sector variables
 	varsQuery := `SELECT var_name, value FROM sector_vars WHERE sector_index = ?;`
-	rows, err = d.db.Query(varsQuery, sectorIndex)
+	rows, err = d.conn().Query(varsQuery, sectorIndex)
 	if err != nil {
 		return fmt.Errorf("failed to load sector vars: %w", err)
 	}
@@ -459,7 +537,7 @@ func (d *SQLiteDatabase) saveSectorRelatedData(sectorIndex int, sector TSector)
 	tables := []string{"ships", "traders", "planets", "sector_vars"}
 	for _, table := range tables {
 		query := fmt.Sprintf("DELETE FROM %s WHERE sector_index = ?;", table)
-		if _, err := d.tx.Exec(query, sectorIndex); err != nil {
+		if _, err := d.conn().Exec(query, sectorIndex); err != nil {
 			return fmt.Errorf("failed to clear %s: %w", table, err)
 		}
 	}
@@ -468,7 +546,7 @@ func (d *SQLiteDatabase) saveSectorRelatedData(sectorIndex int, sector TSector)
 	if len(sector.Ships) > 0 {
 		shipQuery := `INSERT INTO ships (sector_index, name, owner, ship_type, fighters) VALUES (?, ?, ?, ?, ?);`
 		for _, ship := range sector.Ships {
-			if _, err := d.tx.Exec(shipQuery, sectorIndex, ship.Name, ship.Owner, ship.ShipType, ship.Figs); err != nil {
+			if _, err := d.conn().Exec(shipQuery, sectorIndex, ship.Name, ship.Owner, ship.ShipType, ship.Figs); err != nil {
 				return fmt.Errorf("failed to save ship: %w", err)
 			}
 		}
@@ -476,9 +554,9 @@ func (d *SQLiteDatabase) saveSectorRelatedData(sectorIndex int, sector TSector)
 
 	// Save traders
 	if len(sector.Traders) > 0 {
-		traderQuery := `INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters) VALUES (?, ?, ?, ?, ?);`
+		traderQuery := `INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters, alignment) VALUES (?, ?, ?, ?, ?, ?);`
 		for _, trader := range sector.Traders {
-			if _, err := d.tx.Exec(traderQuery, sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Figs); err != nil {
+			if _, err := d.conn().Exec(traderQuery, sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Figs, trader.Alignment); err != nil {
 				return fmt.Errorf("failed to save trader: %w", err)
 			}
 		}
@@ -488,7 +566,7 @@ func (d *SQLiteDatabase) saveSectorRelatedData(sectorIndex int, sector TSector)
 	if len(sector.Planets) > 0 {
 		planetQuery := `INSERT INTO planets (sector_index, name, owner, fighters, citadel, stardock) VALUES (?, ?, ?, ?, ?, ?);`
 		for _, planet := range sector.Planets {
-			if _, err := d.tx.Exec(planetQuery, sectorIndex, planet.Name, planet.Owner, planet.Fighters, planet.Citadel, planet.Stardock); err != nil {
+			if _, err := d.conn().Exec(planetQuery, sectorIndex, planet.Name, planet.Owner, planet.Fighters, planet.Citadel, planet.Stardock); err != nil {
 				return fmt.Errorf("failed to save planet: %w", err)
 			}
 		}
@@ -498,7 +576,7 @@ func (d *SQLiteDatabase) saveSectorRelatedData(sectorIndex int, sector TSector)
 	if len(sector.Vars) > 0 {
 		varQuery := `INSERT INTO sector_vars (sector_index, var_name, value) VALUES (?, ?, ?);`
 		for _, sectorVar := range sector.Vars {
-			if _, err := d.tx.Exec(varQuery, sectorIndex, sectorVar.VarName, sectorVar.Value); err != nil {
+			if _, err := d.conn().Exec(varQuery, sectorIndex, sectorVar.VarName, sectorVar.Value); err != nil {
 				return fmt.Errorf("failed to save sector var: %w", err)
 			}
 		}
@@ -513,7 +591,7 @@ func (d *SQLiteDatabase) saveSectorCollectionsWithParams(sectorIndex int, ships
 	tables := []string{"ships", "traders", "planets", "sector_vars"}
 	for _, table := range tables {
 		query := fmt.Sprintf("DELETE FROM %s WHERE sector_index = ?;", table)
-		if _, err := d.tx.Exec(query, sectorIndex); err != nil {
+		if _, err := d.conn().Exec(query, sectorIndex); err != nil {
 			return fmt.Errorf("failed to clear %s: %w", table, err)
 		}
 	}
@@ -522,7 +600,7 @@ func (d *SQLiteDatabase) saveSectorCollectionsWithParams(sectorIndex int, ships
 	if len(ships) > 0 {
 		shipQuery := `INSERT INTO ships (sector_index, name, owner, ship_type, fighters) VALUES (?, ?, ?, ?, ?);`
 		for _, ship := range ships {
-			if _, err := d.tx.Exec(shipQuery, sectorIndex, ship.Name, ship.Owner, ship.ShipType, ship.Figs); err != nil {
+			if _, err := d.conn().Exec(shipQuery, sectorIndex, ship.Name, ship.Owner, ship.ShipType, ship.Figs); err != nil {
 				return fmt.Errorf("failed to save ship: %w", err)
 			}
 		}
@@ -530,9 +608,9 @@ func (d *SQLiteDatabase) saveSectorCollectionsWithParams(sectorIndex int, ships
 
 	// Save traders from parameter
 	if len(traders) > 0 {
-		traderQuery := `INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters) VALUES (?, ?, ?, ?, ?);`
+		traderQuery := `INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters, alignment) VALUES (?, ?, ?, ?, ?, ?);`
 		for _, trader := range traders {
-			if _, err := d.tx.Exec(traderQuery, sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Figs); err != nil {
+			if _, err := d.conn().Exec(traderQuery, sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Figs, trader.Alignment); err != nil {
 				return fmt.Errorf("failed to save trader: %w", err)
 			}
 		}
@@ -542,7 +620,7 @@ func (d *SQLiteDatabase) saveSectorCollectionsWithParams(sectorIndex int, ships
 	if len(planets) > 0 {
 		planetQuery := `INSERT INTO planets (sector_index, name, owner, fighters, citadel, stardock) VALUES (?, ?, ?, ?, ?, ?);`
 		for _, planet := range planets {
-			if _, err := d.tx.Exec(planetQuery, sectorIndex, planet.Name, planet.Owner, planet.Fighters, planet.Citadel, planet.Stardock); err != nil {
+			if _, err := d.conn().Exec(planetQuery, sectorIndex, planet.Name, planet.Owner, planet.Fighters, planet.Citadel, planet.Stardock); err != nil {
 				return fmt.Errorf("failed to save planet: %w", err)
 			}
 		}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"testing"
+
+	"twist/internal/api"
+)
+
+func TestGetSectorInfoExplored(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	tests := []struct {
+		explored TSectorExploredType
+		want     api.Explored
+		visited  bool
+	}{
+		{EtNo, api.ExploredNo, false},
+		{EtCalc, api.ExploredCalc, false},
+		{EtDensity, api.ExploredDensity, false},
+		{EtHolo, api.ExploredHolo, true},
+	}
+	for _, tt := range tests {
+		if _, err := db.conn().Exec("UPDATE sectors SET explored = ? WHERE sector_index = 1", tt.explored); err != nil {
+			t.Fatalf("Failed to set explored: %v", err)
+		}
+		info, err := db.GetSectorInfo(1)
+		if err != nil {
+			t.Fatalf("GetSectorInfo failed: %v", err)
+		}
+		if info.Explored != tt.want || info.Visited != tt.visited {
+			t.Errorf("Explored %d: expected %d (visited %v), got %d (visited %v)", tt.explored, tt.want, tt.visited, info.Explored, info.Visited)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package database
+
+// Session returns a view of the database that keeps its transactions to itself. Statements
+// made through the view join a transaction begun on it, while other callers of the database
+// never do: they keep using its own connections and see the view's writes once committed.
+// The view shares the database's connections and prepared statements, so closing it only
+// rolls back its transaction.
+func (d *SQLiteDatabase) Session() Database {
+	return &SQLiteDatabase{
+		db:             d.db,
+		dbOpen:         d.dbOpen,
+		filename:       d.filename,
+		sectors:        d.sectors,
+		loadSectorStmt: d.loadSectorStmt,
+		saveSectorStmt: d.saveSectorStmt,
+		session:        true,
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionKeepsTransactionToItself(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(filepath.Join(t.TempDir(), "game.db")); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	session := db.Session()
+	if err := session.BeginTransaction(); err != nil {
+		t.Fatalf("BeginTransaction failed: %v", err)
+	}
+	sector := NULLSector()
+	sector.Beacon = "pending"
+	if err := session.SaveSector(sector, 5); err != nil {
+		t.Fatalf("SaveSector failed: %v", err)
+	}
+
+	// The database neither joins the session's transaction nor sees its writes
+	if db.InTransaction() {
+		t.Error("Expected the database to have no transaction of its own")
+	}
+	if stored, err := db.LoadSector(5); err != nil || stored.Beacon != "" {
+		t.Errorf("Expected the uncommitted sector to be hidden, got %q (err=%v)", stored.Beacon, err)
+	}
+	if got, err := session.LoadSector(5); err != nil || got.Beacon != "pending" {
+		t.Errorf("Expected the session to read its own write, got %q (err=%v)", got.Beacon, err)
+	}
+
+	if err := session.CommitTransaction(); err != nil {
+		t.Fatalf("CommitTransaction failed: %v", err)
+	}
+	if stored, err := db.LoadSector(5); err != nil || stored.Beacon != "pending" {
+		t.Errorf("Expected the committed sector, got %q (err=%v)", stored.Beacon, err)
+	}
+
+	// Closing the session leaves the database open
+	session.CloseDatabase()
+	if !db.GetDatabaseOpen() {
+		t.Fatal("Expected the database to stay open")
+	}
+	if _, err := db.LoadSector(5); err != nil {
+		t.Errorf("LoadSector after closing the session failed: %v", err)
+	}
+}
@@ -24,6 +24,13 @@ const (
 	EtHolo
 )
 
+// Alien occupant types stored in TSector.AlienType. TWX has no record of aliens; Ferrengi
+// are told apart from other races because they attack on sight.
+const (
+	AlienTypeFerrengi = "Ferrengi"
+	AlienTypeOther    = "Alien"
+)
+
 // TProductType matches TWX TProductType
 type TProductType int
 
@@ -70,6 +77,8 @@ type TSector struct {
 	Density       int                 `json:"density"` // LongInt in TWX
 	Warps         int                 `json:"warps"`   // Computed field, not stored in DB
 	Explored      TSectorExploredType `json:"explored"`
+	AlienCount    int                 `json:"alien_count"` // Alien occupants in the last sector display
+	AlienType     string              `json:"alien_type"`  // AlienTypeFerrengi, AlienTypeOther or empty
 
 	// In TWX these are LongInt pointers to linked lists, we'll handle differently
 	Ships   []TShip      `json:"ships"`
@@ -84,6 +93,8 @@ type TTrader struct {
 	ShipType string `json:"ship_type"` // string[40] in TWX
 	ShipName string `json:"ship_name"` // string[40] in TWX
 	Figs     int    `json:"figs"`      // LongInt in TWX
+
+	Alignment string `json:"alignment,omitempty"` // As shown in the sector display, empty if not shown
 }
 
 // TShip matches TWX TShip record
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TradePair is two ports close enough to trade back and forth: each buys a product the
+// other sells, so holds are full in both directions
+type TradePair struct {
+	SectorA, SectorB   int
+	ClassA, ClassB     int
+	BuyA, BuyB         [3]bool      // Buy flags per TProductType, as in TPort.BuyProduct
+	PercentA, PercentB [3]int       // Product percents, as in TPort.ProductPercent
+	AmountA, AmountB   [3]int       // Product amounts, as in TPort.ProductAmount
+	ProductAToB        TProductType // Sold at A and bought at B
+	ProductBToA        TProductType // Sold at B and bought at A
+	Hops               int          // Longer of the two one-way warp distances
+	PercentTotal       int          // Sum of the four product percents the loop trades
+}
+
+// tradePort is the port data FindTradePairs compares
+type tradePort struct {
+	sector  int
+	class   int
+	buy     [3]bool
+	percent [3]int
+	amount  [3]int
+}
+
+// FindTradePairs finds pairs of known ports that are at most maxHops warps apart in both
+// directions and buy what the other sells. Special (class 0/9), dead and avoided ports are
+// skipped. Pairs are sorted by combined product percent, best first, then by distance.
+func (d *SQLiteDatabase) FindTradePairs(maxHops int) ([]TradePair, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+	if maxHops < 1 {
+		return nil, fmt.Errorf("invalid hop distance %d", maxHops)
+	}
+
+	ports, err := d.loadTradePorts()
+	if err != nil {
+		return nil, err
+	}
+
+	warps, err := d.loadWarpMap()
+	if err != nil {
+		return nil, err
+	}
+
+	avoided, err := d.loadAvoidedSet()
+	if err != nil {
+		return nil, err
+	}
+
+	distances := make(map[int]map[int]int, len(ports))
+	for sector := range ports {
+		if !avoided[sector] {
+			distances[sector] = warpDistances(warps, sector, maxHops, avoided)
+		}
+	}
+
+	pairs := make([]TradePair, 0)
+	for sectorA, fromA := range distances {
+		for sectorB, hopsAB := range fromA {
+			if sectorB <= sectorA {
+				continue
+			}
+			portB, isPort := ports[sectorB]
+			if !isPort {
+				continue
+			}
+			hopsBA, reachable := distances[sectorB][sectorA]
+			if !reachable {
+				continue
+			}
+
+			if pair, ok := bestTradePair(ports[sectorA], portB); ok {
+				pair.Hops = max(hopsAB, hopsBA)
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.PercentTotal != b.PercentTotal {
+			return a.PercentTotal > b.PercentTotal
+		}
+		if a.Hops != b.Hops {
+			return a.Hops < b.Hops
+		}
+		if a.SectorA != b.SectorA {
+			return a.SectorA < b.SectorA
+		}
+		return a.SectorB < b.SectorB
+	})
+
+	return pairs, nil
+}
+
+// loadTradePorts reads the live, tradeable ports keyed by sector
+func (d *SQLiteDatabase) loadTradePorts() (map[int]tradePort, error) {
+	rows, err := d.conn().Query(`SELECT sector_index, class_index,
+		COALESCE(buy_fuel_ore, FALSE), COALESCE(buy_organics, FALSE), COALESCE(buy_equipment, FALSE),
+		COALESCE(percent_fuel_ore, 0), COALESCE(percent_organics, 0), COALESCE(percent_equipment, 0),
+		COALESCE(amount_fuel_ore, 0), COALESCE(amount_organics, 0), COALESCE(amount_equipment, 0)
+		FROM ports WHERE class_index BETWEEN 1 AND 8 AND NOT COALESCE(dead, FALSE)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ports: %w", err)
+	}
+	defer rows.Close()
+
+	ports := make(map[int]tradePort)
+	for rows.Next() {
+		var port tradePort
+		if err := rows.Scan(&port.sector, &port.class,
+			&port.buy[PtFuelOre], &port.buy[PtOrganics], &port.buy[PtEquipment],
+			&port.percent[PtFuelOre], &port.percent[PtOrganics], &port.percent[PtEquipment],
+			&port.amount[PtFuelOre], &port.amount[PtOrganics], &port.amount[PtEquipment]); err != nil {
+			return nil, fmt.Errorf("failed to scan port: %w", err)
+		}
+		ports[port.sector] = port
+	}
+
+	return ports, rows.Err()
+}
+
+// bestTradePair picks the product loop between two ports with the highest combined percent.
+// Returns false if neither direction has a product to carry.
+func bestTradePair(a, b tradePort) (TradePair, bool) {
+	best := TradePair{PercentTotal: -1}
+	for toB := PtFuelOre; toB <= PtEquipment; toB++ {
+		if a.buy[toB] || !b.buy[toB] {
+			continue
+		}
+		for toA := PtFuelOre; toA <= PtEquipment; toA++ {
+			if b.buy[toA] || !a.buy[toA] {
+				continue
+			}
+			total := a.percent[toB] + b.percent[toB] + b.percent[toA] + a.percent[toA]
+			if total > best.PercentTotal {
+				best = TradePair{
+					SectorA:      a.sector,
+					SectorB:      b.sector,
+					ClassA:       a.class,
+					ClassB:       b.class,
+					BuyA:         a.buy,
+					BuyB:         b.buy,
+					PercentA:     a.percent,
+					PercentB:     b.percent,
+					AmountA:      a.amount,
+					AmountB:      b.amount,
+					ProductAToB:  toB,
+					ProductBToA:  toA,
+					PercentTotal: total,
+				}
+			}
+		}
+	}
+	return best, best.PercentTotal >= 0
+}
+
+// warpDistances runs a breadth-first search out to maxHops warps and returns the hop count
+// to every reached sector. Avoided sectors are never entered.
+func warpDistances(warpMap map[int][]int, from, maxHops int, avoided map[int]bool) map[int]int {
+	distances := map[int]int{}
+	visited := map[int]bool{from: true}
+	frontier := []int{from}
+
+	for hops := 1; hops <= maxHops && len(frontier) > 0; hops++ {
+		var next []int
+		for _, sector := range frontier {
+			for _, warp := range warpMap[sector] {
+				if visited[warp] || avoided[warp] {
+					continue
+				}
+				visited[warp] = true
+				distances[warp] = hops
+				next = append(next, warp)
+			}
+		}
+		frontier = next
+	}
+
+	return distances
+}
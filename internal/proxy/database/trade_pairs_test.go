@@ -0,0 +1,75 @@
+package database
+
+import "testing"
+
+// savePortForTest stores a port with the given buy pattern (e.g. "BBS") and one percent for all products
+func savePortForTest(t *testing.T, db *SQLiteDatabase, sector, class int, pattern string, percent int) {
+	t.Helper()
+
+	port := TPort{Name: "Port", ClassIndex: class}
+	for i, status := range pattern {
+		port.BuyProduct[i] = status == 'B'
+		port.ProductPercent[i] = percent
+	}
+	if err := db.SavePort(port, sector); err != nil {
+		t.Fatalf("Failed to save port %d: %v", sector, err)
+	}
+}
+
+func TestFindTradePairs(t *testing.T) {
+	// Routes from 1 to 5: 1-2-5 (2 hops) and 1-3-4-5
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	savePortForTest(t, db, 1, 1, "BBS", 80)  // Sells equipment, buys ore and organics
+	savePortForTest(t, db, 2, 4, "SSB", 90)  // Sells ore and organics, buys equipment
+	savePortForTest(t, db, 5, 4, "SSB", 100) // Same trade as 2, but further from 1
+	savePortForTest(t, db, 3, 1, "BBS", 50)  // Same pattern as 1, so it pairs with 2 and 5 only
+	savePortForTest(t, db, 4, 9, "BBB", 100) // Stardock is never a trade port
+
+	pairs, err := db.FindTradePairs(1)
+	if err != nil {
+		t.Fatalf("FindTradePairs failed: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("Expected only the adjacent pair 1-2 within 1 hop, got %+v", pairs)
+	}
+	pair := pairs[0]
+	if pair.SectorA != 1 || pair.SectorB != 2 || pair.Hops != 1 || pair.PercentTotal != 340 {
+		t.Errorf("Unexpected pair %+v", pair)
+	}
+	if pair.ProductAToB != PtEquipment || pair.ProductBToA != PtFuelOre {
+		t.Errorf("Expected equipment to 2 and ore back to 1, got %+v", pair)
+	}
+
+	pairs, err = db.FindTradePairs(2)
+	if err != nil {
+		t.Fatalf("FindTradePairs failed: %v", err)
+	}
+	// 1-5 (360) beats 1-2 (340); 3 is 2 hops from 5 via 4 and 2 hops from 2 via 1
+	expected := [][2]int{{1, 5}, {1, 2}, {3, 5}, {2, 3}}
+	if len(pairs) != len(expected) {
+		t.Fatalf("Expected %d pairs within 2 hops, got %+v", len(expected), pairs)
+	}
+	for i, sectors := range expected {
+		if pairs[i].SectorA != sectors[0] || pairs[i].SectorB != sectors[1] {
+			t.Errorf("Pair %d: expected %d-%d, got %d-%d", i, sectors[0], sectors[1], pairs[i].SectorA, pairs[i].SectorB)
+		}
+	}
+
+	// Avoiding sector 2 removes its pairs and the 1-2-5 route
+	if err := db.AddAvoid(2); err != nil {
+		t.Fatalf("AddAvoid failed: %v", err)
+	}
+	pairs, err = db.FindTradePairs(2)
+	if err != nil {
+		t.Fatalf("FindTradePairs failed: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].SectorA != 3 || pairs[0].SectorB != 5 {
+		t.Errorf("Expected only 3-5 with sector 2 avoided, got %+v", pairs)
+	}
+
+	if _, err := db.FindTradePairs(0); err == nil {
+		t.Error("Expected a zero hop distance to be rejected")
+	}
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DefaultTradePricingFile is read from the working directory when no other trade pricing
+// file is configured. A missing file is not an error.
+const DefaultTradePricingFile = "twist_trade_pricing.json"
+
+// tradePricingFile is the JSON form of TradePricing. Missing values keep the default.
+type tradePricingFile struct {
+	FuelOre   *float64 `json:"fuel_ore"`
+	Organics  *float64 `json:"organics"`
+	Equipment *float64 `json:"equipment"`
+	Margin    *float64 `json:"margin"`
+}
+
+// LoadTradePricing reads the economy constants of a server with a tweaked economy from a
+// JSON file of base prices and margin, any of which may be left out to keep the default:
+//
+//	{"fuel_ore": 30, "organics": 50, "equipment": 90, "margin": 0.3}
+func LoadTradePricing(path string) (TradePricing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return TradePricing{}, err
+	}
+
+	var file tradePricingFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return TradePricing{}, fmt.Errorf("failed to parse trade pricing %s: %w", path, err)
+	}
+
+	pricing := DefaultTradePricing
+	var errs []error
+	prices := []struct {
+		name    string
+		product TProductType
+		price   *float64
+	}{
+		{"fuel_ore", PtFuelOre, file.FuelOre},
+		{"organics", PtOrganics, file.Organics},
+		{"equipment", PtEquipment, file.Equipment},
+	}
+	for _, p := range prices {
+		if p.price == nil {
+			continue
+		}
+		if *p.price <= 0 {
+			errs = append(errs, fmt.Errorf("%s price %g must be above 0", p.name, *p.price))
+		}
+		pricing.BasePrice[p.product] = *p.price
+	}
+	if file.Margin != nil {
+		if *file.Margin < 0 || *file.Margin >= 1 {
+			errs = append(errs, fmt.Errorf("margin %g must be from 0 up to 1", *file.Margin))
+		}
+		pricing.Margin = *file.Margin
+	}
+	if err := errors.Join(errs...); err != nil {
+		return TradePricing{}, fmt.Errorf("invalid trade pricing in %s: %w", path, err)
+	}
+
+	return pricing, nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTradePricing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultTradePricingFile)
+	if err := os.WriteFile(path, []byte(`{"equipment": 100, "margin": 0.5}`), 0644); err != nil {
+		t.Fatalf("Failed to write trade pricing: %v", err)
+	}
+	pricing, err := LoadTradePricing(path)
+	if err != nil {
+		t.Fatalf("LoadTradePricing failed: %v", err)
+	}
+	want := TradePricing{BasePrice: [3]float64{PtFuelOre: 25, PtOrganics: 45, PtEquipment: 100}, Margin: 0.5}
+	if pricing != want {
+		t.Errorf("Expected %+v with the other prices kept, got %+v", want, pricing)
+	}
+
+	// The same BBS port as TestEstimatePortProfit: ore 50 * 25 * 0.5 * 0.4 = 250 plus
+	// equipment 50 * 100 * 0.5 * 0.5 = 1250
+	port := TPort{
+		BuyProduct:     [3]bool{PtFuelOre: true, PtOrganics: true},
+		ProductAmount:  [3]int{PtFuelOre: 2000, PtOrganics: 10, PtEquipment: 1000},
+		ProductPercent: [3]int{PtFuelOre: 40, PtOrganics: 100, PtEquipment: 50},
+	}
+	if got := EstimatePortProfit(port, 50, pricing); got != 1500 {
+		t.Errorf("Expected a profit of 1500, got %d", got)
+	}
+
+	for _, invalid := range []string{`{"margin": 1}`, `{"margin": -0.1}`, `{"fuel_ore": 0}`, `[25, 45, 80]`} {
+		if err := os.WriteFile(path, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write trade pricing: %v", err)
+		}
+		if _, err := LoadTradePricing(path); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package database
+
+// TradePricing holds the economy constants used to estimate trade profits. Servers tweak
+// their economies, so callers can replace DefaultTradePricing with their own values.
+//
+// Prices follow the usual TW curve, where a port with more of its maximum to trade gives
+// the better price:
+//
+//	price a selling port charges = BasePrice * (1 - Margin * percent/100)
+//	price a buying port pays     = BasePrice * (1 + Margin * percent/100)
+//
+// Each leg moves min(holds, units the seller has, units the buyer wants) of one product.
+type TradePricing struct {
+	BasePrice [3]float64 // Mid price per unit, indexed by TProductType
+	Margin    float64    // Fraction a full port's price moves away from BasePrice
+}
+
+// DefaultTradePricing approximates the stock TW2002 economy
+var DefaultTradePricing = TradePricing{
+	BasePrice: [3]float64{PtFuelOre: 25, PtOrganics: 45, PtEquipment: 80},
+	Margin:    0.25,
+}
+
+// EstimateTradeProfit estimates the credits made on one round trip of a trade pair with
+// the given number of cargo holds: A's product sold at B, then B's product sold at A.
+func EstimateTradeProfit(pair TradePair, holds int, pricing TradePricing) int {
+	toB := pricing.legProfit(pair.ProductAToB, holds, pair.AmountA, pair.PercentA, pair.AmountB, pair.PercentB)
+	toA := pricing.legProfit(pair.ProductBToA, holds, pair.AmountB, pair.PercentB, pair.AmountA, pair.PercentA)
+	return int(toB + toA)
+}
+
+// legProfit estimates the profit of carrying one product from a selling port to a buying port
+func (tp TradePricing) legProfit(product TProductType, holds int, sellerAmount, sellerPercent, buyerAmount, buyerPercent [3]int) float64 {
+	units := min(holds, sellerAmount[product], buyerAmount[product])
+	if units <= 0 {
+		return 0
+	}
+
+	base := tp.BasePrice[product]
+	buyPrice := base * (1 - tp.Margin*float64(sellerPercent[product])/100)
+	sellPrice := base * (1 + tp.Margin*float64(buyerPercent[product])/100)
+	return float64(units) * (sellPrice - buyPrice)
+}
+
+// EstimatePortProfit estimates the credits a turn of trading at one port is worth with the
+// given number of cargo holds, against goods bought or sold elsewhere at BasePrice: the
+// best product the port buys sold above the mid price, plus the best product it sells
+// bought below it. It assumes a port visit for each turn, so it suits ports near others.
+func EstimatePortProfit(port TPort, holds int, pricing TradePricing) int {
+	var bestBuying, bestSelling float64
+	for product := PtFuelOre; product <= PtEquipment; product++ {
+		units := min(holds, port.ProductAmount[product])
+		if units <= 0 {
+			continue
+		}
+		margin := float64(units) * pricing.BasePrice[product] * pricing.Margin * float64(port.ProductPercent[product]) / 100
+		if port.BuyProduct[product] {
+			bestBuying = max(bestBuying, margin)
+		} else {
+			bestSelling = max(bestSelling, margin)
+		}
+	}
+	return int(bestBuying + bestSelling)
+}
@@ -0,0 +1,48 @@
+package database
+
+import "testing"
+
+func TestEstimateTradeProfit(t *testing.T) {
+	pair := TradePair{
+		SectorA: 1, SectorB: 2,
+		PercentA:    [3]int{PtFuelOre: 50, PtEquipment: 80},
+		PercentB:    [3]int{PtFuelOre: 100, PtEquipment: 100},
+		AmountA:     [3]int{PtFuelOre: 20, PtEquipment: 1000},
+		AmountB:     [3]int{PtFuelOre: 3000, PtEquipment: 500},
+		ProductAToB: PtEquipment,
+		ProductBToA: PtFuelOre,
+	}
+
+	// Equipment: 50 holds at 64 bought, 100 sold. Ore: only 20 wanted at A, 18.75 bought, 28.125 sold.
+	if got := EstimateTradeProfit(pair, 50, DefaultTradePricing); got != 1987 {
+		t.Errorf("Expected a profit of 1987, got %d", got)
+	}
+
+	if got := EstimateTradeProfit(pair, 0, DefaultTradePricing); got != 0 {
+		t.Errorf("Expected no profit without holds, got %d", got)
+	}
+
+	flat := TradePricing{BasePrice: DefaultTradePricing.BasePrice}
+	if got := EstimateTradeProfit(pair, 50, flat); got != 0 {
+		t.Errorf("Expected no profit without a price margin, got %d", got)
+	}
+}
+
+func TestEstimatePortProfit(t *testing.T) {
+	// A BBS port: sells equipment, buys ore and organics
+	port := TPort{
+		BuyProduct:     [3]bool{PtFuelOre: true, PtOrganics: true},
+		ProductAmount:  [3]int{PtFuelOre: 2000, PtOrganics: 10, PtEquipment: 1000},
+		ProductPercent: [3]int{PtFuelOre: 40, PtOrganics: 100, PtEquipment: 50},
+	}
+
+	// Ore 50 * 25 * 0.25 * 0.4 = 125 beats organics limited to 10 units (112.5), plus
+	// equipment 50 * 80 * 0.25 * 0.5 = 500
+	if got := EstimatePortProfit(port, 50, DefaultTradePricing); got != 625 {
+		t.Errorf("Expected a profit of 625, got %d", got)
+	}
+
+	if got := EstimatePortProfit(port, 0, DefaultTradePricing); got != 0 {
+		t.Errorf("Expected no profit without holds, got %d", got)
+	}
+}
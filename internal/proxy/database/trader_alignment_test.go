@@ -0,0 +1,66 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"twist/internal/api"
+)
+
+func TestGetSectorInfoTraderAlignment(t *testing.T) {
+	db := createCourseTestDatabase(t)
+	defer db.CloseDatabase()
+
+	sector, err := db.LoadSector(1)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	sector.Traders = []TTrader{
+		{Name: "Ann", Alignment: "Good"},
+		{Name: "Bob", Alignment: "Outlaw"},
+		{Name: "Cy"},
+	}
+	if err := db.SaveSector(sector, 1); err != nil {
+		t.Fatalf("SaveSector failed: %v", err)
+	}
+
+	info, err := db.GetSectorInfo(1)
+	if err != nil {
+		t.Fatalf("GetSectorInfo failed: %v", err)
+	}
+	if info.HasTraders != 3 || info.TraderAlignment != api.AlignmentEvil {
+		t.Errorf("Expected 3 traders, the worst evil, got %d traders with alignment %d", info.HasTraders, info.TraderAlignment)
+	}
+	if loaded, err := db.LoadSector(1); err != nil || len(loaded.Traders) != 3 || loaded.Traders[1].Alignment != "Outlaw" {
+		t.Errorf("Expected the trader alignments to be stored, got %+v (err %v)", loaded.Traders, err)
+	}
+
+	if info, err = db.GetSectorInfo(2); err != nil || info.HasTraders != 0 || info.TraderAlignment != api.AlignmentUnknown {
+		t.Errorf("Expected no traders in sector 2, got %d with alignment %d (err %v)", info.HasTraders, info.TraderAlignment, err)
+	}
+}
+
+func TestOpenDatabaseAddsTraderAlignment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db := NewDatabase()
+	if err := db.CreateDatabase(path); err != nil {
+		t.Fatalf("CreateDatabase failed: %v", err)
+	}
+	// A database created before traders had an alignment
+	if _, err := db.db.Exec("ALTER TABLE traders DROP COLUMN alignment"); err != nil {
+		t.Fatalf("Failed to drop column: %v", err)
+	}
+	db.CloseDatabase()
+
+	db = NewDatabase()
+	if err := db.OpenDatabase(path); err != nil {
+		t.Fatalf("OpenDatabase failed: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	sector := NULLSector()
+	sector.Traders = []TTrader{{Name: "Bob", Alignment: "Evil"}}
+	if err := db.SaveSector(sector, 5); err != nil {
+		t.Fatalf("SaveSector failed after opening an old database: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxWarpSector is the largest sector number a game universe can have
+const maxWarpSector = 20000
+
+// WarpIssueKind is the kind of problem VerifyWarps found with a warp
+type WarpIssueKind int
+
+const (
+	WarpDuplicate  WarpIssueKind = iota // The destination is listed more than once
+	WarpOutOfRange                      // The destination can't be a sector, or is the sector itself
+	WarpOneWay                          // The destination's warps are known and don't lead back
+)
+
+// String returns a short description of the issue kind
+func (k WarpIssueKind) String() string {
+	switch k {
+	case WarpDuplicate:
+		return "duplicate"
+	case WarpOutOfRange:
+		return "out of range"
+	case WarpOneWay:
+		return "one-way"
+	default:
+		return "unknown"
+	}
+}
+
+// WarpIssue is a warp from Sector to Warp that VerifyWarps found a problem with
+type WarpIssue struct {
+	Sector int
+	Warp   int
+	Kind   WarpIssueKind
+}
+
+// VerifyWarps checks the warps of every known sector for duplicate destinations,
+// destinations that can't be sectors, and one-way links to sectors whose own warps are
+// known. One-way warps exist in the game, so they are reported but never repaired. Issues
+// are sorted by sector and then destination.
+func (d *SQLiteDatabase) VerifyWarps() ([]WarpIssue, error) {
+	if !d.dbOpen {
+		return nil, fmt.Errorf("database not open")
+	}
+
+	warpMap, err := d.loadWarpMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []WarpIssue
+	for sector, warps := range warpMap {
+		seen := make(map[int]bool, len(warps))
+		for _, warp := range warps {
+			switch {
+			case seen[warp]:
+				issues = append(issues, WarpIssue{Sector: sector, Warp: warp, Kind: WarpDuplicate})
+				continue
+			case warp > maxWarpSector || warp == sector:
+				issues = append(issues, WarpIssue{Sector: sector, Warp: warp, Kind: WarpOutOfRange})
+			case len(warpMap[warp]) > 0 && !containsSector(warpMap[warp], sector):
+				issues = append(issues, WarpIssue{Sector: sector, Warp: warp, Kind: WarpOneWay})
+			}
+			seen[warp] = true
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Sector != issues[j].Sector {
+			return issues[i].Sector < issues[j].Sector
+		}
+		if issues[i].Warp != issues[j].Warp {
+			return issues[i].Warp < issues[j].Warp
+		}
+		return issues[i].Kind < issues[j].Kind
+	})
+	return issues, nil
+}
+
+// RepairWarps rewrites the warps of every sector whose warps have duplicates, out of range
+// destinations or gaps, or aren't in ascending order, keeping each destination once in
+// sorted order as the parser's reverse warps are kept. Returns the number of sectors
+// rewritten.
+func (d *SQLiteDatabase) RepairWarps() (int, error) {
+	if !d.dbOpen {
+		return 0, fmt.Errorf("database not open")
+	}
+
+	rows, err := d.conn().Query(`SELECT sector_index, COALESCE(warp1, 0), COALESCE(warp2, 0), COALESCE(warp3, 0),
+		COALESCE(warp4, 0), COALESCE(warp5, 0), COALESCE(warp6, 0) FROM sectors`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load warps: %w", err)
+	}
+	repairs := make(map[int][6]int)
+	for rows.Next() {
+		var sectorIndex int
+		var warps [6]int
+		if err := rows.Scan(&sectorIndex, &warps[0], &warps[1], &warps[2], &warps[3], &warps[4], &warps[5]); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan warps: %w", err)
+		}
+		if cleaned := cleanWarps(sectorIndex, warps); cleaned != warps {
+			repairs[sectorIndex] = cleaned
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to load warps: %w", err)
+	}
+
+	repaired := 0
+	for sectorIndex, warps := range repairs {
+		count := 0
+		for _, warp := range warps {
+			if warp > 0 {
+				count++
+			}
+		}
+		_, err := d.conn().Exec(`UPDATE sectors SET warp1 = ?, warp2 = ?, warp3 = ?, warp4 = ?, warp5 = ?, warp6 = ?,
+			warps = ? WHERE sector_index = ?`,
+			warps[0], warps[1], warps[2], warps[3], warps[4], warps[5], count, sectorIndex)
+		if err != nil {
+			return repaired, fmt.Errorf("failed to repair warps of sector %d: %w", sectorIndex, err)
+		}
+		repaired++
+	}
+	return repaired, nil
+}
+
+// cleanWarps returns the sector's valid warp destinations once each, in ascending order,
+// with the empty slots last
+func cleanWarps(sector int, warps [6]int) [6]int {
+	var valid []int
+	for _, warp := range warps {
+		if warp > 0 && warp <= maxWarpSector && warp != sector && !containsSector(valid, warp) {
+			valid = append(valid, warp)
+		}
+	}
+	sort.Ints(valid)
+
+	var cleaned [6]int
+	copy(cleaned[:], valid)
+	return cleaned
+}
+
+// containsSector returns true if the sector is in the list
+func containsSector(sectors []int, sector int) bool {
+	for _, s := range sectors {
+		if s == sector {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestVerifyAndRepairWarps(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	save := func(index int, warps [6]int) {
+		sector := NULLSector()
+		sector.Warp = warps
+		if err := db.SaveSector(sector, index); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", index, err)
+		}
+	}
+	save(1, [6]int{3, 2, 3, 0, 0, 0})     // Duplicate 3, unsorted, and 1 -> 3 is one-way
+	save(2, [6]int{1, 0, 0, 0, 0, 0})     // Leads back to 1
+	save(3, [6]int{2, 25000, 0, 0, 0, 0}) // Out of range, and 3 -> 2 is one-way
+	save(4, [6]int{1, 0, 0, 0, 0, 0})     // 1's warps are known and don't include 4
+
+	issues, err := db.VerifyWarps()
+	if err != nil {
+		t.Fatalf("VerifyWarps failed: %v", err)
+	}
+	expected := []WarpIssue{
+		{Sector: 1, Warp: 3, Kind: WarpDuplicate},
+		{Sector: 1, Warp: 3, Kind: WarpOneWay},
+		{Sector: 3, Warp: 2, Kind: WarpOneWay},
+		{Sector: 3, Warp: 25000, Kind: WarpOutOfRange},
+		{Sector: 4, Warp: 1, Kind: WarpOneWay},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected issues %v, got %v", expected, issues)
+	}
+	for i := range expected {
+		if issues[i] != expected[i] {
+			t.Errorf("Issue %d: expected %v, got %v", i, expected[i], issues[i])
+		}
+	}
+
+	repaired, err := db.RepairWarps()
+	if err != nil {
+		t.Fatalf("RepairWarps failed: %v", err)
+	}
+	if repaired != 2 {
+		t.Errorf("Expected sectors 1 and 3 repaired, got %d", repaired)
+	}
+	if warps, _ := db.GetWarps(1); warps != [6]int{2, 3, 0, 0, 0, 0} {
+		t.Errorf("Expected sector 1's warps deduped and sorted, got %v", warps)
+	}
+	if sector, _ := db.LoadSector(3); sector.Warp != [6]int{2, 0, 0, 0, 0, 0} || sector.Warps != 1 {
+		t.Errorf("Expected sector 3 left with one warp, got %v (%d)", sector.Warp, sector.Warps)
+	}
+
+	// Only the one-way warps, which exist in the game, are left
+	issues, _ = db.VerifyWarps()
+	if len(issues) != 3 || issues[0].Kind != WarpOneWay || issues[1].Kind != WarpOneWay || issues[2].Kind != WarpOneWay {
+		t.Errorf("Expected only one-way warps left, got %v", issues)
+	}
+	if repaired, _ := db.RepairWarps(); repaired != 0 {
+		t.Errorf("Expected nothing left to repair, got %d", repaired)
+	}
+}
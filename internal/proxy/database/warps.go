@@ -0,0 +1,33 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetWarps returns the six warp slots of a sector without loading the rest of the sector.
+// Empty slots are 0.
+func (d *SQLiteDatabase) GetWarps(sector int) ([6]int, error) {
+	var warps [6]int
+	if !d.dbOpen {
+		return warps, fmt.Errorf("database not open")
+	}
+
+	query := `SELECT warp1, warp2, warp3, warp4, warp5, warp6 FROM sectors WHERE sector_index = ?`
+
+	var values [6]sql.NullInt64
+	err := d.conn().QueryRow(query, sector).Scan(&values[0], &values[1], &values[2], &values[3], &values[4], &values[5])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return warps, fmt.Errorf("sector %d not found in database", sector)
+		}
+		return warps, fmt.Errorf("failed to get warps for sector %d: %w", sector, err)
+	}
+
+	for i, value := range values {
+		if value.Valid && value.Int64 > 0 {
+			warps[i] = int(value.Int64)
+		}
+	}
+	return warps, nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestGetWarps(t *testing.T) {
+	db := NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	sector := NULLSector()
+	sector.Warp[0] = 2
+	sector.Warp[1] = 3
+	sector.Warp[2] = 10
+	if err := db.SaveSector(sector, 1); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	warps, err := db.GetWarps(1)
+	if err != nil {
+		t.Fatalf("GetWarps failed: %v", err)
+	}
+	if expected := [6]int{2, 3, 10, 0, 0, 0}; warps != expected {
+		t.Errorf("Expected warps %v, got %v", expected, warps)
+	}
+
+	if _, err := db.GetWarps(999); err == nil {
+		t.Error("Expected error for sector not in database")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 	"twist/internal/ansi"
+	"twist/internal/api"
 	"twist/internal/log"
 	"twist/internal/proxy/database"
 	"twist/internal/proxy/scripting"
@@ -26,6 +27,9 @@ const (
 	TokenGameExit       // Exit patterns
 	TokenMainMenu       // Return to main menu patterns
 	TokenUserPrompt     // User input prompt patterns like "Your choice: " or "Enter selection: "
+	TokenCustomGame     // User-defined prompt that identifies a game (see game_detector_patterns.go)
+	TokenCommandPrompt  // Game command prompt, used by the server database fallback (see game_detector_fallback.go)
+	TokenGameSelection  // TWGS "Selection (? for menu):" prompt (see game_detector_menu.go)
 )
 
 type GameDetectionState int
@@ -84,6 +88,7 @@ type stateFn func(*GameDetector) stateFn
 type gameDetectorState struct {
 	currentState       GameDetectionState
 	selectedGame       string
+	selectedLetter     string // Menu letter of the selected game, empty if not picked from a menu
 	gameOptions        map[string]string
 	expectingUserInput bool
 }
@@ -101,6 +106,12 @@ type GameDetector struct {
 	patternMatchers map[string]*PatternMatcher // Active pattern matchers
 	recentContent   string                     // Larger buffer for context analysis (last ~500 chars)
 
+	// User-defined detection patterns: prompt -> game key, and prompts in load order
+	customGames   map[string]string
+	customPrompts []string
+	customRegexes []customRegexPattern
+	customLine    string // Current line text for the custom regexes
+
 	// ANSI stripping for streaming content
 	ansiStripper *ansi.StreamingStripper
 
@@ -118,14 +129,26 @@ type GameDetector struct {
 	// Database management
 	currentDatabase      database.Database
 	currentScriptManager *scripting.ScriptManager
+	activeGame           api.DatabaseStateInfo // Identity of the game whose database is loaded
 
 	// Callbacks
 	onDatabaseLoaded       func(db database.Database, scriptManager *scripting.ScriptManager) error
-	onDatabaseStateChanged func(gameName, serverHost, serverPort, dbName string, isLoaded bool)
+	onDatabaseStateChanged func(info api.DatabaseStateInfo)
+	onDetectionFailed      func(serverHost, serverPort string)
+	onGameSelectionMenu    func(menu api.GameMenuInfo)
 
 	// Timing
 	lastActivity     time.Time
 	detectionTimeout time.Duration
+	promptTimeout    time.Duration // Fallback window for seeing a known prompt (see game_detector_timeout.go)
+	promptTimer      *time.Timer
+
+	// Start a server database at the game command prompt if no game was detected
+	serverDatabaseFallback bool
+
+	// The game database was chosen up front (see UseGameDatabase), so server prompts
+	// neither switch nor unload it
+	pinned bool
 }
 
 // NewGameDetector creates a new lexer-based game detector
@@ -135,7 +158,9 @@ func NewGameDetector(connInfo ConnectionInfo) *GameDetector {
 		serverPort:       connInfo.Port,
 		tokens:           make(chan Token, 100), // Buffered channel
 		detectionTimeout: time.Minute * 5,
+		promptTimeout:    DefaultPromptDetectionTimeout,
 		patternMatchers:  make(map[string]*PatternMatcher),
+		customGames:      make(map[string]string),
 		ansiStripper:     ansi.NewStreamingStripper(),
 		// Initialize instance-specific state machines
 		gOptionState:   &gameOptionState{},
@@ -190,15 +215,17 @@ func copyState(s *gameDetectorState) *gameDetectorState {
 	return &gameDetectorState{
 		currentState:       s.currentState,
 		selectedGame:       s.selectedGame,
+		selectedLetter:     s.selectedLetter,
 		gameOptions:        gameOptionsCopy,
 		expectingUserInput: s.expectingUserInput,
 	}
 }
 
-// initializePatterns sets up all the pattern matchers
-func (l *GameDetector) initializePatterns() {
-	patterns := map[string]TokenType{
+// builtInPatterns returns the fixed detection patterns and the token each one emits
+func builtInPatterns() map[string]TokenType {
+	return map[string]TokenType{
 		"Select a game :":             TokenGameMenu,
+		gameSelectionPattern:          TokenGameSelection,
 		"Show today's log?":           TokenGameStart, // Match the question, ignore the options after
 		"Goodbye":                     TokenGameExit,
 		"Thank you for playing":       TokenGameExit,
@@ -215,9 +242,13 @@ func (l *GameDetector) initializePatterns() {
 		"Enter your choice: ":         TokenUserPrompt,
 		"Please enter your choice: ":  TokenUserPrompt,
 		"Selection: ":                 TokenUserPrompt,
+		commandPromptPattern:          TokenCommandPrompt,
 	}
+}
 
-	for pattern, tokenType := range patterns {
+// initializePatterns sets up all the pattern matchers
+func (l *GameDetector) initializePatterns() {
+	for pattern, tokenType := range builtInPatterns() {
 		l.patternMatchers[pattern] = &PatternMatcher{
 			pattern:   pattern,
 			position:  0,
@@ -243,6 +274,9 @@ func (l *GameDetector) ProcessChunk(data []byte) {
 func (l *GameDetector) ProcessUserInput(input string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if l.pinned {
+		return
+	}
 
 	// Update activity timestamp
 	l.lastActivity = time.Now()
@@ -266,6 +300,9 @@ func (l *GameDetector) ProcessUserInput(input string) {
 func (l *GameDetector) ProcessLine(text string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if l.pinned {
+		return
+	}
 
 	// Update activity timestamp
 	l.lastActivity = time.Now()
@@ -284,6 +321,11 @@ func (l *GameDetector) ProcessLine(text string) {
 		l.processCharacter(char)
 	}
 
+	// The server paused, so a prompt may be waiting on the current line
+	if l.state.Load().currentState != StateGameActive {
+		l.matchCustomRegexes()
+	}
+
 	// Process any emitted tokens
 	l.processTokens()
 
@@ -294,6 +336,13 @@ func (l *GameDetector) ProcessLine(text string) {
 // processCharacter handles a single character through state-appropriate pattern matchers
 func (l *GameDetector) processCharacter(char rune) {
 
+	// User-defined patterns can identify the game at any point before it is active, and are
+	// checked ahead of the built-in patterns
+	currentState := l.state.Load()
+	if currentState.currentState != StateGameActive {
+		l.checkCustomPatterns(char)
+	}
+
 	// Always check for exit and main menu patterns (can happen in any state)
 	l.checkPattern("Goodbye", char)
 	l.checkPattern("Thank you for playing", char)
@@ -306,7 +355,6 @@ func (l *GameDetector) processCharacter(char rune) {
 	l.checkPattern("TradeWars Game Server", char)
 
 	// Always check for user prompt patterns in game menu states
-	currentState := l.state.Load()
 	if currentState.currentState == StateGameMenuVisible {
 		l.checkPattern("Your choice: ", char)
 		l.checkPattern("Enter selection: ", char)
@@ -316,6 +364,15 @@ func (l *GameDetector) processCharacter(char rune) {
 		l.checkPattern("Selection: ", char)
 	}
 
+	if currentState.currentState != StateGameActive && l.serverDatabaseFallback {
+		l.checkPattern(commandPromptPattern, char)
+	}
+
+	// The TWGS selection prompt can follow any menu screen before a game starts
+	if currentState.currentState != StateGameActive {
+		l.checkPattern(gameSelectionPattern, char)
+	}
+
 	// State-specific pattern matching
 	switch currentState.currentState {
 	case StateIdle:
@@ -675,6 +732,9 @@ func (l *GameDetector) processTokens() {
 func (l *GameDetector) handleToken(token Token) {
 	switch token.Type {
 	case TokenGameMenu:
+		// A known prompt arrived, so the detection fallback is no longer needed
+		l.stopPromptTimer()
+
 		// Detect game menu regardless of current state (could be returning from a game)
 		l.updateState(func(s *gameDetectorState) *gameDetectorState {
 			newState := copyState(s)
@@ -702,6 +762,7 @@ func (l *GameDetector) handleToken(token Token) {
 				if gameName, exists := s.gameOptions[token.Value]; exists {
 					newState := copyState(s)
 					newState.selectedGame = gameName
+					newState.selectedLetter = token.Value
 					newState.currentState = StateGameSelected
 					return newState
 				}
@@ -710,6 +771,7 @@ func (l *GameDetector) handleToken(token Token) {
 		})
 
 	case TokenGameStart:
+		l.stopPromptTimer()
 		l.updateState(func(s *gameDetectorState) *gameDetectorState {
 			if s.currentState == StateGameSelected {
 				newState := copyState(s)
@@ -751,6 +813,15 @@ func (l *GameDetector) handleToken(token Token) {
 			}
 		}
 
+	case TokenCustomGame:
+		l.handleCustomGameToken(token)
+
+	case TokenCommandPrompt:
+		l.handleCommandPromptToken()
+
+	case TokenGameSelection:
+		l.handleGameSelectionToken()
+
 	case TokenUserPrompt:
 		// A user prompt was detected - we're now expecting user input
 		l.updateState(func(s *gameDetectorState) *gameDetectorState {
@@ -764,20 +835,8 @@ func (l *GameDetector) handleToken(token Token) {
 // resetGameState clears current game state
 // This method assumes the caller already holds the mutex lock for non-atomic operations
 func (l *GameDetector) resetGameState() {
-	// Get current state for database cleanup
-	oldState := l.state.Load()
-
 	// Notify about database being unloaded if one is currently loaded
-	if l.currentDatabase != nil && l.onDatabaseStateChanged != nil {
-		currentGame := oldState.selectedGame
-		if currentGame == "" {
-			currentGame = "Unknown Game"
-		}
-		currentDbName := l.createDatabaseName(currentGame)
-		go func() {
-			l.onDatabaseStateChanged(currentGame, l.serverHost, l.serverPort, currentDbName, false)
-		}()
-	}
+	l.notifyGameUnloaded()
 
 	// Atomically reset the state
 	newState := &gameDetectorState{
@@ -877,19 +936,20 @@ func (l *GameDetector) CheckTimeoutManual() {
 // Database management functions (reused from original)
 
 func (l *GameDetector) loadGameDatabase() error {
+	l.unloadGameDatabase()
+
+	currentState := l.state.Load()
+	dbName := l.createDatabaseName(currentState.selectedLetter, currentState.selectedGame)
+	l.adoptLegacyDatabase(currentState.selectedGame, dbName)
+
+	return l.openGameDatabase(dbName, currentState.selectedGame, currentState.selectedLetter)
+}
+
+// unloadGameDatabase closes the active game database, if any, and stops its scripts
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) unloadGameDatabase() {
 	// Notify about database being unloaded if one is currently loaded
-	if l.currentDatabase != nil && l.onDatabaseStateChanged != nil {
-		currentState := l.state.Load()
-		currentGame := currentState.selectedGame
-		if currentGame == "" {
-			// If we're replacing a database, use the previous game name if available
-			currentGame = "Unknown Game"
-		}
-		currentDbName := l.createDatabaseName(currentGame)
-		go func() {
-			l.onDatabaseStateChanged(currentGame, l.serverHost, l.serverPort, currentDbName, false)
-		}()
-	}
+	l.notifyGameUnloaded()
 
 	if l.currentDatabase != nil {
 		l.currentDatabase.CloseDatabase()
@@ -898,11 +958,12 @@ func (l *GameDetector) loadGameDatabase() error {
 	if l.currentScriptManager != nil {
 		l.currentScriptManager.Stop()
 	}
+}
 
-	currentState := l.state.Load()
-	dbName := l.createDatabaseName(currentState.selectedGame)
-
-	log.Info("GAME DETECTOR: Loading database", "dbName", dbName, "selectedGame", currentState.selectedGame)
+// openGameDatabase opens (creating if needed) a database file as the active game database
+// This method assumes the caller already holds the mutex lock and has closed any previous database
+func (l *GameDetector) openGameDatabase(dbName, gameName, gameLetter string) error {
+	log.Info("GAME DETECTOR: Loading database", "dbName", dbName, "selectedGame", gameName, "selectedLetter", gameLetter)
 
 	db := database.NewDatabase()
 
@@ -918,14 +979,17 @@ func (l *GameDetector) loadGameDatabase() error {
 
 	l.currentDatabase = db
 	l.currentScriptManager = scriptManager
+	l.activeGame = api.DatabaseStateInfo{
+		GameName:     gameName,
+		GameLetter:   gameLetter,
+		ServerHost:   l.serverHost,
+		ServerPort:   l.serverPort,
+		DatabaseName: dbName,
+		IsLoaded:     true,
+	}
 
 	// Notify about database state change (loaded)
-	if l.onDatabaseStateChanged != nil {
-		go func() {
-			l.onDatabaseStateChanged(currentState.selectedGame, l.serverHost, l.serverPort, dbName, true)
-		}()
-	} else {
-	}
+	l.notifyDatabaseStateChanged(l.activeGame)
 
 	if l.onDatabaseLoaded != nil {
 		log.Info("GameDetector: triggering onDatabaseLoaded callback", "db", db)
@@ -943,11 +1007,16 @@ func (l *GameDetector) loadGameDatabase() error {
 	return nil
 }
 
-func (l *GameDetector) createDatabaseName(gameName string) string {
+// createDatabaseName names the database file for a game on this server. Games picked
+// from a menu are keyed by their letter too, since games on one server can share a name.
+func (l *GameDetector) createDatabaseName(gameLetter, gameName string) string {
 	host := sanitizeForFilename(l.serverHost)
 	port := sanitizeForFilename(l.serverPort)
 	game := sanitizeForFilename(gameName)
 
+	if letter := sanitizeForFilename(gameLetter); letter != "" {
+		return fmt.Sprintf("%s_%s_%s_%s.db", host, port, letter, game)
+	}
 	return fmt.Sprintf("%s_%s_%s.db", host, port, game)
 }
 
@@ -989,7 +1058,7 @@ func (l *GameDetector) SetDatabaseLoadedCallback(callback func(db database.Datab
 	l.onDatabaseLoaded = callback
 }
 
-func (l *GameDetector) SetDatabaseStateChangedCallback(callback func(gameName, serverHost, serverPort, dbName string, isLoaded bool)) {
+func (l *GameDetector) SetDatabaseStateChangedCallback(callback func(info api.DatabaseStateInfo)) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.onDatabaseStateChanged = callback
@@ -1044,18 +1113,10 @@ func (l *GameDetector) Close() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	l.stopPromptTimer()
+
 	// Notify about database being unloaded
-	if l.currentDatabase != nil && l.onDatabaseStateChanged != nil {
-		currentState := l.state.Load()
-		currentGame := currentState.selectedGame
-		if currentGame == "" {
-			currentGame = "Unknown Game"
-		}
-		currentDbName := l.createDatabaseName(currentGame)
-		go func() {
-			l.onDatabaseStateChanged(currentGame, l.serverHost, l.serverPort, currentDbName, false)
-		}()
-	}
+	l.notifyGameUnloaded()
 
 	if l.currentScriptManager != nil {
 		l.currentScriptManager.Stop()
@@ -0,0 +1,182 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"twist/internal/api"
+	"twist/internal/log"
+)
+
+// gameDatabaseExt is the file extension of game database files
+const gameDatabaseExt = ".db"
+
+// serverDatabasePrefix is the file name prefix shared by every game database of this server
+func (l *GameDetector) serverDatabasePrefix() string {
+	return fmt.Sprintf("%s_%s_", sanitizeForFilename(l.serverHost), sanitizeForFilename(l.serverPort))
+}
+
+// ListGameDatabases returns the game databases in the working directory that belong to
+// this server, sorted by name. Databases of other servers are not listed, since their data
+// doesn't describe the game being played.
+func (l *GameDetector) ListGameDatabases() ([]api.GameDatabaseInfo, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list game databases: %w", err)
+	}
+
+	prefix := l.serverDatabasePrefix()
+	databases := make([]api.GameDatabaseInfo, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, gameDatabaseExt) {
+			continue
+		}
+		databases = append(databases, api.GameDatabaseInfo{
+			Name:     name,
+			IsActive: l.activeGame.IsLoaded && l.activeGame.DatabaseName == name,
+		})
+	}
+
+	sort.Slice(databases, func(i, j int) bool { return databases[i].Name < databases[j].Name })
+	return databases, nil
+}
+
+// OpenGameDatabase makes a database returned by ListGameDatabases the active game database,
+// closing the current one. The parser resets itself when it sees the new database.
+func (l *GameDetector) OpenGameDatabase(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prefix := l.serverDatabasePrefix()
+	if filepath.Base(name) != name || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, gameDatabaseExt) {
+		return fmt.Errorf("%q is not a game database for this server", name)
+	}
+	if _, err := os.Stat(name); err != nil {
+		return fmt.Errorf("game database %s not found: %w", name, err)
+	}
+	if l.activeGame.IsLoaded && l.activeGame.DatabaseName == name {
+		return nil
+	}
+
+	gameName := strings.TrimSuffix(strings.TrimPrefix(name, prefix), gameDatabaseExt)
+	log.Info("GameDetector: opening game database", "dbName", name, "game", gameName)
+	return l.switchGameDatabase(name, gameName, "")
+}
+
+// CreateGameDatabase creates a new, empty game database for this server and makes it the
+// active game database
+func (l *GameDetector) CreateGameDatabase(gameName string) error {
+	if sanitizeForFilename(gameName) == "" {
+		return fmt.Errorf("game name %q is empty or unusable as a database name", gameName)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dbName, gameLetter := l.gameDatabaseName(gameName)
+	if _, err := os.Stat(dbName); !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("game database %s already exists", dbName)
+	}
+
+	log.Info("GameDetector: creating game database", "dbName", dbName, "game", gameName)
+	return l.switchGameDatabase(dbName, gameName, gameLetter)
+}
+
+// UseGameDatabase makes the named game's database for this server the active game database,
+// creating it if it doesn't exist yet. Connection profiles use it to pick their database
+// up front instead of waiting for game detection, so detection stops for the rest of the
+// connection: the server's menus would otherwise unload the database on the way into the game.
+func (l *GameDetector) UseGameDatabase(gameName string) error {
+	if sanitizeForFilename(gameName) == "" {
+		return fmt.Errorf("game name %q is empty or unusable as a database name", gameName)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dbName, gameLetter := l.gameDatabaseName(gameName)
+	if gameLetter == "" {
+		dbName, gameLetter = l.detectedGameDatabase(gameName, dbName)
+	}
+	if l.activeGame.IsLoaded && l.activeGame.DatabaseName == dbName {
+		l.pinned = true
+		return nil
+	}
+
+	if gameLetter != "" {
+		l.adoptLegacyDatabase(gameName, dbName)
+	}
+	log.Info("GameDetector: using game database", "dbName", dbName, "game", gameName)
+	if err := l.switchGameDatabase(dbName, gameName, gameLetter); err != nil {
+		return err
+	}
+	l.pinned = true
+	return nil
+}
+
+// gameDatabaseName names the database of a game chosen by name. A game selected from the
+// server's menu keeps its letter, so game detection opens the same file later.
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) gameDatabaseName(gameName string) (string, string) {
+	state := l.state.Load()
+	gameLetter := ""
+	if state.selectedLetter != "" && sanitizeForFilename(state.selectedGame) == sanitizeForFilename(gameName) {
+		gameLetter = state.selectedLetter
+	}
+	return l.createDatabaseName(gameLetter, gameName), gameLetter
+}
+
+// detectedGameDatabase finds the database game detection made for a game on an earlier
+// connection, so a profile naming the game keeps that game's data. It falls back to dbName
+// when no game, or more than one game, of that name was played on this server.
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) detectedGameDatabase(gameName, dbName string) (string, string) {
+	prefix := l.serverDatabasePrefix()
+	suffix := "_" + sanitizeForFilename(gameName) + gameDatabaseExt
+	matches, err := filepath.Glob(prefix + "*" + suffix)
+	if err != nil {
+		return dbName, ""
+	}
+
+	found, foundLetter := "", ""
+	for _, match := range matches {
+		letter := strings.TrimSuffix(strings.TrimPrefix(match, prefix), suffix)
+		if letter == "" || strings.Contains(letter, "_") || l.createDatabaseName(letter, gameName) != match {
+			continue
+		}
+		if found != "" {
+			return dbName, ""
+		}
+		found, foundLetter = match, strings.ToUpper(letter)
+	}
+	if found == "" {
+		return dbName, ""
+	}
+	return found, foundLetter
+}
+
+// switchGameDatabase replaces the active game database with dbName and marks the game active
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) switchGameDatabase(dbName, gameName, gameLetter string) error {
+	l.stopPromptTimer()
+	l.unloadGameDatabase()
+	l.currentDatabase = nil
+	l.currentScriptManager = nil
+
+	l.updateState(func(s *gameDetectorState) *gameDetectorState {
+		newState := copyState(s)
+		newState.selectedGame = gameName
+		newState.selectedLetter = gameLetter
+		newState.currentState = StateGameActive
+		return newState
+	})
+
+	return l.openGameDatabase(dbName, gameName, gameLetter)
+}
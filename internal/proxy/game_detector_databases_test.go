@@ -0,0 +1,161 @@
+package proxy
+
+import (
+	"os"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestGameDetector_CreateListAndOpenGameDatabases(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	// A database of another server is never listed
+	if err := os.WriteFile("otherhost_23_game.db", nil, 0644); err != nil {
+		t.Fatalf("Failed to create other server's database: %v", err)
+	}
+
+	if err := gd.CreateGameDatabase("Alpha"); err != nil {
+		t.Fatalf("CreateGameDatabase(Alpha) failed: %v", err)
+	}
+	alpha := gd.GetActiveGame()
+	alphaDB := gd.GetCurrentDatabase()
+	if !alpha.IsLoaded || alpha.GameName != "Alpha" || alphaDB == nil {
+		t.Fatalf("Expected Alpha to be active, got %+v", alpha)
+	}
+
+	if err := gd.CreateGameDatabase("Beta"); err != nil {
+		t.Fatalf("CreateGameDatabase(Beta) failed: %v", err)
+	}
+	if err := gd.CreateGameDatabase("Beta"); err == nil {
+		t.Error("Expected creating an existing game database to fail")
+	}
+
+	databases, err := gd.ListGameDatabases()
+	if err != nil {
+		t.Fatalf("ListGameDatabases failed: %v", err)
+	}
+	if len(databases) != 2 || databases[0].Name != alpha.DatabaseName {
+		t.Fatalf("Expected Alpha and Beta databases, got %+v", databases)
+	}
+	if databases[0].IsActive || !databases[1].IsActive {
+		t.Errorf("Expected only Beta to be active, got %+v", databases)
+	}
+
+	if err := gd.OpenGameDatabase(alpha.DatabaseName); err != nil {
+		t.Fatalf("OpenGameDatabase failed: %v", err)
+	}
+	reopened := gd.GetActiveGame()
+	if !reopened.IsLoaded || reopened.DatabaseName != alpha.DatabaseName {
+		t.Errorf("Expected Alpha's database to be active, got %+v", reopened)
+	}
+	if gd.GetCurrentDatabase() == nil || gd.GetCurrentDatabase() == alphaDB {
+		t.Error("Expected a newly opened database")
+	}
+	if gd.GetState() != StateGameActive {
+		t.Errorf("Expected StateGameActive, got %v", gd.GetState())
+	}
+
+	for _, name := range []string{"otherhost_23_game.db", "../" + alpha.DatabaseName, gd.createDatabaseName("", "missing")} {
+		if err := gd.OpenGameDatabase(name); err == nil {
+			t.Errorf("Expected OpenGameDatabase(%q) to fail", name)
+		}
+	}
+}
+
+func TestGameDetector_UseGameDatabase(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	if err := gd.UseGameDatabase("Home"); err != nil {
+		t.Fatalf("UseGameDatabase(Home) failed: %v", err)
+	}
+	home := gd.GetActiveGame()
+	if !home.IsLoaded || home.GameName != "Home" || home.DatabaseName != gd.createDatabaseName("", "Home") {
+		t.Fatalf("Expected Home to be active, got %+v", home)
+	}
+	if gd.GetState() != StateGameActive {
+		t.Errorf("Expected StateGameActive, got %v", gd.GetState())
+	}
+
+	// Using it again keeps the open database, and an existing file is reused
+	db := gd.GetCurrentDatabase()
+	if err := gd.UseGameDatabase("Home"); err != nil || gd.GetCurrentDatabase() != db {
+		t.Errorf("Expected the active database to be kept, err=%v", err)
+	}
+	if err := gd.CreateGameDatabase("Away"); err != nil {
+		t.Fatalf("CreateGameDatabase(Away) failed: %v", err)
+	}
+	if err := gd.UseGameDatabase("Home"); err != nil {
+		t.Fatalf("UseGameDatabase(Home) on an existing database failed: %v", err)
+	}
+	if gd.GetActiveGame().DatabaseName != home.DatabaseName {
+		t.Errorf("Expected Home's database to be active, got %+v", gd.GetActiveGame())
+	}
+
+	// The server's menus no longer unload it
+	gd.ProcessLine("TradeWars Game Server\r\nSelect a game :\r\n<A> Other Game\r\nGoodbye\r\n")
+	if active := gd.GetActiveGame(); !active.IsLoaded || active.DatabaseName != home.DatabaseName {
+		t.Errorf("Expected Home's database to stay active, got %+v", active)
+	}
+
+	if err := gd.UseGameDatabase(" . "); err == nil {
+		t.Error("Expected an unusable game name to fail")
+	}
+}
+
+func TestGameDetector_CreateGameDatabaseKeepsSelectedLetter(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	// Creating the database of the game picked from the menu names it the way detection does
+	gd.ProcessLine("Select a game :\r\n<A> Test Game\r\n")
+	gd.ProcessUserInput("A")
+	if err := gd.CreateGameDatabase("Test Game"); err != nil {
+		t.Fatalf("CreateGameDatabase failed: %v", err)
+	}
+	active := gd.GetActiveGame()
+	if active.DatabaseName != gd.createDatabaseName("A", "Test Game") || active.GameLetter != "A" {
+		t.Fatalf("Expected the database of game A, got %+v", active)
+	}
+
+	// Another game has no letter to keep
+	if err := gd.CreateGameDatabase("Other Game"); err != nil {
+		t.Fatalf("CreateGameDatabase failed: %v", err)
+	}
+	if name := gd.GetActiveGame().DatabaseName; name != gd.createDatabaseName("", "Other Game") {
+		t.Errorf("Unexpected database name %s", name)
+	}
+}
+
+func TestGameDetector_UseGameDatabaseKeepsDetectedDatabase(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	createDatabase := func(gameLetter string) {
+		db := database.NewDatabase()
+		if err := db.CreateDatabase(gd.createDatabaseName(gameLetter, "Test Game")); err != nil {
+			t.Fatalf("Failed to create detected database: %v", err)
+		}
+		db.CloseDatabase()
+	}
+
+	// Game detection made game A's database on an earlier connection
+	createDatabase("A")
+	dbName, gameLetter := gd.detectedGameDatabase("Test Game", "fallback.db")
+	if dbName != gd.createDatabaseName("A", "Test Game") || gameLetter != "A" {
+		t.Errorf("Expected the detected database of game A, got %s (%q)", dbName, gameLetter)
+	}
+	if err := gd.UseGameDatabase("Test Game"); err != nil {
+		t.Fatalf("UseGameDatabase failed: %v", err)
+	}
+	if active := gd.GetActiveGame(); active.DatabaseName != dbName || active.GameLetter != "A" {
+		t.Errorf("Expected the detected database of game A, got %+v", active)
+	}
+
+	// Two games of that name leave nothing to choose between
+	createDatabase("B")
+	if dbName, gameLetter := gd.detectedGameDatabase("Test Game", "fallback.db"); dbName != "fallback.db" || gameLetter != "" {
+		t.Errorf("Expected no detected database, got %s (%q)", dbName, gameLetter)
+	}
+}
@@ -0,0 +1,30 @@
+package proxy
+
+import "twist/internal/log"
+
+// commandPromptPattern starts the game's main command prompt. Seeing it means a game is
+// being played, whether or not the server's menus were recognized.
+const commandPromptPattern = "Command [TL="
+
+// SetServerDatabaseFallback enables starting the per-server game database when the game
+// command prompt appears before any game was detected. It is off by default because every
+// game on the server then shares one database.
+func (l *GameDetector) SetServerDatabaseFallback(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.serverDatabaseFallback = enabled
+}
+
+// handleCommandPromptToken starts the server database if the game command prompt shows up
+// with no game database loaded
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) handleCommandPromptToken() {
+	if !l.serverDatabaseFallback || l.currentDatabase != nil {
+		return
+	}
+
+	log.Info("GameDetector: command prompt seen with no game detected, starting server database fallback", "host", l.serverHost, "port", l.serverPort)
+	if err := l.startServerDatabase(); err != nil {
+		log.Warn("GameDetector: server database fallback failed", "host", l.serverHost, "port", l.serverPort, "error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"strings"
+	"testing"
+)
+
+const testCommandPrompt = "\r\nCommand [TL=00:00:00]:[1] (?=Help)? : "
+
+func TestGameDetector_ServerDatabaseFallback(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+	gd.SetServerDatabaseFallback(true)
+
+	// An unrecognized login goes straight to the game
+	gd.ProcessLine("Welcome to Custom BBS\r\nPress any key to enter the void")
+	gd.ProcessLine(testCommandPrompt)
+
+	if gd.GetCurrentDatabase() == nil {
+		t.Fatal("Expected the server database to be started at the command prompt")
+	}
+	active := gd.GetActiveGame()
+	if active.GameName != manualGameName || !strings.HasSuffix(active.DatabaseName, "_"+manualGameName+".db") {
+		t.Errorf("Expected the per-server database, got %+v", active)
+	}
+}
+
+func TestGameDetector_ServerDatabaseFallbackDisabled(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	gd.ProcessLine(testCommandPrompt)
+
+	if gd.GetCurrentDatabase() != nil {
+		t.Error("Expected no database without the server database fallback")
+	}
+}
+
+func TestGameDetector_ServerDatabaseFallbackKeepsDetectedGame(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+	gd.SetServerDatabaseFallback(true)
+
+	gd.ProcessLine("Select a game :\r\n<A> Trade Wars 2002\r\n")
+	gd.ProcessUserInput("A")
+	gd.ProcessLine("Show today's log? (Y/N)")
+	detected := gd.GetActiveGame()
+
+	gd.ProcessLine(testCommandPrompt)
+
+	if active := gd.GetActiveGame(); active.DatabaseName != detected.DatabaseName || active.GameName != "Trade Wars 2002" {
+		t.Errorf("Expected the detected game to stay active, got %+v", active)
+	}
+}
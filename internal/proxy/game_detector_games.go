@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"errors"
+	"os"
+	"twist/internal/api"
+	"twist/internal/log"
+)
+
+// sqliteSidecarSuffixes are the WAL-mode files that belong to a database file
+var sqliteSidecarSuffixes = []string{"-wal", "-shm"}
+
+// GetActiveGame returns the identity of the game whose database is loaded.
+// IsLoaded is false when no game is active.
+func (l *GameDetector) GetActiveGame() api.DatabaseStateInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.activeGame
+}
+
+// notifyDatabaseStateChanged reports a database load or unload without blocking the detector
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) notifyDatabaseStateChanged(info api.DatabaseStateInfo) {
+	if l.onDatabaseStateChanged == nil {
+		return
+	}
+	callback := l.onDatabaseStateChanged
+	go callback(info)
+}
+
+// notifyGameUnloaded marks the active game as no longer loaded and reports it, using the
+// identity it was loaded with rather than whatever game is selected now
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) notifyGameUnloaded() {
+	if !l.activeGame.IsLoaded {
+		return
+	}
+	l.activeGame.IsLoaded = false
+	l.notifyDatabaseStateChanged(l.activeGame)
+}
+
+// adoptLegacyDatabase renames a database created before games were keyed by menu letter,
+// so the first game selected with that name keeps its existing data
+func (l *GameDetector) adoptLegacyDatabase(gameName, dbName string) {
+	legacyName := l.createDatabaseName("", gameName)
+	if legacyName == dbName {
+		return
+	}
+	if _, err := os.Stat(dbName); !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if _, err := os.Stat(legacyName); err != nil {
+		return
+	}
+
+	if err := os.Rename(legacyName, dbName); err != nil {
+		log.Warn("GameDetector: failed to adopt legacy game database", "from", legacyName, "to", dbName, "error", err)
+		return
+	}
+	for _, suffix := range sqliteSidecarSuffixes {
+		if err := os.Rename(legacyName+suffix, dbName+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warn("GameDetector: failed to adopt legacy game database file", "from", legacyName+suffix, "error", err)
+		}
+	}
+	log.Info("GameDetector: adopted legacy game database", "from", legacyName, "to", dbName)
+}
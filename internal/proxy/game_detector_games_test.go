@@ -0,0 +1,77 @@
+package proxy
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestGameDetector_SwitchGamesOnSameServer(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	// Two games with the same name on one server
+	menu := "Select a game :\r\n<A> Trade Wars 2002\r\n<B> Trade Wars 2002\r\n"
+
+	gd.ProcessLine(menu)
+	gd.ProcessUserInput("A")
+	gd.ProcessLine("Show today's log? (Y/N)")
+
+	first := gd.GetActiveGame()
+	if !first.IsLoaded || first.GameLetter != "A" || first.GameName != "Trade Wars 2002" {
+		t.Fatalf("Expected game A to be active, got %+v", first)
+	}
+	firstDB := gd.GetCurrentDatabase()
+
+	gd.ProcessLine("...Now leaving Trade Wars 2002")
+	if gd.GetActiveGame().IsLoaded {
+		t.Fatalf("Expected no active game after leaving, got %+v", gd.GetActiveGame())
+	}
+
+	gd.ProcessLine(menu)
+	gd.ProcessUserInput("B")
+	gd.ProcessLine("Show today's log? (Y/N)")
+
+	second := gd.GetActiveGame()
+	if !second.IsLoaded || second.GameLetter != "B" {
+		t.Fatalf("Expected game B to be active, got %+v", second)
+	}
+	if second.DatabaseName == first.DatabaseName {
+		t.Errorf("Expected games A and B to use different databases, both use %s", first.DatabaseName)
+	}
+	if !strings.Contains(second.DatabaseName, "_b_") {
+		t.Errorf("Expected database name keyed by game letter, got %s", second.DatabaseName)
+	}
+	if gd.GetCurrentDatabase() == firstDB {
+		t.Error("Expected the database to be swapped when switching games")
+	}
+}
+
+func TestGameDetector_AdoptsLegacyDatabase(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	// A database created before games were keyed by letter
+	legacyName := gd.createDatabaseName("", "Test Game")
+	legacy := database.NewDatabase()
+	if err := legacy.CreateDatabase(legacyName); err != nil {
+		t.Fatalf("Failed to create legacy database: %v", err)
+	}
+	legacy.CloseDatabase()
+
+	gd.ProcessLine("Select a game :\r\n<A> Test Game\r\n")
+	gd.ProcessLine("A")
+	gd.ProcessLine("Show today's log? (Y/N)")
+
+	dbName := gd.GetActiveGame().DatabaseName
+	if dbName != gd.createDatabaseName("A", "Test Game") {
+		t.Fatalf("Unexpected database name %s", dbName)
+	}
+	if _, err := os.Stat(legacyName); !os.IsNotExist(err) {
+		t.Errorf("Expected legacy database %s to be renamed, stat error: %v", legacyName, err)
+	}
+	if _, err := os.Stat(dbName); err != nil {
+		t.Errorf("Expected database %s to exist: %v", dbName, err)
+	}
+}
@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"twist/internal/api"
+	"twist/internal/log"
+)
+
+// gameSelectionPattern is the prompt TWGS shows below its game list while it waits for a
+// game letter
+const gameSelectionPattern = "Selection (? for menu):"
+
+// SetGameSelectionMenuCallback sets the function called when the server waits at its game
+// selection prompt
+func (l *GameDetector) SetGameSelectionMenuCallback(callback func(menu api.GameMenuInfo)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onGameSelectionMenu = callback
+}
+
+// GetGameMenu returns the games listed on the server's game selection menu, by letter
+func (l *GameDetector) GetGameMenu() api.GameMenuInfo {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.gameMenu(l.state.Load())
+}
+
+// SelectGame records the game picked at the game selection prompt, so the database loaded
+// when the game starts is the one for that letter. The caller sends the letter to the server.
+func (l *GameDetector) SelectGame(letter string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pinned {
+		return fmt.Errorf("the game database was chosen when connecting")
+	}
+
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	currentState := l.state.Load()
+	if currentState.currentState != StateGameMenuVisible {
+		return fmt.Errorf("the game selection menu is not showing")
+	}
+	gameName, exists := currentState.gameOptions[letter]
+	if !exists {
+		return fmt.Errorf("game %q is not on the menu", letter)
+	}
+
+	l.lastActivity = time.Now()
+	l.updateState(func(s *gameDetectorState) *gameDetectorState {
+		newState := copyState(s)
+		newState.selectedGame = gameName
+		newState.selectedLetter = letter
+		newState.currentState = StateGameSelected
+		return newState
+	})
+	log.Info("GameDetector: game selected", "letter", letter, "game", gameName)
+	return nil
+}
+
+// handleGameSelectionToken puts the detector back at the game menu, since the server is
+// waiting for a game letter whatever was selected before, and reports the games on offer
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) handleGameSelectionToken() {
+	l.stopPromptTimer()
+	l.updateState(func(s *gameDetectorState) *gameDetectorState {
+		newState := copyState(s)
+		newState.currentState = StateGameMenuVisible
+		newState.selectedGame = ""
+		newState.selectedLetter = ""
+		newState.expectingUserInput = true
+		return newState
+	})
+
+	if l.onGameSelectionMenu != nil {
+		callback := l.onGameSelectionMenu
+		go callback(l.gameMenu(l.state.Load()))
+	}
+}
+
+// gameMenu builds the menu info for the options in the given state
+func (l *GameDetector) gameMenu(s *gameDetectorState) api.GameMenuInfo {
+	menu := api.GameMenuInfo{
+		ServerHost: l.serverHost,
+		ServerPort: l.serverPort,
+		Games:      make([]api.GameMenuOption, 0, len(s.gameOptions)),
+	}
+	for letter, name := range s.gameOptions {
+		menu.Games = append(menu.Games, api.GameMenuOption{Letter: letter, Name: name})
+	}
+	sort.Slice(menu.Games, func(i, j int) bool {
+		return menu.Games[i].Letter < menu.Games[j].Letter
+	})
+	return menu
+}
@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+	"twist/internal/api"
+)
+
+const testTWGSMenu = "\r\n<A> Trade Wars 2002\r\n<B> Big Bang\r\n\r\nSelection (? for menu): "
+
+func TestGameDetector_GameSelectionPrompt(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	menus := make(chan api.GameMenuInfo, 1)
+	gd.SetGameSelectionMenuCallback(func(menu api.GameMenuInfo) { menus <- menu })
+
+	gd.ProcessLine(testTWGSMenu)
+
+	if gd.GetState() != StateGameMenuVisible {
+		t.Fatalf("Expected StateGameMenuVisible at the selection prompt, got %v", gd.GetState())
+	}
+
+	select {
+	case menu := <-menus:
+		if len(menu.Games) != 2 || menu.Games[0] != (api.GameMenuOption{Letter: "A", Name: "Trade Wars 2002"}) ||
+			menu.Games[1] != (api.GameMenuOption{Letter: "B", Name: "Big Bang"}) {
+			t.Errorf("Expected games A and B in order, got %+v", menu.Games)
+		}
+		if menu.ServerHost != "localhost" {
+			t.Errorf("Expected the server host, got %q", menu.ServerHost)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the game selection menu callback")
+	}
+}
+
+func TestGameDetector_SelectGame(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	if err := gd.SelectGame("A"); err == nil {
+		t.Error("Expected an error selecting a game before the menu is shown")
+	}
+
+	gd.ProcessLine(testTWGSMenu)
+
+	if err := gd.SelectGame("C"); err == nil {
+		t.Error("Expected an error selecting a game that isn't on the menu")
+	}
+	if err := gd.SelectGame("b"); err != nil {
+		t.Fatalf("SelectGame failed: %v", err)
+	}
+	if gd.GetState() != StateGameSelected || gd.GetCurrentGame() != "Big Bang" {
+		t.Fatalf("Expected Big Bang selected, got %v %q", gd.GetState(), gd.GetCurrentGame())
+	}
+
+	// The letter echoed back by the server doesn't change the selection
+	gd.ProcessLine("B\r\n")
+	gd.ProcessLine("Show today's log? (Y/N)")
+
+	if active := gd.GetActiveGame(); active.GameLetter != "B" || active.GameName != "Big Bang" {
+		t.Errorf("Expected game B's database, got %+v", active)
+	}
+}
+
+func TestGameDetector_SelectionPromptClearsFailedSelection(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	gd.ProcessLine(testTWGSMenu)
+	gd.ProcessUserInput("A")
+	if gd.GetState() != StateGameSelected {
+		t.Fatalf("Expected StateGameSelected, got %v", gd.GetState())
+	}
+
+	// The game didn't start and the server asks again
+	gd.ProcessLine("\r\nThat game is closed.\r\nSelection (? for menu): ")
+
+	if gd.GetState() != StateGameMenuVisible || gd.GetCurrentGame() != "" {
+		t.Errorf("Expected the menu again with no game selected, got %v %q", gd.GetState(), gd.GetCurrentGame())
+	}
+	if menu := gd.GetGameMenu(); len(menu.Games) != 2 {
+		t.Errorf("Expected the menu's games to be kept, got %+v", menu.Games)
+	}
+}
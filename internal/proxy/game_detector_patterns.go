@@ -0,0 +1,264 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"twist/internal/log"
+)
+
+// DefaultDetectionPatternsFile is read from the working directory when no other
+// detection patterns file is configured. A missing file is not an error.
+const DefaultDetectionPatternsFile = "twist_detection_patterns.json"
+
+// minDetectionPromptLength keeps custom prompts long enough not to match ordinary game text
+const minDetectionPromptLength = 4
+
+// maxCustomLineLength bounds the line text kept for regex detection patterns
+const maxCustomLineLength = 256
+
+// DetectionPattern maps a prompt a server shows before a game starts to the game it
+// identifies, for servers whose menus the built-in patterns don't recognize. A pattern has
+// either an exact Prompt or a Regex.
+//
+// Regexes are matched against the current line when it ends and whenever the server pauses
+// output, so they should include the prompt's closing text. Game may reference the regex's
+// capture groups, like "$1" or "${name}".
+//
+// The patterns file is a JSON array:
+//
+//	[{"prompt": "Enter the Void? (Y/N)", "game": "void"},
+//	 {"regex": "Enter universe (\\w+)\\? \\(Y/N\\)", "game": "$1"}]
+type DetectionPattern struct {
+	Prompt string `json:"prompt,omitempty"` // Exact text that identifies the game, matched as it streams
+	Regex  string `json:"regex,omitempty"`  // Regular expression that identifies the game on a line
+	Game   string `json:"game"`             // Game key used to name the game database
+}
+
+// customRegexPattern is a compiled regex detection pattern
+type customRegexPattern struct {
+	re   *regexp.Regexp
+	game string // Game key template, expanded with the regex's capture groups
+}
+
+// LoadDetectionPatterns reads and validates detection patterns from a JSON file
+func LoadDetectionPatterns(path string) ([]DetectionPattern, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []DetectionPattern
+	if err := json.Unmarshal(data, &patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse detection patterns %s: %w", path, err)
+	}
+
+	if err := ValidateDetectionPatterns(patterns); err != nil {
+		return nil, fmt.Errorf("invalid detection patterns in %s: %w", path, err)
+	}
+
+	return patterns, nil
+}
+
+// ValidateDetectionPatterns checks that every pattern has a usable prompt or regex and game
+// key, and that no prompt or regex is duplicated or shadows a built-in pattern
+func ValidateDetectionPatterns(patterns []DetectionPattern) error {
+	builtIn := builtInPatterns()
+	seen := make(map[string]bool, len(patterns))
+
+	var errs []error
+	for i, pattern := range patterns {
+		if err := validateDetectionPattern(pattern, builtIn, seen); err != nil {
+			errs = append(errs, fmt.Errorf("pattern %d: %w", i+1, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateDetectionPattern checks a single pattern, recording its prompt or regex in seen
+func validateDetectionPattern(pattern DetectionPattern, builtIn map[string]TokenType, seen map[string]bool) error {
+	if sanitizeForFilename(pattern.Game) == "" {
+		return fmt.Errorf("game key %q is empty or unusable as a database name", pattern.Game)
+	}
+
+	if pattern.Regex != "" {
+		if pattern.Prompt != "" {
+			return fmt.Errorf("set either prompt %q or regex %q, not both", pattern.Prompt, pattern.Regex)
+		}
+		re, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			return fmt.Errorf("regex %q does not compile: %w", pattern.Regex, err)
+		}
+		if re.MatchString("") {
+			return fmt.Errorf("regex %q matches empty text", pattern.Regex)
+		}
+		key := "regex:" + pattern.Regex
+		if seen[key] {
+			return fmt.Errorf("duplicate regex %q", pattern.Regex)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	switch {
+	case len(strings.TrimSpace(pattern.Prompt)) < minDetectionPromptLength:
+		return fmt.Errorf("prompt %q must be at least %d characters", pattern.Prompt, minDetectionPromptLength)
+	case !isPrintableASCII(pattern.Prompt):
+		return fmt.Errorf("prompt %q must be a single line of printable ASCII", pattern.Prompt)
+	case builtIn[pattern.Prompt] != TokenError:
+		return fmt.Errorf("prompt %q is already a built-in pattern", pattern.Prompt)
+	case seen[pattern.Prompt]:
+		return fmt.Errorf("duplicate prompt %q", pattern.Prompt)
+	}
+	seen[pattern.Prompt] = true
+	return nil
+}
+
+// isPrintableASCII reports whether s has only printable ASCII, which is what the
+// byte-wise pattern matchers can match
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// AddDetectionPatterns validates patterns and adds them to the detector. A custom prompt or
+// regex seen before a game is active selects its game and loads the game database. Custom
+// patterns are checked before the built-in ones.
+func (l *GameDetector) AddDetectionPatterns(patterns []DetectionPattern) error {
+	if err := ValidateDetectionPatterns(patterns); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, pattern := range patterns {
+		if pattern.Regex != "" {
+			l.customRegexes = append(l.customRegexes, customRegexPattern{
+				re:   regexp.MustCompile(pattern.Regex),
+				game: pattern.Game,
+			})
+			continue
+		}
+
+		if _, exists := l.customGames[pattern.Prompt]; !exists {
+			l.customPrompts = append(l.customPrompts, pattern.Prompt)
+		}
+		l.customGames[pattern.Prompt] = pattern.Game
+		l.patternMatchers[pattern.Prompt] = &PatternMatcher{
+			pattern:   pattern.Prompt,
+			tokenType: TokenCustomGame,
+		}
+	}
+
+	return nil
+}
+
+// checkCustomPatterns feeds a character to every custom pattern matcher, and matches the
+// custom regexes against the line it ends
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) checkCustomPatterns(char rune) {
+	for _, prompt := range l.customPrompts {
+		l.checkPattern(prompt, char)
+	}
+
+	if len(l.customRegexes) == 0 {
+		return
+	}
+	if char == '\r' || char == '\n' {
+		l.matchCustomRegexes()
+		l.customLine = ""
+		return
+	}
+	l.customLine += string(char)
+	if len(l.customLine) > maxCustomLineLength {
+		l.customLine = l.customLine[len(l.customLine)-maxCustomLineLength:]
+	}
+}
+
+// matchCustomRegexes emits a custom game token for the first regex matching the current line
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) matchCustomRegexes() {
+	for _, pattern := range l.customRegexes {
+		match := pattern.re.FindStringSubmatchIndex(l.customLine)
+		if match == nil {
+			continue
+		}
+
+		game := string(pattern.re.ExpandString(nil, pattern.game, l.customLine, match))
+		if sanitizeForFilename(game) == "" {
+			log.Warn("GameDetector: custom detection regex matched without a usable game key", "regex", pattern.re.String(), "game", pattern.game)
+			continue
+		}
+
+		l.tokens <- Token{
+			Type:     TokenCustomGame,
+			Value:    pattern.re.String(),
+			GameName: game,
+		}
+		l.customLine = ""
+		return
+	}
+}
+
+// handleCustomGameToken starts the game identified by a matched custom pattern
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) handleCustomGameToken(token Token) {
+	if l.state.Load().currentState == StateGameActive {
+		return
+	}
+
+	game := token.GameName
+	if game == "" {
+		game = l.customGames[token.Value]
+	}
+	if game == "" {
+		return
+	}
+
+	log.Info("GameDetector: custom detection pattern matched", "pattern", token.Value, "game", game, "host", l.serverHost, "port", l.serverPort)
+
+	l.stopPromptTimer()
+	l.updateState(func(s *gameDetectorState) *gameDetectorState {
+		newState := copyState(s)
+		newState.selectedGame = game
+		newState.currentState = StateGameActive
+		return newState
+	})
+
+	if err := l.loadGameDatabase(); err != nil {
+		log.Warn("GameDetector: failed to load database for custom pattern", "game", game, "error", err)
+	}
+}
+
+// loadDetectionPatterns adds custom patterns from path, or the default file if path is
+// empty. Problems are logged rather than failing the connection.
+func loadDetectionPatterns(detector *GameDetector, path string) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultDetectionPatternsFile
+	}
+
+	patterns, err := LoadDetectionPatterns(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		log.Warn("GameDetector: custom detection patterns not loaded", "path", path, "error", err)
+		return
+	}
+
+	if err := detector.AddDetectionPatterns(patterns); err != nil {
+		log.Warn("GameDetector: custom detection patterns not added", "path", path, "error", err)
+		return
+	}
+	log.Info("GameDetector: loaded custom detection patterns", "path", path, "count", len(patterns))
+}
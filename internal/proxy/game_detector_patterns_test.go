@@ -0,0 +1,150 @@
+package proxy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGameDetector_CustomDetectionPattern(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	err := gd.AddDetectionPatterns([]DetectionPattern{
+		{Prompt: "Enter the Void? (Y/N)", Game: "Void Universe"},
+	})
+	if err != nil {
+		t.Fatalf("AddDetectionPatterns failed: %v", err)
+	}
+
+	// Custom menu split across chunks, with ANSI colors around the prompt
+	gd.ProcessLine("Welcome to the Void BBS\r\n\x1b[1;33mEnter the V")
+	gd.ProcessLine("oid? (Y/N)\x1b[0m ")
+
+	if gd.GetState() != StateGameActive {
+		t.Fatalf("Expected StateGameActive after custom prompt, got %v", gd.GetState())
+	}
+	if gd.GetCurrentGame() != "Void Universe" {
+		t.Errorf("Expected game 'Void Universe', got %q", gd.GetCurrentGame())
+	}
+	if gd.GetCurrentDatabase() == nil {
+		t.Error("Expected game database to be loaded")
+	}
+}
+
+func TestValidateDetectionPatterns(t *testing.T) {
+	err := ValidateDetectionPatterns([]DetectionPattern{
+		{Prompt: "Pick a universe:", Game: "alpha"},
+		{Prompt: "ab", Game: "short"},
+		{Prompt: "Select a game :", Game: "builtin"},
+		{Prompt: "Pick a universe:", Game: "duplicate"},
+		{Prompt: "Line one\r\nLine two", Game: "multiline"},
+		{Prompt: "Enter the arena", Game: "../"},
+	})
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	for _, expected := range []string{"pattern 2:", "pattern 3:", "pattern 4:", "pattern 5:", "pattern 6:"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error for %s got: %v", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "pattern 1:") {
+		t.Errorf("Expected pattern 1 to be valid, got: %v", err)
+	}
+}
+
+func TestLoadDetectionPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.json")
+	data := `[{"prompt": "Choose your galaxy:", "game": "galaxy"}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write patterns: %v", err)
+	}
+
+	patterns, err := LoadDetectionPatterns(path)
+	if err != nil {
+		t.Fatalf("LoadDetectionPatterns failed: %v", err)
+	}
+	if len(patterns) != 1 || patterns[0].Prompt != "Choose your galaxy:" || patterns[0].Game != "galaxy" {
+		t.Errorf("Unexpected patterns %+v", patterns)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"prompt": "", "game": "galaxy"}]`), 0644); err != nil {
+		t.Fatalf("Failed to write patterns: %v", err)
+	}
+	if _, err := LoadDetectionPatterns(path); err == nil {
+		t.Error("Expected invalid patterns file to be rejected")
+	}
+}
+
+func TestGameDetector_CustomDetectionRegex(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	err := gd.AddDetectionPatterns([]DetectionPattern{
+		{Regex: `Enter universe (?P<name>\w+)\? \(Y/N\)`, Game: "universe-${name}"},
+	})
+	if err != nil {
+		t.Fatalf("AddDetectionPatterns failed: %v", err)
+	}
+
+	// The prompt is split across chunks and only matches once the server pauses after it
+	gd.ProcessLine("Welcome\r\nEnter universe Ga")
+	if gd.GetState() == StateGameActive {
+		t.Fatal("Expected no match on a partial prompt")
+	}
+	gd.ProcessLine("mma? (Y/N) ")
+
+	if gd.GetState() != StateGameActive {
+		t.Fatalf("Expected StateGameActive after custom regex, got %v", gd.GetState())
+	}
+	if gd.GetCurrentGame() != "universe-Gamma" {
+		t.Errorf("Expected game 'universe-Gamma', got %q", gd.GetCurrentGame())
+	}
+	if gd.GetCurrentDatabase() == nil {
+		t.Error("Expected game database to be loaded")
+	}
+}
+
+func TestGameDetector_CustomDetectionRegexBeforeBuiltIn(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	err := gd.AddDetectionPatterns([]DetectionPattern{
+		{Regex: `^Select a game : \[(\w+)\]$`, Game: "$1"},
+	})
+	if err != nil {
+		t.Fatalf("AddDetectionPatterns failed: %v", err)
+	}
+
+	// The built-in menu prompt is part of the line, but the user pattern decides the game
+	gd.ProcessLine("Select a game : [Arena]\r\n")
+
+	if gd.GetState() != StateGameActive || gd.GetCurrentGame() != "Arena" {
+		t.Errorf("Expected custom game 'Arena' to be active, got %q in %v", gd.GetCurrentGame(), gd.GetState())
+	}
+}
+
+func TestValidateDetectionPatterns_Regex(t *testing.T) {
+	err := ValidateDetectionPatterns([]DetectionPattern{
+		{Regex: `Universe (\w+):`, Game: "$1"},
+		{Regex: `Universe (`, Game: "broken"},
+		{Regex: `.*`, Game: "everything"},
+		{Regex: `Universe (\w+):`, Game: "duplicate"},
+		{Prompt: "Pick a universe:", Regex: `Universe`, Game: "both"},
+	})
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	for _, expected := range []string{"pattern 2:", "pattern 3:", "pattern 4:", "pattern 5:"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error for %s got: %v", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "pattern 1:") {
+		t.Errorf("Expected pattern 1 to be valid, got: %v", err)
+	}
+}
@@ -43,6 +43,8 @@ func newTestGameDetector(t *testing.T) (*GameDetector, func()) {
 
 // TestGameDetector_BasicFlow tests the complete game detection flow
 func TestGameDetector_BasicFlow(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "localhost", Port: t.Name()}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
@@ -90,6 +92,8 @@ func TestGameDetector_BasicFlow(t *testing.T) {
 
 // TestGameDetector_ChunkSplitting tests streaming across chunk boundaries
 func TestGameDetector_ChunkSplitting(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "localhost", Port: t.Name()}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
@@ -172,6 +176,8 @@ func TestGameDetector_ANSISequences(t *testing.T) {
 
 // TestGameDetector_ProcessChunk tests raw byte processing
 func TestGameDetector_ProcessChunk(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "localhost", Port: t.Name()}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
@@ -200,6 +206,8 @@ func TestGameDetector_ProcessChunk(t *testing.T) {
 
 // TestGameDetector_StateProtection tests state-based pattern filtering
 func TestGameDetector_StateProtection(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "localhost", Port: t.Name()}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
@@ -299,6 +307,62 @@ func TestGameDetector_Timeout(t *testing.T) {
 	}
 }
 
+// TestGameDetector_PromptDetectionTimeout tests the fallback when no known prompt arrives
+func TestGameDetector_PromptDetectionTimeout(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	failed := make(chan string, 1)
+	gd.SetDetectionFailedCallback(func(serverHost, serverPort string) {
+		failed <- serverHost
+	})
+	gd.SetPromptDetectionTimeout(time.Millisecond * 10)
+	gd.StartPromptDetection()
+
+	gd.ProcessLine("Welcome to an unrecognized game server\r\n")
+
+	select {
+	case host := <-failed:
+		if host != "localhost" {
+			t.Errorf("Expected host 'localhost', got %q", host)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Detection failed callback was not called within timeout")
+	}
+
+	// Manual fallback starts a database for this server
+	if err := gd.LoadServerDatabase(); err != nil {
+		t.Fatalf("LoadServerDatabase failed: %v", err)
+	}
+	if gd.GetCurrentDatabase() == nil {
+		t.Error("Expected database to be loaded after manual start")
+	}
+	if !gd.IsGameActive() {
+		t.Errorf("Expected StateGameActive after manual start, got %v", gd.GetState())
+	}
+}
+
+// TestGameDetector_PromptDetectionCancelled tests that a known prompt stops the fallback
+func TestGameDetector_PromptDetectionCancelled(t *testing.T) {
+	gd, cleanup := newTestGameDetector(t)
+	defer cleanup()
+
+	failed := make(chan string, 1)
+	gd.SetDetectionFailedCallback(func(serverHost, serverPort string) {
+		failed <- serverHost
+	})
+	gd.SetPromptDetectionTimeout(time.Millisecond * 10)
+	gd.StartPromptDetection()
+
+	gd.ProcessLine("Select a game :")
+
+	select {
+	case <-failed:
+		t.Error("Detection failed callback should not fire after a known prompt")
+	case <-time.After(time.Millisecond * 50):
+	}
+}
+
 // TestGameDetector_DatabaseLoading tests database creation
 func TestGameDetector_DatabaseLoading(t *testing.T) {
 	gd, cleanup := newTestGameDetector(t)
@@ -338,6 +402,8 @@ func TestGameDetector_DatabaseLoading(t *testing.T) {
 
 // TestGameDetector_ConcurrentAccess tests thread safety
 func TestGameDetector_ConcurrentAccess(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "localhost", Port: t.Name()}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
@@ -440,6 +506,8 @@ func TestGameDetector_EdgeCases(t *testing.T) {
 
 // TestGameDetector_RealWorldScenarios tests realistic game connection scenarios
 func TestGameDetector_RealWorldScenarios(t *testing.T) {
+	// Database files are created in the working directory
+	t.Chdir(t.TempDir())
 	connInfo := ConnectionInfo{Host: "example.com", Port: "2323"}
 	gd := NewGameDetector(connInfo)
 	defer gd.Close()
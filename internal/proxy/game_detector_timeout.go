@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"fmt"
+	"time"
+	"twist/internal/log"
+)
+
+// DefaultPromptDetectionTimeout is how long after connecting the detector waits for a
+// known login or game-selection prompt before reporting that detection failed
+const DefaultPromptDetectionTimeout = 30 * time.Second
+
+// manualGameName names the database created when the user starts one without detection
+const manualGameName = "manual"
+
+// SetPromptDetectionTimeout sets how long to wait for a known prompt after connecting.
+// Zero or negative disables the fallback.
+func (l *GameDetector) SetPromptDetectionTimeout(timeout time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.promptTimeout = timeout
+}
+
+// SetDetectionFailedCallback sets the function called when no known prompt is seen in time
+func (l *GameDetector) SetDetectionFailedCallback(callback func(serverHost, serverPort string)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onDetectionFailed = callback
+}
+
+// StartPromptDetection starts the prompt detection timer. Call once the connection is up.
+func (l *GameDetector) StartPromptDetection() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopPromptTimer()
+	if l.promptTimeout <= 0 {
+		return
+	}
+	l.promptTimer = time.AfterFunc(l.promptTimeout, l.promptDetectionExpired)
+}
+
+// stopPromptTimer cancels a pending prompt detection timeout
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) stopPromptTimer() {
+	if l.promptTimer != nil {
+		l.promptTimer.Stop()
+		l.promptTimer = nil
+	}
+}
+
+// promptDetectionExpired fires the detection failed callback if nothing was recognized
+func (l *GameDetector) promptDetectionExpired() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.promptTimer = nil
+	if l.currentDatabase != nil || l.state.Load().currentState != StateIdle {
+		return
+	}
+
+	log.Warn("GameDetector: no known prompt detected, game database not initialized", "host", l.serverHost, "port", l.serverPort, "timeout", l.promptTimeout)
+
+	if l.onDetectionFailed != nil {
+		callback := l.onDetectionFailed
+		go callback(l.serverHost, l.serverPort)
+	}
+}
+
+// LoadServerDatabase starts a game database for this server without a detected game.
+// This is the manual fallback offered when prompt detection fails.
+func (l *GameDetector) LoadServerDatabase() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.currentDatabase != nil {
+		return fmt.Errorf("a game database is already loaded")
+	}
+
+	log.Info("GameDetector: manually starting game database", "host", l.serverHost, "port", l.serverPort)
+	return l.startServerDatabase()
+}
+
+// startServerDatabase loads the single per-server game database used when no game was detected
+// This method assumes the caller already holds the mutex lock
+func (l *GameDetector) startServerDatabase() error {
+	l.stopPromptTimer()
+	l.updateState(func(s *gameDetectorState) *gameDetectorState {
+		newState := copyState(s)
+		newState.selectedGame = manualGameName
+		newState.selectedLetter = ""
+		newState.currentState = StateGameActive
+		return newState
+	})
+
+	return l.loadGameDatabase()
+}
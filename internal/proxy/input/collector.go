@@ -39,6 +39,9 @@ type InputCollector struct {
 
 	// Completion handlers for different input types
 	completionHandlers map[string]CompletionHandler
+
+	// Called after the user cancels collection, with the collection's menu name
+	cancelHandler func(menuName string)
 }
 
 // CompletionHandler processes completed input for specific menu operations
@@ -67,6 +70,12 @@ func (ic *InputCollector) RegisterCompletionHandler(menuName string, handler Com
 	ic.completionHandlers[menuName] = handler
 }
 
+// SetCancelHandler sets a function called after the user cancels input collection, so
+// the caller can return to where the collection started
+func (ic *InputCollector) SetCancelHandler(handler func(menuName string)) {
+	ic.cancelHandler = handler
+}
+
 // StartCollection begins collecting input for a menu operation
 func (ic *InputCollector) StartCollection(menuName, prompt string) {
 	defer func() {
@@ -182,7 +191,7 @@ func (ic *InputCollector) showCollectionHelp() {
 	debugSendOutput("\r\nInput Collection Help:\r\n", ic.sendOutput)
 	debugSendOutput("- Type your value and press Enter to submit\r\n", ic.sendOutput)
 	debugSendOutput("- Press Enter alone to submit empty value\r\n", ic.sendOutput)
-	debugSendOutput("- Press '\\' to cancel input collection\r\n", ic.sendOutput)
+	debugSendOutput("- Press '\\' to cancel and go back to the menu\r\n", ic.sendOutput)
 	debugSendOutput("Current input: "+ic.buffer+"\r\n", ic.sendOutput)
 }
 
@@ -212,9 +221,14 @@ func (ic *InputCollector) completeCollection(value string) error {
 	return nil
 }
 
-// cancelCollection cancels input collection without processing
+// cancelCollection cancels input collection without processing and tells the cancel
+// handler, if any
 func (ic *InputCollector) cancelCollection() {
+	menuName := ic.menuName
 	ic.exitCollection()
+	if ic.cancelHandler != nil {
+		ic.cancelHandler(menuName)
+	}
 }
 
 // exitCollection exits input collection mode
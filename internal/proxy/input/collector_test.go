@@ -499,3 +499,29 @@ func TestInputCollector_NoPrematireCompletion(t *testing.T) {
 		t.Errorf("Expected completed value '%s', got '%s'", filename, completedValue)
 	}
 }
+
+func TestInputCollector_CancelHandler(t *testing.T) {
+	mockOutput := &MockOutputFunc{}
+	collector := NewInputCollector(mockOutput.Send)
+
+	var cancelled []string
+	collector.SetCancelHandler(func(menuName string) {
+		cancelled = append(cancelled, menuName)
+	})
+	collector.RegisterCompletionHandler("TEST", func(menuName, value string) error {
+		return nil
+	})
+
+	collector.StartCollection("TEST", "Test prompt")
+	collector.HandleInput("5")
+	collector.HandleInput("\r")
+	if len(cancelled) != 0 {
+		t.Errorf("Expected completing input not to cancel, got %v", cancelled)
+	}
+
+	collector.StartCollection("TEST", "Test prompt")
+	collector.HandleInput("\\")
+	if len(cancelled) != 1 || cancelled[0] != "TEST" {
+		t.Errorf("Expected the cancel handler called with TEST, got %v", cancelled)
+	}
+}
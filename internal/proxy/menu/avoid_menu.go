@@ -0,0 +1,166 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+// SetAvoidChangedCallback sets the function called after a sector is added to or removed from the avoid list
+func (tmm *TerminalMenuManager) SetAvoidChangedCallback(callback func(sectorNum int)) {
+	tmm.onAvoidChanged = callback
+}
+
+// handleAddAvoid prompts for a sector to add to the avoid list
+func (tmm *TerminalMenuManager) handleAddAvoid(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleAddAvoid", "error", r)
+		}
+	}()
+
+	if _, ok := tmm.openDatabase(); !ok {
+		return nil
+	}
+
+	tmm.sendOutput("\r\nEnter sector number to avoid:\r\n")
+	tmm.startCollection("AVOID_ADD", "Sector to avoid")
+	return nil
+}
+
+// handleRemoveAvoid lists the current avoids and prompts for a sector to remove
+func (tmm *TerminalMenuManager) handleRemoveAvoid(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleRemoveAvoid", "error", r)
+		}
+	}()
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	avoids, err := db.ListAvoids()
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error listing avoids: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	if len(avoids) == 0 {
+		tmm.sendOutput("\r\nNo sectors are being avoided.\r\n")
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	sectors := make([]string, len(avoids))
+	for i, sector := range avoids {
+		sectors[i] = fmt.Sprintf("%d", sector)
+	}
+	tmm.sendOutput("\r\nAvoided sectors: " + strings.Join(sectors, ", ") + "\r\n")
+	tmm.sendOutput("Enter sector number to stop avoiding:\r\n")
+	tmm.startCollection("AVOID_REMOVE", "Sector to clear")
+	return nil
+}
+
+// handleAddAvoidInput handles input collection for adding an avoid
+func (tmm *TerminalMenuManager) handleAddAvoidInput(sectorStr string) error {
+	db, sectorNum, ok := tmm.parseSectorInput(sectorStr)
+	if !ok {
+		return nil
+	}
+
+	if err := db.AddAvoid(sectorNum); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error adding avoid: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Sector %d added to avoid list", sectorNum)))
+		tmm.notifyAvoidChanged(sectorNum)
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleRemoveAvoidInput handles input collection for removing an avoid
+func (tmm *TerminalMenuManager) handleRemoveAvoidInput(sectorStr string) error {
+	db, sectorNum, ok := tmm.parseSectorInput(sectorStr)
+	if !ok {
+		return nil
+	}
+
+	if !db.IsAvoided(sectorNum) {
+		tmm.sendOutput(display.FormatErrorMessage(fmt.Sprintf("Sector %d is not being avoided", sectorNum)))
+	} else if err := db.RemoveAvoid(sectorNum); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error removing avoid: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Sector %d removed from avoid list", sectorNum)))
+		tmm.notifyAvoidChanged(sectorNum)
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// parseSectorInput validates a collected sector number against the open database.
+// On failure the error is shown and the menu redisplayed.
+func (tmm *TerminalMenuManager) parseSectorInput(sectorStr string) (database.Database, int, bool) {
+	sectorStr = strings.TrimSpace(sectorStr)
+	if sectorStr == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No sector number provided"))
+		tmm.displayCurrentMenu()
+		return nil, 0, false
+	}
+
+	sectorNum := 0
+	if _, err := fmt.Sscanf(sectorStr, "%d", &sectorNum); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Invalid sector number: " + sectorStr))
+		tmm.displayCurrentMenu()
+		return nil, 0, false
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil, 0, false
+	}
+
+	if sectorNum < 1 || sectorNum > db.GetSectors() {
+		tmm.sendOutput(display.FormatErrorMessage("That is not a valid sector"))
+		tmm.displayCurrentMenu()
+		return nil, 0, false
+	}
+
+	return db, sectorNum, true
+}
+
+// openDatabase returns the open database, showing an error and redisplaying the menu if unavailable
+func (tmm *TerminalMenuManager) openDatabase() (database.Database, bool) {
+	var dbInterface interface{}
+	if tmm.getDatabase != nil {
+		dbInterface = tmm.getDatabase()
+	}
+
+	db, ok := dbInterface.(database.Database)
+	if !ok || db == nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error: Database not available"))
+		tmm.displayCurrentMenu()
+		return nil, false
+	}
+
+	if !db.GetDatabaseOpen() {
+		tmm.sendOutput(display.FormatErrorMessage("Error: Database not open"))
+		tmm.displayCurrentMenu()
+		return nil, false
+	}
+
+	return db, true
+}
+
+// notifyAvoidChanged reports an avoid list change so the map can restyle the sector
+func (tmm *TerminalMenuManager) notifyAvoidChanged(sectorNum int) {
+	if tmm.onAvoidChanged != nil {
+		tmm.onAvoidChanged(sectorNum)
+	}
+}
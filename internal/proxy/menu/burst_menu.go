@@ -0,0 +1,504 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+// handleSaveBurst prompts for a name to save the last burst under
+func (tmm *TerminalMenuManager) handleSaveBurst(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleSaveBurst", "error", r)
+		}
+	}()
+
+	if tmm.loadLastBurst() == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No burst to save - send one first"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	if _, ok := tmm.openDatabase(); !ok {
+		return nil
+	}
+
+	tmm.sendOutput("\r\nLast burst: " + tmm.lastBurst + "\r\n")
+	tmm.sendOutput("Enter a name to save it under (an existing burst with that name is replaced):\r\n")
+	tmm.startCollection("BURST_SAVE", "Burst name")
+	return nil
+}
+
+// handleListBursts shows the saved bursts
+func (tmm *TerminalMenuManager) handleListBursts(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleListBursts", "error", r)
+		}
+	}()
+
+	if bursts, ok := tmm.listBursts(); ok {
+		tmm.sendOutput(formatBursts(bursts))
+	}
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleSendNamedBurst lists the saved bursts and prompts for the one to send
+func (tmm *TerminalMenuManager) handleSendNamedBurst(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleSendNamedBurst", "error", r)
+		}
+	}()
+
+	if !tmm.promptForBurstName("BURST_SEND_NAMED", "Enter the name of the burst to send:") {
+		tmm.displayCurrentMenu()
+	}
+	return nil
+}
+
+// handleEditNamedBurst prompts for the saved burst to edit, or a new name to create one
+func (tmm *TerminalMenuManager) handleEditNamedBurst(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleEditNamedBurst", "error", r)
+		}
+	}()
+
+	bursts, ok := tmm.listBursts()
+	if !ok {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.sendOutput(formatBursts(bursts))
+	tmm.sendOutput("Enter the name of the burst to edit, or a new name to create one:\r\n")
+	tmm.startCollection("BURST_EDIT_NAME", "Burst name")
+	return nil
+}
+
+// handleDeleteNamedBurst lists the saved bursts and prompts for the one to delete
+func (tmm *TerminalMenuManager) handleDeleteNamedBurst(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleDeleteNamedBurst", "error", r)
+		}
+	}()
+
+	if !tmm.promptForBurstName("BURST_DELETE", "Enter the name of the burst to delete:") {
+		tmm.displayCurrentMenu()
+	}
+	return nil
+}
+
+// handleBurstDelay shows the pause between burst commands and prompts for a new one
+func (tmm *TerminalMenuManager) handleBurstDelay(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleBurstDelay", "error", r)
+		}
+	}()
+
+	tmm.sendOutput(fmt.Sprintf("\r\nBurst commands are sent %dms apart.\r\n", tmm.burstQueue.getDelay().Milliseconds()))
+	tmm.sendOutput(fmt.Sprintf("Enter the delay in milliseconds (0-%d, empty to keep it):\r\n", maxBurstDelay.Milliseconds()))
+	tmm.startCollection("BURST_DELAY", "Delay (ms)")
+	return nil
+}
+
+// handleBurstPrompt shows the prompt burst commands wait for and prompts for a new one
+func (tmm *TerminalMenuManager) handleBurstPrompt(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleBurstPrompt", "error", r)
+		}
+	}()
+
+	if prompt := tmm.burstQueue.getPrompt(); prompt != "" {
+		tmm.sendOutput("\r\nEach burst command waits for the game to send: " + prompt + "\r\n")
+	} else {
+		tmm.sendOutput("\r\nBurst commands don't wait for a prompt.\r\n")
+	}
+	tmm.sendOutput("Enter the text to wait for, such as 'Command [' ('-' to stop waiting, empty to keep it):\r\n")
+	tmm.startCollection("BURST_PROMPT", "Prompt")
+	return nil
+}
+
+// handleBurstHistory lists the recently sent bursts and prompts for the one to send again
+func (tmm *TerminalMenuManager) handleBurstHistory(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleBurstHistory", "error", r)
+		}
+	}()
+
+	history, ok := tmm.listBurstHistory()
+	if !ok {
+		return nil
+	}
+
+	tmm.sendOutput(formatBurstHistory(history))
+	if len(history) == 0 {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	tmm.sendOutput("Enter the number of the burst to send:\r\n")
+	tmm.startCollection("BURST_HISTORY", "Burst number")
+	return nil
+}
+
+// handleBurstSaveInput saves the last burst under the entered name
+func (tmm *TerminalMenuManager) handleBurstSaveInput(name string) error {
+	if name = strings.TrimSpace(name); name == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No name entered - burst not saved"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	if err := db.SaveBurst(name, tmm.lastBurst); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error saving burst: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Burst saved as " + name))
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleBurstSendNamedInput sends the burst saved under the entered name
+func (tmm *TerminalMenuManager) handleBurstSendNamedInput(name string) error {
+	_, text, ok := tmm.lookupBurst(name)
+	if !ok {
+		return nil
+	}
+
+	// Store as last burst so it can be repeated or edited like one typed in
+	tmm.rememberBurst(text)
+
+	// Send the burst command (replace * with newline)
+	expandedText := strings.ReplaceAll(text, "*", "\r\n")
+	tmm.sendBurstToServer(expandedText)
+
+	tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Burst %s sent: %s", strings.TrimSpace(name), text)))
+
+	// Exit menu system after sending burst command so user input goes to game
+	atomic.StoreInt32(&tmm.isActive, 0) // atomic false
+	tmm.currentMenu = nil
+	return nil
+}
+
+// handleBurstHistoryInput sends the burst at the entered position in the history
+func (tmm *TerminalMenuManager) handleBurstHistoryInput(value string) error {
+	if value = strings.TrimSpace(value); value == "" {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	history, ok := tmm.listBurstHistory()
+	if !ok {
+		return nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 || number > len(history) {
+		tmm.sendOutput(display.FormatErrorMessage(fmt.Sprintf("Enter a number from 1 to %d", len(history))))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	text := history[number-1]
+	tmm.rememberBurst(text)
+	tmm.sendBurstToServer(strings.ReplaceAll(text, "*", "\r\n"))
+	tmm.sendOutput(display.FormatSuccessMessage("Burst sent: " + text))
+
+	// Exit menu system after sending burst command so user input goes to game
+	atomic.StoreInt32(&tmm.isActive, 0) // atomic false
+	tmm.currentMenu = nil
+	return nil
+}
+
+// handleBurstEditNameInput shows the burst saved under the entered name and prompts for its
+// new text
+func (tmm *TerminalMenuManager) handleBurstEditNameInput(name string) error {
+	if name = strings.TrimSpace(name); name == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No name entered"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	text, err := db.GetBurst(name)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error loading burst: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.editingBurst = name
+	if text == "" {
+		tmm.sendOutput("\r\nNew burst " + name + "\r\n")
+	} else {
+		tmm.sendOutput("\r\nBurst " + name + ": " + text + "\r\n")
+	}
+	tmm.sendOutput("Enter the burst text, using '*' for ENTER (empty to cancel):\r\n")
+	tmm.startCollection("BURST_EDIT_TEXT", "Burst text")
+	return nil
+}
+
+// handleBurstEditTextInput saves the entered text under the burst name being edited
+func (tmm *TerminalMenuManager) handleBurstEditTextInput(text string) error {
+	name := tmm.editingBurst
+	tmm.editingBurst = ""
+
+	if text = strings.TrimSpace(text); text == "" {
+		tmm.sendOutput(display.FormatErrorMessage("Empty burst - edit cancelled"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	if err := db.SaveBurst(name, text); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error saving burst: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Burst " + name + " saved: " + text))
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleBurstDeleteInput deletes the burst saved under the entered name
+func (tmm *TerminalMenuManager) handleBurstDeleteInput(name string) error {
+	db, _, ok := tmm.lookupBurst(name)
+	if !ok {
+		return nil
+	}
+
+	name = strings.TrimSpace(name)
+	if err := db.DeleteBurst(name); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error deleting burst: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Burst " + name + " deleted"))
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleBurstDelayInput sets the pause between burst commands to the entered milliseconds
+func (tmm *TerminalMenuManager) handleBurstDelayInput(value string) error {
+	if value = strings.TrimSpace(value); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 || time.Duration(ms)*time.Millisecond > maxBurstDelay {
+			tmm.sendOutput(display.FormatErrorMessage(fmt.Sprintf("Delay must be a number from 0 to %d", maxBurstDelay.Milliseconds())))
+		} else {
+			delay := tmm.burstQueue.setDelay(time.Duration(ms) * time.Millisecond)
+			tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Burst delay set to %dms", delay.Milliseconds())))
+		}
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleBurstPromptInput sets the text burst commands wait for; '-' turns waiting off
+func (tmm *TerminalMenuManager) handleBurstPromptInput(value string) error {
+	switch value = strings.TrimSpace(value); value {
+	case "":
+	case "-":
+		tmm.burstQueue.setPrompt("")
+		tmm.sendOutput(display.FormatSuccessMessage("Burst commands no longer wait for a prompt"))
+	default:
+		tmm.burstQueue.setPrompt(value)
+		tmm.sendOutput(display.FormatSuccessMessage("Burst commands wait for: " + value))
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// promptForBurstName lists the saved bursts and starts collecting a name. Returns false,
+// after showing why, if there are no saved bursts to choose from.
+func (tmm *TerminalMenuManager) promptForBurstName(collection, prompt string) bool {
+	bursts, ok := tmm.listBursts()
+	if !ok {
+		return false
+	}
+	if len(bursts) == 0 {
+		tmm.sendOutput("\r\nNo saved bursts. Send a burst, then save it with S.\r\n")
+		return false
+	}
+
+	tmm.sendOutput(formatBursts(bursts))
+	tmm.sendOutput(prompt + "\r\n")
+	tmm.startCollection(collection, "Burst name")
+	return true
+}
+
+// rememberBurst makes text the last burst and records it in the burst history, so it can
+// be repeated after a restart
+func (tmm *TerminalMenuManager) rememberBurst(text string) {
+	tmm.lastBurst = text
+
+	// A burst is still sent without a database; it just isn't remembered past this session
+	db, ok := tmm.getDatabaseQuietly()
+	if !ok {
+		return
+	}
+	if err := db.AddBurstHistory(text); err != nil {
+		log.Warn("Failed to record burst history", "error", err)
+	}
+}
+
+// loadLastBurst returns the last burst, falling back to the newest one in the burst history
+// when none has been sent since starting
+func (tmm *TerminalMenuManager) loadLastBurst() string {
+	if tmm.lastBurst != "" {
+		return tmm.lastBurst
+	}
+
+	db, ok := tmm.getDatabaseQuietly()
+	if !ok {
+		return ""
+	}
+	history, err := db.ListBurstHistory(1)
+	if err != nil {
+		log.Warn("Failed to load burst history", "error", err)
+		return ""
+	}
+	if len(history) > 0 {
+		tmm.lastBurst = history[0]
+	}
+	return tmm.lastBurst
+}
+
+// getDatabaseQuietly returns the open database, or false without showing an error
+func (tmm *TerminalMenuManager) getDatabaseQuietly() (database.Database, bool) {
+	if tmm.getDatabase == nil {
+		return nil, false
+	}
+	db, ok := tmm.getDatabase().(database.Database)
+	if !ok || db == nil || !db.GetDatabaseOpen() {
+		return nil, false
+	}
+	return db, true
+}
+
+// listBurstHistory loads the recently sent bursts, showing an error if they can't be read
+func (tmm *TerminalMenuManager) listBurstHistory() ([]string, bool) {
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil, false
+	}
+
+	history, err := db.ListBurstHistory(database.MaxBurstHistory)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error loading burst history: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil, false
+	}
+	return history, true
+}
+
+// listBursts loads the saved bursts, showing an error if they can't be read
+func (tmm *TerminalMenuManager) listBursts() ([]database.Burst, bool) {
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil, false
+	}
+
+	bursts, err := db.ListBursts()
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error listing bursts: " + err.Error()))
+		return nil, false
+	}
+	return bursts, true
+}
+
+// lookupBurst loads the burst saved under an entered name. On failure the error is shown and
+// the menu redisplayed.
+func (tmm *TerminalMenuManager) lookupBurst(name string) (database.Database, string, bool) {
+	if name = strings.TrimSpace(name); name == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No name entered"))
+		tmm.displayCurrentMenu()
+		return nil, "", false
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil, "", false
+	}
+
+	text, err := db.GetBurst(name)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error loading burst: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil, "", false
+	}
+	if text == "" {
+		tmm.sendOutput(display.FormatErrorMessage("No burst saved as " + name))
+		tmm.displayCurrentMenu()
+		return nil, "", false
+	}
+
+	return db, text, true
+}
+
+// formatBursts renders the saved bursts, one "name  text" per line
+func formatBursts(bursts []database.Burst) string {
+	var output strings.Builder
+	output.WriteString("\r\n")
+
+	if len(bursts) == 0 {
+		output.WriteString("No saved bursts.\r\n")
+		return output.String()
+	}
+
+	width := 0
+	for _, burst := range bursts {
+		width = max(width, len(burst.Name))
+	}
+
+	output.WriteString("Saved bursts:\r\n")
+	for _, burst := range bursts {
+		output.WriteString(fmt.Sprintf("  %-*s  %s\r\n", width, burst.Name, burst.Text))
+	}
+	output.WriteString("\r\n")
+	return output.String()
+}
+
+// formatBurstHistory renders the recently sent bursts, newest first and numbered from 1
+func formatBurstHistory(history []string) string {
+	var output strings.Builder
+	output.WriteString("\r\n")
+
+	if len(history) == 0 {
+		output.WriteString("No bursts sent yet.\r\n")
+		return output.String()
+	}
+
+	output.WriteString("Recent bursts:\r\n")
+	for i, text := range history {
+		output.WriteString(fmt.Sprintf("  %2d  %s\r\n", i+1, text))
+	}
+	output.WriteString("\r\n")
+	return output.String()
+}
@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestNamedBursts(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	var output strings.Builder
+	sent := &sentCommands{}
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		sent.send,
+	)
+
+	tmm.lastBurst = "pt*s*"
+	if err := tmm.handleBurstSaveInput("trade"); err != nil {
+		t.Fatalf("Saving the last burst failed: %v", err)
+	}
+	if text, _ := db.GetBurst("trade"); text != "pt*s*" {
+		t.Fatalf("Expected the last burst saved as trade, got %q", text)
+	}
+
+	// Editing a new name creates the burst
+	tmm.handleBurstEditNameInput("dock")
+	tmm.handleBurstEditTextInput("p*t*")
+	if text, _ := db.GetBurst("dock"); text != "p*t*" {
+		t.Fatalf("Expected the edited burst saved as dock, got %q", text)
+	}
+
+	output.Reset()
+	tmm.handleListBursts(nil, nil)
+	if !strings.Contains(output.String(), "dock   p*t*") || !strings.Contains(output.String(), "trade  pt*s*") {
+		t.Errorf("Expected both bursts listed, got:\n%s", output.String())
+	}
+
+	// Sending by name expands '*' to ENTER and leaves the menu
+	tmm.lastBurst = ""
+	tmm.ActivateMainMenu()
+	tmm.handleBurstSendNamedInput("TRADE")
+	if commands, _ := sent.waitFor(t, 2); len(commands) != 2 || commands[0] != "pt\r\n" || commands[1] != "s\r\n" {
+		t.Errorf("Expected the trade burst sent as two commands, got %q", commands)
+	}
+	if tmm.IsActive() {
+		t.Error("Expected the menu to close after sending a burst")
+	}
+	if tmm.lastBurst != "pt*s*" {
+		t.Errorf("Expected the sent burst to become the last burst, got %q", tmm.lastBurst)
+	}
+
+	output.Reset()
+	tmm.handleBurstSendNamedInput("missing")
+	if !strings.Contains(output.String(), "No burst saved as missing") {
+		t.Errorf("Expected an error for an unknown burst, got:\n%s", output.String())
+	}
+
+	tmm.handleBurstDeleteInput("dock")
+	if text, _ := db.GetBurst("dock"); text != "" {
+		t.Errorf("Expected dock to be deleted, got %q", text)
+	}
+}
+
+func TestBurstHistoryOutlivesMenuManager(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	newManager := func(output *strings.Builder, sent *sentCommands) *TerminalMenuManager {
+		return NewTerminalMenuManager(
+			func(data []byte) { output.Write(data) },
+			func() ScriptManagerInterface { return nil },
+			func() interface{} { return db },
+			func(string) {},
+			sent.send,
+		)
+	}
+
+	var output strings.Builder
+	tmm := newManager(&output, &sentCommands{})
+	tmm.handleBurstSendInput("pt*")
+	tmm.handleBurstSendInput("m 12*")
+
+	// A new manager, as after a restart, repeats the last burst from the history
+	output.Reset()
+	sent := &sentCommands{}
+	tmm = newManager(&output, sent)
+	tmm.ActivateMainMenu()
+	tmm.handleRepeatBurst(nil, nil)
+	if commands, _ := sent.waitFor(t, 1); commands[0] != "m 12\r\n" {
+		t.Errorf("Expected the last burst repeated after a restart, got %q", commands)
+	}
+
+	output.Reset()
+	tmm.ActivateMainMenu()
+	tmm.handleBurstHistory(nil, nil)
+	if !strings.Contains(output.String(), " 1  m 12*") || !strings.Contains(output.String(), " 2  pt*") {
+		t.Errorf("Expected the history listed newest first, got:\n%s", output.String())
+	}
+	tmm.handleBurstHistoryInput("2")
+	if commands, _ := sent.waitFor(t, 2); commands[1] != "pt\r\n" {
+		t.Errorf("Expected burst 2 from the history sent, got %q", commands)
+	}
+	if tmm.lastBurst != "pt*" {
+		t.Errorf("Expected the burst sent from the history to become the last burst, got %q", tmm.lastBurst)
+	}
+}
@@ -0,0 +1,178 @@
+package menu
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"twist/internal/ansi"
+	"twist/internal/log"
+)
+
+const (
+	// defaultBurstDelay is the pause between burst commands, enough for slow servers to
+	// keep up without making bursts feel sluggish
+	defaultBurstDelay = 50 * time.Millisecond
+	// maxBurstDelay bounds the delay so a typo can't stall a burst for minutes
+	maxBurstDelay = 5 * time.Second
+	// burstPromptTimeout is how long a command waits for the prompt before going anyway,
+	// so a prompt that never comes can't stall the rest of the burst
+	burstPromptTimeout = 10 * time.Second
+)
+
+// burstQueue sends burst commands to the server one at a time, pausing between them so
+// slow servers don't drop input. With a prompt set, each command also waits until the
+// server has sent the prompt after the one before. Commands are sent from the queue's own
+// goroutine, so queuing never blocks the menu.
+type burstQueue struct {
+	mutex   sync.Mutex
+	pending []string
+	running bool
+	delay   time.Duration
+	send    func(string)
+
+	prompt   string                  // Server text to wait for between commands, "" to only pause
+	ready    chan struct{}           // Closed when the prompt arrives after the last command sent
+	seen     string                  // Server text since the last command, trimmed to the prompt's length
+	stripper *ansi.StreamingStripper // Strips colors from server text so prompts match as shown
+}
+
+// newBurstQueue creates a queue that sends commands with send
+func newBurstQueue(send func(string)) *burstQueue {
+	return &burstQueue{delay: defaultBurstDelay, send: send, stripper: ansi.NewStreamingStripper()}
+}
+
+// enqueue adds commands to the queue, starting to send them if the queue was idle
+func (q *burstQueue) enqueue(commands []string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.pending = append(q.pending, commands...)
+	if !q.running && len(q.pending) > 0 {
+		q.running = true
+		go q.drain()
+	}
+}
+
+// drain sends queued commands until the queue is empty. The first command goes out
+// immediately; each later one waits for the prompt, if set, and then the delay.
+func (q *burstQueue) drain() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in burstQueue.drain", "error", r)
+			q.mutex.Lock()
+			q.pending = nil
+			q.running = false
+			q.mutex.Unlock()
+		}
+	}()
+
+	for first := true; ; first = false {
+		q.mutex.Lock()
+		if len(q.pending) == 0 {
+			q.running = false
+			q.mutex.Unlock()
+			return
+		}
+		command := q.pending[0]
+		q.pending = q.pending[1:]
+		delay := q.delay
+		ready, prompt := q.ready, q.prompt
+		q.mutex.Unlock()
+
+		if !first {
+			if ready != nil {
+				waitForPrompt(ready, prompt)
+			}
+			time.Sleep(delay)
+		}
+		q.armPrompt()
+		q.send(command)
+	}
+}
+
+// waitForPrompt waits until ready is closed or the prompt timeout passes
+func waitForPrompt(ready chan struct{}, prompt string) {
+	select {
+	case <-ready:
+	case <-time.After(burstPromptTimeout):
+		log.Warn("Burst prompt not seen, sending the next command", "prompt", prompt, "timeout", burstPromptTimeout)
+	}
+}
+
+// armPrompt starts watching server output for the prompt, before a command is sent so a
+// quick reply isn't missed
+func (q *burstQueue) armPrompt() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.ready, q.seen = nil, ""
+	if q.prompt != "" {
+		q.ready = make(chan struct{})
+	}
+}
+
+// serverOutput watches text from the server for the prompt the next command waits for
+func (q *burstQueue) serverOutput(data []byte) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.prompt == "" {
+		return
+	}
+	// Strip even while idle so an escape split across reads doesn't eat the next prompt
+	text := q.stripper.StripChunk(string(data))
+	if q.ready == nil {
+		return
+	}
+	q.seen += text
+	if strings.Contains(q.seen, q.prompt) {
+		close(q.ready)
+		q.ready, q.seen = nil, ""
+		return
+	}
+	// Keep just enough to match a prompt split across reads
+	if keep := len(q.prompt) - 1; len(q.seen) > keep {
+		q.seen = q.seen[len(q.seen)-keep:]
+	}
+}
+
+// setPrompt sets the server text each command after the first waits for, "" to not wait
+func (q *burstQueue) setPrompt(prompt string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.prompt = prompt
+	if prompt == "" && q.ready != nil {
+		close(q.ready)
+		q.ready = nil
+	}
+	q.seen = ""
+}
+
+// getPrompt returns the server text commands wait for, "" if they only pause
+func (q *burstQueue) getPrompt() string {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.prompt
+}
+
+// setDelay changes the pause between commands, clamped to 0 through maxBurstDelay
+func (q *burstQueue) setDelay(delay time.Duration) time.Duration {
+	if delay < 0 {
+		delay = 0
+	} else if delay > maxBurstDelay {
+		delay = maxBurstDelay
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.delay = delay
+	return delay
+}
+
+// getDelay returns the pause between commands
+func (q *burstQueue) getDelay() time.Duration {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.delay
+}
@@ -0,0 +1,113 @@
+package menu
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// sentCommands collects commands sent from the burst queue's goroutine
+type sentCommands struct {
+	mutex    sync.Mutex
+	commands []string
+	times    []time.Time
+}
+
+func (s *sentCommands) send(command string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.commands = append(s.commands, command)
+	s.times = append(s.times, time.Now())
+}
+
+// waitFor waits until count commands have been sent and returns them
+func (s *sentCommands) waitFor(t *testing.T, count int) ([]string, []time.Time) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		s.mutex.Lock()
+		if len(s.commands) >= count {
+			commands, times := append([]string(nil), s.commands...), append([]time.Time(nil), s.times...)
+			s.mutex.Unlock()
+			return commands, times
+		}
+		s.mutex.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d commands", count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBurstQueuePacesCommands(t *testing.T) {
+	sent := &sentCommands{}
+	queue := newBurstQueue(sent.send)
+	if got := queue.setDelay(20 * time.Millisecond); got != 20*time.Millisecond {
+		t.Fatalf("Expected a 20ms delay, got %v", got)
+	}
+
+	start := time.Now()
+	queue.enqueue([]string{"a", "b", "c"})
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Errorf("Expected enqueue to return immediately, took %v", elapsed)
+	}
+
+	commands, times := sent.waitFor(t, 3)
+	if commands[0] != "a" || commands[1] != "b" || commands[2] != "c" {
+		t.Errorf("Expected commands in order, got %v", commands)
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < 20*time.Millisecond {
+			t.Errorf("Expected at least 20ms between commands %d and %d, got %v", i-1, i, gap)
+		}
+	}
+
+	// Commands queued while draining are sent after the earlier ones
+	queue.enqueue([]string{"d"})
+	queue.enqueue([]string{"e"})
+	if commands, _ := sent.waitFor(t, 5); commands[3] != "d" || commands[4] != "e" {
+		t.Errorf("Expected later bursts to follow, got %v", commands)
+	}
+}
+
+func TestBurstQueueDelayLimits(t *testing.T) {
+	queue := newBurstQueue(func(string) {})
+	if queue.getDelay() != defaultBurstDelay {
+		t.Errorf("Expected default delay %v, got %v", defaultBurstDelay, queue.getDelay())
+	}
+	if got := queue.setDelay(-time.Second); got != 0 {
+		t.Errorf("Expected a negative delay to clamp to 0, got %v", got)
+	}
+	if got := queue.setDelay(time.Hour); got != maxBurstDelay {
+		t.Errorf("Expected a huge delay to clamp to %v, got %v", maxBurstDelay, got)
+	}
+}
+
+func TestBurstQueueWaitsForPrompt(t *testing.T) {
+	sent := &sentCommands{}
+	queue := newBurstQueue(sent.send)
+	queue.setDelay(0)
+	queue.setPrompt("Command [")
+
+	queue.enqueue([]string{"a", "b"})
+	sent.waitFor(t, 1)
+	time.Sleep(30 * time.Millisecond)
+	if commands, _ := sent.waitFor(t, 1); len(commands) != 1 {
+		t.Fatalf("Expected the second command to wait for the prompt, got %v", commands)
+	}
+
+	// A colored prompt split across reads still counts
+	queue.serverOutput([]byte("\x1b[35mComm"))
+	queue.serverOutput([]byte("and \x1b[1;33m[\x1b[0m"))
+	if commands, _ := sent.waitFor(t, 2); commands[1] != "b" {
+		t.Errorf("Expected b after the prompt, got %v", commands)
+	}
+
+	// Clearing the prompt releases a waiting command
+	queue.enqueue([]string{"c", "d"})
+	sent.waitFor(t, 3)
+	queue.setPrompt("")
+	if commands, _ := sent.waitFor(t, 4); commands[3] != "d" {
+		t.Errorf("Expected d once the prompt was cleared, got %v", commands)
+	}
+}
@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/api"
+	"twist/internal/log"
+	"twist/internal/proxy/menu/display"
+)
+
+// handleExploredCoverage shows how much of the universe is mapped, by exploration status,
+// to help pick where to scout next
+func (tmm *TerminalMenuManager) handleExploredCoverage(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleExploredCoverage", "error", r)
+		}
+	}()
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	stats, err := db.GetExplorationStats()
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error counting sectors: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.sendOutput(formatExploredCoverage(stats))
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// formatExploredCoverage lays out the sector counts for the terminal. Unknown sectors
+// include those the database has never seen at all.
+func formatExploredCoverage(stats api.ExplorationStats) string {
+	var output strings.Builder
+	output.WriteString("\r\nExplored coverage:\r\n")
+	output.WriteString(fmt.Sprintf("  holo: %d, density: %d, calc-only: %d, unknown: %d\r\n",
+		stats.Holo, stats.Density, stats.Calculated, stats.Unexplored))
+	output.WriteString(fmt.Sprintf("  Sectors known: %d of %d", stats.Known, stats.TotalSectors))
+	if stats.TotalSectors > 0 {
+		output.WriteString(fmt.Sprintf(" (%.1f%% visited or holo scanned)", stats.PercentHolo()))
+	}
+	output.WriteString("\r\n")
+	return output.String()
+}
@@ -0,0 +1,40 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestHandleExploredCoverage(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	for sector, explored := range map[int]database.TSectorExploredType{1: database.EtHolo, 2: database.EtDensity, 3: database.EtCalc, 8: database.EtHolo} {
+		s := database.NULLSector()
+		s.Explored = explored
+		if err := db.SaveSector(s, sector); err != nil {
+			t.Fatalf("Failed to save sector %d: %v", sector, err)
+		}
+	}
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+
+	tmm.handleExploredCoverage(nil, nil)
+	for _, want := range []string{"holo: 2, density: 1, calc-only: 1, unknown: 4", "Sectors known: 4 of 8 (25.0% visited or holo scanned)"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected %q in output, got %q", want, output.String())
+		}
+	}
+}
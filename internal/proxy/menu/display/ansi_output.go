@@ -23,6 +23,9 @@ const (
 	ANSI_BG_BLACK = "\x1b[40m"
 	ANSI_BG_BLUE  = "\x1b[44m"
 
+	// Port class colors
+	PORT_PAIR = "\x1b[33m" // yellow - classes 1 (BBS) and 2 (BSB), which pair up for trading
+
 	// Cursor control
 	ANSI_CLEAR_LINE     = "\x1b[2K"
 	ANSI_CLEAR_SCREEN   = "\x1b[2J"
@@ -271,6 +274,29 @@ func StripANSI(text string) string {
 	result = strings.ReplaceAll(result, MENU_DARK, "")
 	result = strings.ReplaceAll(result, ANSI_BG_BLACK, "")
 	result = strings.ReplaceAll(result, ANSI_BG_BLUE, "")
+	result = strings.ReplaceAll(result, PORT_PAIR, "")
 
 	return result
 }
+
+// PortClassColor returns the color port lists show a port of the class in: classes 1 and 2
+// stand out for pair trading, the other trading classes are the menu's usual color, SSS and
+// BBB ports are dimmed, and special ports such as Sol and Stardock are bright
+func PortClassColor(class int) string {
+	switch class {
+	case 1, 2:
+		return PORT_PAIR
+	case 3, 4, 5, 6:
+		return MENU_MID
+	case 7, 8:
+		return MENU_DARK
+	default:
+		return MENU_LIGHT
+	}
+}
+
+// FormatPortClass returns the port class right-aligned in a five column cell, in bold in
+// its class color. The color codes take no columns, so tables stay aligned.
+func FormatPortClass(class int) string {
+	return fmt.Sprintf("%s%s%5d%s", PortClassColor(class), ANSI_BOLD, class, ANSI_RESET)
+}
@@ -48,7 +48,7 @@ func (hs *HelpSystem) initializeDefaultHelp() {
 	hs.inputHelp = "Input Collection Help:\n" +
 		"- Type your value and press Enter to submit\n" +
 		"- Press Enter alone to submit empty value\n" +
-		"- Press '\\' to cancel input collection\n" +
+		"- Press '\\' to cancel and go back to the menu\n" +
 		"- Press '?' for this help"
 
 	hs.navigationHelp = "Menu Navigation:\n" +
@@ -69,22 +69,39 @@ func (hs *HelpSystem) initializeDefaultHelp() {
 	hs.menuHelp[TWX_SCRIPT] = "TWX Script Menu:\n" +
 		"L - Load Script (load and run a new script)\n" +
 		"T - Terminate Script (stop all running scripts)\n" +
-		"P - Pause Script (pause execution - not implemented)\n" +
-		"R - Resume Script (resume paused scripts - not implemented)\n" +
+		"P - Pause Script (hold a running script so it can be stepped)\n" +
+		"R - Resume Script (let the paused script run on)\n" +
+		"S - Step Script (run the paused script's next statement and show what changed)\n" +
+		"B - Breakpoint on/off (pause a script when it reaches a line, e.g. 'mine 42')\n" +
 		"D - Debug Script (show script debugging info)\n" +
-		"V - Variable Dump (display script variables)"
+		"V - Variable Dump (display script variables)\n" +
+		"W - Watch Variables (variable dump that refreshes as the script runs, any key stops)\n" +
+		"X - Trace on/off (log every script line run, with its arguments, to a file)"
 
 	hs.menuHelp[TWX_DATA] = "TWX Data Menu:\n" +
 		"S - Sector Display (show sector information from database)\n" +
 		"T - Trader List (show trader information - not implemented)\n" +
 		"P - Port List (show port information from database)\n" +
 		"R - Route Plot (show trading routes - not implemented)\n" +
-		"B - Bubble Info (show space bubble info - not implemented)"
+		"N - Sector note (enter a sector and a note to flag it on the map, or just the sector to clear it)\n" +
+		"B - Dump current sector (write it, its port and recent game text to a file for a bug report)\n" +
+		"I - Parser statistics (lines processed, sectors and ports saved, unrecognized prompts, errors recovered)\n" +
+		"O - Clear old traders and ships (forget traders, ships, aliens and foreign fighters in sectors not seen for a while)\n" +
+		"W - Check and repair warps (list duplicate, out of range and one-way warps, then dedup and sort them)\n" +
+		"E - Explored coverage (count sectors visited or holo scanned, density scanned, calculated only and unknown)"
 
 	hs.menuHelp["TWX_BURST"] = "TWX Burst Menu:\n" +
 		"B - Send burst (send a new burst command to game)\n" +
 		"R - Repeat last burst (repeat the previous burst command)\n" +
 		"E - Edit/Send last burst (modify and send previous burst)\n" +
+		"S - Save last burst (store the previous burst under a name)\n" +
+		"L - List saved bursts\n" +
+		"N - Send saved burst (send a burst by name)\n" +
+		"M - Modify saved burst (change or create a burst by name)\n" +
+		"X - Delete saved burst\n" +
+		"D - Set burst delay (pause between commands, default 50ms)\n" +
+		"P - Set burst prompt (wait for text from the game before each next command)\n" +
+		"H - Burst history (send one of the last 20 bursts again)\n" +
 		"\nBurst commands use '*' character for ENTER:\n" +
 		"Examples: 'lt1*' (list trader 1), 'bp100*' (buy 100 product)"
 }
@@ -0,0 +1,21 @@
+package menu
+
+import "twist/internal/log"
+
+// startCollection begins two-stage input collection, remembering the current menu so
+// cancelling with '\' goes back to it
+func (tmm *TerminalMenuManager) startCollection(menuName, prompt string) {
+	tmm.collectionMenu = tmm.currentMenu
+	tmm.inputCollector.StartCollection(menuName, prompt)
+}
+
+// handleCollectionCancel shows the menu that was showing when the cancelled input
+// collection started
+func (tmm *TerminalMenuManager) handleCollectionCancel(menuName string) {
+	log.Info("TerminalMenuManager: input collection cancelled", "collection", menuName)
+	if tmm.collectionMenu != nil {
+		tmm.currentMenu = tmm.collectionMenu
+		tmm.collectionMenu = nil
+	}
+	tmm.displayCurrentMenu()
+}
@@ -0,0 +1,45 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestCancelInputReturnsToMenu(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+
+	tmm.ActivateMainMenu()
+	tmm.MenuText("V")
+	dataMenu := tmm.currentMenu
+	tmm.MenuText("O")
+	if !tmm.inputCollector.IsCollecting() {
+		t.Fatal("Expected the prune item to ask for hours")
+	}
+
+	output.Reset()
+	tmm.MenuText("\\")
+	if tmm.inputCollector.IsCollecting() {
+		t.Error("Expected '\\' to cancel input collection")
+	}
+	if tmm.currentMenu != dataMenu {
+		t.Errorf("Expected to return to the data menu, got %v", tmm.currentMenu.Name)
+	}
+	if !strings.Contains(output.String(), "Input cancelled") || !strings.Contains(output.String(), dataMenu.Name) {
+		t.Errorf("Expected the data menu shown again after cancelling, got %q", output.String())
+	}
+}
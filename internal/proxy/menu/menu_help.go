@@ -0,0 +1,42 @@
+package menu
+
+import (
+	"twist/internal/api"
+)
+
+// TerminalMenus lists the built-in terminal menus and their items, for help shown outside
+// the terminal. The menus are built the way they are when opened, so the help always
+// matches them.
+func TerminalMenus() []api.TerminalMenuInfo {
+	tmm := NewTerminalMenuManager(func([]byte) {}, nil, nil, nil, nil)
+	mainKey := string(tmm.GetMenuKey())
+
+	mainMenu := tmm.createTWXMainMenu()
+	menus := []api.TerminalMenuInfo{terminalMenuInfo(mainMenu, mainKey)}
+
+	// Main menu items that open a submenu, by hotkey
+	submenus := map[rune]*TerminalMenuItem{
+		'B': tmm.createTWXBurstMenu(),
+		'S': tmm.createTWXScriptMenu(),
+		'V': tmm.createTWXDataMenu(),
+		'P': tmm.createTWXPortMenu(),
+	}
+	for _, item := range mainMenu.Children {
+		if submenu, ok := submenus[item.Hotkey]; ok {
+			menus = append(menus, terminalMenuInfo(submenu, mainKey+" "+string(item.Hotkey)))
+		}
+	}
+	return menus
+}
+
+// terminalMenuInfo describes a menu opened by keys
+func terminalMenuInfo(menu *TerminalMenuItem, keys string) api.TerminalMenuInfo {
+	info := api.TerminalMenuInfo{Title: menu.Description, Keys: keys}
+	for _, item := range menu.Children {
+		info.Items = append(info.Items, api.TerminalMenuItemInfo{
+			Hotkey:      string(item.Hotkey),
+			Description: item.Description,
+		})
+	}
+	return info
+}
@@ -0,0 +1,57 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/menu/display"
+)
+
+// SetNoteChangedCallback sets the function called after a sector's note is set or cleared
+func (tmm *TerminalMenuManager) SetNoteChangedCallback(callback func(sectorNum int)) {
+	tmm.onNoteChanged = callback
+}
+
+// handleSectorNote prompts for a sector and the note to store for it
+func (tmm *TerminalMenuManager) handleSectorNote(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleSectorNote", "error", r)
+		}
+	}()
+
+	if _, ok := tmm.openDatabase(); !ok {
+		return nil
+	}
+
+	tmm.sendOutput("\r\nEnter sector number and note (no note clears it), e.g. 1234 good ore port:\r\n")
+	tmm.startCollection("SECTOR_NOTE", "Sector and note")
+	return nil
+}
+
+// handleSectorNoteInput handles input collection for setting or clearing a sector note
+func (tmm *TerminalMenuManager) handleSectorNoteInput(value string) error {
+	sectorStr, note, _ := strings.Cut(strings.TrimSpace(value), " ")
+	db, sectorNum, ok := tmm.parseSectorInput(sectorStr)
+	if !ok {
+		return nil
+	}
+
+	note = strings.TrimSpace(note)
+	if err := db.SetSectorNote(sectorNum, note); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error saving note: " + err.Error()))
+	} else {
+		if note == "" {
+			tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Note cleared for sector %d", sectorNum)))
+		} else {
+			tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Note saved for sector %d", sectorNum)))
+		}
+		if tmm.onNoteChanged != nil {
+			tmm.onNoteChanged(sectorNum)
+		}
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
@@ -0,0 +1,65 @@
+package menu
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestSectorNoteInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.db")
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(path); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	if err := db.SaveSector(database.NULLSector(), 10); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+	// Reopen so the universe size covers the saved sector
+	db.CloseDatabase()
+	db = database.NewDatabase()
+	if err := db.OpenDatabase(path); err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+	var changed []int
+	tmm.SetNoteChangedCallback(func(sectorNum int) { changed = append(changed, sectorNum) })
+
+	tmm.handleSectorNoteInput("10   good ore port ")
+	if note, _ := db.GetSectorNote(10); note != "good ore port" {
+		t.Errorf("Expected the note to be saved, got %q", note)
+	}
+	if !strings.Contains(output.String(), "Note saved for sector 10") {
+		t.Errorf("Expected a saved message, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.handleSectorNoteInput("11 too far")
+	if !strings.Contains(output.String(), "That is not a valid sector") {
+		t.Errorf("Expected an invalid sector error, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.handleSectorNoteInput("10")
+	if note, _ := db.GetSectorNote(10); note != "" {
+		t.Errorf("Expected the note to be cleared, got %q", note)
+	}
+	if !strings.Contains(output.String(), "Note cleared for sector 10") {
+		t.Errorf("Expected a cleared message, got %q", output.String())
+	}
+
+	if len(changed) != 2 || changed[0] != 10 || changed[1] != 10 {
+		t.Errorf("Expected two note changes for sector 10, got %v", changed)
+	}
+}
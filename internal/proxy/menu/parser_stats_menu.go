@@ -0,0 +1,48 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/menu/display"
+	"twist/internal/proxy/streaming"
+)
+
+// SetParserStatsFunc sets the function returning the parser's counts, shown from the data
+// menu
+func (tmm *TerminalMenuManager) SetParserStatsFunc(parserStats func() streaming.ParserStats) {
+	tmm.parserStats = parserStats
+}
+
+// handleParserStats shows how much game text the parser has handled and how much it
+// didn't recognise, to help tell whether a missing sector or port is a parser bug
+func (tmm *TerminalMenuManager) handleParserStats(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleParserStats", "error", r)
+		}
+	}()
+
+	if tmm.parserStats == nil {
+		tmm.sendOutput(display.FormatErrorMessage("Parser not available"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.sendOutput(formatParserStats(tmm.parserStats()))
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// formatParserStats lays out the parser's counts for the terminal
+func formatParserStats(stats streaming.ParserStats) string {
+	var output strings.Builder
+	output.WriteString("\r\nParser statistics:\r\n")
+	output.WriteString(fmt.Sprintf("  Lines processed:      %d\r\n", stats.LinesProcessed))
+	output.WriteString(fmt.Sprintf("  Sectors saved:        %d\r\n", stats.SectorsSaved))
+	output.WriteString(fmt.Sprintf("  Ports saved:          %d\r\n", stats.PortsSaved))
+	output.WriteString(fmt.Sprintf("  Unrecognized prompts: %d\r\n", stats.UnrecognizedPrompts))
+	output.WriteString(fmt.Sprintf("  Errors recovered:     %d\r\n", stats.ErrorsRecovered))
+	return output.String()
+}
@@ -0,0 +1,35 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/streaming"
+)
+
+func TestHandleParserStats(t *testing.T) {
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return nil },
+		func(string) {},
+		func(string) {},
+	)
+
+	tmm.handleParserStats(nil, nil)
+	if !strings.Contains(output.String(), "Parser not available") {
+		t.Errorf("Expected 'Parser not available', got %q", output.String())
+	}
+
+	tmm.SetParserStatsFunc(func() streaming.ParserStats {
+		return streaming.ParserStats{LinesProcessed: 120, SectorsSaved: 7, PortsSaved: 3, UnrecognizedPrompts: 2, ErrorsRecovered: 1}
+	})
+	output.Reset()
+	tmm.handleParserStats(nil, nil)
+	for _, want := range []string{"Lines processed:      120", "Sectors saved:        7", "Ports saved:          3", "Unrecognized prompts: 2", "Errors recovered:     1"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected %q in output, got %q", want, output.String())
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+func TestDisplayPortInTWXFormat_UnderConstruction(t *testing.T) {
+	var captured strings.Builder
+	tmm := newTestMenuManagerWithCapture(func(data []byte) { captured.Write(data) })
+
+	port := database.TPort{Name: "Nova Station", ClassIndex: 4, BuildTime: 5, UpDate: time.Now()}
+	port.BuyProduct[2] = true
+
+	tmm.displayPortInTWXFormat(port, 286)
+	if !strings.Contains(captured.String(), "(Under Construction - 5 days left)") {
+		t.Errorf("Expected construction status in commerce report, got:\n%s", captured.String())
+	}
+
+	captured.Reset()
+	port.BuildTime = 0
+	tmm.displayPortInTWXFormat(port, 286)
+	if strings.Contains(captured.String(), "Under Construction") {
+		t.Errorf("Expected no construction status for a built port, got:\n%s", captured.String())
+	}
+}
+
+func TestDisplayPortInTWXFormat_Destroyed(t *testing.T) {
+	var captured strings.Builder
+	tmm := newTestMenuManagerWithCapture(func(data []byte) { captured.Write(data) })
+
+	port := database.TPort{Name: "Nova Station", ClassIndex: 4, Dead: true, UpDate: time.Now()}
+	tmm.displayPortInTWXFormat(port, 286)
+
+	output := captured.String()
+	if !strings.Contains(output, "This port has been destroyed") {
+		t.Errorf("Expected the commerce report to show the port destroyed, got:\n%s", output)
+	}
+	if strings.Contains(output, "Fuel Ore") {
+		t.Errorf("Expected no product table for a destroyed port, got:\n%s", output)
+	}
+}
+
+func TestDisplayPortInTWXFormat_ProfitEstimate(t *testing.T) {
+	var captured strings.Builder
+	tmm := newTestMenuManagerWithCapture(func(data []byte) { captured.Write(data) })
+
+	// Buys equipment at 100%, sells fuel ore at 80%
+	port := database.TPort{Name: "Nova Station", ClassIndex: 2, UpDate: time.Now()}
+	port.BuyProduct = [3]bool{false, false, true}
+	port.ProductAmount = [3]int{3000, 0, 1500}
+	port.ProductPercent = [3]int{80, 0, 100}
+
+	// 20 holds: equipment 20 * 80 * 0.25 = 400 above the mid price, ore 20 * 25 * 0.2 = 100 below it
+	tmm.displayPortInTWXFormat(port, 286)
+	output := captured.String()
+	if !strings.Contains(output, "Estimated profit/turn: 500 credits with 20 holds") {
+		t.Errorf("Expected a profit estimate below the product table, got:\n%s", output)
+	}
+	if strings.Index(output, "Estimated") < strings.Index(output, "Equipment") {
+		t.Errorf("Expected the estimate after the product table, got:\n%s", output)
+	}
+
+	captured.Reset()
+	tmm.SetTradePricing(database.TradePricing{BasePrice: database.DefaultTradePricing.BasePrice})
+	tmm.displayPortInTWXFormat(port, 286)
+	if !strings.Contains(captured.String(), "Estimated profit/turn: 0 credits") {
+		t.Errorf("Expected no profit without a price margin, got:\n%s", captured.String())
+	}
+}
+
+func TestDisplayPortSummary(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 13, 45, 0, 0, time.UTC)
+	pair := database.TPort{Name: "Pair", ClassIndex: 1, UpDate: updated}
+	pair.BuyProduct = [3]bool{true, true, false}
+	pair.ProductAmount = [3]int{2000, 1500, 800}
+	pair.ProductPercent = [3]int{100, 90, 45}
+	solo := database.TPort{Name: "Solo", ClassIndex: 7, UpDate: updated}
+
+	tmm := newTestMenuManagerWithCapture(func([]byte) {})
+	var output strings.Builder
+	tmm.displayPortSummary(&output, 286, pair)
+	tmm.displayPortSummary(&output, 12345, solo)
+
+	rows := strings.Split(strings.TrimSuffix(output.String(), "\r\n"), "\r\n")
+	if !strings.HasPrefix(rows[0], display.PORT_PAIR) || !strings.HasPrefix(rows[1], display.MENU_DARK) {
+		t.Errorf("Expected rows colored by port class, got %q", rows)
+	}
+
+	want := []string{
+		"   286     1 BBS    2000 (100%)  1500 ( 90%)   800 ( 45%) 13:45",
+		" 12345     7 SSS       0 (  0%)     0 (  0%)     0 (  0%) 13:45",
+	}
+	for i, row := range rows {
+		if got := display.StripANSI(row); got != want[i] {
+			t.Errorf("Row %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+}
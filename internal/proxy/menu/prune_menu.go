@@ -0,0 +1,60 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"twist/internal/log"
+	"twist/internal/proxy/menu/display"
+)
+
+// defaultPruneHours is used when no age is entered for clearing old traders and ships
+const defaultPruneHours = 24
+
+// handlePruneVolatile prompts for how old sector data must be before its traders, ships and
+// other players' fighters are cleared
+func (tmm *TerminalMenuManager) handlePruneVolatile(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handlePruneVolatile", "error", r)
+		}
+	}()
+
+	if _, ok := tmm.openDatabase(); !ok {
+		return nil
+	}
+
+	tmm.sendOutput(fmt.Sprintf("\r\nClear traders, ships and foreign fighters from sectors not seen for how many hours [%d]:\r\n", defaultPruneHours))
+	tmm.startCollection("PRUNE_VOLATILE", "Hours")
+	return nil
+}
+
+// handlePruneVolatileInput clears the volatile data of sectors older than the entered hours
+func (tmm *TerminalMenuManager) handlePruneVolatileInput(hoursStr string) error {
+	hours := defaultPruneHours
+	if hoursStr = strings.TrimSpace(hoursStr); hoursStr != "" {
+		if _, err := fmt.Sscanf(hoursStr, "%d", &hours); err != nil || hours < 1 {
+			tmm.sendOutput(display.FormatErrorMessage("Hours must be a whole number of at least 1"))
+			tmm.displayCurrentMenu()
+			return nil
+		}
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	pruned, err := db.PruneVolatileData(time.Duration(hours) * time.Hour)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error clearing old sector data: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	log.Info("Pruned volatile sector data", "hours", hours, "sectors", pruned)
+
+	tmm.sendOutput(fmt.Sprintf("\r\nCleared old traders, ships and fighters from %d sectors.\r\n", pruned))
+	tmm.displayCurrentMenu()
+	return nil
+}
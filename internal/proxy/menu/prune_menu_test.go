@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"twist/internal/proxy/database"
+)
+
+func TestPruneVolatileInput(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	traders := []database.TTrader{{Name: "Alice", ShipName: "Runner", ShipType: "Scout Marauder"}}
+	if err := db.SaveSectorWithCollections(database.NULLSector(), 7, nil, traders, nil); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+	if _, err := db.GetDB().Exec(`UPDATE sectors SET update_time = ? WHERE sector_index = 7`, time.Now().Add(-30*time.Hour)); err != nil {
+		t.Fatalf("Failed to age sector: %v", err)
+	}
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+
+	tmm.handlePruneVolatileInput("soon")
+	if !strings.Contains(output.String(), "Hours must be a whole number") {
+		t.Errorf("Expected an invalid hours error, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.handlePruneVolatileInput("48")
+	if !strings.Contains(output.String(), "from 0 sectors") {
+		t.Errorf("Expected nothing older than 48 hours, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.handlePruneVolatileInput("")
+	if !strings.Contains(output.String(), "from 1 sectors") {
+		t.Errorf("Expected the default 24 hours to clear sector 7, got %q", output.String())
+	}
+}
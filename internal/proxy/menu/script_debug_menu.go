@@ -0,0 +1,296 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/interfaces"
+	"twist/internal/proxy/menu/display"
+)
+
+// handleScriptPause holds a running script in the debugger, asking which one if several
+// are running
+func (tmm *TerminalMenuManager) handleScriptPause(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleScriptPause", "error", r)
+		}
+	}()
+
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	scripts := runningScriptsByName(scriptManager)
+	switch len(scripts) {
+	case 0:
+		tmm.sendOutput(display.FormatErrorMessage("No scripts are running"))
+		tmm.displayCurrentMenu()
+	case 1:
+		tmm.pauseScript(scriptManager, scripts[0])
+	default:
+		var output strings.Builder
+		output.WriteString("\r\nRunning scripts:\r\n")
+		for i, script := range scripts {
+			output.WriteString(fmt.Sprintf("%d. %s\r\n", i+1, script.GetName()))
+		}
+		output.WriteString("Enter the number of the script to pause:\r\n")
+		tmm.sendOutput(output.String())
+		tmm.startCollection("SCRIPT_PAUSE", "Script number")
+	}
+	return nil
+}
+
+// handleScriptPauseInput pauses the running script with the entered number
+func (tmm *TerminalMenuManager) handleScriptPauseInput(value string) error {
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	scripts := runningScriptsByName(scriptManager)
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || number < 1 || number > len(scripts) {
+		tmm.sendOutput(display.FormatErrorMessage("No running script numbered " + strings.TrimSpace(value)))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.pauseScript(scriptManager, scripts[number-1])
+	return nil
+}
+
+// pauseScript holds the script and shows where it stopped
+func (tmm *TerminalMenuManager) pauseScript(scriptManager ScriptManagerInterface, script interfaces.ScriptInfo) {
+	if err := scriptManager.PauseScript(script.GetID()); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error pausing script: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Paused " + script.GetName() + " - use S to step it and R to resume it"))
+	}
+	tmm.displayCurrentMenu()
+}
+
+// handleScriptResume lets the paused script run on
+func (tmm *TerminalMenuManager) handleScriptResume(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleScriptResume", "error", r)
+		}
+	}()
+
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	if err := scriptManager.ResumeScript(); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error resuming script: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Script resumed"))
+	}
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleScriptStep runs the paused script's next statement and shows the line it stopped
+// at and the variables the statement changed
+func (tmm *TerminalMenuManager) handleScriptStep(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleScriptStep", "error", r)
+		}
+	}()
+
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	result, err := scriptManager.StepScript()
+	if err != nil && result.Status == "" {
+		tmm.sendOutput(display.FormatErrorMessage("Error stepping script: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	var output strings.Builder
+	output.WriteString("\r\n")
+	if result.Line > 0 {
+		output.WriteString(fmt.Sprintf("Next line: %d (%s)\r\n", result.Line, result.Status))
+	} else {
+		output.WriteString("Script " + result.Status + "\r\n")
+	}
+
+	names := make([]string, 0, len(result.Changed))
+	for name := range result.Changed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		output.WriteString(fmt.Sprintf("  %s = %s\r\n", name, result.Changed[name]))
+	}
+	tmm.sendOutput(output.String())
+
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Script error: " + err.Error()))
+	}
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// handleScriptBreakpoint lists the breakpoints and prompts for one to set or clear
+func (tmm *TerminalMenuManager) handleScriptBreakpoint(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleScriptBreakpoint", "error", r)
+		}
+	}()
+
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	tmm.sendOutput(formatBreakpoints(scriptManager.GetBreakpoints()))
+	tmm.sendOutput("Enter a script name and line to set or clear a breakpoint, e.g. 'mine 42'\r\n")
+	tmm.sendOutput("(just the line will do when one script is running):\r\n")
+	tmm.startCollection("SCRIPT_BREAKPOINT", "Script and line")
+	return nil
+}
+
+// handleScriptBreakpointInput sets the entered breakpoint, or clears it if it was set
+func (tmm *TerminalMenuManager) handleScriptBreakpointInput(value string) error {
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	line, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || line < 1 || len(fields) > 2 {
+		tmm.sendOutput(display.FormatErrorMessage("Enter a script name and a line number, e.g. 'mine 42'"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	var name string
+	if len(fields) == 2 {
+		name = fields[0]
+	} else if scripts := runningScriptsByName(scriptManager); len(scripts) == 1 {
+		name = scripts[0].GetName()
+	} else {
+		tmm.sendOutput(display.FormatErrorMessage("Enter the script name as well as the line"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	set := true
+	for _, existing := range scriptManager.GetBreakpoints()[strings.ToLower(name)] {
+		if existing == line {
+			set = false
+		}
+	}
+
+	if set {
+		err = scriptManager.SetBreakpoint(name, line)
+	} else {
+		err = scriptManager.ClearBreakpoint(name, line)
+	}
+	switch {
+	case err != nil:
+		tmm.sendOutput(display.FormatErrorMessage("Error changing breakpoint: " + err.Error()))
+	case set:
+		tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Breakpoint set in %s at line %d", name, line)))
+	default:
+		tmm.sendOutput(display.FormatSuccessMessage(fmt.Sprintf("Breakpoint cleared in %s at line %d", name, line)))
+	}
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// formatBreakpoints lists the breakpoints by script name
+func formatBreakpoints(breakpoints map[string][]int) string {
+	if len(breakpoints) == 0 {
+		return "\r\nNo breakpoints set.\r\n"
+	}
+
+	names := make([]string, 0, len(breakpoints))
+	for name := range breakpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var output strings.Builder
+	output.WriteString("\r\nBreakpoints:\r\n")
+	for _, name := range names {
+		lines := make([]string, len(breakpoints[name]))
+		for i, line := range breakpoints[name] {
+			lines[i] = strconv.Itoa(line)
+		}
+		output.WriteString(fmt.Sprintf("  %s: line %s\r\n", name, strings.Join(lines, ", ")))
+	}
+	return output.String()
+}
+
+// handleScriptTrace turns the execution trace of every script on or off
+func (tmm *TerminalMenuManager) handleScriptTrace(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleScriptTrace", "error", r)
+		}
+	}()
+
+	scriptManager, ok := tmm.scriptManagerForDebug()
+	if !ok {
+		return nil
+	}
+
+	enable := !scriptManager.IsTracing()
+	if err := scriptManager.SetTrace(enable); err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error changing the script trace: " + err.Error()))
+	} else if enable {
+		tmm.sendOutput(display.FormatSuccessMessage("Script trace on - writing to " + scriptManager.GetTraceFile()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Script trace off"))
+	}
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// scriptManagerForDebug returns the script manager, reporting it if it isn't available
+func (tmm *TerminalMenuManager) scriptManagerForDebug() (ScriptManagerInterface, bool) {
+	if tmm.getScriptManager != nil {
+		if scriptManager := tmm.getScriptManager(); scriptManager != nil {
+			return scriptManager, true
+		}
+	}
+	tmm.sendOutput(display.FormatErrorMessage("Error: Script manager not available"))
+	tmm.displayCurrentMenu()
+	return nil, false
+}
+
+// runningScriptsByName returns the running scripts sorted by name, so they are numbered
+// the same way each time they are listed
+func runningScriptsByName(scriptManager ScriptManagerInterface) []interfaces.ScriptInfo {
+	engine := scriptManager.GetEngine()
+	if engine == nil {
+		return nil
+	}
+	scripts := engine.GetRunningScripts()
+	sort.Slice(scripts, func(i, j int) bool {
+		if scripts[i].GetName() != scripts[j].GetName() {
+			return scripts[i].GetName() < scripts[j].GetName()
+		}
+		return scripts[i].GetID() < scripts[j].GetID()
+	})
+	return scripts
+}
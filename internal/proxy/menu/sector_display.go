@@ -0,0 +1,37 @@
+package menu
+
+import (
+	"twist/internal/log"
+	"twist/internal/proxy/menu/display"
+)
+
+// ShowSector displays a sector's stored data in the terminal without navigating the Data
+// menu. It works whether or not the menu is active; sectorNum of zero or less means the
+// current sector isn't known yet.
+func (tmm *TerminalMenuManager) ShowSector(sectorNum int) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in ShowSector", "error", r)
+		}
+	}()
+
+	if sectorNum <= 0 {
+		tmm.sendOutput("\r\nno current sector\r\n")
+		tmm.displayCurrentMenu()
+		return
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return
+	}
+
+	sector, err := db.LoadSector(sectorNum)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error loading sector: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return
+	}
+
+	tmm.displaySectorInTWXFormat(sector, sectorNum)
+}
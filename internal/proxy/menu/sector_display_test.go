@@ -0,0 +1,85 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"twist/internal/proxy/database"
+)
+
+func TestShowSectorWithoutCurrentSector(t *testing.T) {
+	var output strings.Builder
+	tmm := newTestMenuManagerWithCapture(func(data []byte) { output.Write(data) })
+
+	tmm.ShowSector(0)
+
+	if !strings.Contains(output.String(), "no current sector") {
+		t.Errorf("Expected 'no current sector', got %q", output.String())
+	}
+}
+
+func TestShowSectorWhileMenuInactive(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	sector := database.NULLSector()
+	sector.Constellation = "The Federation"
+	sector.Explored = database.EtHolo
+	sector.Warp[0] = 2
+	sector.Warp[1] = 3
+	if err := db.SaveSector(sector, 1); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+
+	if tmm.IsActive() {
+		t.Fatal("Expected menu to be inactive")
+	}
+	tmm.ShowSector(1)
+
+	text := output.String()
+	if !strings.Contains(text, "Sector  : 1 in The Federation") {
+		t.Errorf("Expected sector display, got %q", text)
+	}
+	if !strings.Contains(text, "Warps to Sector(s) :  2 - 3") {
+		t.Errorf("Expected warps in sector display, got %q", text)
+	}
+}
+
+func TestSectorDisplayFlagsStaleData(t *testing.T) {
+	var output strings.Builder
+	tmm := newTestMenuManagerWithCapture(func(data []byte) { output.Write(data) })
+
+	sector := database.NULLSector()
+	sector.UpDate = time.Now().Add(-8*24*time.Hour - time.Hour)
+	tmm.displaySectorInTWXFormat(sector, 5)
+	if !strings.Contains(output.String(), "(data is 8 days old)") {
+		t.Errorf("Expected the sector's age, got %q", output.String())
+	}
+
+	output.Reset()
+	sector.UpDate = time.Now().Add(-time.Hour)
+	tmm.displaySectorInTWXFormat(sector, 5)
+	if strings.Contains(output.String(), "data is") {
+		t.Errorf("Expected no age for recent data, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.SetStaleSectorAge(time.Hour)
+	sector.UpDate = time.Now().Add(-2*time.Hour - time.Minute)
+	tmm.displaySectorInTWXFormat(sector, 5)
+	if !strings.Contains(output.String(), "(data is 2 hours old)") {
+		t.Errorf("Expected the configured threshold to apply, got %q", output.String())
+	}
+}
@@ -0,0 +1,95 @@
+package menu
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+// sectorDump is what a bug report dump records: the current sector and its port as
+// stored, and the server text they were parsed from
+type sectorDump struct {
+	Sector      int              `json:"sector"`
+	Created     time.Time        `json:"created"`
+	Data        database.TSector `json:"data"`
+	Port        *database.TPort  `json:"port,omitempty"`
+	RecentLines []string         `json:"recent_lines"`
+}
+
+// SetRecentLinesFunc sets the function returning the last lines received from the server,
+// included in sector dumps
+func (tmm *TerminalMenuManager) SetRecentLinesFunc(recentLines func() []string) {
+	tmm.recentLines = recentLines
+}
+
+// handleDumpSector writes the current sector, its port and the recent server lines to a
+// JSON file, for attaching to bug reports about sectors that parse wrong
+func (tmm *TerminalMenuManager) handleDumpSector(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleDumpSector", "error", r)
+		}
+	}()
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	path, err := tmm.dumpCurrentSector(db)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error dumping sector: " + err.Error()))
+	} else {
+		tmm.sendOutput(display.FormatSuccessMessage("Sector dump written to " + path))
+	}
+
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// dumpCurrentSector writes the current sector's dump to a file named after the sector and
+// time, returning its path
+func (tmm *TerminalMenuManager) dumpCurrentSector(db database.Database) (string, error) {
+	stats, err := db.LoadPlayerStats()
+	if err != nil {
+		return "", fmt.Errorf("no current sector: %w", err)
+	}
+	if stats.CurrentSector <= 0 {
+		return "", fmt.Errorf("no current sector")
+	}
+
+	dump := sectorDump{Sector: stats.CurrentSector, Created: time.Now()}
+	if dump.Data, err = db.LoadSector(dump.Sector); err != nil {
+		return "", err
+	}
+	port, err := db.LoadPort(dump.Sector)
+	if err != nil {
+		return "", err
+	}
+	if port.Name != "" {
+		dump.Port = &port
+	}
+	if tmm.recentLines != nil {
+		dump.RecentLines = tmm.recentLines()
+	}
+
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("twist_sector_%d_%s.json", dump.Sector, dump.Created.Format("20060102_150405"))
+	path := filepath.Join(tmm.sectorDumpDir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", err
+	}
+
+	log.Info("Sector dumped for bug report", "sector", dump.Sector, "path", path, "lines", len(dump.RecentLines))
+	return path, nil
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestDumpCurrentSector(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+	tmm.sectorDumpDir = t.TempDir()
+	tmm.SetRecentLinesFunc(func() []string { return []string{"Sector  : 12 in uncharted space.", "Warps to Sector(s) :  8 - 13"} })
+
+	// Without a current sector there is nothing to dump
+	tmm.handleDumpSector(nil, nil)
+	if !strings.Contains(output.String(), "no current sector") {
+		t.Errorf("Expected 'no current sector', got %q", output.String())
+	}
+
+	sector := database.NULLSector()
+	sector.Warp[0] = 8
+	sector.Warp[1] = 13
+	if err := db.SaveSector(sector, 12); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+	if err := db.SavePort(database.TPort{Name: "Sol", ClassIndex: 2}, 12); err != nil {
+		t.Fatalf("Failed to save port: %v", err)
+	}
+	if err := db.SavePlayerStats(database.TPlayerStats{CurrentSector: 12}); err != nil {
+		t.Fatalf("Failed to save player stats: %v", err)
+	}
+
+	output.Reset()
+	tmm.handleDumpSector(nil, nil)
+	if !strings.Contains(output.String(), "Sector dump written to") {
+		t.Fatalf("Expected the dump to be written, got %q", output.String())
+	}
+
+	files, _ := filepath.Glob(filepath.Join(tmm.sectorDumpDir, "twist_sector_12_*.json"))
+	if len(files) != 1 {
+		t.Fatalf("Expected one dump file, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read dump: %v", err)
+	}
+
+	var dump sectorDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		t.Fatalf("Dump isn't valid JSON: %v", err)
+	}
+	if dump.Sector != 12 || dump.Data.Warp[0] != 8 || dump.Data.Warp[1] != 13 {
+		t.Errorf("Expected sector 12 with warps 8 and 13, got %d %v", dump.Sector, dump.Data.Warp)
+	}
+	if dump.Port == nil || dump.Port.Name != "Sol" || dump.Port.ClassIndex != 2 {
+		t.Errorf("Expected the class 2 port Sol, got %+v", dump.Port)
+	}
+	if len(dump.RecentLines) != 2 || dump.RecentLines[1] != "Warps to Sector(s) :  8 - 13" {
+		t.Errorf("Expected the recent lines, got %q", dump.RecentLines)
+	}
+}
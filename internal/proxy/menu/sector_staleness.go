@@ -0,0 +1,40 @@
+package menu
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultStaleSectorAge is how old a sector's data must be before the sector display says so
+const DefaultStaleSectorAge = 7 * 24 * time.Hour
+
+// SetStaleSectorAge sets how old a sector's data must be before the sector display shows its
+// age. Zero or less turns the note off.
+func (tmm *TerminalMenuManager) SetStaleSectorAge(age time.Duration) {
+	tmm.staleSectorAge = max(age, 0)
+}
+
+// staleNote returns the age note for sector data last updated at upDate, or an empty string if
+// the data is recent enough to trust, was never recorded, or staleness is off
+func (tmm *TerminalMenuManager) staleNote(upDate, now time.Time) string {
+	if tmm.staleSectorAge <= 0 || upDate.IsZero() {
+		return ""
+	}
+	age := now.Sub(upDate)
+	if age <= tmm.staleSectorAge {
+		return ""
+	}
+	return " (data is " + formatAge(age) + " old)"
+}
+
+// formatAge returns an age in whole days, or in hours if it is under a day
+func formatAge(age time.Duration) string {
+	unit, count := "day", int(age/(24*time.Hour))
+	if count == 0 {
+		unit, count = "hour", int(age/time.Hour)
+	}
+	if count == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"twist/internal/log"
 	"twist/internal/proxy/database"
@@ -12,6 +14,7 @@ import (
 	"twist/internal/proxy/interfaces"
 	"twist/internal/proxy/menu/display"
 	"twist/internal/proxy/scripting/types"
+	"twist/internal/proxy/streaming"
 )
 
 type TerminalMenuManager struct {
@@ -37,9 +40,37 @@ type TerminalMenuManager struct {
 	// Separate components for advanced features
 	inputCollector *input.InputCollector // Two-stage input collection
 	helpSystem     *HelpSystem           // Contextual help system
+	collectionMenu *TerminalMenuItem     // Menu showing when input collection started, returned to on cancel
 
 	// Burst command storage (like TWX LastBurst)
-	lastBurst string // Last burst command sent
+	lastBurst    string // Last burst command sent, loaded from the burst history after a restart
+	editingBurst string // Name of the saved burst being edited, between its two prompts
+	burstQueue   *burstQueue
+
+	// Called after the avoid list changes so the TUI can refresh the sector
+	onAvoidChanged func(sectorNum int)
+
+	// Called after a sector note is set or cleared so the TUI can refresh the sector
+	onNoteChanged func(sectorNum int)
+
+	// Returns the last lines received from the server, for sector dumps
+	recentLines func() []string
+
+	// Returns the parser's counts, for the parser statistics item
+	parserStats func() streaming.ParserStats
+
+	// Directory sector dumps are written to, the working directory if empty
+	sectorDumpDir string
+
+	// Economy constants for the trade pair profit estimates
+	tradePricing database.TradePricing
+
+	// Sector data older than this is shown with its age; zero turns the note off
+	staleSectorAge time.Duration
+
+	// Running variable watch, nil when not watching; see variable_watch.go
+	watchMutex    sync.Mutex
+	variableWatch *variableWatch
 }
 
 // ScriptMenuData represents a menu created by script commands
@@ -73,6 +104,15 @@ type ScriptManagerInterface interface {
 	GetEngine() interfaces.ScriptEngine
 	HasScriptWaitingForInput() (string, string)
 	ResumeScriptWithInput(scriptID, input string) error
+	PauseScript(scriptID string) error
+	ResumeScript() error
+	StepScript() (types.StepResult, error)
+	SetBreakpoint(scriptName string, line int) error
+	ClearBreakpoint(scriptName string, line int) error
+	GetBreakpoints() map[string][]int
+	SetTrace(enabled bool) error
+	IsTracing() bool
+	GetTraceFile() string
 }
 
 func NewTerminalMenuManager(
@@ -99,13 +139,19 @@ func NewTerminalMenuManager(
 		getDatabase:        getDatabase,
 		sendInput:          sendInput,
 		sendDirectToServer: sendDirectToServer,
+		tradePricing:       database.DefaultTradePricing,
+		staleSectorAge:     DefaultStaleSectorAge,
 	}
 
+	// Burst commands are paced through a queue so slow servers don't drop them
+	tmm.burstQueue = newBurstQueue(tmm.sendDirectToServer)
+
 	// Store the inject data function
 	tmm.injectDataFunc.Store(injectDataFunc)
 
 	// Initialize input collector with output function
 	tmm.inputCollector = input.NewInputCollector(tmm.sendOutput)
+	tmm.inputCollector.SetCancelHandler(tmm.handleCollectionCancel)
 
 	// Initialize help system with output function
 	tmm.helpSystem = NewHelpSystem(tmm.sendOutput)
@@ -135,6 +181,46 @@ func (tmm *TerminalMenuManager) setupInputHandlers() {
 		return tmm.handleBurstEditInput(value)
 	})
 
+	tmm.inputCollector.RegisterCompletionHandler("BURST_SAVE", func(menuName, value string) error {
+		return tmm.handleBurstSaveInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_SEND_NAMED", func(menuName, value string) error {
+		return tmm.handleBurstSendNamedInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_EDIT_NAME", func(menuName, value string) error {
+		return tmm.handleBurstEditNameInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_EDIT_TEXT", func(menuName, value string) error {
+		return tmm.handleBurstEditTextInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_DELETE", func(menuName, value string) error {
+		return tmm.handleBurstDeleteInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_DELAY", func(menuName, value string) error {
+		return tmm.handleBurstDelayInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_PROMPT", func(menuName, value string) error {
+		return tmm.handleBurstPromptInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("BURST_HISTORY", func(menuName, value string) error {
+		return tmm.handleBurstHistoryInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("SCRIPT_PAUSE", func(menuName, value string) error {
+		return tmm.handleScriptPauseInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("SCRIPT_BREAKPOINT", func(menuName, value string) error {
+		return tmm.handleScriptBreakpointInput(value)
+	})
+
 	tmm.inputCollector.RegisterCompletionHandler("SECTOR_DISPLAY", func(menuName, value string) error {
 		return tmm.handleSectorDisplayInput(value)
 	})
@@ -146,6 +232,34 @@ func (tmm *TerminalMenuManager) setupInputHandlers() {
 	tmm.inputCollector.RegisterCompletionHandler("VARIABLE_DUMP", func(menuName, value string) error {
 		return tmm.handleVariableDumpInput(value)
 	})
+
+	tmm.inputCollector.RegisterCompletionHandler("VARIABLE_WATCH", func(menuName, value string) error {
+		return tmm.handleVariableWatchInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("AVOID_ADD", func(menuName, value string) error {
+		return tmm.handleAddAvoidInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("AVOID_REMOVE", func(menuName, value string) error {
+		return tmm.handleRemoveAvoidInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("SECTOR_NOTE", func(menuName, value string) error {
+		return tmm.handleSectorNoteInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("TRADE_PAIRS", func(menuName, value string) error {
+		return tmm.handleTradePairsInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("PRUNE_VOLATILE", func(menuName, value string) error {
+		return tmm.handlePruneVolatileInput(value)
+	})
+
+	tmm.inputCollector.RegisterCompletionHandler("WARP_REPAIR", func(menuName, value string) error {
+		return tmm.handleCheckWarpsInput(value)
+	})
 }
 
 func (tmm *TerminalMenuManager) ProcessMenuKey(data string) bool {
@@ -174,6 +288,12 @@ func (tmm *TerminalMenuManager) MenuText(input string) error {
 		return nil
 	}
 
+	// Any key ends a variable watch and goes back to the menu
+	if tmm.stopVariableWatch() {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
 	input = strings.TrimSpace(input)
 
 	// Debug logging to see what's happening
@@ -480,7 +600,7 @@ func (tmm *TerminalMenuManager) handleLoadScript(item *TerminalMenuItem, params
 	tmm.sendOutput("Common scripts: login.ts, autorun.ts, trading.ts\r\n")
 
 	// Start input collection for script filename
-	tmm.inputCollector.StartCollection("SCRIPT_LOAD", "Script filename")
+	tmm.startCollection("SCRIPT_LOAD", "Script filename")
 	return nil
 }
 
@@ -514,7 +634,7 @@ func (tmm *TerminalMenuManager) handleTerminateScript(item *TerminalMenuItem, pa
 	tmm.sendOutput("\r\nEnter script name to terminate (or 'ALL' for all scripts):\r\n")
 
 	// Start input collection for script termination
-	tmm.inputCollector.StartCollection("SCRIPT_TERMINATE", "Script to terminate")
+	tmm.startCollection("SCRIPT_TERMINATE", "Script to terminate")
 	return nil
 }
 
@@ -582,16 +702,26 @@ func (tmm *TerminalMenuManager) createTWXScriptMenu() *TerminalMenuItem {
 	terminateScriptItem.Handler = tmm.handleScriptTerminate
 	scriptMenu.AddChild(terminateScriptItem)
 
-	// Pause Script (placeholder - TWX has this but our engine may not support it yet)
+	// Pause Script in the debugger
 	pauseScriptItem := NewTerminalMenuItem("Pause Script", "Pause Script", 'P')
 	pauseScriptItem.Handler = tmm.handleScriptPause
 	scriptMenu.AddChild(pauseScriptItem)
 
-	// Resume Script (placeholder)
+	// Resume Script from the debugger
 	resumeScriptItem := NewTerminalMenuItem("Resume Script", "Resume Script", 'R')
 	resumeScriptItem.Handler = tmm.handleScriptResume
 	scriptMenu.AddChild(resumeScriptItem)
 
+	// Step the paused script one statement
+	stepScriptItem := NewTerminalMenuItem("Step Script", "Step Script", 'S')
+	stepScriptItem.Handler = tmm.handleScriptStep
+	scriptMenu.AddChild(stepScriptItem)
+
+	// Breakpoints hold a script when it reaches a line
+	breakpointItem := NewTerminalMenuItem("Breakpoint on/off", "Breakpoint on/off", 'B')
+	breakpointItem.Handler = tmm.handleScriptBreakpoint
+	scriptMenu.AddChild(breakpointItem)
+
 	// Debug Script
 	debugScriptItem := NewTerminalMenuItem("Debug Script", "Debug Script", 'D')
 	debugScriptItem.Handler = tmm.handleScriptDebug
@@ -602,6 +732,16 @@ func (tmm *TerminalMenuManager) createTWXScriptMenu() *TerminalMenuItem {
 	variableDumpItem.Handler = tmm.handleVariableDump
 	scriptMenu.AddChild(variableDumpItem)
 
+	// Variable Watch, a dump that refreshes until a key is pressed
+	variableWatchItem := NewTerminalMenuItem("Watch Variables", "Watch Variables", 'W')
+	variableWatchItem.Handler = tmm.handleVariableWatch
+	scriptMenu.AddChild(variableWatchItem)
+
+	// Execution trace
+	traceItem := NewTerminalMenuItem("Trace on/off", "Trace on/off", 'X')
+	traceItem.Handler = tmm.handleScriptTrace
+	scriptMenu.AddChild(traceItem)
+
 	return scriptMenu
 }
 
@@ -649,6 +789,46 @@ func (tmm *TerminalMenuManager) createTWXDataMenu() *TerminalMenuItem {
 	plotCourseItem.Handler = tmm.handlePlotCourse
 	dataMenu.AddChild(plotCourseItem)
 
+	// Add sector to avoid list (V)
+	addAvoidItem := NewTerminalMenuItem("Avoid a sector", "Avoid a sector", 'V')
+	addAvoidItem.Handler = tmm.handleAddAvoid
+	dataMenu.AddChild(addAvoidItem)
+
+	// Remove sector from avoid list (X)
+	removeAvoidItem := NewTerminalMenuItem("Clear an avoided sector", "Clear an avoided sector", 'X')
+	removeAvoidItem.Handler = tmm.handleRemoveAvoid
+	dataMenu.AddChild(removeAvoidItem)
+
+	// Set or clear a sector's note (N)
+	sectorNoteItem := NewTerminalMenuItem("Sector note", "Sector note", 'N')
+	sectorNoteItem.Handler = tmm.handleSectorNote
+	dataMenu.AddChild(sectorNoteItem)
+
+	// Dump current sector to a file for a bug report (B)
+	dumpSectorItem := NewTerminalMenuItem("Dump current sector for a bug report", "Dump current sector for a bug report", 'B')
+	dumpSectorItem.Handler = tmm.handleDumpSector
+	dataMenu.AddChild(dumpSectorItem)
+
+	// Show the parser's counts (I)
+	parserStatsItem := NewTerminalMenuItem("Parser statistics", "Parser statistics", 'I')
+	parserStatsItem.Handler = tmm.handleParserStats
+	dataMenu.AddChild(parserStatsItem)
+
+	// Clear traders, ships and foreign fighters from sectors not seen in a while (O)
+	pruneItem := NewTerminalMenuItem("Clear old traders and ships", "Clear old traders and ships", 'O')
+	pruneItem.Handler = tmm.handlePruneVolatile
+	dataMenu.AddChild(pruneItem)
+
+	// Check the stored warps for duplicates and bad links, and repair them (W)
+	checkWarpsItem := NewTerminalMenuItem("Check and repair warps", "Check and repair warps", 'W')
+	checkWarpsItem.Handler = tmm.handleCheckWarps
+	dataMenu.AddChild(checkWarpsItem)
+
+	// Count the sectors by how much is known about them (E)
+	coverageItem := NewTerminalMenuItem("Explored coverage", "Explored coverage", 'E')
+	coverageItem.Handler = tmm.handleExploredCoverage
+	dataMenu.AddChild(coverageItem)
+
 	return dataMenu
 }
 
@@ -681,6 +861,11 @@ func (tmm *TerminalMenuManager) createTWXPortMenu() *TerminalMenuItem {
 	upgradedPortsItem.Handler = tmm.handleListUpgradedPorts
 	portMenu.AddChild(upgradedPortsItem)
 
+	// Find trade pairs (T)
+	tradePairsItem := NewTerminalMenuItem("Find trade pairs", "Find trade pairs", 'T')
+	tradePairsItem.Handler = tmm.handleFindTradePairs
+	portMenu.AddChild(tradePairsItem)
+
 	return portMenu
 }
 
@@ -708,6 +893,39 @@ func (tmm *TerminalMenuManager) createTWXBurstMenu() *TerminalMenuItem {
 	editBurstItem.Handler = tmm.handleEditBurst
 	burstMenu.AddChild(editBurstItem)
 
+	// Named bursts saved in the game database
+	saveBurstItem := NewTerminalMenuItem("Save last burst", "Save last burst", 'S')
+	saveBurstItem.Handler = tmm.handleSaveBurst
+	burstMenu.AddChild(saveBurstItem)
+
+	listBurstsItem := NewTerminalMenuItem("List saved bursts", "List saved bursts", 'L')
+	listBurstsItem.Handler = tmm.handleListBursts
+	burstMenu.AddChild(listBurstsItem)
+
+	sendNamedBurstItem := NewTerminalMenuItem("Send saved burst", "Send saved burst", 'N')
+	sendNamedBurstItem.Handler = tmm.handleSendNamedBurst
+	burstMenu.AddChild(sendNamedBurstItem)
+
+	editNamedBurstItem := NewTerminalMenuItem("Modify saved burst", "Modify saved burst", 'M')
+	editNamedBurstItem.Handler = tmm.handleEditNamedBurst
+	burstMenu.AddChild(editNamedBurstItem)
+
+	deleteNamedBurstItem := NewTerminalMenuItem("Delete saved burst", "Delete saved burst", 'X')
+	deleteNamedBurstItem.Handler = tmm.handleDeleteNamedBurst
+	burstMenu.AddChild(deleteNamedBurstItem)
+
+	delayItem := NewTerminalMenuItem("Set burst delay", "Set burst delay", 'D')
+	delayItem.Handler = tmm.handleBurstDelay
+	burstMenu.AddChild(delayItem)
+
+	promptItem := NewTerminalMenuItem("Set burst prompt", "Set burst prompt", 'P')
+	promptItem.Handler = tmm.handleBurstPrompt
+	burstMenu.AddChild(promptItem)
+
+	historyItem := NewTerminalMenuItem("Burst history", "Burst history", 'H')
+	historyItem.Handler = tmm.handleBurstHistory
+	burstMenu.AddChild(historyItem)
+
 	return burstMenu
 }
 
@@ -749,7 +967,7 @@ func (tmm *TerminalMenuManager) handleScriptLoad(item *TerminalMenuItem, params
 	tmm.sendOutput(output.String())
 
 	// Start input collection for script filename
-	tmm.inputCollector.StartCollection("SCRIPT_LOAD", "Script filename")
+	tmm.startCollection("SCRIPT_LOAD", "Script filename")
 	return nil
 }
 
@@ -785,30 +1003,6 @@ func (tmm *TerminalMenuManager) handleScriptTerminate(item *TerminalMenuItem, pa
 	return nil
 }
 
-func (tmm *TerminalMenuManager) handleScriptPause(item *TerminalMenuItem, params []string) error {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Error("PANIC in handleScriptPause", "error", r)
-		}
-	}()
-
-	tmm.sendOutput("Script pause functionality not yet implemented.\r\n")
-	tmm.displayCurrentMenu()
-	return nil
-}
-
-func (tmm *TerminalMenuManager) handleScriptResume(item *TerminalMenuItem, params []string) error {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Error("PANIC in handleScriptResume", "error", r)
-		}
-	}()
-
-	tmm.sendOutput("Script resume functionality not yet implemented.\r\n")
-	tmm.displayCurrentMenu()
-	return nil
-}
-
 func (tmm *TerminalMenuManager) handleScriptDebug(item *TerminalMenuItem, params []string) error {
 	defer func() {
 		if r := recover(); r != nil {
@@ -905,7 +1099,7 @@ func (tmm *TerminalMenuManager) handleVariableDump(item *TerminalMenuItem, param
 	tmm.sendOutput("\r\nEnter a full or partial variable name to search for (or blank to list them all):\r\n")
 
 	// Start input collection for variable pattern
-	tmm.inputCollector.StartCollection("VARIABLE_DUMP", "Variable pattern")
+	tmm.startCollection("VARIABLE_DUMP", "Variable pattern")
 	return nil
 }
 
@@ -942,7 +1136,7 @@ func (tmm *TerminalMenuManager) handleSectorDisplay(item *TerminalMenuItem, para
 		tmm.sendOutput("\r\nEnter sector number to display (1-" + fmt.Sprintf("%d", sectorCount) + "):\r\n")
 
 		// Start input collection for sector number
-		tmm.inputCollector.StartCollection("SECTOR_DISPLAY", "Sector number")
+		tmm.startCollection("SECTOR_DISPLAY", "Sector number")
 		return nil
 	} else {
 		tmm.sendOutput(display.FormatErrorMessage("Error: Invalid database interface"))
@@ -992,8 +1186,8 @@ func (tmm *TerminalMenuManager) handlePortList(item *TerminalMenuItem, params []
 
 		var output strings.Builder
 		output.WriteString("\r\n")
-		output.WriteString("Sector Class Fuel Ore     Organics     Equipment    Updated\r\n")
-		output.WriteString("-------------------------------------------------------------\r\n")
+		output.WriteString("Sector Class Trade Fuel Ore     Organics     Equipment    Updated\r\n")
+		output.WriteString("-------------------------------------------------------------------\r\n")
 		output.WriteString("\r\n")
 
 		sectorCount := db.GetSectors()
@@ -1260,7 +1454,7 @@ func (tmm *TerminalMenuManager) handleScriptMenuItem(item *TerminalMenuItem, par
 	// Check if we need to collect input from the user
 	if scriptMenu.Prompt != "" {
 		// Two-stage input collection: Start collecting input with the custom prompt
-		tmm.inputCollector.StartCollection(menuName, scriptMenu.Prompt)
+		tmm.startCollection(menuName, scriptMenu.Prompt)
 
 		// Show options if available
 		if scriptMenu.Options != "" {
@@ -1382,7 +1576,7 @@ func (tmm *TerminalMenuManager) handleSendBurst(item *TerminalMenuItem, params [
 	tmm.sendOutput("Examples: 'bp100*' (buy 100 product), 'sp50*' (sell 50 product), 'tw1234*' (transwarp to sector 1234)\r\n")
 
 	// Start input collection for burst command
-	tmm.inputCollector.StartCollection("BURST_SEND", "Burst command")
+	tmm.startCollection("BURST_SEND", "Burst command")
 	return nil
 }
 
@@ -1394,13 +1588,14 @@ func (tmm *TerminalMenuManager) handleRepeatBurst(item *TerminalMenuItem, params
 		}
 	}()
 
-	if tmm.lastBurst == "" {
+	if tmm.loadLastBurst() == "" {
 		tmm.sendOutput(display.FormatErrorMessage("No previous burst command to repeat"))
 		tmm.displayCurrentMenu()
 		return nil
 	}
 
 	tmm.sendOutput("Repeating last burst: " + tmm.lastBurst + "\r\n")
+	tmm.rememberBurst(tmm.lastBurst)
 
 	// Send the burst command (replace * with newline)
 	burstText := strings.ReplaceAll(tmm.lastBurst, "*", "\r\n")
@@ -1430,7 +1625,7 @@ func (tmm *TerminalMenuManager) handleEditBurst(item *TerminalMenuItem, params [
 		}
 	}()
 
-	if tmm.lastBurst == "" {
+	if tmm.loadLastBurst() == "" {
 		tmm.sendOutput(display.FormatErrorMessage("No previous burst command to edit"))
 		tmm.displayCurrentMenu()
 		return nil
@@ -1438,10 +1633,10 @@ func (tmm *TerminalMenuManager) handleEditBurst(item *TerminalMenuItem, params [
 
 	tmm.sendOutput("\r\n" + display.FormatMenuTitle("Edit Last Burst Command"))
 	tmm.sendOutput("Previous burst: " + tmm.lastBurst + "\r\n")
-	tmm.sendOutput("Edit and press Enter to send (or cancel with 'q'):\r\n")
+	tmm.sendOutput("Edit and press Enter to send:\r\n")
 
 	// Pre-fill the input collection with the last burst
-	tmm.inputCollector.StartCollection("BURST_EDIT", "Edit burst command")
+	tmm.startCollection("BURST_EDIT", "Edit burst command")
 	// Set the current input to the last burst for editing
 	return nil
 }
@@ -1456,7 +1651,7 @@ func (tmm *TerminalMenuManager) handleBurstSendInput(burstText string) error {
 	}
 
 	// Store as last burst
-	tmm.lastBurst = burstText
+	tmm.rememberBurst(burstText)
 
 	// Send the burst command (replace * with newline)
 	expandedText := strings.ReplaceAll(burstText, "*", "\r\n")
@@ -1480,7 +1675,7 @@ func (tmm *TerminalMenuManager) handleBurstEditInput(burstText string) error {
 	}
 
 	// Store as last burst
-	tmm.lastBurst = burstText
+	tmm.rememberBurst(burstText)
 
 	// Send the burst command (replace * with newline)
 	expandedText := strings.ReplaceAll(burstText, "*", "\r\n")
@@ -1502,14 +1697,23 @@ func (tmm *TerminalMenuManager) sendBurstToServer(text string) {
 	}
 
 	// Split into individual commands (separated by \r\n from * expansion)
-	commands := strings.Split(text, "\r\n")
-	for _, cmd := range commands {
+	var commands []string
+	for _, cmd := range strings.Split(text, "\r\n") {
 		if strings.TrimSpace(cmd) != "" {
-			// Send each command directly to server bypassing menu system
-			tmm.sendDirectToServer(strings.TrimSpace(cmd) + "\r\n")
-			log.Info("Burst command sent directly to server", "command", strings.TrimSpace(cmd))
+			commands = append(commands, strings.TrimSpace(cmd)+"\r\n")
 		}
 	}
+
+	// Queue the commands to go directly to the server, bypassing the menu system; the
+	// queue paces them in the background so the menu can exit straight away
+	tmm.burstQueue.enqueue(commands)
+	log.Info("Burst commands queued for server", "count", len(commands), "delay", tmm.burstQueue.getDelay(), "prompt", tmm.burstQueue.getPrompt())
+}
+
+// ServerOutput is called with each read from the server, so burst commands can wait for
+// the game's prompt
+func (tmm *TerminalMenuManager) ServerOutput(data []byte) {
+	tmm.burstQueue.serverOutput(data)
 }
 
 // handleSectorDisplayInput handles input collection for sector display
@@ -1572,7 +1776,8 @@ func (tmm *TerminalMenuManager) displaySectorInTWXFormat(sector database.TSector
 	var output strings.Builder
 
 	// Last seen date/time (TWX format)
-	output.WriteString("\r\nLast seen on " + sector.UpDate.Format("01/02/2006") + " at " + sector.UpDate.Format("15:04:05") + "\r\n\r\n")
+	output.WriteString("\r\nLast seen on " + sector.UpDate.Format("01/02/2006") + " at " + sector.UpDate.Format("15:04:05") +
+		tmm.staleNote(sector.UpDate, time.Now()) + "\r\n\r\n")
 
 	// Sector and constellation
 	constellation := sector.Constellation
@@ -1643,6 +1848,11 @@ func (tmm *TerminalMenuManager) displaySectorInTWXFormat(sector database.TSector
 		}
 	}
 
+	// Aliens (only the count and type are recorded)
+	if sector.AlienCount > 0 {
+		output.WriteString("Aliens  : " + fmt.Sprintf("%d", sector.AlienCount) + " " + sector.AlienType + "\r\n")
+	}
+
 	// Fighters
 	if sector.Figs.Quantity > 0 {
 		output.WriteString("Fighters: " + fmt.Sprintf("%d", sector.Figs.Quantity) + " (" + sector.Figs.Owner + ") ")
@@ -1705,7 +1915,9 @@ func (tmm *TerminalMenuManager) displayPortInformation(output *strings.Builder,
 
 	if db, ok := dbInterface.(database.Database); ok {
 		port, err := db.LoadPort(sectorIndex)
-		if err == nil && port.Name != "" && !port.Dead {
+		if err == nil && port.Dead {
+			output.WriteString("Ports   : <=-DANGER-=> " + port.Name + " (destroyed)\r\n")
+		} else if err == nil && port.Name != "" {
 			output.WriteString("Ports   : " + port.Name + ", Class " + fmt.Sprintf("%d", port.ClassIndex) + " (")
 
 			if port.ClassIndex == 0 || port.ClassIndex == 9 {
@@ -1735,12 +1947,17 @@ func (tmm *TerminalMenuManager) displayPortInformation(output *strings.Builder,
 
 			// Construction status
 			if port.BuildTime > 0 {
-				output.WriteString("           (Under Construction - " + fmt.Sprintf("%d", port.BuildTime) + " days left)\r\n")
+				output.WriteString("           " + constructionStatus(port.BuildTime) + "\r\n")
 			}
 		}
 	}
 }
 
+// constructionStatus describes a port that is still being built
+func constructionStatus(buildTime int) string {
+	return fmt.Sprintf("(Under Construction - %d days left)", buildTime)
+}
+
 // handleShowPort handles the "Show port details as last seen" menu option
 func (tmm *TerminalMenuManager) handleShowPort(item *TerminalMenuItem, params []string) error {
 	defer func() {
@@ -1774,7 +1991,7 @@ func (tmm *TerminalMenuManager) handleShowPort(item *TerminalMenuItem, params []
 		tmm.sendOutput("\r\nEnter sector number to show port details (1-" + fmt.Sprintf("%d", sectorCount) + "):\r\n")
 
 		// Start input collection for sector number
-		tmm.inputCollector.StartCollection("PORT_DISPLAY", "Sector number")
+		tmm.startCollection("PORT_DISPLAY", "Sector number")
 		return nil
 	} else {
 		tmm.sendOutput(display.FormatErrorMessage("Error: Invalid database interface"))
@@ -1785,27 +2002,9 @@ func (tmm *TerminalMenuManager) handleShowPort(item *TerminalMenuItem, params []
 
 // displayPortSummary displays a port summary line (like TWX DisplayPortSummary)
 func (tmm *TerminalMenuManager) displayPortSummary(output *strings.Builder, sectorIndex int, port database.TPort) {
-	// Format: sector number, class, buy/sell pattern, product amounts and percentages, update time
-	sectorStr := fmt.Sprintf("%6d", sectorIndex)
-	classStr := fmt.Sprintf("%5d", port.ClassIndex)
-
-	// Build buy/sell pattern (BSS format)
-	pattern := ""
-	if port.BuyProduct[0] {
-		pattern += "B"
-	} else {
-		pattern += "S"
-	}
-	if port.BuyProduct[1] {
-		pattern += "B"
-	} else {
-		pattern += "S"
-	}
-	if port.BuyProduct[2] {
-		pattern += "B"
-	} else {
-		pattern += "S"
-	}
+	// Format: sector number, class, buy/sell pattern, product amounts and percentages, update time.
+	// The row is colored by port class, so pair trading ports stand out.
+	color := display.PortClassColor(port.ClassIndex)
 
 	// Format product amounts and percentages
 	fuelOreStr := fmt.Sprintf("%5d (%3d%%)", port.ProductAmount[0], port.ProductPercent[0])
@@ -1815,14 +2014,17 @@ func (tmm *TerminalMenuManager) displayPortSummary(output *strings.Builder, sect
 	// Format update time
 	updateStr := port.UpDate.Format("15:04")
 
-	output.WriteString(fmt.Sprintf("%s %s %s %s %s %s %s\r\n",
-		sectorStr,
-		classStr,
-		pattern,
+	output.WriteString(fmt.Sprintf("%s%6d %s%s %-5s %s %s %s %s%s\r\n",
+		color,
+		sectorIndex,
+		display.FormatPortClass(port.ClassIndex),
+		color,
+		tradePattern(port.BuyProduct),
 		fuelOreStr,
 		organicsStr,
 		equipmentStr,
-		updateStr))
+		updateStr,
+		display.ANSI_RESET))
 }
 
 // handlePortDisplayInput handles input collection for port display
@@ -1894,7 +2096,17 @@ func (tmm *TerminalMenuManager) displayPortInTWXFormat(port database.TPort, sect
 
 	// Commerce report header (like TWX DisplayPort)
 	output.WriteString("\r\nCommerce report for " + port.Name + " (sector " + fmt.Sprintf("%d", sectorIndex) + ") : ")
-	output.WriteString(port.UpDate.Format("15:04:05 01/02/2006") + "\r\n\r\n")
+	output.WriteString(port.UpDate.Format("15:04:05 01/02/2006") + "\r\n")
+	if port.Dead {
+		output.WriteString("<=-DANGER-=> This port has been destroyed\r\n\r\n")
+		tmm.sendOutput(output.String())
+		tmm.displayCurrentMenu()
+		return
+	}
+	if port.BuildTime > 0 {
+		output.WriteString(constructionStatus(port.BuildTime) + "\r\n")
+	}
+	output.WriteString("\r\n")
 
 	// Product table header
 	output.WriteString(" Items     Status  Trading % of max\r\n")
@@ -1927,7 +2139,7 @@ func (tmm *TerminalMenuManager) displayPortInTWXFormat(port database.TPort, sect
 	}
 	output.WriteString(fmt.Sprintf("%5d    %3d%%\r\n", port.ProductAmount[2], port.ProductPercent[2]))
 
-	output.WriteString("\r\n\r\n")
+	output.WriteString("\r\n" + tmm.portProfitEstimate(port) + "\r\n\r\n")
 	tmm.sendOutput(output.String())
 	tmm.displayCurrentMenu()
 }
@@ -2068,30 +2280,11 @@ func (tmm *TerminalMenuManager) handleVariableDumpInput(pattern string) error {
 	variableCount := 0
 
 	if engine != nil {
-		// Try to access the VM's variable manager using our new interface
-		if vm, ok := engine.(interface {
-			GetAllVariables() map[string]*types.Value
-		}); ok {
-			variables := vm.GetAllVariables()
-			for name, value := range variables {
-				if pattern == "" || strings.Contains(strings.ToLower(name), strings.ToLower(pattern)) {
-					// Format the value based on its type
-					var valueStr string
-					switch value.Type {
-					case types.StringType:
-						valueStr = value.String
-					case types.NumberType:
-						valueStr = fmt.Sprintf("%.0f", value.Number)
-					case types.ArrayType:
-						// Show array size
-						valueStr = fmt.Sprintf("[Array with %d elements]", len(value.Array))
-					default:
-						valueStr = fmt.Sprintf("%v", value)
-					}
-					output.WriteString(fmt.Sprintf("%-20s = %s\r\n", name, valueStr))
-					variableCount++
-				}
+		if lines, ok := matchingVariables(engine, pattern); ok {
+			for _, line := range lines {
+				output.WriteString(line + "\r\n")
 			}
+			variableCount = len(lines)
 		} else {
 			// Fallback: script engine found but doesn't implement our interface
 			output.WriteString("Script engine found, but variable access interface not available.\r\n")
@@ -2145,7 +2338,7 @@ func (tmm *TerminalMenuManager) StartScriptInputCollection(prompt string, callba
 	})
 
 	// Start input collection with script prompt
-	tmm.inputCollector.StartCollection("SCRIPT_INPUT", prompt)
+	tmm.startCollection("SCRIPT_INPUT", prompt)
 
 	return nil
 }
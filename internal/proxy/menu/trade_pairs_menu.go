@@ -0,0 +1,150 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+const (
+	// defaultTradePairHops is used when no hop distance is entered
+	defaultTradePairHops = 2
+	// maxTradePairHops bounds the search so it stays quick on large maps
+	maxTradePairHops = 10
+	// maxTradePairsShown limits how many of the best pairs are listed
+	maxTradePairsShown = 25
+	// estimateHolds is assumed for port profit estimates until the player's holds are
+	// known; it is what a new Merchant Cruiser carries
+	estimateHolds = 20
+)
+
+// tradeProductNames are the short product names used in the trade pair list
+var tradeProductNames = [3]string{"Ore", "Org", "Equ"}
+
+// SetTradePricing replaces the economy constants used for trade profit estimates
+func (tmm *TerminalMenuManager) SetTradePricing(pricing database.TradePricing) {
+	tmm.tradePricing = pricing
+}
+
+// handleFindTradePairs prompts for the maximum warp hops between paired ports
+func (tmm *TerminalMenuManager) handleFindTradePairs(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleFindTradePairs", "error", r)
+		}
+	}()
+
+	if _, ok := tmm.openDatabase(); !ok {
+		return nil
+	}
+
+	tmm.sendOutput(fmt.Sprintf("\r\nEnter maximum warp hops between ports [%d]:\r\n", defaultTradePairHops))
+	tmm.startCollection("TRADE_PAIRS", "Maximum hops")
+	return nil
+}
+
+// handleTradePairsInput lists the trade pairs within the entered hop distance
+func (tmm *TerminalMenuManager) handleTradePairsInput(hopsStr string) error {
+	hops := defaultTradePairHops
+	if hopsStr = strings.TrimSpace(hopsStr); hopsStr != "" {
+		if _, err := fmt.Sscanf(hopsStr, "%d", &hops); err != nil || hops < 1 || hops > maxTradePairHops {
+			tmm.sendOutput(display.FormatErrorMessage(fmt.Sprintf("Hops must be a number from 1 to %d", maxTradePairHops)))
+			tmm.displayCurrentMenu()
+			return nil
+		}
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	pairs, err := db.FindTradePairs(hops)
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error finding trade pairs: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	log.Info("Found trade pairs", "hops", hops, "count", len(pairs))
+
+	// Without known holds the list is still useful, just without profit estimates
+	holds := 0
+	if stats, err := db.GetPlayerStatsInfo(); err == nil {
+		holds = stats.TotalHolds
+	} else {
+		log.Warn("Could not load holds for trade profit estimates", "error", err)
+	}
+
+	tmm.sendOutput(formatTradePairs(pairs, hops, holds, tmm.tradePricing))
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// formatTradePairs renders the best trade pairs as a table. Each pair's estimated round trip
+// profit is shown when holds is known.
+func formatTradePairs(pairs []database.TradePair, hops, holds int, pricing database.TradePricing) string {
+	var output strings.Builder
+	output.WriteString("\r\n")
+
+	if len(pairs) == 0 {
+		output.WriteString(fmt.Sprintf("No trade pairs found within %d hops.\r\n", hops))
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("Trade pairs within %d hops, best first:\r\n\r\n", hops))
+	output.WriteString("Sector Class      Sector Class  Hops  Trade    Percent   Profit\r\n")
+	output.WriteString("---------------------------------------------------------------\r\n")
+
+	for i, pair := range pairs {
+		if i == maxTradePairsShown {
+			output.WriteString(fmt.Sprintf("... and %d more\r\n", len(pairs)-maxTradePairsShown))
+			break
+		}
+		profit := "-"
+		if holds > 0 {
+			profit = fmt.Sprintf("%d", database.EstimateTradeProfit(pair, holds, pricing))
+		}
+		output.WriteString(fmt.Sprintf("%6d %d %s <-> %6d %d %s  %4d  %s/%s  %7d  %7s\r\n",
+			pair.SectorA, pair.ClassA, tradePattern(pair.BuyA),
+			pair.SectorB, pair.ClassB, tradePattern(pair.BuyB),
+			pair.Hops,
+			tradeProductNames[pair.ProductAToB], tradeProductNames[pair.ProductBToA],
+			pair.PercentTotal, profit))
+	}
+
+	if holds > 0 {
+		output.WriteString(fmt.Sprintf("\r\nProfit is an estimate per round trip with %d holds.\r\n", holds))
+	}
+	output.WriteString("\r\n")
+	return output.String()
+}
+
+// tradePattern formats port buy flags in the usual BBS style
+func tradePattern(buy [3]bool) string {
+	pattern := make([]byte, len(buy))
+	for i, buying := range buy {
+		if buying {
+			pattern[i] = 'B'
+		} else {
+			pattern[i] = 'S'
+		}
+	}
+	return string(pattern)
+}
+
+// portProfitEstimate describes roughly what a turn of trading at the port is worth, with
+// the player's holds if they are known
+func (tmm *TerminalMenuManager) portProfitEstimate(port database.TPort) string {
+	holds := estimateHolds
+	if db, ok := tmm.getDatabaseQuietly(); ok {
+		if stats, err := db.GetPlayerStatsInfo(); err == nil && stats.TotalHolds > 0 {
+			holds = stats.TotalHolds
+		}
+	}
+	profit := database.EstimatePortProfit(port, holds, tmm.tradePricing)
+	return fmt.Sprintf("Estimated profit/turn: %d credits with %d holds\r\n"+
+		"(estimate only - real prices depend on your holds and experience)", profit, holds)
+}
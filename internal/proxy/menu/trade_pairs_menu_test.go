@@ -0,0 +1,66 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestFormatTradePairs(t *testing.T) {
+	pair := database.TradePair{
+		SectorA: 12, SectorB: 345,
+		ClassA: 1, ClassB: 4,
+		BuyA: [3]bool{true, true, false}, BuyB: [3]bool{false, false, true},
+		PercentA: [3]int{80, 80, 80}, PercentB: [3]int{100, 100, 100},
+		AmountA: [3]int{1000, 1000, 1000}, AmountB: [3]int{1000, 1000, 1000},
+		ProductAToB: database.PtEquipment, ProductBToA: database.PtFuelOre,
+		Hops: 1, PercentTotal: 340,
+	}
+
+	output := formatTradePairs([]database.TradePair{pair}, 2, 0, database.DefaultTradePricing)
+	if !strings.Contains(output, "    12 1 BBS <->    345 4 SSB     1  Equ/Ore      340        -") {
+		t.Errorf("Unexpected trade pair row without holds:\n%s", output)
+	}
+
+	// 10 holds of equipment at 36 profit each, then 10 of ore at 11.25 each on the way back
+	output = formatTradePairs([]database.TradePair{pair}, 2, 10, database.DefaultTradePricing)
+	if !strings.Contains(output, "340      472") || !strings.Contains(output, "with 10 holds") {
+		t.Errorf("Expected a profit estimate for 10 holds:\n%s", output)
+	}
+
+	pairs := make([]database.TradePair, maxTradePairsShown+3)
+	if output := formatTradePairs(pairs, 2, 0, database.DefaultTradePricing); !strings.Contains(output, "... and 3 more") {
+		t.Errorf("Expected the list to be truncated, got:\n%s", output)
+	}
+
+	if output := formatTradePairs(nil, 3, 0, database.DefaultTradePricing); !strings.Contains(output, "No trade pairs found within 3 hops") {
+		t.Errorf("Expected an empty result message, got:\n%s", output)
+	}
+}
+
+func TestPortProfitEstimatePricing(t *testing.T) {
+	tmm := NewTerminalMenuManager(
+		func([]byte) {},
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return nil },
+		func(string) {},
+		func(string) {},
+	)
+	port := database.TPort{
+		BuyProduct:     [3]bool{database.PtFuelOre: true, database.PtOrganics: true},
+		ProductAmount:  [3]int{database.PtFuelOre: 2000, database.PtOrganics: 10, database.PtEquipment: 1000},
+		ProductPercent: [3]int{database.PtFuelOre: 40, database.PtOrganics: 100, database.PtEquipment: 50},
+	}
+
+	// Organics 10 * 45 * 0.25 = 112 plus equipment 20 * 80 * 0.25 * 0.5 = 200
+	if estimate := tmm.portProfitEstimate(port); !strings.Contains(estimate, "312 credits with 20 holds") {
+		t.Errorf("Expected the default pricing estimate, got %q", estimate)
+	}
+
+	// Organics 10 * 45 * 0.5 = 225 plus equipment 20 * 100 * 0.5 * 0.5 = 500
+	tmm.SetTradePricing(database.TradePricing{BasePrice: [3]float64{25, 45, 100}, Margin: 0.5})
+	if estimate := tmm.portProfitEstimate(port); !strings.Contains(estimate, "725 credits with 20 holds") {
+		t.Errorf("Expected the server's pricing to be used, got %q", estimate)
+	}
+}
@@ -0,0 +1,175 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"twist/internal/log"
+	"twist/internal/proxy/interfaces"
+	"twist/internal/proxy/menu/display"
+	"twist/internal/proxy/scripting/types"
+)
+
+// variableWatchInterval is how often a variable watch checks the variables for changes
+const variableWatchInterval = time.Second
+
+// variableWatch re-renders the variables matching a pattern until stopped
+type variableWatch struct {
+	pattern string
+	stop    chan struct{}
+}
+
+// variableSource is the part of the script engine a variable dump reads
+type variableSource interface {
+	GetAllVariables() map[string]*types.Value
+}
+
+// handleVariableWatch prompts for the variables to watch
+func (tmm *TerminalMenuManager) handleVariableWatch(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleVariableWatch", "error", r)
+		}
+	}()
+
+	if tmm.getScriptManager == nil || tmm.getScriptManager() == nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error: Script manager not available"))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.sendOutput("\r\nEnter a full or partial variable name to watch (or blank to watch them all):\r\n")
+	tmm.startCollection("VARIABLE_WATCH", "Variable pattern")
+	return nil
+}
+
+// handleVariableWatchInput starts watching the variables matching pattern. Without an engine
+// that exposes its variables, it falls back to a one-time dump.
+func (tmm *TerminalMenuManager) handleVariableWatchInput(pattern string) error {
+	var engine interfaces.ScriptEngine
+	if tmm.getScriptManager != nil {
+		if scriptManager := tmm.getScriptManager(); scriptManager != nil {
+			engine = scriptManager.GetEngine()
+		}
+	}
+	if _, ok := engine.(variableSource); !ok {
+		return tmm.handleVariableDumpInput(pattern)
+	}
+
+	watch := &variableWatch{pattern: strings.TrimSpace(pattern), stop: make(chan struct{})}
+	tmm.stopVariableWatch()
+	tmm.watchMutex.Lock()
+	tmm.variableWatch = watch
+	tmm.watchMutex.Unlock()
+
+	log.Info("Variable watch started", "pattern", watch.pattern)
+	go tmm.runVariableWatch(watch, engine)
+	return nil
+}
+
+// runVariableWatch renders the watched variables, then again each time they change, until
+// the watch is stopped
+func (tmm *TerminalMenuManager) runVariableWatch(watch *variableWatch, engine interfaces.ScriptEngine) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in runVariableWatch", "error", r)
+		}
+	}()
+
+	ticker := time.NewTicker(variableWatchInterval)
+	defer ticker.Stop()
+
+	shown, first := "", true
+	for {
+		lines, _ := matchingVariables(engine, watch.pattern)
+		if rendered := strings.Join(lines, "\r\n"); first || rendered != shown {
+			tmm.sendOutput(formatVariableWatch(watch.pattern, lines))
+			shown, first = rendered, false
+		}
+
+		select {
+		case <-watch.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopVariableWatch ends the running variable watch. Returns false if there wasn't one.
+func (tmm *TerminalMenuManager) stopVariableWatch() bool {
+	tmm.watchMutex.Lock()
+	defer tmm.watchMutex.Unlock()
+
+	if tmm.variableWatch == nil {
+		return false
+	}
+	close(tmm.variableWatch.stop)
+	tmm.variableWatch = nil
+	log.Info("Variable watch stopped")
+	return true
+}
+
+// formatVariableWatch clears the screen and renders the watched variables
+func formatVariableWatch(pattern string, lines []string) string {
+	var output strings.Builder
+	output.WriteString("\x1b[2J\x1b[H")
+	output.WriteString(display.FormatMenuTitle("Variable Watch"))
+
+	if pattern != "" {
+		output.WriteString("Watching variables matching: '" + pattern + "'\r\n")
+	} else {
+		output.WriteString("Watching all variables:\r\n")
+	}
+	output.WriteString("\r\n")
+
+	for _, line := range lines {
+		output.WriteString(line + "\r\n")
+	}
+	if len(lines) == 0 {
+		output.WriteString("No matching variables yet.\r\n")
+	}
+
+	output.WriteString(fmt.Sprintf("\r\nUpdated %s. Press any key to stop watching.\r\n", time.Now().Format("15:04:05")))
+	return output.String()
+}
+
+// matchingVariables formats the variables whose names contain pattern, ignoring case, in
+// name order. Returns false if the engine doesn't expose its variables.
+func matchingVariables(engine interfaces.ScriptEngine, pattern string) ([]string, bool) {
+	source, ok := engine.(variableSource)
+	if !ok {
+		return nil, false
+	}
+
+	variables := source.GetAllVariables()
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if pattern == "" || strings.Contains(strings.ToLower(name), strings.ToLower(pattern)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%-20s = %s", name, formatVariableValue(variables[name]))
+	}
+	return lines, true
+}
+
+// formatVariableValue renders a variable's value for a dump
+func formatVariableValue(value *types.Value) string {
+	switch value.Type {
+	case types.StringType:
+		return value.String
+	case types.NumberType:
+		return fmt.Sprintf("%.0f", value.Number)
+	case types.ArrayType:
+		// Show array size
+		return fmt.Sprintf("[Array with %d elements]", len(value.Array))
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
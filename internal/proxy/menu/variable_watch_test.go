@@ -0,0 +1,186 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"twist/internal/proxy/interfaces"
+	"twist/internal/proxy/scripting/types"
+	"twist/internal/proxy/scripting/vm"
+)
+
+// watchedEngine is a script engine whose variables a test can change
+type watchedEngine struct {
+	mutex     sync.Mutex
+	variables map[string]*types.Value
+}
+
+func (e *watchedEngine) GetRunningScripts() []interfaces.ScriptInfo { return nil }
+func (e *watchedEngine) GetAllScripts() []interfaces.ScriptInfo     { return nil }
+func (e *watchedEngine) GetScriptCount() int                        { return 0 }
+func (e *watchedEngine) GetRunningScriptCount() int                 { return 0 }
+func (e *watchedEngine) GetStatus() map[string]interface{}          { return nil }
+
+func (e *watchedEngine) GetAllVariables() map[string]*types.Value {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	variables := make(map[string]*types.Value, len(e.variables))
+	for name, value := range e.variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+func (e *watchedEngine) set(name string, value *types.Value) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.variables[name] = value
+}
+
+// scriptVariablesEngine is a script engine reading a script's own variables
+type scriptVariablesEngine struct {
+	watchedEngine
+	variables *vm.VariableManager
+}
+
+func (e *scriptVariablesEngine) GetAllVariables() map[string]*types.Value {
+	return e.variables.GetAll()
+}
+
+// engineScriptManager is a script manager that only provides an engine
+type engineScriptManager struct {
+	engine interfaces.ScriptEngine
+}
+
+func (m *engineScriptManager) LoadAndRunScript(filename string) error             { return nil }
+func (m *engineScriptManager) Stop() error                                        { return nil }
+func (m *engineScriptManager) GetStatus() map[string]interface{}                  { return nil }
+func (m *engineScriptManager) GetEngine() interfaces.ScriptEngine                 { return m.engine }
+func (m *engineScriptManager) HasScriptWaitingForInput() (string, string)         { return "", "" }
+func (m *engineScriptManager) ResumeScriptWithInput(scriptID, input string) error { return nil }
+func (m *engineScriptManager) PauseScript(scriptID string) error                  { return nil }
+func (m *engineScriptManager) ResumeScript() error                                { return nil }
+func (m *engineScriptManager) StepScript() (types.StepResult, error)              { return types.StepResult{}, nil }
+func (m *engineScriptManager) SetBreakpoint(scriptName string, line int) error    { return nil }
+func (m *engineScriptManager) ClearBreakpoint(scriptName string, line int) error  { return nil }
+func (m *engineScriptManager) GetBreakpoints() map[string][]int                   { return nil }
+func (m *engineScriptManager) SetTrace(enabled bool) error                        { return nil }
+func (m *engineScriptManager) IsTracing() bool                                    { return false }
+func (m *engineScriptManager) GetTraceFile() string                               { return "" }
+
+// lockedOutput collects menu output written from the watch goroutine
+type lockedOutput struct {
+	mutex  sync.Mutex
+	output strings.Builder
+}
+
+func (o *lockedOutput) write(data []byte) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.output.Write(data)
+}
+
+func (o *lockedOutput) contains(text string) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return strings.Contains(o.output.String(), text)
+}
+
+func (o *lockedOutput) waitFor(t *testing.T, text string) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		if o.contains(text) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %q", text)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestVariableWatchRefreshesUntilKeypress(t *testing.T) {
+	engine := &watchedEngine{variables: map[string]*types.Value{
+		"$sector": types.NewNumberValue(12),
+		"$other":  types.NewStringValue("x"),
+	}}
+	output := &lockedOutput{}
+	tmm := NewTerminalMenuManager(
+		output.write,
+		func() ScriptManagerInterface { return &engineScriptManager{engine: engine} },
+		func() interface{} { return nil },
+		func(string) {},
+		func(string) {},
+	)
+	tmm.ActivateMainMenu()
+
+	tmm.handleVariableWatchInput("sect")
+	output.waitFor(t, "$sector              = 12")
+
+	engine.set("$sector", types.NewNumberValue(13))
+	output.waitFor(t, "$sector              = 13")
+	if output.contains("$other") {
+		t.Error("Expected only variables matching the pattern to be watched")
+	}
+
+	// Any key stops the watch and goes back to the menu
+	if err := tmm.MenuText("x"); err != nil {
+		t.Fatalf("MenuText failed: %v", err)
+	}
+	if tmm.stopVariableWatch() {
+		t.Error("Expected the keypress to stop the watch")
+	}
+	if !tmm.IsActive() {
+		t.Error("Expected the menu to stay open after the watch")
+	}
+}
+
+func TestVariableWatchFallsBackToDump(t *testing.T) {
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return &engineScriptManager{} },
+		func() interface{} { return nil },
+		func(string) {},
+		func(string) {},
+	)
+	tmm.ActivateMainMenu()
+
+	tmm.handleVariableWatchInput("")
+	if tmm.stopVariableWatch() {
+		t.Error("Expected no watch without an engine")
+	}
+	if !strings.Contains(output.String(), "Variable Dump Complete") {
+		t.Errorf("Expected a one-time dump instead, got:\n%s", output.String())
+	}
+}
+
+func TestVariableWatchWhileScriptSetsVariables(t *testing.T) {
+	variables := vm.NewVariableManager(nil)
+	variables.Set("$sector", types.NewNumberValue(1))
+	engine := &scriptVariablesEngine{variables: variables}
+	output := &lockedOutput{}
+	tmm := NewTerminalMenuManager(
+		output.write,
+		func() ScriptManagerInterface { return &engineScriptManager{engine: engine} },
+		func() interface{} { return nil },
+		func(string) {},
+		func(string) {},
+	)
+	tmm.ActivateMainMenu()
+
+	tmm.handleVariableWatchInput("")
+	output.waitFor(t, "SECTOR")
+
+	// The script keeps setting variables, including new array elements, while the watch reads
+	for i := 2; i <= 500; i++ {
+		variables.Set("$sector", types.NewNumberValue(float64(i)))
+		variables.Set(fmt.Sprintf("$visited[%d]", i), types.NewStringValue("yes"))
+	}
+	output.waitFor(t, "SECTOR               = 500")
+	tmm.stopVariableWatch()
+}
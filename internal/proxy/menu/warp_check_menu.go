@@ -0,0 +1,87 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu/display"
+)
+
+// maxWarpIssuesShown bounds how many warp issues are listed; the counts cover them all
+const maxWarpIssuesShown = 50
+
+// handleCheckWarps lists the problems with the stored warps and offers to repair them
+func (tmm *TerminalMenuManager) handleCheckWarps(item *TerminalMenuItem, params []string) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("PANIC in handleCheckWarps", "error", r)
+		}
+	}()
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	issues, err := db.VerifyWarps()
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error checking warps: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	tmm.sendOutput(formatWarpIssues(issues))
+	tmm.sendOutput("\r\nRemove duplicate and out of range warps and sort every sector's warps? (Y/N) [N]:\r\n")
+	tmm.startCollection("WARP_REPAIR", "Repair")
+	return nil
+}
+
+// handleCheckWarpsInput repairs the warps if the answer was yes
+func (tmm *TerminalMenuManager) handleCheckWarpsInput(answer string) error {
+	if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+		tmm.displayCurrentMenu()
+		return nil
+	}
+
+	db, ok := tmm.openDatabase()
+	if !ok {
+		return nil
+	}
+
+	repaired, err := db.RepairWarps()
+	if err != nil {
+		tmm.sendOutput(display.FormatErrorMessage("Error repairing warps: " + err.Error()))
+		tmm.displayCurrentMenu()
+		return nil
+	}
+	log.Info("Repaired sector warps", "sectors", repaired)
+
+	tmm.sendOutput(fmt.Sprintf("\r\nRepaired the warps of %d sectors.\r\n", repaired))
+	tmm.displayCurrentMenu()
+	return nil
+}
+
+// formatWarpIssues lists the first issues and counts them by kind
+func formatWarpIssues(issues []database.WarpIssue) string {
+	if len(issues) == 0 {
+		return "\r\nNo warp problems found.\r\n"
+	}
+
+	var output strings.Builder
+	output.WriteString("\r\nWarp problems:\r\n")
+	counts := make(map[database.WarpIssueKind]int)
+	for i, issue := range issues {
+		counts[issue.Kind]++
+		if i < maxWarpIssuesShown {
+			output.WriteString(fmt.Sprintf("  %5d -> %-5d %s\r\n", issue.Sector, issue.Warp, issue.Kind))
+		}
+	}
+	if len(issues) > maxWarpIssuesShown {
+		output.WriteString(fmt.Sprintf("  ... and %d more\r\n", len(issues)-maxWarpIssuesShown))
+	}
+	output.WriteString(fmt.Sprintf("%d duplicate, %d out of range, %d one-way\r\n",
+		counts[database.WarpDuplicate], counts[database.WarpOutOfRange], counts[database.WarpOneWay]))
+	return output.String()
+}
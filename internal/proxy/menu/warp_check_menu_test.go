@@ -0,0 +1,50 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestCheckWarpsInput(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	sector := database.NULLSector()
+	sector.Warp = [6]int{9, 2, 9, 0, 0, 0}
+	if err := db.SaveSector(sector, 1); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	var output strings.Builder
+	tmm := NewTerminalMenuManager(
+		func(data []byte) { output.Write(data) },
+		func() ScriptManagerInterface { return nil },
+		func() interface{} { return db },
+		func(string) {},
+		func(string) {},
+	)
+
+	tmm.handleCheckWarps(nil, nil)
+	if !strings.Contains(output.String(), "1 duplicate, 0 out of range, 0 one-way") {
+		t.Errorf("Expected the duplicate warp counted, got %q", output.String())
+	}
+
+	output.Reset()
+	tmm.handleCheckWarpsInput("n")
+	if warps, _ := db.GetWarps(1); warps != sector.Warp {
+		t.Errorf("Expected the warps untouched without a yes, got %v", warps)
+	}
+
+	tmm.handleCheckWarpsInput("Y")
+	if !strings.Contains(output.String(), "Repaired the warps of 1 sectors") {
+		t.Errorf("Expected sector 1 repaired, got %q", output.String())
+	}
+	if warps, _ := db.GetWarps(1); warps != [6]int{2, 9, 0, 0, 0, 0} {
+		t.Errorf("Expected the warps deduped and sorted, got %v", warps)
+	}
+}
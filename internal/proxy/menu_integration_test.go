@@ -15,6 +15,7 @@ type mockTuiAPI struct {
 func (m *mockTuiAPI) OnData(data []byte)                                         { m.dataReceived = append(m.dataReceived, string(data)) }
 func (m *mockTuiAPI) OnConnectionStatusChanged(status api.ConnectionStatus, address string) {}
 func (m *mockTuiAPI) OnConnectionError(err error)                                {}
+func (m *mockTuiAPI) OnConnectionStateChanged(state api.ConnectionState)        {}
 func (m *mockTuiAPI) OnScriptStatusChanged(status api.ScriptStatusInfo)         {}
 func (m *mockTuiAPI) OnScriptError(scriptName string, err error)                {}
 func (m *mockTuiAPI) OnDatabaseStateChanged(info api.DatabaseStateInfo)         {}
@@ -23,6 +24,11 @@ func (m *mockTuiAPI) OnTraderDataUpdated(sectorNumber int, traders []api.TraderI
 func (m *mockTuiAPI) OnPlayerStatsUpdated(stats api.PlayerStatsInfo)            {}
 func (m *mockTuiAPI) OnPortUpdated(portInfo api.PortInfo)                       {}
 func (m *mockTuiAPI) OnSectorUpdated(sectorInfo api.SectorInfo)                 {}
+func (m *mockTuiAPI) OnSectorWarpsUpdated(sector int, warps [6]int)             {}
+func (m *mockTuiAPI) OnMapInvalidated(sectors []int)                            {}
+func (m *mockTuiAPI) OnCoursePlotted(sectors []int)                             {}
+func (m *mockTuiAPI) OnGameDetectionFailed(serverHost, serverPort string)        {}
+func (m *mockTuiAPI) OnGameSelectionMenu(menu api.GameMenuInfo)                 {}
 
 func TestTerminalMenuIntegration(t *testing.T) {
 	t.Skip("Terminal menu test - needs telnet mocking for fast execution")
@@ -0,0 +1,30 @@
+package proxy
+
+import (
+	"errors"
+	"os"
+
+	"twist/internal/log"
+	"twist/internal/proxy/streaming"
+)
+
+// loadPortClasses gives the parser a server's custom port classes from a JSON file, the
+// default file if path is empty. Without a file the parser keeps the stock classes.
+func loadPortClasses(parser *streaming.TWXParser, path string) {
+	explicit := path != ""
+	if !explicit {
+		path = streaming.DefaultPortClassesFile
+	}
+
+	classes, err := streaming.LoadPortClasses(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		log.Warn("Proxy: custom port classes not loaded", "path", path, "error", err)
+		return
+	}
+
+	parser.SetPortClasses(classes)
+	log.Info("Proxy: loaded custom port classes", "path", path, "count", len(classes))
+}
@@ -7,6 +7,8 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"twist/internal/api"
 	"twist/internal/log"
@@ -197,6 +199,22 @@ type Proxy struct {
 
 	// Input handler state
 	inputHandlerStarted bool
+
+	// Volatile sector data older than this is pruned when a game database loads; zero keeps it
+	pruneVolatileAfter time.Duration
+
+	// Game letter to select at the first game selection prompt that lists it; empty leaves it to the user
+	autoGameLetter   string
+	autoGameSelected atomic.Bool
+
+	// Redialing the server after the connection drops (see reconnect.go)
+	reconnectOptions api.ReconnectOptions
+	transport        api.TransportOptions
+	loginScript      string
+	reconnectMu      sync.Mutex
+	reconnecting     bool
+	reconnectStopped bool
+	reconnectStop    chan struct{}
 }
 
 // State helper methods
@@ -258,14 +276,6 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 	reader := bufio.NewReader(conn)
 	writer := bufio.NewWriter(conn)
 
-	writerFunc := func(data []byte) error {
-		_, err := writer.Write(data)
-		if err != nil {
-			return err
-		}
-		return writer.Flush()
-	}
-
 	p := &Proxy{
 		outputChan:     make(chan string, 100),
 		inputChan:      make(chan string, 100),
@@ -276,7 +286,15 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 		currentAddress: address,
 		currentHost:    currentHost,
 		currentPort:    currentPort,
+
+		pruneVolatileAfter: options.PruneVolatileAfter,
+		autoGameLetter:     strings.ToUpper(strings.TrimSpace(options.GameLetter)),
+		reconnectOptions:   options.Reconnect,
+		transport:          options.Transport,
+		loginScript:        options.ScriptName,
+		reconnectStop:      make(chan struct{}),
 	}
+	pruneOnLoad(db, options.PruneVolatileAfter)
 
 	// Initialize terminal menu manager with function dependencies (no circular reference)
 	p.terminalMenuManager = menu.NewTerminalMenuManager(
@@ -286,6 +304,24 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 		p.SendInput,
 		p.SendToServer,
 	)
+	p.terminalMenuManager.SetAvoidChangedCallback(p.onAvoidChanged)
+	p.terminalMenuManager.SetNoteChangedCallback(p.onNoteChanged)
+	if options.StaleSectorAge != 0 {
+		p.terminalMenuManager.SetStaleSectorAge(options.StaleSectorAge)
+	}
+	loadTradePricing(p.terminalMenuManager, options.TradePricingPath)
+	p.terminalMenuManager.SetRecentLinesFunc(func() []string {
+		if parser := p.GetParser(); parser != nil {
+			return parser.RecentLines()
+		}
+		return nil
+	})
+	p.terminalMenuManager.SetParserStatsFunc(func() streaming.ParserStats {
+		if parser := p.GetParser(); parser != nil {
+			return parser.GetParserStats()
+		}
+		return streaming.ParserStats{}
+	})
 
 	// Initialize script input collector - reuses same logic as menu input
 	p.scriptInputCollector = input.NewInputCollector(func(output string) {
@@ -307,9 +343,29 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 	// Set up game detector callbacks to update database and notify TUI when loaded
 	gameDetector.SetDatabaseLoadedCallback(p.onDatabaseLoaded)
 	gameDetector.SetDatabaseStateChangedCallback(p.onDatabaseStateChanged)
+	gameDetector.SetDetectionFailedCallback(p.onGameDetectionFailed)
+	gameDetector.SetGameSelectionMenuCallback(p.onGameSelectionMenu)
+	if options.GameDetectionTimeout != 0 {
+		gameDetector.SetPromptDetectionTimeout(options.GameDetectionTimeout)
+	}
+	loadDetectionPatterns(gameDetector, options.DetectionPatternsPath)
+	if options.ServerDatabaseFallback {
+		log.Info("Proxy: server database fallback enabled", "address", address)
+		gameDetector.SetServerDatabaseFallback(true)
+	}
+
+	// Telnet replies go to whichever connection is current, so the pipeline outlives a reconnect
+	writerFunc := func(data []byte) error {
+		return p.getState().writeServerData(string(data))
+	}
 
 	// Create pipeline with established connection (immutable)
 	pipeline := streaming.NewPipeline(p.tuiAPI, func() database.Database { return p.db }, p.scriptManager, p, p.gameDetector, writerFunc)
+	pipeline.GetParser().SetUnrecognizedPromptLog(options.UnrecognizedPromptsPath)
+	loadPortClasses(pipeline.GetParser(), options.PortClassesPath)
+	if options.DisplayWatchdogLines != 0 {
+		pipeline.GetParser().SetDisplayWatchdog(options.DisplayWatchdogLines)
+	}
 
 	// Create connected state with pipeline
 	connectedState := NewConnectedState(conn, reader, writer, pipeline, p.scriptManager, p.gameDetector)
@@ -318,17 +374,36 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 	// Start the pipeline
 	pipeline.Start()
 
-	// Send initial telnet negotiation through pipeline
-	err := pipeline.SendTelnetNegotiation()
-	if err != nil {
-		conn.Close()
-		panic(fmt.Errorf("telnet negotiation failed: %w", err))
+	// Send initial telnet negotiation through pipeline; an SSH session isn't telnet
+	if options.Transport.UsesTelnet() {
+		if err := pipeline.SendTelnetNegotiation(); err != nil {
+			conn.Close()
+			panic(fmt.Errorf("telnet negotiation failed: %w", err))
+		}
 	}
 
 	// Start I/O handlers
 	p.inputHandlerStarted = true
 	go p.handleInput()
 	go p.handleOutput()
+	p.startCheckpoints(options.CheckpointInterval)
+	p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateConnected)
+
+	// Without a forced database, use the requested game's database, or fall back to a
+	// manual start if no known prompt shows up
+	if p.db == nil {
+		gameChosen := false
+		if options.GameName != "" {
+			if err := gameDetector.UseGameDatabase(options.GameName); err != nil {
+				log.Error("Failed to open game database", "game", options.GameName, "error", err)
+			} else {
+				gameChosen = true
+			}
+		}
+		if !gameChosen {
+			gameDetector.StartPromptDetection()
+		}
+	}
 
 	// Load initial script if configured
 	if err := p.scriptManager.LoadInitialScript(); err != nil {
@@ -346,13 +421,15 @@ func New(conn net.Conn, address string, tuiAPI api.TuiAPI, options *api.ConnectO
 }
 
 func (p *Proxy) Disconnect() error {
-	if !p.getState().IsConnected() {
+	// A lost connection waiting to be redialed still has its session to clean up
+	reconnecting := p.stopReconnecting()
+	if !p.getState().IsConnected() && !reconnecting {
 		return nil
 	}
 
 	// Transition to disconnected state first - this closes the connection
 	// and causes handleOutput() to exit naturally
-	p.setState(NewDisconnectedState())
+	p.endConnection(api.ConnectionStateDisconnected)
 
 	// Stop all scripts
 	if p.scriptManager != nil {
@@ -471,6 +548,9 @@ func (p *Proxy) handleInput() {
 func (p *Proxy) handleOutput() {
 	// Use a buffer for continuous reading
 	buffer := make([]byte, 4096)
+	endState := api.ConnectionStateDisconnected
+	parser := p.GetParser()
+	var pipeline *streaming.Pipeline
 
 	for {
 		state := p.getState()
@@ -483,6 +563,7 @@ func (p *Proxy) handleOutput() {
 		if !ok {
 			break
 		}
+		pipeline = connectedState.pipeline
 
 		// Read raw bytes from connection
 		n, err := connectedState.readServerData(buffer)
@@ -490,6 +571,7 @@ func (p *Proxy) handleOutput() {
 			// Read error in handleOutput - connection likely closed
 			if err.Error() != "EOF" {
 				p.errorChan <- fmt.Errorf("read error: %w", err)
+				endState = api.ConnectionStateError
 			} else {
 				// Got EOF, sending to error channel
 				p.errorChan <- fmt.Errorf("connection closed: %w", err)
@@ -499,6 +581,7 @@ func (p *Proxy) handleOutput() {
 
 		if n > 0 {
 			rawData := buffer[:n]
+			p.terminalMenuManager.ServerOutput(rawData)
 			// Send raw data directly to the streaming pipeline
 			connectedState.processServerData(rawData)
 		}
@@ -506,7 +589,17 @@ func (p *Proxy) handleOutput() {
 
 	// If we exit the loop, it means connection was lost
 	// handleOutput exiting, setting disconnected state
-	p.setState(NewDisconnectedState())
+	lost := p.endConnection(endState)
+
+	// Forget any half-parsed display. This goroutine fed the parser, so nothing else is using it.
+	if parser != nil {
+		parser.Reset()
+	}
+
+	// Only the server dropping the connection is redialed, not a user disconnect
+	if lost && pipeline != nil {
+		p.startReconnecting(pipeline)
+	}
 }
 
 // injectInboundData injects data into the inbound stream as if it came from the server
@@ -559,7 +652,11 @@ func (p *Proxy) SendBurstCommand(burstText string) error {
 
 // LoadScript loads a script from file
 func (p *Proxy) LoadScript(filename string) error {
-	return p.scriptManager.LoadAndRunScript(filename)
+	if err := p.scriptManager.LoadAndRunScript(filename); err != nil {
+		return err
+	}
+	p.refreshCurrentSector("script loaded")
+	return nil
 }
 
 // ExecuteScriptCommand executes a single script command
@@ -674,6 +771,7 @@ func (p *Proxy) SetPlayerName(name string) {
 func (p *Proxy) onDatabaseLoaded(db database.Database, scriptManager *scripting.ScriptManager) error {
 	log.Info("onDatabaseLoaded: callback triggered", "db", db)
 	// Update proxy state with new database
+	pruneOnLoad(db, p.pruneVolatileAfter)
 	p.db = db
 
 	// Update existing script manager with new database instead of replacing it
@@ -683,49 +781,144 @@ func (p *Proxy) onDatabaseLoaded(db database.Database, scriptManager *scripting.
 		p.scriptManager.SetupConnections(p.SendInput, p.SendToTUI, nil)
 	}
 
-	// If we have a connected state, update its pipeline without recreating the state
-	currentState := p.getState()
-	if connectedState, ok := currentState.(*ConnectedState); ok {
-		// Stop the old pipeline first
-		if connectedState.pipeline != nil {
-			connectedState.pipeline.Stop()
-		}
+	// The connected pipeline keeps running: it reads the database through p.db, and its
+	// parser resets itself when it sees a different database (a game switch)
+	p.refreshCurrentSector("database loaded")
+	return nil
+}
 
-		// Create new pipeline with same writer function
-		writerFunc := func(data []byte) error {
-			_, err := connectedState.writer.Write(data)
-			if err != nil {
-				return err
+// refreshCurrentSector resends the stored current sector to the TUI, whose view may be
+// stale after a change that came without a sector display
+func (p *Proxy) refreshCurrentSector(reason string) {
+	parser := p.GetParser()
+	if parser == nil {
+		return
+	}
+	if err := parser.RefreshCurrentSector(); err != nil {
+		log.Debug("Current sector not refreshed", "reason", reason, "error", err)
+	}
+}
+
+// onGameDetectionFailed is called when no known login/game prompt was detected in time
+func (p *Proxy) onGameDetectionFailed(serverHost, serverPort string) {
+	if p.tuiAPI != nil {
+		p.tuiAPI.OnGameDetectionFailed(serverHost, serverPort)
+	}
+}
+
+// onGameSelectionMenu is called when the server waits at its game selection prompt. The
+// configured game is selected the first time the menu lists it; otherwise the TUI is told.
+func (p *Proxy) onGameSelectionMenu(menu api.GameMenuInfo) {
+	if p.autoGameLetter != "" && !p.autoGameSelected.Load() {
+		for _, game := range menu.Games {
+			if game.Letter == p.autoGameLetter && p.autoGameSelected.CompareAndSwap(false, true) {
+				if err := p.SelectGame(game.Letter); err != nil {
+					log.Warn("Failed to select game automatically", "letter", game.Letter, "error", err)
+					break
+				}
+				log.Info("Selected game automatically", "letter", game.Letter, "game", game.Name)
+				return
 			}
-			return connectedState.writer.Flush()
 		}
+	}
+
+	if p.tuiAPI != nil {
+		p.tuiAPI.OnGameSelectionMenu(menu)
+	}
+}
+
+// SelectGame picks a game at the server's game selection prompt by sending its letter
+func (p *Proxy) SelectGame(letter string) error {
+	if !p.getState().IsConnected() {
+		return errors.New("not connected")
+	}
+	if err := p.gameDetector.SelectGame(letter); err != nil {
+		return err
+	}
+	p.SendToServer(strings.ToUpper(strings.TrimSpace(letter)))
+	return nil
+}
 
-		newPipeline := streaming.NewPipeline(p.tuiAPI, func() database.Database { return p.db }, p.scriptManager, p, p.gameDetector, writerFunc)
-		connectedState.pipeline = newPipeline
-		newPipeline.Start()
+// StartServerDatabase starts a game database for this server when game detection failed
+func (p *Proxy) StartServerDatabase() error {
+	if p.db != nil {
+		return errors.New("game database already loaded")
 	}
+	return p.gameDetector.LoadServerDatabase()
+}
 
+// ShowCurrentSector writes the current sector's stored data to the terminal, without
+// going through the terminal menu
+func (p *Proxy) ShowCurrentSector() error {
+	sectorNum, err := p.GetCurrentSector()
+	if err != nil {
+		log.Debug("No current sector to show", "error", err)
+		sectorNum = 0
+	}
+	p.terminalMenuManager.ShowSector(sectorNum)
 	return nil
 }
 
-// onDatabaseStateChanged is called when the game detector loads/unloads a database
-func (p *Proxy) onDatabaseStateChanged(gameName, serverHost, serverPort, dbName string, isLoaded bool) {
+// onAvoidChanged is called when a sector is added to or removed from the avoid list
+func (p *Proxy) onAvoidChanged(sectorNum int) {
+	p.refreshSector(sectorNum, "avoid")
+}
+
+// onNoteChanged is called when a sector's note is set or cleared from the terminal menu
+func (p *Proxy) onNoteChanged(sectorNum int) {
+	p.refreshSector(sectorNum, "note")
+}
 
-	// Create database state info for TUI notification
-	info := api.DatabaseStateInfo{
-		GameName:     gameName,
-		ServerHost:   serverHost,
-		ServerPort:   serverPort,
-		DatabaseName: dbName,
-		IsLoaded:     isLoaded,
+// refreshSector sends a sector's stored data to the TUI after a player change to it
+func (p *Proxy) refreshSector(sectorNum int, change string) {
+	if p.tuiAPI == nil || p.db == nil {
+		return
+	}
+
+	sectorInfo, err := p.db.GetSectorInfo(sectorNum)
+	if err != nil {
+		log.Debug("Sector changed without data", "sector", sectorNum, "change", change, "error", err)
+		return
 	}
+	p.markSessionSector(&sectorInfo)
+	p.tuiAPI.OnSectorUpdated(sectorInfo)
+}
+
+// markSessionSector flags a sector first explored this session, which only the parser knows
+func (p *Proxy) markSessionSector(info *api.SectorInfo) {
+	if parser := p.GetParser(); parser != nil {
+		parser.MarkSessionSector(info)
+	}
+}
 
+// onDatabaseStateChanged is called when the game detector loads/unloads a database
+func (p *Proxy) onDatabaseStateChanged(info api.DatabaseStateInfo) {
 	// Notify TUI about database state change
 	if p.tuiAPI != nil {
 		p.tuiAPI.OnDatabaseStateChanged(info)
 	}
 }
 
+// GetActiveGame returns the identity of the game whose database is loaded
+func (p *Proxy) GetActiveGame() api.DatabaseStateInfo {
+	return p.gameDetector.GetActiveGame()
+}
+
+// ListGameDatabases returns the game databases on disk for the connected server
+func (p *Proxy) ListGameDatabases() ([]api.GameDatabaseInfo, error) {
+	return p.gameDetector.ListGameDatabases()
+}
+
+// OpenGameDatabase makes a listed game database the active one
+func (p *Proxy) OpenGameDatabase(name string) error {
+	return p.gameDetector.OpenGameDatabase(name)
+}
+
+// CreateGameDatabase creates a new game database for the connected server and makes it active
+func (p *Proxy) CreateGameDatabase(gameName string) error {
+	return p.gameDetector.CreateGameDatabase(gameName)
+}
+
 // GetCurrentGame returns the currently detected game name
 func (p *Proxy) GetCurrentGame() string {
 	return p.gameDetector.GetCurrentGame()
@@ -809,10 +1002,125 @@ func (p *Proxy) GetSectorInfo(sectorNum int) (api.SectorInfo, error) {
 	if err != nil {
 		return api.SectorInfo{}, err
 	}
+	p.markSessionSector(&sectorInfo)
 
 	return sectorInfo, nil
 }
 
+// GetRecentSectors returns the sectors the player was last in this connection, most recent first
+func (p *Proxy) GetRecentSectors() ([]int, error) {
+	parser := p.GetParser()
+	if parser == nil {
+		return nil, errors.New("not connected")
+	}
+	return parser.RecentSectors(), nil
+}
+
+// IsAtCommandPrompt reports whether the game is waiting at the Command prompt
+func (p *Proxy) IsAtCommandPrompt() bool {
+	parser := p.GetParser()
+	return parser != nil && parser.AtCommandPrompt()
+}
+
+// GetRecentANSILines returns the last complete lines from the server, oldest first, each
+// starting with the ANSI colors it was shown in
+func (p *Proxy) GetRecentANSILines() ([]string, error) {
+	parser := p.GetParser()
+	if parser == nil {
+		return nil, errors.New("not connected")
+	}
+	return parser.RecentANSILines(), nil
+}
+
+// GetRecentParsedLines returns the last lines the parser handled, oldest first, with the
+// display state each left it in
+func (p *Proxy) GetRecentParsedLines() ([]api.ParsedLineInfo, error) {
+	parser := p.GetParser()
+	if parser == nil {
+		return nil, errors.New("not connected")
+	}
+
+	parsed := parser.RecentParsedLines()
+	lines := make([]api.ParsedLineInfo, len(parsed))
+	for i, line := range parsed {
+		lines[i] = api.ParsedLineInfo{Raw: line.Raw, Stripped: line.Stripped, Display: line.Display.String()}
+	}
+	return lines, nil
+}
+
+// GetExplorationStats counts the game's sectors by how much is known about them
+func (p *Proxy) GetExplorationStats() (api.ExplorationStats, error) {
+	if p.db == nil {
+		return api.ExplorationStats{}, errors.New("database not available")
+	}
+	return p.db.GetExplorationStats()
+}
+
+// GetSessionStats returns the turns spent, credits gained, sectors explored and ports
+// found since the session started
+func (p *Proxy) GetSessionStats() (api.SessionStats, error) {
+	parser := p.GetParser()
+	if parser == nil {
+		return api.SessionStats{}, errors.New("not connected")
+	}
+	if p.db == nil {
+		return api.SessionStats{}, errors.New("database not available")
+	}
+	return parser.GetSessionStats(), nil
+}
+
+// GetPlayerStatsDelta returns the latest player stats and the ones before them
+func (p *Proxy) GetPlayerStatsDelta() (api.PlayerStatsDelta, error) {
+	parser := p.GetParser()
+	if parser == nil {
+		return api.PlayerStatsDelta{}, errors.New("not connected")
+	}
+	return parser.GetPlayerStatsDelta(), nil
+}
+
+// GetSectorWarps returns the warp slots of a sector without loading the rest of its data
+func (p *Proxy) GetSectorWarps(sectorNum int) ([6]int, error) {
+	if p.db == nil {
+		return [6]int{}, errors.New("database not available")
+	}
+
+	if sectorNum < 1 || sectorNum > 99999 {
+		return [6]int{}, errors.New("invalid sector number")
+	}
+
+	return p.db.GetWarps(sectorNum)
+}
+
+// GetInferredWarps returns the destinations of a sector's warps that are only inferred
+func (p *Proxy) GetInferredWarps(sectorNum int) ([]int, error) {
+	if p.db == nil {
+		return nil, errors.New("database not available")
+	}
+
+	if sectorNum < 1 || sectorNum > 99999 {
+		return nil, errors.New("invalid sector number")
+	}
+
+	return p.db.GetInferredWarps(sectorNum)
+}
+
+// SetSectorNote stores a player note for a sector and refreshes the sector in the TUI
+func (p *Proxy) SetSectorNote(sectorNum int, note string) error {
+	if p.db == nil {
+		return errors.New("database not available")
+	}
+
+	if sectorNum < 1 || sectorNum > 99999 {
+		return errors.New("invalid sector number")
+	}
+
+	if err := p.db.SetSectorNote(sectorNum, note); err != nil {
+		return err
+	}
+	p.refreshSector(sectorNum, "note")
+	return nil
+}
+
 // GetPortInfo returns port information for a specific sector
 func (p *Proxy) GetPortInfo(sectorNum int) (*api.PortInfo, error) {
 	if p.db == nil {
@@ -202,10 +202,81 @@ func (p *ProxyApiImpl) GetSectorInfo(sectorNum int) (api.SectorInfo, error) {
 		// Never return empty sector data - return zero value and let caller handle error
 		return api.SectorInfo{}, err
 	}
+	p.proxy.markSessionSector(&sectorInfo)
 
 	return sectorInfo, nil
 }
 
+func (p *ProxyApiImpl) GetRecentSectors() ([]int, error) {
+	if p.proxy == nil {
+		return nil, errors.New("not connected")
+	}
+	return p.proxy.GetRecentSectors()
+}
+
+func (p *ProxyApiImpl) IsAtCommandPrompt() bool {
+	if p.proxy == nil {
+		return false
+	}
+	return p.proxy.IsAtCommandPrompt()
+}
+
+func (p *ProxyApiImpl) GetRecentANSILines() ([]string, error) {
+	if p.proxy == nil {
+		return nil, errors.New("not connected")
+	}
+	return p.proxy.GetRecentANSILines()
+}
+
+func (p *ProxyApiImpl) GetRecentParsedLines() ([]api.ParsedLineInfo, error) {
+	if p.proxy == nil {
+		return nil, errors.New("not connected")
+	}
+	return p.proxy.GetRecentParsedLines()
+}
+
+func (p *ProxyApiImpl) GetExplorationStats() (api.ExplorationStats, error) {
+	if p.proxy == nil {
+		return api.ExplorationStats{}, errors.New("not connected")
+	}
+	return p.proxy.GetExplorationStats()
+}
+
+func (p *ProxyApiImpl) GetSessionStats() (api.SessionStats, error) {
+	if p.proxy == nil {
+		return api.SessionStats{}, errors.New("not connected")
+	}
+	return p.proxy.GetSessionStats()
+}
+
+func (p *ProxyApiImpl) GetPlayerStatsDelta() (api.PlayerStatsDelta, error) {
+	if p.proxy == nil {
+		return api.PlayerStatsDelta{}, errors.New("not connected")
+	}
+	return p.proxy.GetPlayerStatsDelta()
+}
+
+func (p *ProxyApiImpl) GetSectorWarps(sectorNum int) ([6]int, error) {
+	if p.proxy == nil {
+		return [6]int{}, errors.New("not connected")
+	}
+	return p.proxy.GetSectorWarps(sectorNum)
+}
+
+func (p *ProxyApiImpl) GetInferredWarps(sectorNum int) ([]int, error) {
+	if p.proxy == nil {
+		return nil, errors.New("not connected")
+	}
+	return p.proxy.GetInferredWarps(sectorNum)
+}
+
+func (p *ProxyApiImpl) SetSectorNote(sectorNum int, note string) error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+	return p.proxy.SetSectorNote(sectorNum, note)
+}
+
 func (p *ProxyApiImpl) GetPlayerInfo() (api.PlayerInfo, error) {
 	if p.proxy == nil {
 		return api.PlayerInfo{}, errors.New("not connected")
@@ -313,3 +384,67 @@ func (p *ProxyApiImpl) SendBurstCommand(burstText string) error {
 	// This reuses the exact same logic as the terminal menu system
 	return p.proxy.SendBurstCommand(burstText)
 }
+
+func (p *ProxyApiImpl) StartServerDatabase() error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.StartServerDatabase()
+}
+
+func (p *ProxyApiImpl) SelectGame(letter string) error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.SelectGame(letter)
+}
+
+func (p *ProxyApiImpl) GetActiveGame() api.DatabaseStateInfo {
+	if p.proxy == nil {
+		return api.DatabaseStateInfo{}
+	}
+
+	return p.proxy.GetActiveGame()
+}
+
+func (p *ProxyApiImpl) ListGameDatabases() ([]api.GameDatabaseInfo, error) {
+	if p.proxy == nil {
+		return nil, errors.New("not connected")
+	}
+
+	return p.proxy.ListGameDatabases()
+}
+
+func (p *ProxyApiImpl) OpenGameDatabase(name string) error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.OpenGameDatabase(name)
+}
+
+func (p *ProxyApiImpl) CreateGameDatabase(gameName string) error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.CreateGameDatabase(gameName)
+}
+
+func (p *ProxyApiImpl) ShowCurrentSector() error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.ShowCurrentSector()
+}
+
+func (p *ProxyApiImpl) CloseDatabase() error {
+	if p.proxy == nil {
+		return errors.New("not connected")
+	}
+
+	return p.proxy.CloseDatabase()
+}
@@ -0,0 +1,23 @@
+package proxy
+
+import (
+	"time"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+)
+
+// pruneOnLoad clears traders, ships and foreign fighters from sectors last seen longer ago
+// than olderThan when a game database is loaded, so intel that has surely moved on isn't
+// trusted. Zero or less leaves the data alone.
+func pruneOnLoad(db database.Database, olderThan time.Duration) {
+	if olderThan <= 0 || db == nil {
+		return
+	}
+	pruned, err := db.PruneVolatileData(olderThan)
+	if err != nil {
+		log.Warn("Proxy: pruning old sector data failed", "older_than", olderThan, "error", err)
+		return
+	}
+	log.Info("Proxy: pruned old sector data", "older_than", olderThan, "sectors", pruned)
+}
@@ -0,0 +1,145 @@
+package proxy
+
+import (
+	"bufio"
+	"net"
+	"time"
+
+	"twist/internal/api"
+	"twist/internal/log"
+	"twist/internal/proxy/streaming"
+	"twist/internal/proxy/transport"
+)
+
+// Reconnect backoff: the first attempt waits DefaultReconnectInterval unless configured
+// otherwise, and each failure doubles the wait up to MaxReconnectInterval
+const (
+	DefaultReconnectInterval = 2 * time.Second
+	MaxReconnectInterval     = time.Minute
+)
+
+// reconnectDelay returns how long to wait before a reconnect attempt, counting from 1
+func reconnectDelay(options api.ReconnectOptions, attempt int) time.Duration {
+	delay := options.Interval
+	if delay <= 0 {
+		delay = DefaultReconnectInterval
+	}
+	for i := 1; i < attempt && delay < MaxReconnectInterval; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxReconnectInterval)
+}
+
+// startReconnecting redials the server in the background after it dropped the connection,
+// if reconnecting is configured and the user hasn't disconnected
+func (p *Proxy) startReconnecting(pipeline *streaming.Pipeline) {
+	if p.reconnectOptions.MaxAttempts <= 0 {
+		return
+	}
+
+	p.reconnectMu.Lock()
+	defer p.reconnectMu.Unlock()
+	if p.reconnectStopped || p.reconnecting {
+		return
+	}
+	p.reconnecting = true
+	go p.reconnect(pipeline)
+}
+
+// stopReconnecting cancels any reconnect in progress and stops later ones, as the user
+// disconnected. Returns true if a reconnect was in progress.
+func (p *Proxy) stopReconnecting() bool {
+	p.reconnectMu.Lock()
+	defer p.reconnectMu.Unlock()
+	if !p.reconnectStopped {
+		p.reconnectStopped = true
+		close(p.reconnectStop)
+	}
+	wasReconnecting := p.reconnecting
+	p.reconnecting = false
+	return wasReconnecting
+}
+
+// mayReconnect returns true if a lost connection will be redialed: reconnecting is on and
+// neither the user disconnected nor the attempts ran out
+func (p *Proxy) mayReconnect() bool {
+	p.reconnectMu.Lock()
+	defer p.reconnectMu.Unlock()
+	return p.reconnectOptions.MaxAttempts > 0 && !p.reconnectStopped
+}
+
+// reconnect redials the server with backoff until it answers or the attempts run out
+func (p *Proxy) reconnect(pipeline *streaming.Pipeline) {
+	for attempt := 1; attempt <= p.reconnectOptions.MaxAttempts; attempt++ {
+		delay := reconnectDelay(p.reconnectOptions, attempt)
+		log.Info("Proxy: reconnecting", "address", p.currentAddress, "attempt", attempt, "delay", delay)
+		p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateReconnecting)
+
+		select {
+		case <-p.reconnectStop:
+			return
+		case <-time.After(delay):
+		}
+
+		p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateConnecting)
+		conn, err := transport.Dial(p.currentAddress, p.transport)
+		if err != nil {
+			log.Info("Proxy: reconnect attempt failed", "address", p.currentAddress, "attempt", attempt, "error", err)
+			p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateError)
+			continue
+		}
+		if !p.resumeConnection(conn, pipeline) {
+			conn.Close()
+			return
+		}
+
+		// Log into the game again
+		if p.loginScript != "" {
+			if err := p.scriptManager.LoadAndRunScript(p.loginScript); err != nil {
+				log.Error("Failed to run login script after reconnecting", "script", p.loginScript, "error", err)
+			}
+		}
+		return
+	}
+
+	log.Info("Proxy: giving up reconnecting", "address", p.currentAddress, "attempts", p.reconnectOptions.MaxAttempts)
+	p.reconnectMu.Lock()
+	p.reconnecting = false
+	p.reconnectStopped = true
+	p.reconnectMu.Unlock()
+	p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateDisconnected)
+}
+
+// resumeConnection carries the session on over a new connection. The pipeline, parser and
+// game database are kept: the parser was reset when the old connection ended, the telnet
+// state is cleared here, and the game detector reloads the game's database when the
+// server's prompts show it again. Returns false if the user disconnected meanwhile.
+func (p *Proxy) resumeConnection(conn net.Conn, pipeline *streaming.Pipeline) bool {
+	p.reconnectMu.Lock()
+	defer p.reconnectMu.Unlock()
+	if p.reconnectStopped {
+		return false
+	}
+	p.reconnecting = false
+
+	pipeline.ResetTelnet()
+	p.setState(NewConnectedState(conn, bufio.NewReader(conn), bufio.NewWriter(conn), pipeline, p.scriptManager, p.gameDetector))
+	pipeline.Start()
+	if p.transport.UsesTelnet() {
+		if err := pipeline.SendTelnetNegotiation(); err != nil {
+			log.Info("Proxy: telnet negotiation failed after reconnecting", "error", err)
+		}
+	}
+
+	// Pick the same game again at the selection prompt
+	p.autoGameSelected.Store(false)
+	if p.db == nil {
+		p.gameDetector.StartPromptDetection()
+	}
+
+	go p.handleOutput()
+	log.Info("Proxy: reconnected", "address", p.currentAddress)
+	p.tuiAPI.OnConnectionStateChanged(api.ConnectionStateConnected)
+	p.tuiAPI.OnConnectionStatusChanged(api.ConnectionStatusConnected, p.currentAddress)
+	return true
+}
@@ -0,0 +1,108 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+	"time"
+	"twist/internal/api"
+)
+
+// connectionStateRecorder passes the connection states the proxy reports to a channel
+type connectionStateRecorder struct {
+	mockTuiAPI
+	states chan api.ConnectionState
+}
+
+func (r *connectionStateRecorder) OnData(data []byte) {}
+func (r *connectionStateRecorder) OnConnectionStateChanged(state api.ConnectionState) {
+	r.states <- state
+}
+
+// waitForState waits for the proxy to report a connection state, failing the test if it doesn't
+func (r *connectionStateRecorder) waitForState(t *testing.T, want api.ConnectionState) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case state := <-r.states:
+			if state == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for the %s state", want)
+		}
+	}
+}
+
+func TestReconnectDelay(t *testing.T) {
+	options := api.ReconnectOptions{Interval: 10 * time.Second}
+	for _, tt := range []struct {
+		attempt int
+		want    time.Duration
+	}{{1, 10 * time.Second}, {2, 20 * time.Second}, {3, 40 * time.Second}, {4, time.Minute}, {30, time.Minute}} {
+		if got := reconnectDelay(options, tt.attempt); got != tt.want {
+			t.Errorf("Attempt %d: expected %v, got %v", tt.attempt, tt.want, got)
+		}
+	}
+
+	if got := reconnectDelay(api.ReconnectOptions{}, 1); got != DefaultReconnectInterval {
+		t.Errorf("Expected the default interval, got %v", got)
+	}
+}
+
+func TestProxyReconnectsAfterDrop(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	recorder := &connectionStateRecorder{states: make(chan api.ConnectionState, 100)}
+	p := New(conn, listener.Addr().String(), recorder, &api.ConnectOptions{
+		GameDetectionTimeout: -1,
+		CheckpointInterval:   -1,
+		Reconnect:            api.ReconnectOptions{MaxAttempts: 3, Interval: 10 * time.Millisecond},
+	})
+	recorder.waitForState(t, api.ConnectionStateConnected)
+	parser := p.GetParser()
+
+	// The server drops the connection
+	(<-accepted).Close()
+	recorder.waitForState(t, api.ConnectionStateReconnecting)
+	recorder.waitForState(t, api.ConnectionStateConnected)
+	second := <-accepted
+	defer second.Close()
+
+	if !p.IsConnected() {
+		t.Fatal("Expected the proxy to be connected again")
+	}
+	if p.GetParser() != parser {
+		t.Error("Expected the reconnected session to keep its parser")
+	}
+
+	// A user disconnect isn't redialed
+	if err := p.Disconnect(); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	select {
+	case conn := <-accepted:
+		conn.Close()
+		t.Error("Expected no reconnect after disconnecting")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -74,6 +74,13 @@ type Engine struct {
 	// ANSI stripper for streaming text processing
 	ansiStripper *ansi.StreamingStripper
 
+	// Execution trace shared by every script's VM
+	tracer *vm.Tracer
+
+	// Breakpoint lines by lowercased script name, applied to scripts as they load
+	breakpointMutex sync.Mutex
+	breakpoints     map[string]map[int]bool
+
 	// Event handlers
 	outputHandler func(string) error
 	echoHandler   func(string) error
@@ -85,6 +92,7 @@ func NewEngine(gameInterface types.GameInterface) *Engine {
 	engine := &Engine{
 		gameInterface: gameInterface,
 		ansiStripper:  ansi.NewStreamingStripper(),
+		tracer:        vm.NewTracer(vm.DefaultTraceFile),
 	}
 	engine.nextScriptID.Store(1)
 
@@ -183,6 +191,8 @@ func (e *Engine) LoadScript(filename string) (*Script, error) {
 		scriptVM.SetOutputHandler(e.outputHandler)
 		scriptVM.SetEchoHandler(e.echoHandler)
 		scriptVM.SetSendHandler(e.sendHandler)
+		scriptVM.SetTracer(e.tracer)
+		e.applyBreakpoints(script.Name, scriptVM)
 		script.VM = scriptVM
 
 		// Copy current scripts and add new one
@@ -225,6 +235,8 @@ func (e *Engine) LoadScriptFromString(content, name string) (*Script, error) {
 		scriptVM.SetOutputHandler(e.outputHandler)
 		scriptVM.SetEchoHandler(e.echoHandler)
 		scriptVM.SetSendHandler(e.sendHandler)
+		scriptVM.SetTracer(e.tracer)
+		e.applyBreakpoints(script.Name, scriptVM)
 		script.VM = scriptVM
 
 		// Copy current scripts and add new one
@@ -577,11 +589,46 @@ func (e *Engine) ProcessTextOut(text string) error {
 	return e.triggerManager.ProcessTextOut(text)
 }
 
-// ProcessEvent processes system events through triggers
+// ProcessEvent fires the event triggers set for the named event in all running scripts.
+// setEventTrigger sets its triggers on the script's own VM, so the engine's manager only
+// holds triggers added to it directly.
 func (e *Engine) ProcessEvent(eventName string) error {
+	for _, script := range e.getScripts() {
+		if script.Running && script.VM != nil {
+			if err := script.VM.ProcessEvent(eventName); err != nil {
+				log.Error("Engine: event trigger failed", "script", script.Name, "event", eventName, "error", err)
+			}
+		}
+	}
 	return e.triggerManager.ProcessEvent(eventName)
 }
 
+// ProcessSectorComplete fires sector triggers in all running scripts for a sector the
+// parser has finished reading
+func (e *Engine) ProcessSectorComplete(sector int) error {
+	for _, script := range e.getScripts() {
+		if script.Running && script.VM != nil {
+			if err := script.VM.ProcessSectorComplete(sector); err != nil {
+				log.Error("Engine: sector trigger failed", "script", script.Name, "sector", sector, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ProcessSectorArrival fires arrival triggers in all running scripts for a sector the
+// player has arrived in
+func (e *Engine) ProcessSectorArrival(sector int) error {
+	for _, script := range e.getScripts() {
+		if script.Running && script.VM != nil {
+			if err := script.VM.ProcessSectorArrival(sector); err != nil {
+				log.Error("Engine: arrival trigger failed", "script", script.Name, "sector", sector, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
 // ActivateTriggers activates script triggers (mirrors Pascal TWXInterpreter.ActivateTriggers)
 func (e *Engine) ActivateTriggers() error {
 	// In Pascal TWX, ActivateTriggers processes delay triggers and reactivates disabled triggers
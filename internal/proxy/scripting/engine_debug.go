@@ -0,0 +1,169 @@
+package scripting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"twist/internal/proxy/scripting/types"
+	"twist/internal/proxy/scripting/vm"
+)
+
+// The debugger holds one running script at a time so it can be stepped a statement at a
+// time from the script menu; see vm/debug.go for what holding means for waitfors and
+// triggers. Breakpoints hold a script when it reaches a line, and are kept by script name
+// so they also apply to the script the next time it's loaded. The execution
+// trace logs every statement any script runs to a file.
+
+// PauseScript holds a running script in the debugger
+func (e *Engine) PauseScript(scriptID string) error {
+	script, err := e.GetScript(scriptID)
+	if err != nil {
+		return err
+	}
+	if !script.Running {
+		return fmt.Errorf("script %s is not running", script.Name)
+	}
+	if paused := e.GetPausedScript(); paused != nil {
+		return fmt.Errorf("script %s is already paused", paused.Name)
+	}
+	return script.VM.DebugPause()
+}
+
+// GetPausedScript returns the script held in the debugger, or nil if there isn't one
+func (e *Engine) GetPausedScript() *Script {
+	for _, script := range e.getScripts() {
+		if script.Running && script.VM != nil && script.VM.IsDebugPaused() {
+			return script
+		}
+	}
+	return nil
+}
+
+// ResumeScript releases the script held in the debugger, which runs on until it next waits
+func (e *Engine) ResumeScript() error {
+	script := e.GetPausedScript()
+	if script == nil {
+		return fmt.Errorf("no script is paused")
+	}
+	err := script.VM.DebugResume()
+	e.markScriptStopped(script)
+	return err
+}
+
+// Step runs the next statement of the script held in the debugger
+func (e *Engine) Step() (types.StepResult, error) {
+	script := e.GetPausedScript()
+	if script == nil {
+		return types.StepResult{}, fmt.Errorf("no script is paused")
+	}
+	result, err := script.VM.Step()
+	e.markScriptStopped(script)
+	return result, err
+}
+
+// SetBreakpoint holds the named script when it reaches a source line, in running scripts
+// with that name and in ones loaded later
+func (e *Engine) SetBreakpoint(scriptName string, line int) error {
+	return e.updateBreakpoint(scriptName, line, true)
+}
+
+// ClearBreakpoint removes a breakpoint set with SetBreakpoint
+func (e *Engine) ClearBreakpoint(scriptName string, line int) error {
+	return e.updateBreakpoint(scriptName, line, false)
+}
+
+// GetBreakpoints returns the breakpoint lines, in order, by script name
+func (e *Engine) GetBreakpoints() map[string][]int {
+	e.breakpointMutex.Lock()
+	defer e.breakpointMutex.Unlock()
+
+	breakpoints := make(map[string][]int, len(e.breakpoints))
+	for name, lines := range e.breakpoints {
+		for line := range lines {
+			breakpoints[name] = append(breakpoints[name], line)
+		}
+		sort.Ints(breakpoints[name])
+	}
+	return breakpoints
+}
+
+// updateBreakpoint records a breakpoint change and applies it to the loaded scripts
+func (e *Engine) updateBreakpoint(scriptName string, line int, enabled bool) error {
+	name := strings.ToLower(strings.TrimSpace(scriptName))
+	if name == "" {
+		return fmt.Errorf("no script name given")
+	}
+	if line < 1 {
+		return fmt.Errorf("line %d is not a script line", line)
+	}
+
+	e.breakpointMutex.Lock()
+	if e.breakpoints == nil {
+		e.breakpoints = make(map[string]map[int]bool)
+	}
+	if enabled {
+		if e.breakpoints[name] == nil {
+			e.breakpoints[name] = make(map[int]bool)
+		}
+		e.breakpoints[name][line] = true
+	} else {
+		delete(e.breakpoints[name], line)
+		if len(e.breakpoints[name]) == 0 {
+			delete(e.breakpoints, name)
+		}
+	}
+	e.breakpointMutex.Unlock()
+
+	for _, script := range e.getScripts() {
+		if script.VM != nil && strings.ToLower(script.Name) == name {
+			script.VM.SetBreakpoint(line, enabled)
+		}
+	}
+	return nil
+}
+
+// applyBreakpoints sets the breakpoints recorded for a script's name on its new VM
+func (e *Engine) applyBreakpoints(scriptName string, scriptVM *vm.VirtualMachine) {
+	e.breakpointMutex.Lock()
+	defer e.breakpointMutex.Unlock()
+
+	for line := range e.breakpoints[strings.ToLower(scriptName)] {
+		scriptVM.SetBreakpoint(line, true)
+	}
+}
+
+// markScriptStopped marks a script that has ended or failed as no longer running
+func (e *Engine) markScriptStopped(script *Script) {
+	state := script.VM.GetState()
+	if !state.IsHalted() && !state.HasError() {
+		return
+	}
+	e.updateScripts(func(currentScripts map[string]*Script) map[string]*Script {
+		newScripts := make(map[string]*Script, len(currentScripts))
+		for k, v := range currentScripts {
+			newScripts[k] = v
+		}
+		if _, exists := newScripts[script.ID]; exists {
+			newScripts[script.ID].Running = false
+		}
+		return newScripts
+	})
+}
+
+// SetTrace turns the execution trace of every script on or off
+func (e *Engine) SetTrace(enabled bool) error {
+	if enabled {
+		return e.tracer.Start()
+	}
+	return e.tracer.Stop()
+}
+
+// IsTracing returns true while the execution trace is on
+func (e *Engine) IsTracing() bool {
+	return e.tracer.IsEnabled()
+}
+
+// GetTraceFile returns the file the execution trace is written to
+func (e *Engine) GetTraceFile() string {
+	return e.tracer.Path()
+}
@@ -92,6 +92,8 @@ func (g *GameAdapter) GetSector(index int) (types.SectorData, error) {
 		Ships:         make([]types.ShipData, 0),
 		Traders:       make([]types.TraderData, 0),
 		Planets:       make([]types.PlanetData, 0),
+		AlienCount:    sector.AlienCount,
+		AlienType:     sector.AlienType,
 	}
 
 	// Load port data from separate ports table
@@ -156,14 +158,19 @@ func (g *GameAdapter) GetSectorParameter(sector int, name string) (string, error
 
 // GetCourse implements GameInterface
 func (g *GameAdapter) GetCourse(from, to int) ([]int, error) {
-	// TODO: Implement course calculation
-	return []int{from, to}, nil
+	if g.db == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return g.db.GetCourse(from, to)
 }
 
 // GetDistance implements GameInterface
 func (g *GameAdapter) GetDistance(from, to int) (int, error) {
-	// TODO: Implement distance calculation
-	return 1, nil
+	course, err := g.GetCourse(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return len(course) - 1, nil
 }
 
 // GetAllCourses implements GameInterface
@@ -435,6 +442,51 @@ func (sm *ScriptManager) ResumeScriptWithInput(scriptID, input string) error {
 	return sm.engine.ResumeScriptWithInput(scriptID, input)
 }
 
+// PauseScript holds a running script in the debugger so it can be stepped
+func (sm *ScriptManager) PauseScript(scriptID string) error {
+	return sm.engine.PauseScript(scriptID)
+}
+
+// ResumeScript releases the script held in the debugger
+func (sm *ScriptManager) ResumeScript() error {
+	return sm.engine.ResumeScript()
+}
+
+// SetBreakpoint holds the named script when it reaches a source line
+func (sm *ScriptManager) SetBreakpoint(scriptName string, line int) error {
+	return sm.engine.SetBreakpoint(scriptName, line)
+}
+
+// ClearBreakpoint removes a breakpoint from the named script
+func (sm *ScriptManager) ClearBreakpoint(scriptName string, line int) error {
+	return sm.engine.ClearBreakpoint(scriptName, line)
+}
+
+// GetBreakpoints returns the breakpoint lines by script name
+func (sm *ScriptManager) GetBreakpoints() map[string][]int {
+	return sm.engine.GetBreakpoints()
+}
+
+// StepScript runs the next statement of the script held in the debugger
+func (sm *ScriptManager) StepScript() (types.StepResult, error) {
+	return sm.engine.Step()
+}
+
+// SetTrace turns the script execution trace on or off
+func (sm *ScriptManager) SetTrace(enabled bool) error {
+	return sm.engine.SetTrace(enabled)
+}
+
+// IsTracing returns true while the script execution trace is on
+func (sm *ScriptManager) IsTracing() bool {
+	return sm.engine.IsTracing()
+}
+
+// GetTraceFile returns the file the script execution trace is written to
+func (sm *ScriptManager) GetTraceFile() string {
+	return sm.engine.GetTraceFile()
+}
+
 // LoadAndRunScript loads and runs a script file
 func (sm *ScriptManager) LoadAndRunScript(filename string) error {
 	script, err := sm.engine.LoadScript(filename)
@@ -203,7 +203,7 @@ func (sm *ScriptManager) RestoreFromDatabase() error {
 	FROM scripts
 	ORDER BY loaded_at;`
 
-	rows, err := sm.db.GetDB().Query(query)
+	rows, err := sm.db.GetExecutor().Query(query)
 	if err != nil {
 		return fmt.Errorf("failed to query scripts: %w", err)
 	}
@@ -274,7 +274,7 @@ func (sm *ScriptManager) saveScriptToDB(script *ScriptInfo) error {
 	                    loaded_at, include_scripts, description)
 	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
-	_, err := sm.db.GetDB().Exec(query,
+	_, err := sm.db.GetExecutor().Exec(query,
 		script.ID, script.Name, script.Filename, script.Version,
 		script.Running, script.System, script.LoadedAt.Format("2006-01-02 15:04:05"),
 		string(includeScriptsJSON), script.Description,
@@ -299,7 +299,7 @@ func (sm *ScriptManager) updateScriptInDB(script *ScriptInfo) error {
 	    stopped_at = ?, include_scripts = ?, description = ?
 	WHERE script_id = ?;`
 
-	_, err := sm.db.GetDB().Exec(query,
+	_, err := sm.db.GetExecutor().Exec(query,
 		script.Name, script.Filename, script.Version, script.Running, script.System,
 		stoppedAtStr, string(includeScriptsJSON), script.Description, script.ID,
 	)
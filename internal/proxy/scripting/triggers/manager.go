@@ -247,6 +247,38 @@ func (m *Manager) ProcessDelayTriggers() error {
 	return nil
 }
 
+// ProcessSectorComplete fires sector triggers for a sector the parser has finished reading.
+// Sector triggers are permanent until killed.
+func (m *Manager) ProcessSectorComplete(sector int) error {
+	return m.processSectorTriggers(types.TriggerSector, sector)
+}
+
+// ProcessSectorArrival fires arrival triggers for a sector the player has arrived in.
+// Arrival triggers are permanent until killed.
+func (m *Manager) ProcessSectorArrival(sector int) error {
+	return m.processSectorTriggers(types.TriggerArrival, sector)
+}
+
+// processSectorTriggers fires the active sector triggers of triggerType for sector
+func (m *Manager) processSectorTriggers(triggerType types.TriggerType, sector int) error {
+	m.mutex.RLock()
+	triggers := make([]*types.SectorTrigger, 0)
+	for _, trigger := range m.triggers {
+		if sectorTrigger, ok := trigger.(*types.SectorTrigger); ok && sectorTrigger.IsActive() && sectorTrigger.GetType() == triggerType {
+			triggers = append(triggers, sectorTrigger)
+		}
+	}
+	m.mutex.RUnlock()
+
+	for _, trigger := range triggers {
+		if err := trigger.ExecuteForSector(m.vm, sector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // SetTextTrigger creates a text trigger
 func (m *Manager) SetTextTrigger(pattern, response, label string) (string, error) {
 	id := m.generateID()
@@ -148,6 +148,8 @@ type SectorData struct {
 	Ships         []ShipData
 	Traders       []TraderData
 	Planets       []PlanetData
+	AlienCount    int
+	AlienType     string
 }
 
 // ShipData represents ship information
@@ -0,0 +1,8 @@
+package types
+
+// StepResult describes a script after the debugger steps it
+type StepResult struct {
+	Line    int               // Source line of the next statement, 0 once the script has ended
+	Status  string            // What the script does next, e.g. "ready" or "waiting for \"Command\""
+	Changed map[string]string // Variables the step changed, by name
+}
@@ -17,6 +17,8 @@ const (
 	TriggerEvent
 	TriggerAuto
 	TriggerAutoText
+	TriggerSector
+	TriggerArrival
 )
 
 // TriggerInterface defines the interface for all triggers
@@ -294,6 +296,43 @@ func (t *EventTrigger) Execute(vm VMInterface) error {
 	return nil
 }
 
+// SectorTrigger fires each time the parser finishes reading a sector, whether from a
+// sector display, a holo scan or a probe. With the TriggerArrival type it fires only when
+// the player arrives in a sector instead.
+type SectorTrigger struct {
+	BaseTrigger
+	SectorVar string // Variable set to the completed sector's number, if not empty
+}
+
+// Matches returns true while the trigger is active - every completed or arrived sector matches
+func (t *SectorTrigger) Matches(input string) bool {
+	return t.Active
+}
+
+// Execute jumps to the trigger's label
+func (t *SectorTrigger) Execute(vm VMInterface) error {
+	scriptName := "unknown"
+	if script := vm.GetCurrentScript(); script != nil {
+		scriptName = script.GetName()
+	}
+
+	log.Info("SECTOR TRIGGER FIRED", "script", scriptName, "currentLine", vm.GetCurrentLine(), "triggerId", t.ID, "label", t.Label)
+
+	if t.Label != "" {
+		return vm.GotoAndExecuteSync(t.Label)
+	}
+	return nil
+}
+
+// ExecuteForSector sets the trigger's sector variable to the completed or arrived sector,
+// then executes the trigger
+func (t *SectorTrigger) ExecuteForSector(vm VMInterface, sector int) error {
+	if t.SectorVar != "" {
+		vm.SetVariable(t.SectorVar, &Value{Type: NumberType, Number: float64(sector)})
+	}
+	return t.Execute(vm)
+}
+
 // AutoTrigger handles automatic response triggers
 type AutoTrigger struct {
 	BaseTrigger
@@ -360,6 +399,9 @@ type TriggerManagerInterface interface {
 	ProcessTextLine(line string) (bool, error)
 	ProcessTextOut(text string) error
 	ProcessDelayTriggers() error
+	ProcessSectorComplete(sector int) error
+	ProcessSectorArrival(sector int) error
+	ProcessEvent(eventName string) error
 
 	// Trigger queries
 	HasTriggers() bool
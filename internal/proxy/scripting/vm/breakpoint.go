@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"twist/internal/log"
+)
+
+// breakpoint.go - Holding a script in the debugger when it reaches a source line
+
+// A script reaching a breakpoint is held just as DebugPause holds it, before the statement
+// on that line runs, so it can be stepped, its variables dumped, and resumed. Resuming runs
+// the statement at the breakpoint before checking for breakpoints again, so the script
+// doesn't stop at the same one twice in a row.
+
+// SetBreakpoint adds or removes a breakpoint on a source line
+func (vm *VirtualMachine) SetBreakpoint(line int, enabled bool) {
+	vm.debug.breakMutex.Lock()
+	defer vm.debug.breakMutex.Unlock()
+
+	if vm.debug.breakpoints == nil {
+		vm.debug.breakpoints = make(map[int]bool)
+	}
+	if enabled {
+		vm.debug.breakpoints[line] = true
+	} else {
+		delete(vm.debug.breakpoints, line)
+	}
+}
+
+// Breakpoints returns the lines the script stops at, in order
+func (vm *VirtualMachine) Breakpoints() []int {
+	vm.debug.breakMutex.Lock()
+	defer vm.debug.breakMutex.Unlock()
+
+	lines := make([]int, 0, len(vm.debug.breakpoints))
+	for line := range vm.debug.breakpoints {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// hitBreakpoint holds the script if its next statement is on a breakpoint line, reporting
+// where it stopped. Returns true if the script is now held.
+func (vm *VirtualMachine) hitBreakpoint() bool {
+	line := vm.nextLine()
+	if line == 0 {
+		return false
+	}
+
+	vm.debug.breakMutex.Lock()
+	hit := vm.debug.breakpoints[line]
+	vm.debug.breakMutex.Unlock()
+	if !hit {
+		return false
+	}
+
+	vm.debug.held.Store(true)
+	log.Info("VM: breakpoint hit", "script", vm.scriptName(), "line", line)
+	if vm.outputHandler != nil {
+		vm.outputHandler(fmt.Sprintf("Breakpoint hit in %s at line %d - script paused, use S to step it and R to resume it", vm.scriptName(), line))
+	}
+	return true
+}
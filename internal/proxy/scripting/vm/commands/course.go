@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"twist/internal/log"
+	"twist/internal/proxy/scripting/types"
+)
+
+// RegisterCourseCommands registers the commands that plot courses through the known warps
+// in the game database. Avoided sectors are never part of a course.
+func RegisterCourseCommands(vm CommandRegistry) {
+	vm.RegisterCommand("GETCOURSE", 3, 5, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamVar, types.ParamVar, types.ParamVar}, cmdGetCourse)
+}
+
+// cmdGetCourse sets the hops variable to the number of warps from one sector to another
+// and, if given, the array variable's elements to the sectors along the way, starting with
+// the first sector and ending with the second. If there is no known path the hops are -1
+// and the error variable, if given, says why; otherwise the error variable is set to "".
+// Syntax: getCourse <from> <to> <hops_var> [array_var] [error_var]
+// Example: getCourse CURRENTSECTOR 1 $hops $course $err  (then $course[2] is the first warp)
+func cmdGetCourse(vm types.VMInterface, params []*types.CommandParam) error {
+	if len(params) < 3 {
+		return vm.Error("GETCOURSE requires at least 3 parameters: from, to, hops_var")
+	}
+
+	gameInterface := vm.GetGameInterface()
+	if gameInterface == nil {
+		return vm.Error("Game interface not available")
+	}
+
+	from := int(GetParamNumber(vm, params[0]))
+	to := int(GetParamNumber(vm, params[1]))
+
+	problem := ""
+	course, err := gameInterface.GetCourse(from, to)
+	if err == nil && len(course) == 0 {
+		err = fmt.Errorf("no path")
+	}
+	if err != nil {
+		log.Debug("GETCOURSE: no course", "from", from, "to", to, "error", err)
+		problem = err.Error()
+		course = nil
+	}
+
+	vm.SetVariable(params[2].VarName, &types.Value{Type: types.NumberType, Number: float64(len(course) - 1)})
+	if len(params) > 3 {
+		for i, sector := range course {
+			vm.SetVariable(fmt.Sprintf("%s[%d]", params[3].VarName, i+1), &types.Value{Type: types.NumberType, Number: float64(sector)})
+		}
+	}
+	if len(params) > 4 {
+		vm.SetVariable(params[4].VarName, &types.Value{Type: types.StringType, String: problem})
+	}
+	return nil
+}
@@ -31,6 +31,8 @@ func RegisterGameCommands(vm CommandRegistry) {
 
 	// Game data commands - TWX compatibility
 	vm.RegisterCommand("GETSECTOR", 2, 2, []types.ParameterType{types.ParamValue, types.ParamVar}, cmdGetSector)
+	RegisterSectorQueryCommands(vm)
+	RegisterCourseCommands(vm)
 }
 
 func cmdSend(vm types.VMInterface, params []*types.CommandParam) error {
@@ -412,18 +414,7 @@ func setSectorVariables(vm types.VMInterface, varName string, index int, sector
 	}
 
 	// Set exploration status
-	switch sector.Explored {
-	case 0: // etNo
-		vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: "NO"})
-	case 1: // etCalc
-		vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: "CALC"})
-	case 2: // etDensity
-		vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: "DENSITY"})
-	case 3: // etHolo
-		vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: "YES"})
-	default:
-		vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: "NO"})
-	}
+	vm.SetVariable(varName+".EXPLORED", &types.Value{Type: types.StringType, String: exploredName(sector.Explored)})
 
 	// Basic sector properties
 	vm.SetVariable(varName+".BEACON", &types.Value{Type: types.StringType, String: sector.Beacon})
@@ -462,6 +453,10 @@ func setSectorVariables(vm types.VMInterface, varName string, index int, sector
 	vm.SetVariable(varName+".TRADERS", &types.Value{Type: types.NumberType, Number: 0})
 	vm.SetVariable(varName+".SHIPS", &types.Value{Type: types.NumberType, Number: 0})
 	vm.SetVariable(varName+".PLANETS", &types.Value{Type: types.NumberType, Number: 0})
+
+	// Alien occupants (Twist extension) so routes can avoid Ferrengi
+	vm.SetVariable(varName+".ALIENS", &types.Value{Type: types.NumberType, Number: float64(sector.AlienCount)})
+	vm.SetVariable(varName+".ALIENTYPE", &types.Value{Type: types.StringType, String: sector.AlienType})
 }
 
 // setPortVariables sets port variables exactly like Pascal TWX
@@ -514,6 +509,8 @@ func setDefaultSectorValues(vm types.VMInterface, varName string) {
 	vm.SetVariable(varName+".WARPS", &types.Value{Type: types.NumberType, Number: 0})
 	vm.SetVariable(varName+".DENSITY", &types.Value{Type: types.NumberType, Number: -1})
 	vm.SetVariable(varName+".NAVHAZ", &types.Value{Type: types.NumberType, Number: 0})
+	vm.SetVariable(varName+".ALIENS", &types.Value{Type: types.NumberType, Number: 0})
+	vm.SetVariable(varName+".ALIENTYPE", &types.Value{Type: types.StringType, String: ""})
 
 	// Default warp array
 	for i := 1; i <= 6; i++ {
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"twist/internal/log"
+	"twist/internal/proxy/scripting/types"
+)
+
+// Sector query commands read single facts about a sector from the game database, for
+// scripts that don't need everything getSector sets. Sectors missing from the database
+// give the same defaults getSector uses: no warps, no port (class 0), density -1 and not
+// explored.
+
+// RegisterSectorQueryCommands registers the sector query commands
+func RegisterSectorQueryCommands(vm CommandRegistry) {
+	vm.RegisterCommand("GETSECTORWARPS", 2, 3, []types.ParameterType{types.ParamValue, types.ParamVar, types.ParamVar}, cmdGetSectorWarps)
+	vm.RegisterCommand("GETSECTORPORT", 2, 3, []types.ParameterType{types.ParamValue, types.ParamVar, types.ParamVar}, cmdGetSectorPort)
+	vm.RegisterCommand("GETSECTORDENSITY", 2, 2, []types.ParameterType{types.ParamValue, types.ParamVar}, cmdGetSectorDensity)
+	vm.RegisterCommand("ISSECTOREXPLORED", 2, 3, []types.ParameterType{types.ParamValue, types.ParamVar, types.ParamVar}, cmdIsSectorExplored)
+}
+
+// cmdGetSectorWarps sets the count variable to the number of warps out of the sector and,
+// if given, the array variable's elements to the warps themselves
+// Syntax: getSectorWarps <index> <count_var> [array_var]
+// Example: getSectorWarps 123 $count $warps  (then $warps[1] .. $warps[$count])
+func cmdGetSectorWarps(vm types.VMInterface, params []*types.CommandParam) error {
+	sector, found, err := querySector(vm, "GETSECTORWARPS", params)
+	if err != nil {
+		return err
+	}
+
+	var warps []int
+	if found {
+		warps = sector.Warps
+	}
+	vm.SetVariable(params[1].VarName, &types.Value{Type: types.NumberType, Number: float64(len(warps))})
+	if len(params) > 2 {
+		for i, warp := range warps {
+			vm.SetVariable(fmt.Sprintf("%s[%d]", params[2].VarName, i+1), &types.Value{Type: types.NumberType, Number: float64(warp)})
+		}
+	}
+	return nil
+}
+
+// cmdGetSectorPort sets the class variable to the class of the sector's port, 0 if it has
+// none, and the name variable, if given, to the port's name
+// Syntax: getSectorPort <index> <class_var> [name_var]
+// Example: getSectorPort 1234 $class  (then if ($class = 1) ...)
+func cmdGetSectorPort(vm types.VMInterface, params []*types.CommandParam) error {
+	sector, found, err := querySector(vm, "GETSECTORPORT", params)
+	if err != nil {
+		return err
+	}
+
+	class, name := 0, ""
+	if found && sector.HasPort {
+		class, name = sector.PortClass, sector.PortName
+	}
+	vm.SetVariable(params[1].VarName, &types.Value{Type: types.NumberType, Number: float64(class)})
+	if len(params) > 2 {
+		vm.SetVariable(params[2].VarName, &types.Value{Type: types.StringType, String: name})
+	}
+	return nil
+}
+
+// cmdGetSectorDensity sets the variable to the sector's density, -1 if it isn't known
+// Syntax: getSectorDensity <index> <var>
+func cmdGetSectorDensity(vm types.VMInterface, params []*types.CommandParam) error {
+	sector, found, err := querySector(vm, "GETSECTORDENSITY", params)
+	if err != nil {
+		return err
+	}
+
+	density := -1
+	if found {
+		density = sector.Density
+	}
+	vm.SetVariable(params[1].VarName, &types.Value{Type: types.NumberType, Number: float64(density)})
+	return nil
+}
+
+// cmdIsSectorExplored sets the variable to 1 if the sector has been visited or holo scanned,
+// so its contents are known, and 0 otherwise. The level variable, if given, is set to how
+// much is known, as getSector's EXPLORED: NO, CALC (warps only), DENSITY or YES.
+// Syntax: isSectorExplored <index> <var> [level_var]
+// Example: isSectorExplored 1234 $explored  (then if ($explored = 0) ...)
+func cmdIsSectorExplored(vm types.VMInterface, params []*types.CommandParam) error {
+	sector, found, err := querySector(vm, "ISSECTOREXPLORED", params)
+	if err != nil {
+		return err
+	}
+
+	level := exploredName(0)
+	if found {
+		level = exploredName(sector.Explored)
+	}
+	explored := 0
+	if level == "YES" {
+		explored = 1
+	}
+	vm.SetVariable(params[1].VarName, &types.Value{Type: types.NumberType, Number: float64(explored)})
+	if len(params) > 2 {
+		vm.SetVariable(params[2].VarName, &types.Value{Type: types.StringType, String: level})
+	}
+	return nil
+}
+
+// exploredName returns TWX's name for a sector's exploration status
+func exploredName(explored int) string {
+	switch explored {
+	case 1: // etCalc
+		return "CALC"
+	case 2: // etDensity
+		return "DENSITY"
+	case 3: // etHolo
+		return "YES"
+	default: // etNo
+		return "NO"
+	}
+}
+
+// querySector loads the sector named by the first parameter. found is false if the sector
+// isn't in the database.
+func querySector(vm types.VMInterface, command string, params []*types.CommandParam) (sector types.SectorData, found bool, err error) {
+	if len(params) < 2 {
+		return sector, false, vm.Error(command + " requires at least 2 parameters: sector_index, result_var")
+	}
+
+	gameInterface := vm.GetGameInterface()
+	if gameInterface == nil {
+		return sector, false, vm.Error("Game interface not available")
+	}
+
+	sectorIndex := int(GetParamNumber(vm, params[0]))
+	if sectorIndex < 1 {
+		return sector, false, nil
+	}
+
+	sector, err = gameInterface.GetSector(sectorIndex)
+	if err != nil {
+		log.Debug("Sector query: sector not found", "command", command, "sector", sectorIndex, "error", err)
+		return sector, false, nil
+	}
+	return sector, true, nil
+}
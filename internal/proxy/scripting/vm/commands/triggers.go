@@ -20,6 +20,12 @@ func RegisterTriggerCommands(vm CommandRegistry) {
 	vm.RegisterCommand("SETTEXTOUTTRIGGER", 3, 3, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamValue}, cmdSetTextOutTrigger)
 	vm.RegisterCommand("SETDELAYTRIGGER", 3, 3, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamValue}, cmdSetDelayTrigger)
 	vm.RegisterCommand("SETEVENTTRIGGER", 3, 3, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamValue}, cmdSetEventTrigger)
+
+	// Twist extension: fire on each sector the parser completes
+	vm.RegisterCommand("SETSECTORTRIGGER", 2, 3, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamVar}, cmdSetSectorTrigger)
+
+	// Twist extension: fire when the player arrives in a sector
+	vm.RegisterCommand("SETARRIVALTRIGGER", 2, 3, []types.ParameterType{types.ParamValue, types.ParamValue, types.ParamVar}, cmdSetArrivalTrigger)
 }
 
 // cmdSetTextLineTrigger implements the setTextLineTrigger command
@@ -237,3 +243,75 @@ func cmdSetEventTrigger(vm types.VMInterface, params []*types.CommandParam) erro
 
 	return vm.SetTrigger(trigger)
 }
+
+// cmdSetSectorTrigger implements the setSectorTrigger command. The trigger jumps to its label
+// each time the parser finishes reading a sector, setting the optional variable to the
+// sector's number first. It stays set until killed.
+// Syntax: setSectorTrigger <id> <label> [sector_var]
+// Example: setSectorTrigger 1 :newSector $sector
+func cmdSetSectorTrigger(vm types.VMInterface, params []*types.CommandParam) error {
+	if len(params) < 2 {
+		return vm.Error("SETSECTORTRIGGER requires at least 2 parameters: id, label")
+	}
+
+	id := GetParamString(vm, params[0])
+	label := GetParamString(vm, params[1])
+	if id == "" {
+		return vm.Error("SETSECTORTRIGGER requires a non-empty trigger ID")
+	}
+	if label == "" {
+		return vm.Error("SETSECTORTRIGGER requires a non-empty label")
+	}
+
+	trigger := &types.SectorTrigger{
+		BaseTrigger: types.BaseTrigger{
+			ID:        id,
+			Type:      types.TriggerSector,
+			Label:     label,
+			Active:    true,
+			LifeCycle: -1,
+		},
+	}
+	if len(params) > 2 {
+		trigger.SectorVar = params[2].VarName
+	}
+
+	return vm.SetTrigger(trigger)
+}
+
+// cmdSetArrivalTrigger implements the setArrivalTrigger command. The trigger jumps to its
+// label each time the player arrives in a sector, setting the optional variable to the
+// sector's number first. Unlike a sector trigger it doesn't fire for sectors a probe
+// reports, nor again when the sector the player is in is displayed again. It stays set
+// until killed.
+// Syntax: setArrivalTrigger <id> <label> [sector_var]
+// Example: setArrivalTrigger 1 :arrived $sector
+func cmdSetArrivalTrigger(vm types.VMInterface, params []*types.CommandParam) error {
+	if len(params) < 2 {
+		return vm.Error("SETARRIVALTRIGGER requires at least 2 parameters: id, label")
+	}
+
+	id := GetParamString(vm, params[0])
+	label := GetParamString(vm, params[1])
+	if id == "" {
+		return vm.Error("SETARRIVALTRIGGER requires a non-empty trigger ID")
+	}
+	if label == "" {
+		return vm.Error("SETARRIVALTRIGGER requires a non-empty label")
+	}
+
+	trigger := &types.SectorTrigger{
+		BaseTrigger: types.BaseTrigger{
+			ID:        id,
+			Type:      types.TriggerArrival,
+			Label:     label,
+			Active:    true,
+			LifeCycle: -1,
+		},
+	}
+	if len(params) > 2 {
+		trigger.SectorVar = params[2].VarName
+	}
+
+	return vm.SetTrigger(trigger)
+}
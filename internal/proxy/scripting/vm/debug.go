@@ -0,0 +1,182 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"twist/internal/log"
+	"twist/internal/proxy/scripting/parser"
+	"twist/internal/proxy/scripting/types"
+)
+
+// debug.go - Holding a script in the debugger and stepping it one statement at a time
+
+// A held script only runs when stepped. Text that satisfies its waitfor still clears the
+// wait, and a trigger that fires still moves it to the trigger's label, but nothing runs
+// until the next step, so the parser goroutine never waits on the user. Steps come from
+// the menu rather than the parser, so stepping, resuming and the parser's text and sector
+// processing are serialised by the debug mutex.
+
+// debugState is the debugger's hold on a script
+type debugState struct {
+	mutex sync.Mutex
+	held  atomic.Bool
+
+	// Where to continue once a trigger handler entered while held returns
+	triggerReturn *triggerReturn
+
+	// Source lines the script is held at, see breakpoint.go. Set from the menu while the
+	// script runs, so they have their own lock.
+	breakMutex  sync.Mutex
+	breakpoints map[int]bool
+}
+
+// triggerReturn is the position and state a trigger interrupted
+type triggerReturn struct {
+	position int
+	running  bool
+}
+
+// DebugPause holds the script before its next statement
+func (vm *VirtualMachine) DebugPause() error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	if vm.state.IsHalted() || vm.state.HasError() {
+		return fmt.Errorf("script is not running")
+	}
+	vm.debug.held.Store(true)
+	log.Info("VM.DebugPause: script held by the debugger", "script", vm.scriptName(), "line", vm.nextLine())
+	return nil
+}
+
+// IsDebugPaused returns true if the debugger is holding the script
+func (vm *VirtualMachine) IsDebugPaused() bool {
+	return vm.debug.held.Load()
+}
+
+// DebugResume releases the script from the debugger and runs it until it next waits, or
+// until it reaches a breakpoint, where it is held again
+func (vm *VirtualMachine) DebugResume() error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	if !vm.debug.held.Load() {
+		return fmt.Errorf("script is not paused")
+	}
+	log.Info("VM.DebugResume: script released by the debugger", "script", vm.scriptName(), "line", vm.nextLine())
+
+	// Run while still held, so a trigger handler entered by a step returns to the
+	// position the trigger interrupted
+	for first := true; vm.state.IsRunning() && !vm.state.IsWaiting(); first = false {
+		if !first && vm.hitBreakpoint() {
+			return nil
+		}
+		if err := vm.debugStep(); err != nil {
+			vm.debug.held.Store(false)
+			return err
+		}
+	}
+	vm.debug.held.Store(false)
+	return nil
+}
+
+// Step runs the held script's next statement, or its whole if or while block, and reports
+// where it stopped. A script waiting for text or paused until a trigger fires doesn't move;
+// the step only reports what it is waiting for.
+func (vm *VirtualMachine) Step() (types.StepResult, error) {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	if !vm.debug.held.Load() {
+		return types.StepResult{}, fmt.Errorf("script is not paused")
+	}
+	if !vm.state.IsRunning() || vm.state.IsWaiting() {
+		return vm.stepResult(nil), nil
+	}
+
+	before := vm.variableValues()
+	err := vm.debugStep()
+
+	changed := make(map[string]string)
+	for name, value := range vm.variableValues() {
+		if old, exists := before[name]; !exists || old != value {
+			changed[name] = value
+		}
+	}
+	return vm.stepResult(changed), err
+}
+
+// debugStep runs the statement at the current position. A trigger handler entered while
+// held that ends with a return continues where the trigger interrupted the script, as
+// GotoAndExecuteSync does.
+func (vm *VirtualMachine) debugStep() error {
+	err := vm.execution.ExecuteStep()
+	if err != nil && vm.debug.triggerReturn != nil && strings.Contains(err.Error(), "Return without gosub") {
+		vm.state.Position = vm.debug.triggerReturn.position
+		if vm.debug.triggerReturn.running {
+			vm.state.SetRunning()
+		} else {
+			vm.state.SetPaused()
+		}
+		vm.debug.triggerReturn = nil
+		return nil
+	}
+	if err != nil {
+		vm.lastError = err
+	}
+	return err
+}
+
+// stepResult describes where the script stopped
+func (vm *VirtualMachine) stepResult(changed map[string]string) types.StepResult {
+	result := types.StepResult{Line: vm.nextLine(), Changed: changed}
+	switch {
+	case vm.state.HasError():
+		result.Status = "stopped: " + vm.state.Error
+	case vm.state.IsHalted():
+		result.Line = 0
+		result.Status = "ended"
+	case vm.state.IsWaiting():
+		result.Status = fmt.Sprintf("waiting for %q", vm.state.WaitText)
+	case vm.waitingForInput:
+		result.Status = "waiting for input"
+	case vm.state.IsPaused():
+		result.Status = "paused until a trigger fires"
+	default:
+		result.Status = "ready"
+	}
+	return result
+}
+
+// nextLine returns the source line of the next statement to run, 0 at the end of the script
+func (vm *VirtualMachine) nextLine() int {
+	ast := vm.execution.GetAST()
+	if ast == nil {
+		return 0
+	}
+	for position := vm.state.Position; position < len(ast.Children); position++ {
+		if ast.Children[position].Type != parser.NodeLabel {
+			return ast.Children[position].Line
+		}
+	}
+	return 0
+}
+
+// variableValues returns every variable's value as a string
+func (vm *VirtualMachine) variableValues() map[string]string {
+	values := make(map[string]string)
+	for name, value := range vm.GetAllVariables() {
+		values[name] = value.ToString()
+	}
+	return values
+}
+
+// scriptName returns the loaded script's name for logging
+func (vm *VirtualMachine) scriptName() string {
+	if vm.script != nil {
+		return vm.script.GetName()
+	}
+	return "unknown"
+}
@@ -0,0 +1,192 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+	"twist/internal/proxy/scripting/parser"
+)
+
+// loadDebugScript parses the source and loads it into a new VM without running it
+func loadDebugScript(t *testing.T, source string) *VirtualMachine {
+	t.Helper()
+
+	lines := strings.Split(source, "\n")
+	preprocessor := parser.NewPreprocessor()
+	processedLines, err := preprocessor.ProcessScript(lines)
+	if err != nil {
+		t.Fatalf("Preprocessing failed: %v", err)
+	}
+	lexer := parser.NewLexer(strings.NewReader(strings.Join(processedLines, "\n")), preprocessor.GetLineMappings())
+	tokens, err := lexer.TokenizeAll()
+	if err != nil {
+		t.Fatalf("Tokenization failed: %v", err)
+	}
+	ast, err := parser.NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parsing failed: %v", err)
+	}
+
+	vm := NewVirtualMachine(&MockGameInterface{})
+	if err := vm.LoadScript(ast, nil); err != nil {
+		t.Fatalf("Failed to load script: %v", err)
+	}
+	return vm
+}
+
+func TestStepAcrossWaitFor(t *testing.T) {
+	vm := loadDebugScript(t, `setVar $a 1
+setVar $b 2
+waitfor "Command"
+setVar $c 3`)
+
+	if _, err := vm.Step(); err == nil {
+		t.Error("Step should fail before the script is paused")
+	}
+	if err := vm.DebugPause(); err != nil {
+		t.Fatalf("DebugPause failed: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if value := vm.GetVariable("$a").ToString(); value != "" {
+		t.Fatalf("held script ran: $a = %q", value)
+	}
+
+	result, err := vm.Step()
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if result.Line != 2 || result.Status != "ready" {
+		t.Errorf("after first step got line %d (%s), want line 2 (ready)", result.Line, result.Status)
+	}
+	if len(result.Changed) != 1 || result.Changed["A"] != "1" {
+		t.Errorf("first step changed %v, want only $a = 1", result.Changed)
+	}
+
+	vm.Step()
+	result, _ = vm.Step()
+	if result.Status != `waiting for "Command"` {
+		t.Errorf("after stepping the waitfor got status %q", result.Status)
+	}
+	result, _ = vm.Step()
+	if result.Status != `waiting for "Command"` || len(result.Changed) != 0 {
+		t.Errorf("stepping while waiting got %q, changed %v", result.Status, result.Changed)
+	}
+
+	// The matching text clears the wait but the held script doesn't run on
+	if err := vm.ProcessIncomingText("Command [TL=00:00:00]:[1] (?=Help)? :"); err != nil {
+		t.Fatalf("ProcessIncomingText failed: %v", err)
+	}
+	if value := vm.GetVariable("$c").ToString(); value != "" {
+		t.Fatalf("held script ran after its waitfor matched: $c = %q", value)
+	}
+
+	result, _ = vm.Step()
+	if result.Changed["C"] != "3" {
+		t.Errorf("step after the waitfor changed %v, want $c = 3", result.Changed)
+	}
+	result, _ = vm.Step()
+	if result.Status != "ended" || result.Line != 0 {
+		t.Errorf("final step got line %d (%s), want the script to have ended", result.Line, result.Status)
+	}
+}
+
+func TestStepIntoTriggerHandler(t *testing.T) {
+	vm := loadDebugScript(t, `setTextTrigger 1 :handler "hello"
+pause
+halt
+:handler
+setVar $seen 1
+return`)
+
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if err := vm.DebugPause(); err != nil {
+		t.Fatalf("DebugPause failed: %v", err)
+	}
+	result, _ := vm.Step()
+	if result.Status != "paused until a trigger fires" {
+		t.Errorf("paused script got status %q", result.Status)
+	}
+
+	if err := vm.ProcessIncomingText("hello there"); err != nil {
+		t.Fatalf("ProcessIncomingText failed: %v", err)
+	}
+	if value := vm.GetVariable("$seen").ToString(); value != "" {
+		t.Fatalf("held trigger handler ran: $seen = %q", value)
+	}
+
+	result, _ = vm.Step()
+	if result.Changed["SEEN"] != "1" || result.Line != 6 {
+		t.Errorf("stepping the handler got line %d, changed %v", result.Line, result.Changed)
+	}
+
+	// Returning from the handler goes back to waiting after the interrupted pause, as it
+	// would have without the debugger
+	result, err := vm.Step()
+	if err != nil {
+		t.Fatalf("stepping the return failed: %v", err)
+	}
+	if result.Line != 3 || result.Status != "paused until a trigger fires" {
+		t.Errorf("after the handler returned got line %d (%s), want line 3 (paused)", result.Line, result.Status)
+	}
+}
+
+func TestDebugResume(t *testing.T) {
+	vm := loadDebugScript(t, `setVar $a 1
+setVar $b 2`)
+
+	if err := vm.DebugResume(); err == nil {
+		t.Error("DebugResume should fail when the script isn't paused")
+	}
+	vm.DebugPause()
+	vm.Step()
+	if err := vm.DebugResume(); err != nil {
+		t.Fatalf("DebugResume failed: %v", err)
+	}
+	if vm.IsDebugPaused() {
+		t.Error("script still held after resuming")
+	}
+	if !vm.GetState().IsHalted() || vm.GetVariable("$b").ToString() != "2" {
+		t.Errorf("resumed script didn't run to the end: halted %v, $b = %q", vm.GetState().IsHalted(), vm.GetVariable("$b").ToString())
+	}
+}
+
+func TestBreakpoint(t *testing.T) {
+	vm := loadDebugScript(t, `setVar $a 1
+setVar $b 2
+setVar $c 3
+setVar $d 4`)
+
+	vm.SetBreakpoint(2, true)
+	vm.SetBreakpoint(4, true)
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !vm.IsDebugPaused() || vm.nextLine() != 2 {
+		t.Fatalf("expected the script held at line 2, held %v at line %d", vm.IsDebugPaused(), vm.nextLine())
+	}
+	if vm.GetVariable("$a").ToString() != "1" || vm.GetVariable("$b").ToString() != "" {
+		t.Errorf("expected only line 1 run, $a = %q, $b = %q", vm.GetVariable("$a").ToString(), vm.GetVariable("$b").ToString())
+	}
+
+	// Resuming runs the breakpoint's line and stops at the next one
+	if err := vm.DebugResume(); err != nil {
+		t.Fatalf("DebugResume failed: %v", err)
+	}
+	if !vm.IsDebugPaused() || vm.nextLine() != 4 || vm.GetVariable("$c").ToString() != "3" {
+		t.Fatalf("expected the script held at line 4, held %v at line %d", vm.IsDebugPaused(), vm.nextLine())
+	}
+
+	vm.SetBreakpoint(4, false)
+	if breakpoints := vm.Breakpoints(); len(breakpoints) != 1 || breakpoints[0] != 2 {
+		t.Errorf("expected only line 2 left, got %v", breakpoints)
+	}
+	if err := vm.DebugResume(); err != nil {
+		t.Fatalf("DebugResume failed: %v", err)
+	}
+	if vm.IsDebugPaused() || vm.GetVariable("$d").ToString() != "4" {
+		t.Errorf("expected the script to run to the end, held %v, $d = %q", vm.IsDebugPaused(), vm.GetVariable("$d").ToString())
+	}
+}
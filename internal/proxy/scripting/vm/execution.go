@@ -119,6 +119,15 @@ func (ee *ExecutionEngine) ExecuteStep() (retErr error) {
 
 // executeNode executes a single AST node
 func (ee *ExecutionEngine) executeNode(node *parser.ASTNode) error {
+	if ee.vm.tracer.IsEnabled() {
+		switch node.Type {
+		case parser.NodeIf, parser.NodeWhile:
+			ee.vm.traceStatement(node)
+		case parser.NodeAssignment, parser.NodeCompoundAssignment, parser.NodeIncrementDecrement:
+			defer ee.vm.traceStatement(node)
+		}
+	}
+
 	switch node.Type {
 	case parser.NodeCommand:
 		return ee.executeCommand(node)
@@ -172,6 +181,10 @@ func (ee *ExecutionEngine) executeCommand(node *parser.ASTNode) error {
 			cmdName, cmdDef.MinParams, maxParamStr, len(params))
 	}
 
+	if ee.vm.tracer.IsEnabled() {
+		ee.vm.traceCommand(node, params)
+	}
+
 	// Execute the command
 	err = cmdDef.Handler(ee.vm, params)
 
@@ -709,14 +722,6 @@ func (ee *ExecutionEngine) evaluateArrayAccess(node *parser.ASTNode) (*types.Val
 
 	baseName := node.Children[0].Value
 
-	// Get or create VarParam for this variable
-	varParam := ee.vm.variables.GetVarParam(baseName)
-	if varParam == nil {
-		// Auto-vivification: create new VarParam
-		varParam = types.NewVarParam(baseName, types.VarParamVariable)
-		ee.vm.variables.SetVarParam(baseName, varParam)
-	}
-
 	// Evaluate all index expressions and build index path
 	indexes := make([]string, len(node.Children)-1)
 	for i := 1; i < len(node.Children); i++ {
@@ -727,13 +732,10 @@ func (ee *ExecutionEngine) evaluateArrayAccess(node *parser.ASTNode) (*types.Val
 		indexes[i-1] = indexValue.ToString()
 	}
 
-	// Get the indexed variable
-	indexedVar := varParam.GetIndexVar(indexes)
-
-	// Return the value
+	// Get the indexed variable, creating it if needed
 	return &types.Value{
 		Type:   types.StringType,
-		String: indexedVar.GetValue(),
+		String: ee.vm.variables.GetIndexValue(baseName, indexes),
 	}, nil
 }
 
@@ -0,0 +1,132 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"twist/internal/log"
+	"twist/internal/proxy/scripting/parser"
+	"twist/internal/proxy/scripting/types"
+)
+
+// trace.go - Opt-in execution trace of every script statement, for diagnosing scripts that
+// misbehave or hang
+
+// DefaultTraceFile is where the trace is written when it is turned on from the menu
+const DefaultTraceFile = "twist_trace.log"
+
+// Tracer appends a line to its file for each script statement executed, with a command's
+// arguments as they were resolved when it ran. One tracer is shared by every script's VM;
+// while it is off, tracing costs each statement a single atomic load.
+type Tracer struct {
+	path    string
+	enabled atomic.Bool
+	mutex   sync.Mutex
+	out     io.WriteCloser
+}
+
+// NewTracer creates a tracer that writes to path once started
+func NewTracer(path string) *Tracer {
+	return &Tracer{path: path}
+}
+
+// Start opens the trace file, appending to it if it exists, and starts tracing
+func (t *Tracer) Start() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.out != nil {
+		return nil
+	}
+	file, err := os.OpenFile(t.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	t.out = file
+	fmt.Fprintf(t.out, "%s trace started\n", time.Now().Format("2006-01-02 15:04:05.000"))
+	t.enabled.Store(true)
+	log.Info("Script trace started", "file", t.path)
+	return nil
+}
+
+// Stop stops tracing and closes the trace file
+func (t *Tracer) Stop() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.enabled.Store(false)
+	if t.out == nil {
+		return nil
+	}
+	fmt.Fprintf(t.out, "%s trace stopped\n", time.Now().Format("2006-01-02 15:04:05.000"))
+	err := t.out.Close()
+	t.out = nil
+	log.Info("Script trace stopped", "file", t.path)
+	return err
+}
+
+// IsEnabled returns true while the tracer is writing
+func (t *Tracer) IsEnabled() bool {
+	return t != nil && t.enabled.Load()
+}
+
+// Path returns the trace file's path
+func (t *Tracer) Path() string {
+	return t.path
+}
+
+// trace writes one statement to the trace file
+func (t *Tracer) trace(script string, line int, statement string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.out == nil {
+		return
+	}
+	if _, err := fmt.Fprintf(t.out, "%s %s:%d %s\n", time.Now().Format("2006-01-02 15:04:05.000"), script, line, statement); err != nil {
+		log.Warn("Failed to write script trace", "file", t.path, "error", err)
+	}
+}
+
+// SetTracer sets the tracer the VM reports executed statements to
+func (vm *VirtualMachine) SetTracer(tracer *Tracer) {
+	vm.tracer = tracer
+}
+
+// traceCommand traces a command with its resolved arguments
+func (vm *VirtualMachine) traceCommand(node *parser.ASTNode, params []*types.CommandParam) {
+	var statement strings.Builder
+	statement.WriteString(strings.ToUpper(node.Value))
+	for _, param := range params {
+		statement.WriteString(" ")
+		if param.Type == types.ParamVar {
+			statement.WriteString(fmt.Sprintf("%s=%q", param.VarName, vm.GetVariable(param.VarName).ToString()))
+		} else {
+			statement.WriteString(fmt.Sprintf("%q", param.Value.ToString()))
+		}
+	}
+	vm.tracer.trace(vm.scriptName(), node.Line, statement.String())
+}
+
+// traceStatement traces an if or while as it starts, or an assignment once it has run with
+// the value it set
+func (vm *VirtualMachine) traceStatement(node *parser.ASTNode) {
+	var statement string
+	switch node.Type {
+	case parser.NodeIf:
+		statement = "IF"
+	case parser.NodeWhile:
+		statement = "WHILE"
+	default:
+		if len(node.Children) == 0 {
+			return
+		}
+		name := node.Children[0].Value
+		statement = fmt.Sprintf("%s %s -> %q", name, node.Value, vm.GetVariable(name).ToString())
+	}
+	vm.tracer.trace(vm.scriptName(), node.Line, statement)
+}
@@ -0,0 +1,57 @@
+package vm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTracerLogsExecutedStatements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.log")
+	tracer := NewTracer(path)
+	if err := tracer.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	vm := loadDebugScript(t, `setVar $sector 1234
+$count = 0
+if ($sector > 1000)
+  $count++
+end
+send "m" $sector "*"`)
+	vm.SetTracer(tracer)
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if err := tracer.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading trace: %v", err)
+	}
+	trace := string(data)
+	for _, want := range []string{
+		`:1 SETVAR $sector="" "1234"`,
+		`:2 $count = -> "0"`,
+		`:3 BRANCH "($sector > 1000)"`,
+		`:4 $count ++ -> "1"`,
+		`:6 SEND "m" $sector="1234" "\r"`,
+		"trace stopped",
+	} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace is missing %q:\n%s", want, trace)
+		}
+	}
+
+	// Nothing more is written once the trace is off
+	vm = loadDebugScript(t, `setVar $a 1`)
+	vm.SetTracer(tracer)
+	vm.Execute()
+	after, _ := os.ReadFile(path)
+	if len(after) != len(data) {
+		t.Errorf("trace grew after it was stopped:\n%s", after)
+	}
+}
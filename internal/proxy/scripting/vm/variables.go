@@ -6,12 +6,16 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"twist/internal/log"
 	"twist/internal/proxy/scripting/types"
 )
 
-// VariableManager manages script variables with full array support
+// VariableManager manages script variables with full array support. The script runs on
+// one goroutine while menus such as the variable watch read its variables from another,
+// so every access goes through mu.
 type VariableManager struct {
+	mu            sync.RWMutex
 	variables     map[string]*types.VarParam // All variables using VarParam system
 	scriptID      string                     // Current script ID for database operations
 	gameInterface types.GameInterface        // For loading persisted variables
@@ -31,6 +35,9 @@ func NewVariableManager(gameInterface types.GameInterface) *VariableManager {
 
 // SetScriptID sets the current script ID for database operations
 func (vm *VariableManager) SetScriptID(scriptID string) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	vm.scriptID = scriptID
 }
 
@@ -113,6 +120,14 @@ func (vm *VariableManager) parseVariableNameOld(name string) (string, []string)
 
 // Get retrieves a variable value by name, supporting array indexing and object properties
 func (vm *VariableManager) Get(name string) *types.Value {
+	// Reading a variable that doesn't exist creates it
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	return vm.get(name)
+}
+
+// get retrieves a variable value; the caller holds mu
+func (vm *VariableManager) get(name string) *types.Value {
 	baseName, indexes, properties := vm.parseVariableName(name)
 
 	// Get or create the base variable (check user variables first, then system constants)
@@ -163,7 +178,7 @@ func (vm *VariableManager) Get(name string) *types.Value {
 	for i, index := range indexes {
 		if strings.HasPrefix(index, "$") {
 			// This is a variable reference, resolve it
-			indexValue := vm.Get(index)
+			indexValue := vm.get(index)
 			resolvedIndexes[i] = indexValue.ToString()
 		} else {
 			// This is a literal index
@@ -185,6 +200,9 @@ func (vm *VariableManager) Get(name string) *types.Value {
 
 // Set sets a variable value, supporting array indexing and object properties
 func (vm *VariableManager) Set(name string, value *types.Value) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	if value == nil {
 		value = &types.Value{
 			Type:   types.StringType,
@@ -215,7 +233,7 @@ func (vm *VariableManager) Set(name string, value *types.Value) {
 	for i, index := range indexes {
 		if strings.HasPrefix(index, "$") {
 			// This is a variable reference, resolve it
-			indexValue := vm.Get(index)
+			indexValue := vm.get(index)
 			resolvedIndexes[i] = indexValue.ToString()
 		} else {
 			// This is a literal index
@@ -238,6 +256,9 @@ func (vm *VariableManager) Set(name string, value *types.Value) {
 
 // SetVarParam sets a variable using the VarParam directly
 func (vm *VariableManager) SetVarParam(name string, varParam *types.VarParam) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	if len(indexes) == 0 {
@@ -263,6 +284,9 @@ func (vm *VariableManager) SetVarParam(name string, varParam *types.VarParam) {
 
 // GetVarParam gets the VarParam directly for advanced operations
 func (vm *VariableManager) GetVarParam(name string) *types.VarParam {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	baseVar, exists := vm.variables[baseName]
@@ -274,8 +298,26 @@ func (vm *VariableManager) GetVarParam(name string) *types.VarParam {
 	return baseVar.GetIndexVar(indexes)
 }
 
+// GetIndexValue returns the value of an element of an array variable, creating the
+// variable and the element if they don't exist
+func (vm *VariableManager) GetIndexValue(name string, indexes []string) string {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	baseName, _ := vm.parseVariableNameOld(name)
+	baseVar, exists := vm.variables[baseName]
+	if !exists {
+		baseVar = types.NewVarParam(baseName, types.VarParamVariable)
+		vm.variables[baseName] = baseVar
+	}
+	return baseVar.GetIndexVar(indexes).GetValue()
+}
+
 // SetArray initializes an array variable with given dimensions
 func (vm *VariableManager) SetArray(name string, dimensions []int) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, _ := vm.parseVariableNameOld(name)
 
 	varParam := types.NewVarParam(baseName, types.VarParamVariable)
@@ -285,6 +327,9 @@ func (vm *VariableManager) SetArray(name string, dimensions []int) {
 
 // SetArrayFromStrings sets array from string list (TWX style)
 func (vm *VariableManager) SetArrayFromStrings(name string, strings []string) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, _ := vm.parseVariableNameOld(name)
 
 	varParam := types.NewVarParam(baseName, types.VarParamVariable)
@@ -294,6 +339,9 @@ func (vm *VariableManager) SetArrayFromStrings(name string, strings []string) {
 
 // Exists checks if a variable exists
 func (vm *VariableManager) Exists(name string) bool {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	baseVar, exists := vm.variables[baseName]
@@ -312,6 +360,9 @@ func (vm *VariableManager) Exists(name string) bool {
 
 // Delete removes a variable
 func (vm *VariableManager) Delete(name string) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	if len(indexes) == 0 {
@@ -338,11 +389,17 @@ func (vm *VariableManager) Delete(name string) {
 
 // Clear removes all variables
 func (vm *VariableManager) Clear() {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	vm.variables = make(map[string]*types.VarParam)
 }
 
 // GetAll returns all variables as Value map for compatibility
 func (vm *VariableManager) GetAll() map[string]*types.Value {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
 	result := make(map[string]*types.Value)
 
 	for name, varParam := range vm.variables {
@@ -376,11 +433,17 @@ func (vm *VariableManager) addArrayElements(baseName string, varParam *types.Var
 
 // Count returns the number of base variables
 func (vm *VariableManager) Count() int {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
 	return len(vm.variables)
 }
 
 // GetNames returns all base variable names
 func (vm *VariableManager) GetNames() []string {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
 	names := make([]string, 0, len(vm.variables))
 	for name := range vm.variables {
 		names = append(names, name)
@@ -457,6 +520,9 @@ func (vm *VariableManager) valueToString(value *types.Value) string {
 
 // GetArrayElementCount returns the number of elements in an array variable
 func (vm *VariableManager) GetArrayElementCount(name string) int {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	baseVar, exists := vm.variables[baseName]
@@ -470,6 +536,9 @@ func (vm *VariableManager) GetArrayElementCount(name string) int {
 
 // GetArrayKeys returns the keys of an array variable
 func (vm *VariableManager) GetArrayKeys(name string) []string {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	baseVar, exists := vm.variables[baseName]
@@ -483,6 +552,9 @@ func (vm *VariableManager) GetArrayKeys(name string) []string {
 
 // IsArray checks if a variable is an array
 func (vm *VariableManager) IsArray(name string) bool {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	baseName, indexes := vm.parseVariableNameOld(name)
 
 	baseVar, exists := vm.variables[baseName]
@@ -496,6 +568,9 @@ func (vm *VariableManager) IsArray(name string) bool {
 
 // Clone creates a deep copy of all variables
 func (vm *VariableManager) Clone() *VariableManager {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
 	clone := &VariableManager{
 		variables: make(map[string]*types.VarParam),
 		scriptID:  vm.scriptID,
@@ -536,6 +611,9 @@ func (vm *VariableManager) valueToVarParam(name string, value *types.Value) *typ
 
 // ToJSON serializes all variables to JSON for database persistence
 func (vm *VariableManager) ToJSON() (string, error) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
 	data := make(map[string]interface{})
 	data["scriptID"] = vm.scriptID
 	data["variables"] = vm.variables
@@ -550,6 +628,9 @@ func (vm *VariableManager) ToJSON() (string, error) {
 
 // FromJSON deserializes variables from JSON for database restoration
 func (vm *VariableManager) FromJSON(jsonStr string) error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
 	if jsonStr == "" {
 		return nil
 	}
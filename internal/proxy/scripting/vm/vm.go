@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -49,6 +50,12 @@ type VirtualMachine struct {
 
 	// Trigger processing state (for TWX compatibility)
 	processingTrigger bool
+
+	// Debugger hold, see debug.go
+	debug debugState
+
+	// Execution trace, shared with the other scripts' VMs; nil or disabled when not tracing
+	tracer *Tracer
 }
 
 // NewVirtualMachine creates a new virtual machine
@@ -107,6 +114,12 @@ func (vm *VirtualMachine) LoadScript(ast *parser.ASTNode, script types.ScriptInt
 
 // Execute runs the script execution loop
 func (vm *VirtualMachine) Execute() error {
+	return vm.ExecuteContext(context.Background())
+}
+
+// ExecuteContext runs the script like Execute, but stops with an error between
+// statements once ctx is done, so a script stuck in a loop can be abandoned
+func (vm *VirtualMachine) ExecuteContext(ctx context.Context) error {
 	scriptName := "unknown"
 	if vm.script != nil {
 		scriptName = vm.script.GetName()
@@ -114,6 +127,20 @@ func (vm *VirtualMachine) Execute() error {
 	log.Info("VM.Execute: starting execution loop", "script", scriptName, "isRunning", vm.state.IsRunning(), "isWaiting", vm.state.IsWaiting(), "isPaused", vm.state.IsPaused(), "position", vm.state.Position)
 
 	for vm.state.IsRunning() && !vm.state.IsWaiting() {
+		if err := ctx.Err(); err != nil {
+			log.Warn("VM.Execute: execution stopped", "script", scriptName, "position", vm.state.Position, "error", err)
+			vm.lastError = fmt.Errorf("script execution stopped at position %d: %w", vm.state.Position, err)
+			return vm.lastError
+		}
+
+		if vm.debug.held.Load() {
+			log.Info("VM.Execute: script is held by the debugger", "script", scriptName, "position", vm.state.Position)
+			return nil
+		}
+		if vm.hitBreakpoint() {
+			return nil
+		}
+
 		log.Info("VM.Execute: executing step", "script", scriptName, "position", vm.state.Position)
 
 		if err := vm.execution.ExecuteStep(); err != nil {
@@ -238,6 +265,14 @@ func (vm *VirtualMachine) GotoAndExecuteSync(label string) error {
 	log.Info("TRIGGER JUMP: synchronous execution to label", "script", scriptName, "label", label, "oldPosition", savedPosition, "oldLine", oldLine, "newPosition", newPos, "newLine", newLine)
 	vm.state.Position = newPos
 
+	// Held by the debugger: stop at the handler so it can be stepped, remembering where
+	// to continue when it returns
+	if vm.debug.held.Load() {
+		vm.debug.triggerReturn = &triggerReturn{position: savedPosition + 1, running: savedRunning}
+		vm.state.SetRunning()
+		return nil
+	}
+
 	// Temporarily set the VM to running state for trigger handler execution
 	// This is necessary because triggers must execute even when the script is paused
 	vm.state.SetRunning()
@@ -245,6 +280,13 @@ func (vm *VirtualMachine) GotoAndExecuteSync(label string) error {
 	log.Info("About to execute ", "isRunning", vm.state.IsRunning(), "isWaiting", vm.state.IsWaiting(), "isPaused", vm.state.IsPaused(), "position", vm.state.Position)
 	// Execute until we hit a pause, halt, or return
 	for vm.state.IsRunning() && !vm.state.IsWaiting() && !vm.state.IsPaused() {
+		// A breakpoint in the handler holds the script there, as for a trigger firing
+		// while held
+		if vm.hitBreakpoint() {
+			vm.debug.triggerReturn = &triggerReturn{position: savedPosition + 1, running: savedRunning}
+			return nil
+		}
+
 		log.Info("Executing somehow")
 		if err := vm.execution.ExecuteStep(); err != nil {
 			log.Info("VM.GotoAndExecuteSync: ExecuteStep returned error", "script", scriptName, "error", err)
@@ -513,6 +555,9 @@ func (vm *VirtualMachine) GetActiveTriggersCount() int {
 // Text processing - ProcessTriggers method removed, logic moved to ProcessIncomingText for TWX compatibility
 
 func (vm *VirtualMachine) ProcessIncomingText(text string) error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
 	scriptName := "unknown"
 	if vm.script != nil {
 		scriptName = vm.script.GetName()
@@ -550,6 +595,32 @@ func (vm *VirtualMachine) ProcessIncomingText(text string) error {
 	return nil
 }
 
+// ProcessSectorComplete fires the script's sector triggers for a sector the parser has
+// finished reading
+func (vm *VirtualMachine) ProcessSectorComplete(sector int) error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	return vm.triggerManager.ProcessSectorComplete(sector)
+}
+
+// ProcessSectorArrival fires the script's arrival triggers for a sector the player has
+// arrived in
+func (vm *VirtualMachine) ProcessSectorArrival(sector int) error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	return vm.triggerManager.ProcessSectorArrival(sector)
+}
+
+// ProcessEvent fires the script's event triggers set for the named event
+func (vm *VirtualMachine) ProcessEvent(eventName string) error {
+	vm.debug.mutex.Lock()
+	defer vm.debug.mutex.Unlock()
+
+	return vm.triggerManager.ProcessEvent(eventName)
+}
+
 // Error handling
 func (vm *VirtualMachine) Error(message string) error {
 	vm.state.SetError(message)
@@ -658,7 +729,7 @@ func (vm *VirtualMachine) saveCallStack(scriptID string) error {
 
 	// Clear existing call stack for this script
 	deleteQuery := `DELETE FROM script_call_stack WHERE script_id = ?;`
-	if _, err := dbInterface.GetDB().Exec(deleteQuery, scriptID); err != nil {
+	if _, err := dbInterface.GetExecutor().Exec(deleteQuery, scriptID); err != nil {
 		return fmt.Errorf("failed to clear call stack: %w", err)
 	}
 
@@ -673,7 +744,7 @@ func (vm *VirtualMachine) saveCallStack(scriptID string) error {
 	VALUES (?, ?, ?, ?, ?);`
 
 	for i, frame := range frames {
-		_, err := dbInterface.GetDB().Exec(insertQuery, scriptID, i, frame.Label, frame.Position, frame.ReturnAddr)
+		_, err := dbInterface.GetExecutor().Exec(insertQuery, scriptID, i, frame.Label, frame.Position, frame.ReturnAddr)
 		if err != nil {
 			return fmt.Errorf("failed to save call stack frame %d: %w", i, err)
 		}
@@ -707,7 +778,7 @@ func (vm *VirtualMachine) restoreCallStack(scriptID string) error {
 	WHERE script_id = ?
 	ORDER BY frame_index;`
 
-	rows, err := dbInterface.GetDB().Query(query, scriptID)
+	rows, err := dbInterface.GetExecutor().Query(query, scriptID)
 	if err != nil {
 		return fmt.Errorf("failed to query call stack: %w", err)
 	}
@@ -0,0 +1,45 @@
+package streaming
+
+import (
+	"strings"
+
+	"twist/internal/log"
+)
+
+// Program events fired by the prompts that halt a move, for scripts to catch with
+// setEventTrigger, e.g. to abort a multi-sector move. "Stop in this sector" means the ship
+// was pulled up in the sector just displayed (an interdictor, fighters or mines there, or a
+// single-stepped autopilot); "Engage the Autopilot?" means a course was plotted and the
+// game waits for a go-ahead.
+const (
+	EventInterdicted     = "Interdicted"
+	EventAutopilotEngage = "Autopilot engage"
+)
+
+// stopPromptEvent returns the program event for a stop prompt, empty if the line isn't one
+func stopPromptEvent(line string) string {
+	switch {
+	case strings.Contains(line, "Stop in this sector"):
+		return EventInterdicted
+	case strings.Contains(line, "Engage the Autopilot?"):
+		return EventAutopilotEngage
+	default:
+		return ""
+	}
+}
+
+// fireStopPromptEvent fires the program event for a stop prompt. A prompt is parsed again as
+// each chunk of it arrives and once more when answered, so an event fires only once until the
+// next sector display or command prompt.
+func (p *TWXParser) fireStopPromptEvent(line string) {
+	event := stopPromptEvent(line)
+	if event == "" || event == p.stopPromptFired || p.scriptEventProcessor == nil {
+		return
+	}
+	p.stopPromptFired = event
+
+	log.Info("TWX_PARSER: Move halted at prompt", "sector", p.currentSectorIndex, "event", event)
+	if err := p.scriptEventProcessor.FireProgramEvent(event); err != nil {
+		log.Error("Error firing stop prompt event", "error", err, "sector", p.currentSectorIndex, "event", event)
+	}
+}
@@ -0,0 +1,42 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTWXParser_StopPromptEvents(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	engine := NewMockScriptEngine()
+	parser.SetScriptEngine(engine)
+
+	parser.ProcessInBound("\r\nCommand [TL=00:00:00]:[1] (?=Help)? : 9\r\n" +
+		"The shortest path (2 hops, 6 turns) from sector 1 to sector 9 is:\r\n" +
+		"1 > 5 > 9\r\n\r\n" +
+		"Engage the Autopilot? (Y/N/Single step/Express) [Y] ")
+	// The answer completes the prompt line, which is parsed again
+	parser.ProcessInBound("E\r\n")
+
+	// The ship is pulled up on the way, with the prompt arriving in two chunks
+	parser.ProcessInBound("\r\nSector  : 5 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  1 - 9\r\n\r\n" +
+		"Stop in this sector")
+	parser.ProcessInBound(" (Y,N,E,I,R,S,D,P,?) (?=Help) [N] ? ")
+
+	expected := []string{EventAutopilotEngage, EventInterdicted}
+	if !reflect.DeepEqual(engine.programEvents, expected) {
+		t.Errorf("Expected events %v, got %v", expected, engine.programEvents)
+	}
+
+	// The next sector's stop fires again
+	parser.ProcessInBound("N\r\n\r\nSector  : 9 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  5\r\n\r\n" +
+		"Stop in this sector (Y,N,E,I,R,S,D,P,?) (?=Help) [N] ? ")
+	if len(engine.programEvents) != 3 || engine.programEvents[2] != EventInterdicted {
+		t.Errorf("Expected a second %q event, got %v", EventInterdicted, engine.programEvents)
+	}
+	if parser.GetCurrentSector() != 9 {
+		t.Errorf("Expected the stop sector to be current, got %d", parser.GetCurrentSector())
+	}
+}
@@ -0,0 +1,114 @@
+package streaming
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+// replayedCapture is a captured session that has been fed through a parser, for asserting
+// what it stored
+type replayedCapture struct {
+	t  *testing.T
+	db database.Database
+}
+
+// replayCapture feeds a raw capture from testdata, ANSI codes and all, through a new parser
+// with an in-memory database, chunkSize bytes at a time so chunk boundaries fall mid-line
+// and mid-escape. A chunkSize of 0 feeds the whole capture at once.
+func replayCapture(t *testing.T, name string, chunkSize int) *replayedCapture {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read capture: %v", err)
+	}
+
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	t.Cleanup(func() { db.CloseDatabase() })
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	if chunkSize <= 0 {
+		chunkSize = len(data)
+	}
+	for start := 0; start < len(data); start += chunkSize {
+		parser.ProcessInBound(string(data[start:min(start+chunkSize, len(data))]))
+	}
+	parser.Finalize()
+
+	return &replayedCapture{t: t, db: db}
+}
+
+// sector returns a stored sector, failing the test if it wasn't stored
+func (c *replayedCapture) sector(index int) database.TSector {
+	c.t.Helper()
+	sector, err := c.db.LoadSector(index)
+	if err != nil {
+		c.t.Fatalf("Sector %d not stored: %v", index, err)
+	}
+	return sector
+}
+
+// port returns a stored port, failing the test if it wasn't stored
+func (c *replayedCapture) port(index int) database.TPort {
+	c.t.Helper()
+	port, err := c.db.LoadPort(index)
+	if err != nil {
+		c.t.Fatalf("Port in sector %d not stored: %v", index, err)
+	}
+	return port
+}
+
+// forEachChunking runs the test once with the whole capture and once in small chunks
+func forEachChunking(t *testing.T, test func(t *testing.T, chunkSize int)) {
+	t.Run("whole", func(t *testing.T) { test(t, 0) })
+	t.Run("chunked", func(t *testing.T) { test(t, 7) })
+}
+
+func TestReplayCapture_TWGS(t *testing.T) {
+	forEachChunking(t, func(t *testing.T, chunkSize int) {
+		capture := replayCapture(t, "twgs_login_sector.raw", chunkSize)
+
+		stardock := capture.sector(190)
+		if stardock.Constellation != "The Federation" || stardock.Beacon != "FedSpace, FedLaw Enforced" {
+			t.Errorf("Expected Federation sector 190 with its beacon, got %q / %q", stardock.Constellation, stardock.Beacon)
+		}
+		if stardock.Warp != [6]int{39, 79, 199, 274, 776, 815} || stardock.Explored != database.EtHolo {
+			t.Errorf("Expected sector 190 visited with six warps, got %v (explored %d)", stardock.Warp, stardock.Explored)
+		}
+		if port := capture.port(190); port.Name != "Stargate Alpha I" || port.ClassIndex != 9 {
+			t.Errorf("Expected the class 9 Stargate Alpha I, got %q class %d", port.Name, port.ClassIndex)
+		}
+
+		if sector := capture.sector(274); sector.Warp != [6]int{190, 705, 0, 0, 0, 0} {
+			t.Errorf("Expected sector 274 to warp to 190 and 705, got %v", sector.Warp)
+		}
+		port := capture.port(274)
+		if port.Name != "Nova Station" || port.ClassIndex != 4 || port.BuyProduct != [3]bool{false, false, true} {
+			t.Errorf("Expected the SSB class 4 Nova Station, got %q class %d buying %v", port.Name, port.ClassIndex, port.BuyProduct)
+		}
+	})
+}
+
+func TestReplayCapture_TW2002(t *testing.T) {
+	forEachChunking(t, func(t *testing.T, chunkSize int) {
+		capture := replayCapture(t, "tw2002_login_sector.raw", chunkSize)
+
+		sector := capture.sector(2921)
+		if sector.Constellation != "uncharted space" || sector.Warp != [6]int{3212, 7656, 0, 0, 0, 0} {
+			t.Errorf("Expected sector 2921 warping to 3212 and 7656, got %q %v", sector.Constellation, sector.Warp)
+		}
+		port := capture.port(2921)
+		if port.Name != "Vega Depot" || port.ClassIndex != 2 || port.BuyProduct != [3]bool{true, false, true} {
+			t.Errorf("Expected the BSB class 2 Vega Depot, got %q class %d buying %v", port.Name, port.ClassIndex, port.BuyProduct)
+		}
+
+		if sector := capture.sector(3212); sector.Warp != [6]int{2921, 10870, 16983, 17563, 0, 0} || sector.Explored != database.EtHolo {
+			t.Errorf("Expected sector 3212 visited with four warps, got %v (explored %d)", sector.Warp, sector.Explored)
+		}
+	})
+}
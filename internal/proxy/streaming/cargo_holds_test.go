@@ -0,0 +1,46 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_QuickStatsAndInfoAgreeOnCargo(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+
+	assertCargo := func(when string, total, ore, org, equ, col, empty int) {
+		t.Helper()
+		stats, err := db.GetPlayerStatsInfo()
+		if err != nil {
+			t.Fatalf("GetPlayerStatsInfo failed: %v", err)
+		}
+		if stats.TotalHolds != total || stats.OreHolds != ore || stats.OrgHolds != org ||
+			stats.EquHolds != equ || stats.ColHolds != col || stats.EmptyHolds != empty {
+			t.Errorf("%s: expected holds total=%d ore=%d org=%d equ=%d col=%d empty=%d, got total=%d ore=%d org=%d equ=%d col=%d empty=%d",
+				when, total, ore, org, equ, col, empty,
+				stats.TotalHolds, stats.OreHolds, stats.OrgHolds, stats.EquHolds, stats.ColHolds, stats.EmptyHolds)
+		}
+	}
+
+	parser.ProcessInBound("\r\n Sect 286│Turns 20,000│Creds 374,916│Figs 2,500│Shlds 0│Hlds 40│Ore 2│Org 3\r\n" +
+		" Equ 5│Col 10│Phot 0│Armd 0│Lmpt 0│GTorp 0│TWarp No│Clks 0│Beacns 0│AtmDt 0\r\n" +
+		" Crbo 0│EPrb 14│MDis 0│PsPrb No│PlScn No│LRS Holo│Aln 16│Exp 4│Ship 1 MerCru\r\n\r\n" + prompt)
+	assertCargo("after quick stats", 40, 2, 3, 5, 10, 20)
+
+	// The info screen shows the same cargo; it must not reset equipment or colonists
+	parser.ProcessInBound("\r\n<Info>\r\n\r\n" +
+		"Total Holds    : 40 - Fuel Ore=2 Organics=3 Equipment=5 Colonists=10 Empty=20\r\n" + prompt)
+	assertCargo("after matching info screen", 40, 2, 3, 5, 10, 20)
+
+	// The fresher display wins: colonists dropped off, so the game omits them
+	parser.ProcessInBound("\r\n<Info>\r\n\r\n" +
+		"Total Holds    : 40 - Fuel Ore=2 Organics=3 Equipment=5 Empty=30\r\n" + prompt)
+	assertCargo("after info screen without colonists", 40, 2, 3, 5, 0, 30)
+}
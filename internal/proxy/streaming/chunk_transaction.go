@@ -0,0 +1,144 @@
+package streaming
+
+import "twist/internal/log"
+
+// lineSavepoint holds the writes of the line being parsed within the chunk transaction, so
+// a handler that panics loses its own line's writes and not the lines parsed before it
+const lineSavepoint = "parsed_line"
+
+// beginChunkTransaction opens one transaction for the parser's writes while it processes a
+// chunk, so a CIM download or dense sector display costs one commit instead of dozens. The
+// transaction is on a session of the database that Database returns until the chunk ends,
+// so only the parser writes through it: menus, scripts and the TUI keep using the database.
+// Returns false if no transaction was started: no open database, or one is already
+// active (e.g. Finalize called while a chunk is being processed).
+func (p *TWXParser) beginChunkTransaction() bool {
+	if p.chunkDB != nil {
+		return false
+	}
+
+	db, err := p.loadedDatabase()
+	if err != nil || !db.GetDatabaseOpen() {
+		return false
+	}
+
+	session := db.Session()
+	if err := session.BeginTransaction(); err != nil {
+		log.Warn("TWXParser: failed to begin chunk transaction, writing without one", "error", err)
+		return false
+	}
+	p.chunkDB = session
+	p.chunkSource = db
+	return true
+}
+
+// endChunkTransaction commits the transaction if started is true. It must be deferred
+// directly so a panic escaping the chunk still commits the lines parsed before it.
+func (p *TWXParser) endChunkTransaction(started bool) {
+	if !started {
+		return
+	}
+	if r := recover(); r != nil {
+		p.rollbackLineSavepoint("panic")
+		p.commitChunkTransaction()
+		panic(r)
+	}
+	p.commitChunkTransaction()
+}
+
+// commitChunkTransaction commits the writes made during the chunk
+func (p *TWXParser) commitChunkTransaction() {
+	db := p.chunkDB
+	if db == nil {
+		return
+	}
+	p.chunkDB = nil
+	p.chunkSource = nil
+	p.lineSavepointOpen = false
+
+	if err := db.CommitTransaction(); err != nil {
+		log.Error("TWXParser: failed to commit chunk transaction", "error", err)
+	}
+}
+
+// shareChunkWrites commits the chunk's writes so far before running scripts handle an
+// event. Scripts use the database directly, so they would otherwise read data older than
+// the screen they react to and wait on the write lock the chunk's transaction holds.
+func (p *TWXParser) shareChunkWrites() {
+	db := p.chunkDB
+	if db == nil {
+		return
+	}
+
+	if err := db.CommitTransaction(); err != nil {
+		log.Error("TWXParser: failed to commit chunk transaction", "error", err)
+	}
+	if err := db.BeginTransaction(); err != nil {
+		log.Warn("TWXParser: failed to begin chunk transaction, writing without one", "error", err)
+		p.chunkDB = nil
+		p.chunkSource = nil
+		p.lineSavepointOpen = false
+		return
+	}
+	if p.lineSavepointOpen {
+		if _, err := db.GetExecutor().Exec("SAVEPOINT " + lineSavepoint); err != nil {
+			log.Warn("TWXParser: failed to mark line writes", "error", err)
+			p.lineSavepointOpen = false
+		}
+	}
+}
+
+// beginLineSavepoint marks where the writes of the line being parsed start. Returns false
+// if no savepoint was made: no chunk transaction, or the enclosing line already has one.
+func (p *TWXParser) beginLineSavepoint() bool {
+	if p.chunkDB == nil || p.lineSavepointOpen {
+		return false
+	}
+	if _, err := p.chunkDB.GetExecutor().Exec("SAVEPOINT " + lineSavepoint); err != nil {
+		log.Warn("TWXParser: failed to mark line writes", "error", err)
+		return false
+	}
+	p.lineSavepointOpen = true
+	return true
+}
+
+// endLineSavepoint keeps the line's writes in the chunk transaction if started is true. It
+// must be deferred directly so a panic escaping the line discards them before propagating.
+func (p *TWXParser) endLineSavepoint(started bool) {
+	if !started {
+		return
+	}
+	if r := recover(); r != nil {
+		p.rollbackLineSavepoint("panic")
+		p.releaseLineSavepoint()
+		panic(r)
+	}
+	p.releaseLineSavepoint()
+}
+
+// releaseLineSavepoint merges the line's writes into the chunk transaction
+func (p *TWXParser) releaseLineSavepoint() {
+	if p.chunkDB == nil || !p.lineSavepointOpen {
+		return
+	}
+	p.lineSavepointOpen = false
+
+	if _, err := p.chunkDB.GetExecutor().Exec("RELEASE " + lineSavepoint); err != nil {
+		log.Error("TWXParser: failed to keep line writes", "error", err)
+	}
+}
+
+// rollbackLineSavepoint discards the writes of the line being parsed. Called when parsing
+// panics, since the trackers may have written a partial sector or port; the lines parsed
+// before it are still committed with the chunk.
+func (p *TWXParser) rollbackLineSavepoint(reason string) {
+	if p.chunkDB == nil || !p.lineSavepointOpen {
+		return
+	}
+
+	if _, err := p.chunkDB.GetExecutor().Exec("ROLLBACK TO " + lineSavepoint); err != nil {
+		log.Error("TWXParser: failed to discard line writes", "reason", reason, "error", err)
+		return
+	}
+	log.Warn("TWXParser: discarded writes of the line being parsed", "reason", reason)
+}
@@ -0,0 +1,182 @@
+package streaming
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestChunkTransactionCommitsSector(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	// A whole sector display arriving in one chunk is written in a single transaction
+	parser.ProcessInBound("Sector  : 1234 in Sol\rWarps to Sector(s) :  2 - 3\rCommand [TL=00:00:00]:[1234] (?=Help)? : ")
+
+	if db.InTransaction() {
+		t.Fatal("Expected chunk transaction to be committed after ProcessInBound")
+	}
+
+	sector, err := db.LoadSector(1234)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if sector.Warp[0] != 2 || sector.Warp[1] != 3 {
+		t.Errorf("Expected warps [2 3] to be committed, got %v", sector.Warp)
+	}
+}
+
+func TestChunkTransactionKeepsEarlierLinesOnPanic(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	if !parser.beginChunkTransaction() {
+		t.Fatal("Expected chunk transaction to start")
+	}
+	if parser.beginChunkTransaction() {
+		t.Error("Expected nested chunk transaction to be refused")
+	}
+	insert := func(sector int, constellation string) {
+		if _, err := parser.GetDatabase().GetExecutor().Exec("INSERT INTO sectors (sector_index, constellation) VALUES (?, ?)", sector, constellation); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	// One line is parsed, then the next line's handler writes part of a sector and panics
+	func() {
+		defer parser.endLineSavepoint(parser.beginLineSavepoint())
+		insert(76, "parsed")
+	}()
+	func() {
+		defer parser.endLineSavepoint(parser.beginLineSavepoint())
+		defer parser.recoverFromPanic("test")
+		insert(77, "partial")
+		panic("handler failure")
+	}()
+
+	// Only the parser writes through the chunk's transaction
+	if db.InTransaction() {
+		t.Error("Expected the database to have no transaction of its own")
+	}
+	count := func(sector int) int {
+		var n int
+		if err := db.GetExecutor().QueryRow("SELECT COUNT(*) FROM sectors WHERE sector_index = ?", sector).Scan(&n); err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		return n
+	}
+	if count(76) != 0 {
+		t.Error("Expected the chunk's writes to be hidden until it commits")
+	}
+
+	parser.endChunkTransaction(true)
+	if count(76) != 1 {
+		t.Error("Expected the line parsed before the panic to be committed")
+	}
+	if count(77) != 0 {
+		t.Error("Expected the failing line's partial write to be discarded")
+	}
+}
+
+// sectorReadingEngine reads a sector from the database when scripts see a line
+type sectorReadingEngine struct {
+	*MockScriptEngine
+	db    database.Database
+	line  string
+	warps []int
+}
+
+func (e *sectorReadingEngine) ProcessTextLine(line string) (bool, error) {
+	if line == e.line {
+		sector, err := e.db.LoadSector(1234)
+		if err != nil {
+			return false, err
+		}
+		e.warps = append(e.warps, sector.Warp[0], sector.Warp[1])
+	}
+	return e.MockScriptEngine.ProcessTextLine(line)
+}
+
+func TestChunkTransactionSharedWithScripts(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	engine := &sectorReadingEngine{MockScriptEngine: NewMockScriptEngine(), db: db, line: "<Re-Display>"}
+	parser.SetScriptEngine(engine)
+
+	// A script reacting to a line reads the sector displayed earlier in the same chunk
+	parser.ProcessInBound("Sector  : 1234 in Sol\rWarps to Sector(s) :  2 - 3\rCommand [TL=00:00:00]:[1234] (?=Help)? : D\r<Re-Display>\r")
+
+	if len(engine.warps) != 2 || engine.warps[0] != 2 || engine.warps[1] != 3 {
+		t.Errorf("Expected scripts to see warps [2 3], got %v", engine.warps)
+	}
+}
+
+func TestChunkTransactionConcurrentReads(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	display := func(sector int) string {
+		return fmt.Sprintf("\rSector  : %d in Sol\rWarps to Sector(s) :  2 - 3\rCommand [TL=00:00:00]:[%d] (?=Help)? : ", sector, sector)
+	}
+	parser.ProcessInBound(display(100))
+
+	// The TUI reads sectors while the parser opens and commits chunk transactions
+	done := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			db.InTransaction()
+			if _, err := db.LoadSector(100); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := db.GetSectorInfo(100); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	for sector := 101; sector <= 150; sector++ {
+		parser.ProcessInBound(display(sector))
+	}
+	close(done)
+
+	if err := <-errs; err != nil {
+		t.Fatalf("Concurrent read failed: %v", err)
+	}
+
+	sector, err := db.LoadSector(150)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if sector.Warp[0] != 2 || sector.Warp[1] != 3 {
+		t.Errorf("Expected last chunk's sector to be committed, got warps %v", sector.Warp)
+	}
+}
@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"sort"
+
+	"twist/internal/log"
+)
+
+// cimBurst collects the sectors a CIM download changes, so the TUI hears about them once
+// when the download ends instead of once per sector. Only the parser goroutine uses it.
+type cimBurst struct {
+	sectors  map[int]bool
+	complete bool // The download ended; the sectors are reported at the end of the chunk
+}
+
+// inCIMDisplay returns true while CIM report lines are being parsed
+func (p *TWXParser) inCIMDisplay() bool {
+	switch p.currentDisplay {
+	case DisplayCIM, DisplayPortCIM, DisplayWarpCIM:
+		return true
+	}
+	return false
+}
+
+// addCIMSector records a sector the current CIM download changed
+func (p *TWXParser) addCIMSector(sectorNum int) {
+	if p.tuiAPI == nil || sectorNum <= 0 {
+		return
+	}
+	if p.cimBurst.sectors == nil {
+		p.cimBurst.sectors = make(map[int]bool)
+	}
+	p.cimBurst.sectors[sectorNum] = true
+}
+
+// endCIMBlock marks the current CIM download as ended, once the display leaves CIM or the
+// CIM prompt shows again
+func (p *TWXParser) endCIMBlock() {
+	if len(p.cimBurst.sectors) > 0 {
+		p.cimBurst.complete = true
+	}
+}
+
+// flushCIMBurst tells the TUI about every sector an ended CIM download changed, in one
+// event. It runs after the chunk's writes are committed, so the TUI reads the new data.
+func (p *TWXParser) flushCIMBurst() {
+	if !p.cimBurst.complete {
+		return
+	}
+	sectors := make([]int, 0, len(p.cimBurst.sectors))
+	for sectorNum := range p.cimBurst.sectors {
+		sectors = append(sectors, sectorNum)
+	}
+	sort.Ints(sectors)
+	p.cimBurst = cimBurst{}
+
+	if p.tuiAPI == nil {
+		return
+	}
+	log.Info("TWX_PARSER: CIM download ended, invalidating map", "sectors", len(sectors))
+	p.tuiAPI.OnMapInvalidated(sectors)
+}
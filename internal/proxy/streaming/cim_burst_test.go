@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+// mapEventRecorder records warp and map invalidation events; other TuiAPI methods are not expected
+type mapEventRecorder struct {
+	api.TuiAPI
+	warps       []int
+	invalidated [][]int
+}
+
+func (r *mapEventRecorder) OnSectorWarpsUpdated(sector int, warps [6]int) {
+	r.warps = append(r.warps, sector)
+}
+
+func (r *mapEventRecorder) OnMapInvalidated(sectors []int) {
+	r.invalidated = append(r.invalidated, sectors)
+}
+
+func TestTWXParser_CIMDownloadInvalidatesMapOnce(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	recorder := &mapEventRecorder{}
+	parser := NewTWXParser(func() database.Database { return db }, recorder)
+
+	// A warp report arrives over several chunks; nothing is reported until it ends
+	parser.ProcessInBound(": ")
+	parser.ProcessInBound("\r\n   30    12    45\r\n")
+	parser.ProcessInBound("   12    30\r\n   45    30    12\r\n")
+	if len(recorder.warps) != 0 || len(recorder.invalidated) != 0 {
+		t.Fatalf("Expected no events during the download, got warps %v and %v", recorder.warps, recorder.invalidated)
+	}
+
+	// The blank line and the CIM prompt end the report
+	parser.ProcessInBound("\r\n: ")
+	if len(recorder.invalidated) != 1 || !reflect.DeepEqual(recorder.invalidated[0], []int{12, 30, 45}) {
+		t.Fatalf("Expected one invalidation of sectors 12, 30 and 45, got %v", recorder.invalidated)
+	}
+	if sector, err := db.LoadSector(30); err != nil || sector.Warp[0] != 12 || sector.Warp[1] != 45 {
+		t.Errorf("Expected sector 30's warps to be saved, got %v (err=%v)", sector.Warp, err)
+	}
+
+	// A port report right after is its own download
+	parser.ProcessInBound("\r\n 1234  5000 60%  3000 80% -2000 90%\r\n")
+	parser.ProcessInBound("\r\n")
+	if len(recorder.invalidated) != 2 || !reflect.DeepEqual(recorder.invalidated[1], []int{1234}) {
+		t.Fatalf("Expected the port report to invalidate sector 1234, got %v", recorder.invalidated)
+	}
+	if len(recorder.warps) != 0 {
+		t.Errorf("Expected no per-sector warp events from CIM, got %v", recorder.warps)
+	}
+}
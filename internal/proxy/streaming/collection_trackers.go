@@ -3,6 +3,7 @@ package streaming
 import (
 	"database/sql"
 	"twist/internal/log"
+	"twist/internal/proxy/database"
 )
 
 // SectorCollections manages all collection trackers for a sector
@@ -29,9 +30,15 @@ func (sc *SectorCollections) AddShip(name, owner, shipType string, fighters int)
 	sc.shipsTracker.AddShip(name, owner, shipType, fighters)
 }
 
+// SetLastShipType sets the type of the ship added last, which the sector display lists
+// on the line after the ship
+func (sc *SectorCollections) SetLastShipType(shipType string) {
+	sc.shipsTracker.SetLastShipType(shipType)
+}
+
 // AddTrader adds a trader to the discovered traders collection
-func (sc *SectorCollections) AddTrader(name, shipName, shipType string, fighters int) {
-	sc.tradersTracker.AddTrader(name, shipName, shipType, fighters)
+func (sc *SectorCollections) AddTrader(name, shipName, shipType string, fighters int, alignment string) {
+	sc.tradersTracker.AddTrader(name, shipName, shipType, fighters, alignment)
 }
 
 // AddPlanet adds a planet to the discovered planets collection
@@ -47,7 +54,7 @@ func (sc *SectorCollections) HasData() bool {
 }
 
 // Execute performs atomic replacement of all collections in the sector
-func (sc *SectorCollections) Execute(db *sql.DB) error {
+func (sc *SectorCollections) Execute(db database.Executor) error {
 	// Execute all collection updates in sequence
 	if sc.shipsTracker.HasShips() {
 		if err := sc.shipsTracker.Execute(db); err != nil {
@@ -102,14 +109,21 @@ func (s *ShipsCollectionTracker) AddShip(name, owner, shipType string, fighters
 	})
 }
 
+// SetLastShipType sets the type of the ship added last, if there is one
+func (s *ShipsCollectionTracker) SetLastShipType(shipType string) {
+	if len(s.ships) > 0 {
+		s.ships[len(s.ships)-1].ShipType = shipType
+	}
+}
+
 // HasShips returns true if ships were discovered
 func (s *ShipsCollectionTracker) HasShips() bool {
 	return len(s.ships) > 0
 }
 
 // Execute performs atomic replace: DELETE + INSERT in transaction
-func (s *ShipsCollectionTracker) Execute(db *sql.DB) error {
-	tx, err := db.Begin()
+func (s *ShipsCollectionTracker) Execute(db database.Executor) error {
+	tx, err := beginCollectionUpdate(db)
 	if err != nil {
 		return err
 	}
@@ -144,10 +158,11 @@ func (s *ShipsCollectionTracker) Execute(db *sql.DB) error {
 
 // TraderData represents a discovered trader
 type TraderData struct {
-	Name     string
-	ShipName string
-	ShipType string
-	Fighters int
+	Name      string
+	ShipName  string
+	ShipType  string
+	Fighters  int
+	Alignment string
 }
 
 // TradersCollectionTracker manages atomic replacement of traders for a sector
@@ -165,12 +180,13 @@ func NewTradersCollectionTracker(sectorIndex int) *TradersCollectionTracker {
 }
 
 // AddTrader adds a trader to the collection
-func (t *TradersCollectionTracker) AddTrader(name, shipName, shipType string, fighters int) {
+func (t *TradersCollectionTracker) AddTrader(name, shipName, shipType string, fighters int, alignment string) {
 	t.traders = append(t.traders, TraderData{
-		Name:     name,
-		ShipName: shipName,
-		ShipType: shipType,
-		Fighters: fighters,
+		Name:      name,
+		ShipName:  shipName,
+		ShipType:  shipType,
+		Fighters:  fighters,
+		Alignment: alignment,
 	})
 }
 
@@ -180,8 +196,8 @@ func (t *TradersCollectionTracker) HasTraders() bool {
 }
 
 // Execute performs atomic replace: DELETE + INSERT in transaction
-func (t *TradersCollectionTracker) Execute(db *sql.DB) error {
-	tx, err := db.Begin()
+func (t *TradersCollectionTracker) Execute(db database.Executor) error {
+	tx, err := beginCollectionUpdate(db)
 	if err != nil {
 		return err
 	}
@@ -196,9 +212,9 @@ func (t *TradersCollectionTracker) Execute(db *sql.DB) error {
 	// Insert discovered traders
 	for _, trader := range t.traders {
 		_, err = tx.Exec(`
-			INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters) 
-			VALUES (?, ?, ?, ?, ?)`,
-			t.sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Fighters)
+			INSERT INTO traders (sector_index, name, ship_type, ship_name, fighters, alignment) 
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			t.sectorIndex, trader.Name, trader.ShipType, trader.ShipName, trader.Fighters, trader.Alignment)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -254,8 +270,8 @@ func (p *PlanetsCollectionTracker) HasPlanets() bool {
 }
 
 // Execute performs atomic replace: DELETE + INSERT in transaction
-func (p *PlanetsCollectionTracker) Execute(db *sql.DB) error {
-	tx, err := db.Begin()
+func (p *PlanetsCollectionTracker) Execute(db database.Executor) error {
+	tx, err := beginCollectionUpdate(db)
 	if err != nil {
 		return err
 	}
@@ -287,3 +303,52 @@ func (p *PlanetsCollectionTracker) Execute(db *sql.DB) error {
 	log.Info("COLLECTIONS: Updated planets for sector", "count", len(p.planets), "sector", p.sectorIndex)
 	return nil
 }
+
+// collectionUpdate applies one collection replace atomically. Inside the parser's chunk
+// transaction it uses a savepoint so a failed replace is undone without aborting the chunk.
+type collectionUpdate struct {
+	exec database.Executor
+	tx   *sql.Tx // Own transaction, nil when running under a savepoint
+}
+
+// beginCollectionUpdate starts a transaction on db, or a savepoint if db is already a transaction
+func beginCollectionUpdate(db database.Executor) (*collectionUpdate, error) {
+	if sqlDB, ok := db.(*sql.DB); ok {
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			return nil, err
+		}
+		return &collectionUpdate{exec: tx, tx: tx}, nil
+	}
+
+	if _, err := db.Exec("SAVEPOINT collection_update"); err != nil {
+		return nil, err
+	}
+	return &collectionUpdate{exec: db}, nil
+}
+
+// Exec runs a statement as part of the update
+func (c *collectionUpdate) Exec(query string, args ...any) (sql.Result, error) {
+	return c.exec.Exec(query, args...)
+}
+
+// Commit applies the update
+func (c *collectionUpdate) Commit() error {
+	if c.tx != nil {
+		return c.tx.Commit()
+	}
+	_, err := c.exec.Exec("RELEASE collection_update")
+	return err
+}
+
+// Rollback discards the update
+func (c *collectionUpdate) Rollback() error {
+	if c.tx != nil {
+		return c.tx.Rollback()
+	}
+	if _, err := c.exec.Exec("ROLLBACK TO collection_update"); err != nil {
+		return err
+	}
+	_, err := c.exec.Exec("RELEASE collection_update")
+	return err
+}
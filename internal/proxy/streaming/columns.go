@@ -50,6 +50,8 @@ const (
 	ColSectorDensity       = "density"
 	ColSectorAnomaly       = "anomaly"
 	ColSectorExplored      = "explored"
+	ColSectorAliensCount   = "aliens_count"
+	ColSectorAliensType    = "aliens_type"
 )
 
 // Phase 3: Port column constants
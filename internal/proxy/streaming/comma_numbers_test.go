@@ -0,0 +1,67 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_CommaFormattedNumbers(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+
+	assertStats := func(when string, credits, experience, alignment int) {
+		t.Helper()
+		stats, err := db.GetPlayerStatsInfo()
+		if err != nil {
+			t.Fatalf("GetPlayerStatsInfo failed: %v", err)
+		}
+		if stats.Credits != credits || stats.Experience != experience || stats.Alignment != alignment {
+			t.Errorf("%s: expected credits=%d experience=%d alignment=%d, got credits=%d experience=%d alignment=%d",
+				when, credits, experience, alignment, stats.Credits, stats.Experience, stats.Alignment)
+		}
+	}
+
+	parser.ProcessInBound("\r\n Sect 286│Turns 20,000│Creds 1,234,567│Figs 2,500│Shlds 0│Hlds 40│Ore 2│Org 3\r\n" +
+		" Equ 5│Col 10│Phot 0│Armd 0│Lmpt 0│GTorp 0│TWarp No│Clks 0│Beacns 0│AtmDt 0\r\n" +
+		" Crbo 0│EPrb 14│MDis 0│PsPrb No│PlScn No│LRS Holo│Aln -1,234│Exp 1,234,567│Ship 1 MerCru\r\n\r\n" + prompt)
+	assertStats("after quick stats", 1234567, 1234567, -1234)
+
+	parser.ProcessInBound("\r\n<Info>\r\n\r\n" +
+		"Rank and Exp   : 2,345,678 points, Alignment=-2,345 Villain\r\n" +
+		"Credits        : 3,456,789\r\n" + prompt)
+	assertStats("after info screen", 3456789, 2345678, -2345)
+
+	parser.ProcessInBound("\r\n                          Relative Density Scan\r\n" +
+		"Sector  ( 300)  ==>         12,345  Warps : 2    NavHaz :     0%    Anom : No\r\n" + prompt)
+	if sector, err := db.LoadSector(300); err != nil || sector.Density != 12345 {
+		t.Errorf("Expected density 12,345 for sector 300, got %d (%v)", sector.Density, err)
+	}
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Grav, Class 1 (BBS)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+	parser.ProcessInBound("P\r\n<Port>\r\n\r\nDocking...\r\n\r\n" +
+		"Commerce report for Grav: 10:02:07 PM Sun Aug 17, 2053\r\n\r\n" +
+		" Items     Status  Trading % of max OnBoard\r\n" +
+		" -----     ------  ------- -------- -------\r\n" +
+		"Fuel Ore   Buying   12,500    100%       0\r\n" +
+		"Organics   Buying    1,180     57%       0\r\n" +
+		"Equipment  Selling  23,930     12%       0\r\n\r\n" + prompt)
+	port, err := db.GetPortInfo(286)
+	if err != nil || port == nil {
+		t.Fatalf("GetPortInfo failed: %v", err)
+	}
+	expected := map[api.ProductType]int{api.ProductTypeFuelOre: 12500, api.ProductTypeOrganics: 1180, api.ProductTypeEquipment: 23930}
+	for _, product := range port.Products {
+		if product.Quantity != expected[product.Type] {
+			t.Errorf("Expected %s quantity %d, got %d", product.Type, expected[product.Type], product.Quantity)
+		}
+	}
+}
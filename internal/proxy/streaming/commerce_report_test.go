@@ -0,0 +1,73 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+func TestExtractCommerceReportName(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"Commerce report for Grav: 10:02:07 PM Sun Aug 17, 2053", "Grav"},
+		{"Commerce report for Io: 1:02:07 AM Mon Aug 18, 2053", "Io"},
+		{"Commerce report for Trader's Rest: Bar & Grill: 10:02:07 PM Sun Aug 17, 2053", "Trader's Rest: Bar & Grill"},
+		{"Commerce report for Bob: the Trader: 10:02:07 PM Sun Aug 17, 2053", "Bob: the Trader"},
+		{"Commerce report for Club 12:30: 09:15:00 AM Tue Aug 19, 2053", "Club 12:30"},
+		{"Commerce report for StarPort Alpha:", "StarPort Alpha"},
+		{"Commerce report for :", ""},
+	}
+
+	for _, tt := range tests {
+		if got := extractCommerceReportName(tt.line); got != tt.want {
+			t.Errorf("extractCommerceReportName(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestTWXParser_CommerceReportUpdatesPort(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Trader's Rest: Bar & Grill, Class 1 (BBS)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+
+	parser.ProcessInBound("P\r\n<Port>\r\n\r\nDocking...\r\n\r\n" +
+		"Commerce report for Trader's Rest: Bar & Grill: 10:02:07 PM Sun Aug 17, 2053\r\n\r\n" +
+		" Items     Status  Trading % of max OnBoard\r\n" +
+		" -----     ------  ------- -------- -------\r\n" +
+		"Fuel Ore   Buying     2500    100%       0\r\n" +
+		"Organics   Buying     1180     57%       0\r\n" +
+		"Equipment  Selling     930     12%       0\r\n\r\n" + prompt)
+
+	port, err := db.GetPortInfo(286)
+	if err != nil || port == nil {
+		t.Fatalf("GetPortInfo failed: %v", err)
+	}
+	if port.Name != "Trader's Rest: Bar & Grill" {
+		t.Errorf("Expected port name with colon, got %q", port.Name)
+	}
+
+	expected := map[api.ProductType]api.ProductInfo{
+		api.ProductTypeFuelOre:   {Type: api.ProductTypeFuelOre, Status: api.ProductStatusBuying, Quantity: 2500, Percentage: 100},
+		api.ProductTypeOrganics:  {Type: api.ProductTypeOrganics, Status: api.ProductStatusBuying, Quantity: 1180, Percentage: 57},
+		api.ProductTypeEquipment: {Type: api.ProductTypeEquipment, Status: api.ProductStatusSelling, Quantity: 930, Percentage: 12},
+	}
+	if len(port.Products) != len(expected) {
+		t.Fatalf("Expected %d products, got %+v", len(expected), port.Products)
+	}
+	for _, product := range port.Products {
+		if product != expected[product.Type] {
+			t.Errorf("Expected %s %+v, got %+v", product.Type, expected[product.Type], product)
+		}
+	}
+}
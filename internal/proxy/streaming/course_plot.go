@@ -0,0 +1,25 @@
+package streaming
+
+import "twist/internal/log"
+
+// addCourseSectors collects the sectors of a course the game is plotting ("The shortest
+// path ... is:"), which can wrap over several lines. Returns false for a line with none.
+func (p *TWXParser) addCourseSectors(sectors []int) bool {
+	if len(sectors) == 0 {
+		return false
+	}
+	p.courseSectors = append(p.courseSectors, sectors...)
+	return true
+}
+
+// fireCoursePlotted tells the TUI the course the game plotted, once the blank line after it
+// shows the course is complete, so the map can highlight it
+func (p *TWXParser) fireCoursePlotted() {
+	sectors := p.courseSectors
+	p.courseSectors = nil
+	if p.tuiAPI == nil || len(sectors) < 2 {
+		return
+	}
+	log.Info("TWX_PARSER: Firing OnCoursePlotted", "from", sectors[0], "to", sectors[len(sectors)-1], "hops", len(sectors)-1)
+	p.tuiAPI.OnCoursePlotted(sectors)
+}
@@ -0,0 +1,38 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+
+	"twist/internal/api"
+)
+
+// courseRecorder records plotted courses; other TuiAPI methods are not expected
+type courseRecorder struct {
+	api.TuiAPI
+	courses [][]int
+}
+
+func (r *courseRecorder) OnCoursePlotted(sectors []int) {
+	r.courses = append(r.courses, sectors)
+}
+
+func TestTWXParser_CoursePlottedOnceComplete(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	recorder := &courseRecorder{}
+	parser.tuiAPI = recorder
+
+	// A long course wraps onto a second line
+	parser.ProcessInBound("The shortest path (6 hops, 18 turns) from sector 1 to sector 9 is:\r\n" +
+		"1 > 5 > (12) > 3 >\r\n")
+	parser.ProcessInBound("7 > 8 > 9\r\n")
+	if len(recorder.courses) != 0 {
+		t.Fatalf("Expected no course before it ends, got %v", recorder.courses)
+	}
+
+	parser.ProcessInBound("\r\n\r\n")
+	if expected := [][]int{{1, 5, 12, 3, 7, 8, 9}}; !reflect.DeepEqual(recorder.courses, expected) {
+		t.Errorf("Expected courses %v, got %v", expected, recorder.courses)
+	}
+}
@@ -0,0 +1,94 @@
+package streaming
+
+import (
+	"fmt"
+
+	"twist/internal/log"
+)
+
+// notifyCurrentSectorChanged tells the TUI the player is in sectorNum. A single move reaches
+// this from both the sector display and the command prompt that follows it, so repeats for
+// the sector last notified are not sent as a sector change: the TUI would regenerate the map
+// for each one. A re-display of the same sector (fromDisplay) may carry new data, so it is
+// sent as a sector update instead. Scripts' arrival triggers fire only for the command
+// prompt: sector displays also come from holo and density scans, which the player doesn't
+// move for.
+func (p *TWXParser) notifyCurrentSectorChanged(sectorNum int, source string, fromDisplay bool) {
+	if sectorNum <= 0 {
+		return
+	}
+	if !fromDisplay {
+		p.fireSectorArrival(sectorNum)
+	}
+	if p.tuiAPI == nil {
+		return
+	}
+
+	repeat := sectorNum == p.lastNotifiedSector
+	if repeat && !fromDisplay {
+		return
+	}
+
+	sectorInfo, err := p.GetDatabase().GetSectorInfo(sectorNum)
+	if err != nil {
+		log.Info("TWX_PARSER: Failed to read fresh sector info for API event", "sector", sectorNum, "source", source, "error", err)
+		return
+	}
+	p.MarkSessionSector(&sectorInfo)
+
+	if repeat {
+		p.tuiAPI.OnSectorUpdated(sectorInfo)
+		return
+	}
+
+	log.Info("TWX_PARSER: Firing OnCurrentSectorChanged", "sector", sectorNum, "source", source, "previous", p.lastNotifiedSector)
+	p.lastNotifiedSector = sectorNum
+	p.tuiAPI.OnCurrentSectorChanged(sectorInfo)
+}
+
+// fireSectorArrival fires scripts' arrival triggers when the player is in a sector other
+// than the one they last fired for
+func (p *TWXParser) fireSectorArrival(sectorNum int) {
+	if sectorNum == p.lastArrivedSector {
+		return
+	}
+	p.lastArrivedSector = sectorNum
+	if p.scriptEventProcessor == nil {
+		return
+	}
+	if err := p.scriptEventProcessor.FireSectorArrivalEvent(sectorNum); err != nil {
+		log.Error("Error firing arrival triggers", "error", err, "sector", sectorNum)
+	}
+}
+
+// RefreshCurrentSector sends the TUI the current sector as stored, as a sector change even if
+// it is the sector last notified, so the TUI can resync after changes no sector display came
+// with: a database switch, a reconnect, a script run. It only reads the database, so it can
+// be called from outside the pipeline.
+func (p *TWXParser) RefreshCurrentSector() error {
+	if p.tuiAPI == nil {
+		return nil
+	}
+
+	db, err := p.loadedDatabase()
+	if err != nil {
+		return err
+	}
+	stats, err := db.LoadPlayerStats()
+	if err != nil {
+		return fmt.Errorf("no current sector: %w", err)
+	}
+	if stats.CurrentSector <= 0 {
+		return fmt.Errorf("no current sector")
+	}
+
+	sectorInfo, err := db.GetSectorInfo(stats.CurrentSector)
+	if err != nil {
+		return err
+	}
+	p.MarkSessionSector(&sectorInfo)
+
+	log.Info("TWX_PARSER: Firing OnCurrentSectorChanged", "sector", stats.CurrentSector, "source", "refresh")
+	p.tuiAPI.OnCurrentSectorChanged(sectorInfo)
+	return nil
+}
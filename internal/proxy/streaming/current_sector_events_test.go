@@ -0,0 +1,92 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+// sectorEventRecorder records current sector events; other TuiAPI methods are not expected
+type sectorEventRecorder struct {
+	api.TuiAPI
+	changed []int
+	updated []int
+}
+
+func (r *sectorEventRecorder) OnCurrentSectorChanged(sectorInfo api.SectorInfo) {
+	r.changed = append(r.changed, sectorInfo.Number)
+}
+
+func (r *sectorEventRecorder) OnSectorUpdated(sectorInfo api.SectorInfo) {
+	r.updated = append(r.updated, sectorInfo.Number)
+}
+
+func (r *sectorEventRecorder) OnSectorWarpsUpdated(sector int, warps [6]int) {}
+
+func (r *sectorEventRecorder) OnTraderDataUpdated(sectorNumber int, traders []api.TraderInfo) {}
+
+func (r *sectorEventRecorder) OnPlayerStatsUpdated(stats api.PlayerStatsInfo) {}
+
+func TestTWXParser_CurrentSectorChangedOncePerMove(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	recorder := &sectorEventRecorder{}
+	parser := NewTWXParser(func() database.Database { return db }, recorder)
+
+	moveTo := func(sector, warp string) {
+		parser.ProcessInBound("\r\nSector  : " + sector + " in uncharted space.\r")
+		parser.ProcessInBound("Warps to Sector(s) :  " + warp + "\r")
+		parser.ProcessInBound("\rCommand [TL=00:00:00]:[" + sector + "] (?=Help)? : ")
+	}
+
+	moveTo("705", "279")
+	moveTo("279", "705")
+	// Re-display of the same sector refreshes its data without a sector change
+	moveTo("279", "705")
+	// Returning to a prior sector is a real change
+	moveTo("705", "279")
+
+	expected := []int{705, 279, 705}
+	if len(recorder.changed) != len(expected) {
+		t.Fatalf("Expected sector changes %v, got %v", expected, recorder.changed)
+	}
+	for i := range expected {
+		if recorder.changed[i] != expected[i] {
+			t.Fatalf("Expected sector changes %v, got %v", expected, recorder.changed)
+		}
+	}
+	if len(recorder.updated) != 1 || recorder.updated[0] != 279 {
+		t.Errorf("Expected one sector update for the 279 re-display, got %v", recorder.updated)
+	}
+}
+
+func TestTWXParser_RefreshCurrentSector(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	recorder := &sectorEventRecorder{}
+	parser := NewTWXParser(func() database.Database { return db }, recorder)
+
+	if err := parser.RefreshCurrentSector(); err == nil {
+		t.Error("Expected an error refreshing without a current sector")
+	}
+
+	parser.ProcessInBound("\r\nSector  : 705 in uncharted space.\r")
+	parser.ProcessInBound("Warps to Sector(s) :  279\r")
+	parser.ProcessInBound("\rCommand [TL=00:00:00]:[705] (?=Help)? : ")
+
+	// The sector is sent again even though the TUI was last told of it
+	if err := parser.RefreshCurrentSector(); err != nil {
+		t.Fatalf("RefreshCurrentSector failed: %v", err)
+	}
+	if len(recorder.changed) != 2 || recorder.changed[1] != 705 {
+		t.Errorf("Expected the refresh to send sector 705 again, got %v", recorder.changed)
+	}
+}
@@ -0,0 +1,23 @@
+package streaming
+
+import "twist/internal/log"
+
+// resetOnDatabaseSwitch resets the parser when the game database is swapped, e.g. after
+// the user leaves one game and selects another on the same server. State from the old
+// game's screens (current sector, display, partial line) must not be saved to the new one.
+func (p *TWXParser) resetOnDatabaseSwitch() {
+	if p.getDatabaseFunc == nil {
+		return
+	}
+	db := p.getDatabaseFunc()
+	if db == nil || db == p.activeDB {
+		return
+	}
+
+	if p.activeDB != nil {
+		log.Info("TWXParser: game database changed, resetting parser state")
+		p.Reset()
+	}
+	p.activeDB = db
+	p.sessionStats.recountStartPorts(db)
+}
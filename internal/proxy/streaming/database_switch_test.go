@@ -0,0 +1,40 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_ResetsOnDatabaseSwitch(t *testing.T) {
+	first := database.NewDatabase()
+	if err := first.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer first.CloseDatabase()
+
+	second := database.NewDatabase()
+	if err := second.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer second.CloseDatabase()
+
+	active := first
+	parser := NewTWXParser(func() database.Database { return active }, nil)
+
+	// Leave the first game partway through a sector display
+	parser.ProcessInBound("Sector  : 55 in Sol\r")
+	if parser.GetCurrentSector() != 55 {
+		t.Fatalf("Expected current sector 55, got %d", parser.GetCurrentSector())
+	}
+
+	// The user selects another game and its database is loaded
+	active = second
+	parser.ProcessInBound("Warps to Sector(s) :  2 - 3\r")
+
+	if parser.GetCurrentSector() != 0 {
+		t.Errorf("Expected parser state to be reset after database switch, current sector is %d", parser.GetCurrentSector())
+	}
+	if sector, err := second.LoadSector(55); err == nil && sector.Warps > 0 {
+		t.Errorf("Expected sector 55 from the previous game not to be saved to the new database, got %+v", sector)
+	}
+}
@@ -0,0 +1,69 @@
+package streaming
+
+import (
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+)
+
+// isDensityScanHeader returns true for the heading of a density scan, "Relative Density
+// Scan", however it is indented or spaced. TWX only looks for it at column 27, which misses
+// servers that lay the scan out differently. The heading may follow the prompt the scan was
+// asked for at, when the key pressed wasn't echoed, but text merely mentioning it doesn't match.
+func isDensityScanHeader(line string) bool {
+	fields := strings.Fields(line)
+	start := len(fields) - 2
+	if len(fields) > 0 && fields[len(fields)-1] == "Scan" {
+		start--
+	}
+	if start < 0 || fields[start] != "Relative" || fields[start+1] != "Density" {
+		return false
+	}
+	return start == 0 || strings.HasSuffix(fields[start-1], ":")
+}
+
+// startDensityDisplay begins a density scan display. Exploration statuses looked up for
+// the scan are cached until the next scan starts.
+func (p *TWXParser) startDensityDisplay() {
+	p.currentDisplay = DisplayDensity
+	p.densityExplored = nil
+	p.densityOrigin = p.currentSectorIndex
+}
+
+// densityExploredStatus returns the exploration status of a scanned sector. The scan lists
+// the sector it was made from and its neighbours, so the first lookup loads all of them in
+// one query rather than loading each sector as its line arrives.
+func (p *TWXParser) densityExploredStatus(sectorNum int) database.TSectorExploredType {
+	if explored, ok := p.densityExplored[sectorNum]; ok {
+		return explored
+	}
+	if p.densityExplored == nil {
+		p.densityExplored = make(map[int]database.TSectorExploredType)
+	}
+
+	db := p.GetDatabase()
+	sectors := []int{sectorNum}
+	if p.densityOrigin > 0 {
+		warps, err := db.GetWarps(p.densityOrigin)
+		if err == nil {
+			sectors = append(sectors, p.densityOrigin)
+			for _, warp := range warps {
+				if warp > 0 {
+					sectors = append(sectors, warp)
+				}
+			}
+		}
+		p.densityOrigin = 0
+	}
+
+	explored, err := db.LoadExploredStatus(sectors)
+	if err != nil {
+		log.Info("DENSITY: Failed to load explored status", "sector", sectorNum, "error", err)
+		return database.EtNo
+	}
+	for sector, status := range explored {
+		p.densityExplored[sector] = status
+	}
+	return p.densityExplored[sectorNum]
+}
@@ -0,0 +1,94 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestDensityScanLoadsExploredStatusOnce(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	origin := database.NULLSector()
+	origin.Explored = database.EtHolo
+	origin.Warp = [6]int{200, 300, 0, 0, 0, 0}
+	if err := db.SaveSector(origin, 100); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+	visited := database.NULLSector()
+	visited.Explored = database.EtHolo
+	visited.Constellation = "The Federation"
+	if err := db.SaveSector(visited, 200); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	parser.currentSectorIndex = 100
+
+	parser.ProcessInBound("                          Relative Density Scan\r")
+	parser.ProcessInBound("Sector ( 200) ==>            100  Warps : 4    NavHaz :     0%    Anom : No\r")
+
+	// The first lookup loads the whole neighbourhood of the scan's origin
+	for _, sector := range []int{100, 200, 300} {
+		if _, ok := parser.densityExplored[sector]; !ok {
+			t.Errorf("Expected explored status for sector %d to be cached, got %v", sector, parser.densityExplored)
+		}
+	}
+
+	parser.ProcessInBound("Sector   300  ==>            500  Warps : 2    NavHaz :     0%    Anom : No\r")
+
+	holo, err := db.LoadSector(200)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if holo.Explored != database.EtHolo || holo.Density != 100 {
+		t.Errorf("Expected sector 200 to keep EtHolo and get density 100, got explored=%v density=%d", holo.Explored, holo.Density)
+	}
+
+	scanned, err := db.LoadSector(300)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if scanned.Explored != database.EtDensity || scanned.Density != 500 {
+		t.Errorf("Expected sector 300 to be EtDensity with density 500, got explored=%v density=%d", scanned.Explored, scanned.Density)
+	}
+}
+
+func TestDensityScanHeaderLayouts(t *testing.T) {
+	for name, header := range map[string]string{
+		"TWX column":   "                          Relative Density Scan",
+		"flush left":   "Relative  Density  Scan",
+		"no scan":      "        Relative Density",
+		"ANSI colors":  "\x1b[1;33m          Relative Density Scan\x1b[0m",
+		"after prompt": "Command [TL=00:00:00]:[2921] (?=Help)? :                           Relative Density Scan",
+	} {
+		t.Run(name, func(t *testing.T) {
+			parser := NewTestTWXParser()
+			parser.ProcessInBound(header + "\r")
+			if parser.currentDisplay != DisplayDensity {
+				t.Fatalf("Expected the density display to start, got %v", parser.currentDisplay)
+			}
+
+			parser.ProcessInBound("Sector   300  ==>            500  Warps : 2    NavHaz :     0%    Anom : No\r")
+			sector, err := parser.GetDatabase().LoadSector(300)
+			if err != nil || sector.Density != 500 {
+				t.Errorf("Expected sector 300 stored with density 500, got %d (%v)", sector.Density, err)
+			}
+		})
+	}
+
+	for _, line := range []string{
+		"Relative Density Scanner not installed!",
+		"R Bob Does the Relative Density Scan work?",
+		"The Relative Density Scan shows nothing",
+		"Scan",
+		"",
+	} {
+		if isDensityScanHeader(line) {
+			t.Errorf("Expected %q not to start a density scan", line)
+		}
+	}
+}
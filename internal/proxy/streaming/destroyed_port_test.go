@@ -0,0 +1,65 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+// portEventRecorder records port updated events; other TuiAPI methods are not expected
+type portEventRecorder struct {
+	sectorEventRecorder
+	ports []api.PortInfo
+}
+
+func (r *portEventRecorder) OnPortUpdated(portInfo api.PortInfo) {
+	r.ports = append(r.ports, portInfo)
+}
+
+func TestTWXParser_DestroyedPort(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	recorder := &portEventRecorder{}
+	parser := NewTWXParser(func() database.Database { return db }, recorder)
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+	showSector := func(portLine string) {
+		parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+			portLine + "\r\n" +
+			"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+	}
+
+	showSector("Ports   : Nova Station, Class 4 (SSB)")
+	parser.ProcessInBound("P\r\n<Port>\r\n\r\nDocking...\r\n\r\n" +
+		"Commerce report for Nova Station: 10:02:07 PM Sun Aug 17, 2053\r\n\r\n" +
+		" Items     Status  Trading % of max OnBoard\r\n" +
+		" -----     ------  ------- -------- -------\r\n" +
+		"Fuel Ore   Selling    2500    100%       0\r\n" +
+		"Organics   Selling    1180     57%       0\r\n" +
+		"Equipment  Buying      930     12%       0\r\n\r\n" + prompt)
+
+	showSector("Ports   : <=-DANGER-=> Nova Station, Class 4 (SSB)")
+
+	port, err := db.LoadPort(286)
+	if err != nil {
+		t.Fatalf("LoadPort failed: %v", err)
+	}
+	if !port.Dead {
+		t.Error("Expected the port to be marked destroyed")
+	}
+	if port.ProductAmount != [3]int{} || port.ProductPercent != [3]int{} {
+		t.Errorf("Expected a destroyed port to have no products, got amounts %v percents %v", port.ProductAmount, port.ProductPercent)
+	}
+	if len(recorder.ports) == 0 || !recorder.ports[len(recorder.ports)-1].Dead {
+		t.Errorf("Expected a port updated event for the destroyed port, got %+v", recorder.ports)
+	}
+
+	// A port rebuilt in the sector is alive again
+	showSector("Ports   : Nova Station, Class 4 (SSB)")
+	if port, err = db.LoadPort(286); err != nil || port.Dead {
+		t.Errorf("Expected the rebuilt port to be alive, got %+v (err %v)", port, err)
+	}
+}
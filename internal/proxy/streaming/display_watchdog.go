@@ -0,0 +1,56 @@
+package streaming
+
+import (
+	"twist/internal/log"
+)
+
+// DefaultDisplayWatchdogLines is how many lines a display may run without saving anything
+// before the parser gives up on it
+const DefaultDisplayWatchdogLines = 500
+
+// displayWatchdog notices a display state that never ended. Game prompts end every display,
+// so one that runs on for many lines is most likely a malformed screen, and every line
+// after it would be parsed as part of it. Lines that save a sector or port, such as each
+// line of a CIM report or density scan, show the display is still going.
+type displayWatchdog struct {
+	limit int         // Lines allowed without progress, 0 if the watchdog is off
+	lines int         // Lines since the display began or last saved something
+	saves int64       // Sector and port saves when the last line began
+	shown DisplayType // Display the lines were counted for
+}
+
+// SetDisplayWatchdog sets how many lines a display may run without saving anything before
+// it is reset. Zero or less turns the watchdog off.
+func (p *TWXParser) SetDisplayWatchdog(lines int) {
+	p.watchdog.limit = max(lines, 0)
+	p.watchdog.lines = 0
+}
+
+// checkDisplayWatchdog counts a processed line against the current display, resetting the
+// display if it has run too long without saving anything
+func (p *TWXParser) checkDisplayWatchdog() {
+	w := &p.watchdog
+	saves := p.counters.sectorsSaved.Load() + p.counters.portsSaved.Load()
+	progressed := saves != w.saves
+	w.saves = saves
+
+	if w.limit == 0 || p.currentDisplay == DisplayNone || progressed {
+		w.shown = p.currentDisplay
+		w.lines = 0
+		return
+	}
+	if p.currentDisplay != w.shown {
+		w.shown = p.currentDisplay
+		w.lines = 0
+	}
+
+	w.lines++
+	if w.lines < w.limit {
+		return
+	}
+
+	log.Warn("TWX_PARSER: display never ended, resetting it", "display", p.currentDisplay.String(), "lines", w.lines)
+	p.currentDisplay = DisplayNone
+	w.shown = DisplayNone
+	w.lines = 0
+}
@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"fmt"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_DisplayWatchdog(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	parser.SetDisplayWatchdog(5)
+
+	// A CIM report saves a sector on every line, so it runs as long as it needs to
+	parser.SetCurrentDisplay(DisplayWarpCIM)
+	for sector := 1; sector <= 10; sector++ {
+		parser.ProcessString(fmt.Sprintf("%d %d %d\r\n", sector, sector+1, sector+2))
+	}
+	if parser.GetCurrentDisplay() != DisplayWarpCIM {
+		t.Fatalf("Expected the CIM report to keep going, got %v", parser.GetCurrentDisplay())
+	}
+
+	// A fighter scan that never reaches its prompt is given up on
+	parser.SetCurrentDisplay(DisplayFigScan)
+	for i := 0; i < 4; i++ {
+		parser.ProcessString("garbled line\r\n")
+	}
+	if parser.GetCurrentDisplay() != DisplayFigScan {
+		t.Fatalf("Expected the display kept below the limit, got %v", parser.GetCurrentDisplay())
+	}
+	parser.ProcessString("garbled line\r\n")
+	if parser.GetCurrentDisplay() != DisplayNone {
+		t.Errorf("Expected the display reset after 5 lines, got %v", parser.GetCurrentDisplay())
+	}
+
+	// Turned off, the display is left alone
+	parser.SetDisplayWatchdog(0)
+	parser.SetCurrentDisplay(DisplayFigScan)
+	for i := 0; i < 10; i++ {
+		parser.ProcessString("garbled line\r\n")
+	}
+	if parser.GetCurrentDisplay() != DisplayFigScan {
+		t.Errorf("Expected no reset with the watchdog off, got %v", parser.GetCurrentDisplay())
+	}
+}
@@ -59,9 +59,12 @@ func TestTWXParser_FighterResetOwnerVerification(t *testing.T) {
 	// The method should handle the case gracefully with empty database
 }
 
-func TestTWXParser_IsPersonalOrCorpFighter(t *testing.T) {
-	// Create parser for testing
+func TestTWXParser_FighterResetOwnerMatching(t *testing.T) {
 	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
 	parser := NewTWXParser(func() database.Database { return db }, nil)
 
 	testCases := []struct {
@@ -77,16 +80,29 @@ func TestTWXParser_IsPersonalOrCorpFighter(t *testing.T) {
 		{"Your Corporation", true, "Contains 'your corporation'"},
 		{"Enemy Player", false, "Enemy player should not match"},
 		{"Neutral Trader", false, "Neutral trader should not match"},
-		{"", false, "Empty owner should not match"},
 		{"someone else", false, "Generic other owner should not match"},
 		{"Corporate Alliance", false, "Different corp should not match"},
 	}
 
-	for _, tc := range testCases {
+	// One sector per owner, all outside FedSpace
+	fighters := make([]fighterTestData, len(testCases))
+	for i, tc := range testCases {
+		fighters[i] = fighterTestData{sectorNum: 20 + i, owner: tc.owner, quantity: 100, fighterType: 1}
+	}
+	if err := setupTestSectorsWithFighters(db, fighters); err != nil {
+		t.Fatalf("Failed to setup test sectors: %v", err)
+	}
+
+	parser.resetFighterDatabase()
+
+	for i, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			result := parser.isPersonalOrCorpFighter(tc.owner)
-			if result != tc.expected {
-				t.Errorf("Expected %v for owner '%s', got %v", tc.expected, tc.owner, result)
+			sector, err := db.LoadSector(20 + i)
+			if err != nil {
+				t.Fatalf("LoadSector(%d) failed: %v", 20+i, err)
+			}
+			if reset := sector.Figs.Quantity == 0; reset != tc.expected {
+				t.Errorf("Expected reset %v for owner '%s', got %d fighters", tc.expected, tc.owner, sector.Figs.Quantity)
 			}
 		})
 	}
@@ -119,6 +135,47 @@ func TestTWXParser_FindStardockSector(t *testing.T) {
 	}
 }
 
+func TestTWXParser_FighterResetLeavesEnemyFighters(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	err := setupTestSectorsWithFighters(db, []fighterTestData{
+		{sectorNum: 5, owner: "yours", quantity: 100, fighterType: 1},                // FedSpace
+		{sectorNum: 12, owner: "yours", quantity: 200, fighterType: 1},               // Personal
+		{sectorNum: 13, owner: "belong to your Corp", quantity: 300, fighterType: 2}, // Corp
+		{sectorNum: 14, owner: "Your Corporation", quantity: 400, fighterType: 1},    // Corp variant
+		{sectorNum: 15, owner: "yours", quantity: 500, fighterType: 1},               // Stardock
+		{sectorNum: 16, owner: "belong to Rebel Alliance", quantity: 600, fighterType: 2},
+		{sectorNum: 17, owner: "Captain Zyrain", quantity: 700, fighterType: 1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup test sectors: %v", err)
+	}
+	if err := setupStardockSector(db, 15); err != nil {
+		t.Fatalf("Failed to setup Stardock sector: %v", err)
+	}
+
+	parser.resetFighterDatabase()
+
+	expected := map[int]int{5: 100, 12: 0, 13: 0, 14: 0, 15: 500, 16: 600, 17: 700}
+	for sectorNum, quantity := range expected {
+		sector, err := db.LoadSector(sectorNum)
+		if err != nil {
+			t.Fatalf("LoadSector(%d) failed: %v", sectorNum, err)
+		}
+		if sector.Figs.Quantity != quantity {
+			t.Errorf("Sector %d: expected %d fighters, got %d (owner %q)", sectorNum, quantity, sector.Figs.Quantity, sector.Figs.Owner)
+		}
+		if quantity == 0 && (sector.Figs.Owner != "" || sector.Figs.FigType != 3) {
+			t.Errorf("Sector %d: expected owner cleared and type ftNone, got %q type %d", sectorNum, sector.Figs.Owner, sector.Figs.FigType)
+		}
+	}
+}
+
 // Helper types and functions for testing
 
 type fighterTestData struct {
@@ -182,3 +239,48 @@ func setupStardockSector(db database.Database, sectorNum int) error {
 
 	return db.SaveSectorWithCollections(sector, sectorNum, sector.Ships, sector.Traders, sector.Planets)
 }
+
+// BenchmarkFighterDatabaseReset measures the fighter reset over a 2000 sector universe with
+// fighters in every tenth sector, inside a chunk transaction as when triggered by parsing
+func BenchmarkFighterDatabaseReset(b *testing.B) {
+	path := b.TempDir() + "/reset.db"
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(path); err != nil {
+		b.Fatalf("Failed to create database: %v", err)
+	}
+	for i := 1; i <= 2000; i++ {
+		sector := database.NULLSector()
+		sector.Warp[0] = i%2000 + 1
+		if i%10 == 0 {
+			sector.Figs.Owner = "yours"
+			sector.Figs.Quantity = 100
+		}
+		if err := db.SaveSector(sector, i); err != nil {
+			b.Fatalf("Failed to save sector %d: %v", i, err)
+		}
+	}
+	db.CloseDatabase()
+
+	if err := db.OpenDatabase(path); err != nil {
+		b.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Redeploy the fighters the previous iteration cleared
+		b.StopTimer()
+		if _, err := db.GetExecutor().Exec("UPDATE sectors SET figs_quantity = 100, figs_owner = 'yours' WHERE sector_index % 10 = 0"); err != nil {
+			b.Fatalf("Failed to redeploy fighters: %v", err)
+		}
+		b.StartTimer()
+
+		started := parser.beginChunkTransaction()
+		if err := parser.resetFighterDatabasePascalCompliant(); err != nil {
+			b.Fatalf("Reset failed: %v", err)
+		}
+		parser.endChunkTransaction(started)
+	}
+}
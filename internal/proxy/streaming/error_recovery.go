@@ -78,7 +78,9 @@ func (p *TWXParser) recoverFromPanic(operation string) {
 	if r := recover(); r != nil {
 		stackTrace := debug.Stack()
 		log.Error("PANIC recovered in TWX parser", "function", "recoverFromPanic", "operation", operation, "error", r, "stack", string(stackTrace))
-		// Reset parser state to prevent cascade failures
+		p.counters.errorsRecovered.Add(1)
+		// Discard the failing line's partial writes and reset parser state to prevent cascade failures
+		p.rollbackLineSavepoint(operation)
 		p.resetParserState()
 	}
 }
@@ -93,6 +95,7 @@ func (p *TWXParser) resetParserState() {
 	p.currentANSILine = ""
 	p.rawANSILine = ""
 	p.inANSI = false
+	p.ansiColor.Reset()
 
 	// Clear message context
 	p.currentChannel = 0
@@ -268,6 +271,7 @@ func (p *TWXParser) errorRecoveryHandler(operation string, criticalFunc func() e
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error("PANIC recovered in error recovery handler", "function", "errorRecoveryHandler", "operation", operation, "error", r)
+			p.counters.errorsRecovered.Add(1)
 			p.resetParserState()
 		}
 	}()
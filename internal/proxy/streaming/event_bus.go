@@ -57,13 +57,23 @@ func (eb *EventBus) Unsubscribe(eventType EventType, subscriptionID string) {
 	}
 }
 
-// Fire synchronously delivers an event to all subscribers
-func (eb *EventBus) Fire(event Event) {
+// handlersFor returns a copy of the handlers subscribed to an event type, so they can be
+// called without holding the lock while other goroutines subscribe and unsubscribe
+func (eb *EventBus) handlersFor(eventType EventType) map[string]EventHandler {
 	eb.mutex.RLock()
-	handlers, exists := eb.subscribers[event.Type]
-	eb.mutex.RUnlock()
+	defer eb.mutex.RUnlock()
+
+	handlers := make(map[string]EventHandler, len(eb.subscribers[eventType]))
+	for subscriptionID, handler := range eb.subscribers[eventType] {
+		handlers[subscriptionID] = handler
+	}
+	return handlers
+}
 
-	if !exists {
+// Fire synchronously delivers an event to all subscribers
+func (eb *EventBus) Fire(event Event) {
+	handlers := eb.handlersFor(event.Type)
+	if len(handlers) == 0 {
 		return
 	}
 
@@ -73,11 +83,11 @@ func (eb *EventBus) Fire(event Event) {
 	}
 
 	// Call all handlers synchronously
-	for _, handler := range handlers {
+	for subscriptionID, handler := range handlers {
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					log.Error("PANIC recovered in event handler", "function", "Fire", "event_type", event.Type, "error", r)
+					log.Error("PANIC recovered in event handler", "function", "Fire", "subscription_id", subscriptionID, "event_type", event.Type, "error", r)
 				}
 			}()
 			handler(event)
@@ -87,11 +97,8 @@ func (eb *EventBus) Fire(event Event) {
 
 // FireAsync asynchronously delivers an event to all subscribers
 func (eb *EventBus) FireAsync(event Event) {
-	eb.mutex.RLock()
-	handlers, exists := eb.subscribers[event.Type]
-	eb.mutex.RUnlock()
-
-	if !exists {
+	handlers := eb.handlersFor(event.Type)
+	if len(handlers) == 0 {
 		return
 	}
 
@@ -0,0 +1,39 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_ParseFighterQuantity(t *testing.T) {
+	db := database.NewDatabase()
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"1,500", 1500},
+		{"1000", 1000},
+		{"1.5K", 1500},
+		{"1.5k", 1500},
+		{"2.3M", 2300000},
+		{"10T", 10000},
+		{"2B", 2000000000},
+		{"1.0005K", 1001},
+		{"", invalidFighterQuantity},
+		{"K", invalidFighterQuantity},
+		{".K", invalidFighterQuantity},
+		{"1.2.3M", invalidFighterQuantity},
+		{"-5", invalidFighterQuantity},
+		{"1e3", invalidFighterQuantity},
+		{"abc", invalidFighterQuantity},
+		{"N/A", invalidFighterQuantity},
+	}
+
+	for _, tt := range tests {
+		if got := parser.parseFighterQuantity(tt.input); got != tt.expected {
+			t.Errorf("parseFighterQuantity(%q) = %d, expected %d", tt.input, got, tt.expected)
+		}
+	}
+}
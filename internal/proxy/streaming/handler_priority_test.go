@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_AddHandlerSpecificPatternWins(t *testing.T) {
+	var matched []string
+	parser := &TWXParser{}
+	// Registered catch-all first, which used to swallow every line with a ": " in it
+	parser.AddHandler(": ", HandlerPriorityFallback, func(line string) { matched = append(matched, "cim") })
+	parser.AddHandler("Probe entering sector :", HandlerPriorityDefault, func(line string) { matched = append(matched, "probe") })
+
+	parser.checkPatterns("Probe entering sector : 274")
+	parser.checkPatterns(": ")
+
+	if len(matched) != 2 || matched[0] != "probe" || matched[1] != "cim" {
+		t.Errorf("Expected the probe line to reach the probe handler and the prompt the CIM handler, got %v", matched)
+	}
+}
+
+func TestTWXParser_AddHandlerOrder(t *testing.T) {
+	var matched string
+	parser := &TWXParser{}
+	parser.AddHandler("Sector", HandlerPriorityDefault, func(line string) { matched = "short" })
+	parser.AddHandler("Sector  : ", HandlerPriorityDefault, func(line string) { matched = "long" })
+	parser.AddHandler("Sector  : 1", HandlerPriorityFallback, func(line string) { matched = "fallback" })
+
+	// The longer pattern wins at the same priority, and a higher priority wins over length
+	parser.checkPatterns("Sector  : 1 in uncharted space.")
+	if matched != "long" {
+		t.Errorf("Expected the longest default priority pattern to win, got %q", matched)
+	}
+
+	// Patterns that tie keep their registration order
+	parser.AddHandler("Sector  : ", HandlerPriorityDefault, func(line string) { matched = "second" })
+	parser.checkPatterns("Sector  : 2 in uncharted space.")
+	if matched != "long" {
+		t.Errorf("Expected the first handler added for a pattern to win, got %q", matched)
+	}
+}
+
+func TestTWXParser_ProbeLineIsNotCIMPrompt(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	parser.ProcessString("Probe entering sector : 274\r\n")
+	if parser.GetCurrentDisplay() == DisplayCIM {
+		t.Fatal("Expected a probe line not to start a CIM download")
+	}
+
+	parser.ProcessString(": \r\n")
+	if parser.GetCurrentDisplay() != DisplayCIM {
+		t.Errorf("Expected the CIM prompt to start a CIM download, got %v", parser.GetCurrentDisplay())
+	}
+}
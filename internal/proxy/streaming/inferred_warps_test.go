@@ -0,0 +1,49 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_InferredReverseWarps(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	// Sector 54 is known but its warps don't lead to 286
+	known := database.NULLSector()
+	known.Warp[0] = 99
+	known.Explored = database.EtHolo
+	if err := db.SaveSector(known, 54); err != nil {
+		t.Fatalf("Failed to save sector: %v", err)
+	}
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	showSector := func(sector, warps string) {
+		parser.ProcessInBound("\r\nSector  : " + sector + " in uncharted space.\r\n" +
+			"Warps to Sector(s) :  " + warps + "\r\n" +
+			"Command [TL=00:00:00]:[" + sector + "] (?=Help)? : ")
+	}
+
+	showSector("286", "54 - 801")
+	if warps, _ := db.GetWarps(54); warps != [6]int{99, 286, 0, 0, 0, 0} {
+		t.Errorf("Expected the reverse warp added to sector 54, got %v", warps)
+	}
+	if inferred, _ := db.GetInferredWarps(54); len(inferred) != 1 || inferred[0] != 286 {
+		t.Errorf("Expected 54 -> 286 to be inferred, got %v", inferred)
+	}
+	if info, _ := db.GetSectorInfo(54); len(info.InferredWarps) != 1 || info.InferredWarps[0] != 286 {
+		t.Errorf("Expected the sector info to carry the inferred warp, got %v", info.InferredWarps)
+	}
+	if inferred, _ := db.GetInferredWarps(286); len(inferred) != 0 {
+		t.Errorf("Expected the displayed sector's warps to be confirmed, got %v", inferred)
+	}
+
+	// Seeing sector 54's own warps confirms them
+	showSector("54", "99 - 286")
+	if inferred, _ := db.GetInferredWarps(54); len(inferred) != 0 {
+		t.Errorf("Expected 54 -> 286 confirmed, got %v", inferred)
+	}
+}
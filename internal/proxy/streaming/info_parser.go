@@ -22,18 +22,18 @@ const (
 // setupInfoHandlers sets up handlers for info display parsing
 func (p *TWXParser) setupInfoHandlers() {
 	// Info display headers - detect start of info display
-	p.AddHandler("<Info>", p.handleInfoDisplayStart)
+	p.AddHandler("<Info>", HandlerPriorityDefault, p.handleInfoDisplayStart)
 
 	// Info display fields - only active when inside info display
-	p.AddHandler("Trader Name    :", p.handleInfoTraderName)
-	p.AddHandler("Rank and Exp   :", p.handleInfoRankExp)
-	p.AddHandler("Ship Info      :", p.handleInfoShipInfo)
-	p.AddHandler("Turns left     :", p.handleInfoTurnsLeft)
-	p.AddHandler("Total Holds    :", p.handleInfoTotalHolds)
-	p.AddHandler("Fighters       :", p.handleInfoFighters)
-	p.AddHandler("Ether Probes   :", p.handleInfoEtherProbes)
-	p.AddHandler("Credits        :", p.handleInfoCredits)
-	p.AddHandler("Current Sector :", p.handleInfoCurrentSector)
+	p.AddHandler("Trader Name    :", HandlerPriorityDefault, p.handleInfoTraderName)
+	p.AddHandler("Rank and Exp   :", HandlerPriorityDefault, p.handleInfoRankExp)
+	p.AddHandler("Ship Info      :", HandlerPriorityDefault, p.handleInfoShipInfo)
+	p.AddHandler("Turns left     :", HandlerPriorityDefault, p.handleInfoTurnsLeft)
+	p.AddHandler("Total Holds    :", HandlerPriorityDefault, p.handleInfoTotalHolds)
+	p.AddHandler("Fighters       :", HandlerPriorityDefault, p.handleInfoFighters)
+	p.AddHandler("Ether Probes   :", HandlerPriorityDefault, p.handleInfoEtherProbes)
+	p.AddHandler("Credits        :", HandlerPriorityDefault, p.handleInfoCredits)
+	p.AddHandler("Current Sector :", HandlerPriorityDefault, p.handleInfoCurrentSector)
 }
 
 // Add info display state to TWXParser
@@ -80,9 +80,9 @@ func (p *TWXParser) completeInfoDisplay() {
 	p.infoDisplay.Active = false
 	p.infoDisplay.Complete = true
 
-	// Execute SQL update with ONLY discovered fields
-	if p.playerStatsTracker != nil && p.playerStatsTracker.HasUpdates() {
-		err := p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+	// Execute SQL update with ONLY discovered fields; dropped if the game database went away
+	if p.playerStatsTracker != nil && p.playerStatsTracker.HasUpdates() && p.HasDatabase() {
+		err := p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 		if err != nil {
 			log.Info("INFO_PARSER: Failed to update player stats", "error", err)
 			return
@@ -234,45 +234,30 @@ func (p *TWXParser) handleInfoTotalHolds(line string) {
 func (p *TWXParser) parseCargoHolds(cargoInfo string) {
 	defer p.recoverFromPanic("parseCargoHolds")
 
-	// Parse format: "Fuel Ore=2 Organics=3 Empty=15"
-
-	// Extract Fuel Ore
-	if orePos := strings.Index(cargoInfo, "Fuel Ore="); orePos >= 0 {
-		oreStart := orePos + 9 // After "Fuel Ore="
-		oreEnd := strings.IndexAny(cargoInfo[oreStart:], " \t")
-		if oreEnd == -1 {
-			oreEnd = len(cargoInfo) - oreStart
-		}
-		if oreEnd > 0 {
-			oreStr := cargoInfo[oreStart : oreStart+oreEnd]
-			oreHolds := p.parseIntSafe(oreStr)
-			if p.playerStatsTracker != nil {
-				p.playerStatsTracker.SetOreHolds(oreHolds)
-			}
-		}
+	// Parse format: "Fuel Ore=2 Organics=3 Equipment=1 Colonists=10 Empty=4"
+	// The game only lists cargo it carries, so a missing entry means 0 holds. Every cargo
+	// field is set, so the info screen and quick stats agree on whichever was seen last.
+	if p.playerStatsTracker == nil {
+		return
 	}
+	p.playerStatsTracker.SetOreHolds(p.parseCargoEntry(cargoInfo, "Fuel Ore="))
+	p.playerStatsTracker.SetOrgHolds(p.parseCargoEntry(cargoInfo, "Organics="))
+	p.playerStatsTracker.SetEquHolds(p.parseCargoEntry(cargoInfo, "Equipment="))
+	p.playerStatsTracker.SetColHolds(p.parseCargoEntry(cargoInfo, "Colonists="))
+}
 
-	// Extract Organics
-	if orgPos := strings.Index(cargoInfo, "Organics="); orgPos >= 0 {
-		orgStart := orgPos + 9 // After "Organics="
-		orgEnd := strings.IndexAny(cargoInfo[orgStart:], " \t")
-		if orgEnd == -1 {
-			orgEnd = len(cargoInfo) - orgStart
-		}
-		if orgEnd > 0 {
-			orgStr := cargoInfo[orgStart : orgStart+orgEnd]
-			orgHolds := p.parseIntSafe(orgStr)
-			if p.playerStatsTracker != nil {
-				p.playerStatsTracker.SetOrgHolds(orgHolds)
-			}
-		}
+// parseCargoEntry returns the holds count after label in a cargo breakdown, or 0 if absent
+func (p *TWXParser) parseCargoEntry(cargoInfo, label string) int {
+	pos := strings.Index(cargoInfo, label)
+	if pos < 0 {
+		return 0
 	}
 
-	// Equipment and Colonist holds set to 0 as defaults (not shown in this format)
-	if p.playerStatsTracker != nil {
-		p.playerStatsTracker.SetEquHolds(0)
-		p.playerStatsTracker.SetColHolds(0)
+	value := cargoInfo[pos+len(label):]
+	if end := strings.IndexAny(value, " \t"); end >= 0 {
+		value = value[:end]
 	}
+	return p.parseIntSafe(value)
 }
 
 // handleInfoFighters parses fighters from info display
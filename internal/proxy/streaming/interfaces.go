@@ -28,11 +28,17 @@ type Event struct {
 // EventHandler defines the signature for event handling functions
 type EventHandler func(event Event)
 
-// IEventBus defines the interface for event communication
+// IEventBus defines the interface for event communication. Handlers only receive events
+// of the type they subscribed to, so a listener for EventSectorComplete never sees text
+// events. A panicking handler is logged and doesn't stop delivery to the others.
 type IEventBus interface {
+	// Subscribe registers a handler for one event type and returns its subscription ID
 	Subscribe(eventType EventType, handler EventHandler) string
+	// Unsubscribe removes the handler registered under the subscription ID
 	Unsubscribe(eventType EventType, subscriptionID string)
+	// Fire delivers an event to its type's handlers before returning
 	Fire(event Event)
+	// FireAsync delivers an event to its type's handlers on their own goroutines
 	FireAsync(event Event)
 }
 
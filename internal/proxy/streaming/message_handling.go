@@ -3,6 +3,7 @@ package streaming
 import (
 	"strings"
 	"time"
+	"twist/internal/log"
 	"twist/internal/proxy/database"
 )
 
@@ -307,13 +308,27 @@ func (p *TWXParser) GetMessageHistoryByType(msgType MessageType) []MessageHistor
 	return filtered
 }
 
-// SetHistorySize sets the maximum history size
+// SetHistorySize sets how many messages the in-memory history keeps, dropping the oldest
+// beyond it. Sizes below 1 are ignored; the database keeps every message regardless.
 func (p *TWXParser) SetHistorySize(size int) {
+	if size < 1 {
+		log.Warn("Ignoring invalid message history size", "size", size)
+		return
+	}
 	p.maxHistorySize = size
-	// Trim existing history if needed
-	if len(p.messageHistory) > size {
-		p.messageHistory = p.messageHistory[len(p.messageHistory)-size:]
+	p.trimHistory()
+}
+
+// trimHistory drops the oldest messages beyond the history size. The kept messages are
+// copied to the front of the slice so dropped ones don't stay referenced by it.
+func (p *TWXParser) trimHistory() {
+	excess := len(p.messageHistory) - p.maxHistorySize
+	if excess <= 0 {
+		return
 	}
+	kept := copy(p.messageHistory, p.messageHistory[excess:])
+	clear(p.messageHistory[kept:])
+	p.messageHistory = p.messageHistory[:kept]
 }
 
 // GetRecentMessages returns the N most recent messages
@@ -343,10 +358,7 @@ func (p *TWXParser) addToHistory(msgType MessageType, content, sender string, ch
 
 	// Add to in-memory history (with size limit)
 	p.messageHistory = append(p.messageHistory, message)
-	if len(p.messageHistory) > p.maxHistorySize {
-		// Remove oldest messages
-		p.messageHistory = p.messageHistory[len(p.messageHistory)-p.maxHistorySize:]
-	}
+	p.trimHistory()
 
 	// Save to database (required) - convert inline without converter
 	dbMessage := database.TMessageHistory{
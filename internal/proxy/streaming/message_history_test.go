@@ -0,0 +1,48 @@
+package streaming
+
+import (
+	"fmt"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestMessageHistoryTrimsOldest(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	parser.SetHistorySize(5)
+
+	for i := 1; i <= 8; i++ {
+		if err := parser.addToHistory(MessageRadio, fmt.Sprintf("message %d", i), "Kirk", 1); err != nil {
+			t.Fatalf("Failed to add message %d: %v", i, err)
+		}
+	}
+
+	history := parser.GetMessageHistory()
+	if len(history) != 5 {
+		t.Fatalf("Expected 5 messages kept, got %d", len(history))
+	}
+	for i, msg := range history {
+		if want := fmt.Sprintf("message %d", i+4); msg.Content != want {
+			t.Errorf("Expected history[%d] to be %q, got %q", i, want, msg.Content)
+		}
+	}
+
+	// Shrinking the size trims what is already there; invalid sizes are ignored
+	parser.SetHistorySize(2)
+	parser.SetHistorySize(0)
+	history = parser.GetMessageHistory()
+	if len(history) != 2 || history[0].Content != "message 7" || history[1].Content != "message 8" {
+		t.Errorf("Expected messages 7 and 8 after shrinking, got %+v", history)
+	}
+	if err := parser.addToHistory(MessageRadio, "message 9", "Kirk", 1); err != nil {
+		t.Fatalf("Failed to add message 9: %v", err)
+	}
+	if history = parser.GetMessageHistory(); len(history) != 2 || history[1].Content != "message 9" {
+		t.Errorf("Expected the size of 2 to still apply, got %+v", history)
+	}
+}
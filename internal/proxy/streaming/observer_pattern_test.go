@@ -160,6 +160,65 @@ func TestEventBusBasic(t *testing.T) {
 	}
 }
 
+func TestEventBusDeliversOnlySubscribedTypes(t *testing.T) {
+	eventBus := NewEventBus()
+
+	var sectors, panics int
+	eventBus.Subscribe(EventSectorComplete, func(event Event) {
+		if event.Type != EventSectorComplete {
+			t.Errorf("Sector handler received event type %d", int(event.Type))
+		}
+		sectors++
+	})
+	eventBus.Subscribe(EventSectorComplete, func(event Event) {
+		panics++
+		panic("broken handler")
+	})
+
+	eventBus.Fire(Event{Type: EventText, Source: "TestSource"})
+	eventBus.Fire(Event{Type: EventStateChange, Source: "TestSource"})
+	eventBus.Fire(Event{Type: EventSectorComplete, Source: "TestSource"})
+
+	// The panicking handler doesn't stop delivery to the other one
+	if sectors != 1 || panics != 1 {
+		t.Errorf("Expected each sector handler to run once, got %d and %d", sectors, panics)
+	}
+}
+
+func TestEventBusSubscribeDuringDelivery(t *testing.T) {
+	eventBus := NewEventBus()
+
+	delivered := 0
+	eventBus.Subscribe(EventText, func(Event) { delivered++ })
+
+	var subscriptionID string
+	subscriptionID = eventBus.Subscribe(EventText, func(event Event) {
+		// Handlers can change subscriptions without deadlocking the bus
+		eventBus.Unsubscribe(EventText, subscriptionID)
+		eventBus.Subscribe(EventTextLine, func(Event) {})
+	})
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			id := eventBus.Subscribe(EventText, func(Event) {})
+			eventBus.Unsubscribe(EventText, id)
+		}
+		done <- true
+	}()
+	for i := 0; i < 100; i++ {
+		eventBus.Fire(Event{Type: EventText})
+	}
+	<-done
+
+	if count := eventBus.GetSubscriberCount(EventText); count != 1 {
+		t.Errorf("Expected only the counting subscriber left, got %d", count)
+	}
+	if delivered != 100 {
+		t.Errorf("Expected every event delivered to the counting subscriber, got %d", delivered)
+	}
+}
+
 func TestEventBusAsync(t *testing.T) {
 	eventBus := NewEventBus()
 
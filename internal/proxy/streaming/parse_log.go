@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/text/encoding/charmap"
+	"twist/internal/proxy/database"
+	"twist/internal/telnet"
+)
+
+// parseLogChunkSize is how much of a captured session is parsed at a time, about what one
+// read from the server returns
+const parseLogChunkSize = 4096
+
+// ParseLog parses a captured game session into db the way the pipeline parses what the
+// server sends, without a TUI, scripts or a server: telnet commands are stripped but not
+// answered. Returns the number of bytes read.
+func ParseLog(r io.Reader, db database.Database) (int64, error) {
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	telnetHandler := telnet.NewHandler(nil)
+	decoder := charmap.CodePage437.NewDecoder()
+
+	var total int64
+	buf := make([]byte, parseLogChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			total += int64(n)
+			if cleanData := telnetHandler.ProcessData(buf[:n]); len(cleanData) > 0 {
+				decoded, decodeErr := decoder.Bytes(cleanData)
+				if decodeErr != nil {
+					decoded = cleanData
+				}
+				processLines(parser, string(decoded))
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return total, fmt.Errorf("failed to read session: %w", err)
+		}
+	}
+
+	// Complete whatever display the session ended in
+	parser.Finalize()
+	return total, nil
+}
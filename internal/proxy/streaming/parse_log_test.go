@@ -0,0 +1,60 @@
+package streaming
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+	"twist/internal/proxy/database"
+	"twist/internal/telnet"
+)
+
+func TestParseLog(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "tw2002_login_sector.raw"))
+	if err != nil {
+		t.Fatalf("Failed to read capture: %v", err)
+	}
+
+	// A capture straight off the wire starts with the server's telnet negotiation
+	session := append([]byte{telnet.IAC, telnet.WILL, telnet.ECHO, telnet.IAC, telnet.WILL, telnet.SUPPRESS_GO_AHEAD}, data...)
+
+	for name, wrap := range map[string]func(io.Reader) io.Reader{
+		"whole":    func(r io.Reader) io.Reader { return r },
+		"one byte": iotest.OneByteReader,
+	} {
+		t.Run(name, func(t *testing.T) {
+			db := NewTestDatabase()
+			defer db.CloseDatabase()
+
+			n, err := ParseLog(wrap(bytes.NewReader(session)), db)
+			if err != nil {
+				t.Fatalf("ParseLog failed: %v", err)
+			}
+			if n != int64(len(session)) {
+				t.Errorf("Expected %d bytes read, got %d", len(session), n)
+			}
+
+			sector, err := db.LoadSector(2921)
+			if err != nil || sector.Warp != [6]int{3212, 7656, 0, 0, 0, 0} {
+				t.Errorf("Expected sector 2921 warping to 3212 and 7656, got %v (%v)", sector.Warp, err)
+			}
+			if port, err := db.LoadPort(2921); err != nil || port.Name != "Vega Depot" {
+				t.Errorf("Expected the Vega Depot in sector 2921, got %q (%v)", port.Name, err)
+			}
+			if sector, err := db.LoadSector(3212); err != nil || sector.Explored != database.EtHolo {
+				t.Errorf("Expected sector 3212 visited, got explored %d (%v)", sector.Explored, err)
+			}
+		})
+	}
+}
+
+func TestParseLogReadError(t *testing.T) {
+	db := NewTestDatabase()
+	defer db.CloseDatabase()
+
+	if _, err := ParseLog(iotest.ErrReader(os.ErrClosed), db); err == nil {
+		t.Error("Expected the read error to be returned")
+	}
+}
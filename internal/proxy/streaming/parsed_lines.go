@@ -0,0 +1,52 @@
+package streaming
+
+// ParsedLine is a complete line as the parser saw it, for debugging the parsers
+type ParsedLine struct {
+	Raw      string      // The line as received, with its ANSI codes
+	Stripped string      // The line the parsers matched against
+	Display  DisplayType // The display the parser was in once the line was handled
+}
+
+// String names the display type, as shown when debugging the parser
+func (d DisplayType) String() string {
+	switch d {
+	case DisplayNone:
+		return "None"
+	case DisplaySector:
+		return "Sector"
+	case DisplayDensity:
+		return "Density"
+	case DisplayWarpLane:
+		return "WarpLane"
+	case DisplayCIM:
+		return "CIM"
+	case DisplayPortCIM:
+		return "PortCIM"
+	case DisplayPort:
+		return "Port"
+	case DisplayPortCR:
+		return "PortCR"
+	case DisplayWarpCIM:
+		return "WarpCIM"
+	case DisplayFigScan:
+		return "FigScan"
+	default:
+		return "Unknown"
+	}
+}
+
+// RecentParsedLines returns the last lines the parser handled, oldest first, each with its
+// ANSI codes, the text the parsers matched and the display the parser was left in
+func (p *TWXParser) RecentParsedLines() []ParsedLine {
+	return p.recentParsedLines.snapshot()
+}
+
+// recordParsedLine remembers a line once it has been parsed, so its display state is the
+// one the line led to
+func (p *TWXParser) recordParsedLine(line string) {
+	p.recentParsedLines.add(ParsedLine{
+		Raw:      p.currentANSILine,
+		Stripped: line,
+		Display:  p.currentDisplay,
+	})
+}
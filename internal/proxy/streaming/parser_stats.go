@@ -0,0 +1,45 @@
+package streaming
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// ParserStats counts what the parser has done since it was created, for bug reports about
+// game text that didn't parse
+type ParserStats struct {
+	LinesProcessed      int64 // Complete lines received
+	SectorsSaved        int64 // Sector writes: sector displays, CIM and warp lanes, density scans
+	PortsSaved          int64 // Port writes: port reports, CIM and commerce reports
+	UnrecognizedPrompts int64 // Prompts no prompt handler knew
+	ErrorsRecovered     int64 // Panics the parser recovered from
+}
+
+// parserCounters are the running counts behind ParserStats. They are incremented on the
+// pipeline goroutine and read from the menu, so they are atomics; an uncontended add
+// costs about as much as a plain one.
+type parserCounters struct {
+	linesProcessed      atomic.Int64
+	sectorsSaved        atomic.Int64
+	portsSaved          atomic.Int64
+	unrecognizedPrompts atomic.Int64
+	errorsRecovered     atomic.Int64
+}
+
+// GetParserStats returns the parser's counts so far
+func (p *TWXParser) GetParserStats() ParserStats {
+	return ParserStats{
+		LinesProcessed:      p.counters.linesProcessed.Load(),
+		SectorsSaved:        p.counters.sectorsSaved.Load(),
+		PortsSaved:          p.counters.portsSaved.Load(),
+		UnrecognizedPrompts: p.counters.unrecognizedPrompts.Load(),
+		ErrorsRecovered:     p.counters.errorsRecovered.Load(),
+	}
+}
+
+// looksLikePrompt returns true if partial text left at the end of a chunk reads as a
+// prompt waiting for input, rather than a line split across chunks
+func looksLikePrompt(text string) bool {
+	text = strings.TrimSpace(text)
+	return strings.HasSuffix(text, "?") || strings.HasSuffix(text, ":")
+}
@@ -0,0 +1,48 @@
+package streaming
+
+import (
+	"testing"
+)
+
+func TestTWXParser_ParserStats(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Nova Station, Class 4 (SSB)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+
+	stats := parser.GetParserStats()
+	if stats.LinesProcessed < 4 {
+		t.Errorf("Expected at least 4 lines processed, got %d", stats.LinesProcessed)
+	}
+	if stats.SectorsSaved == 0 {
+		t.Error("Expected the sector display to be counted as a sector save")
+	}
+	if stats.PortsSaved == 0 {
+		t.Error("Expected the port in the sector display to be counted as a port save")
+	}
+	if stats.UnrecognizedPrompts != 0 {
+		t.Errorf("Expected the command prompt to be recognised, got %d unrecognized prompts", stats.UnrecognizedPrompts)
+	}
+
+	// A question no handler knows is counted, text split across chunks is not
+	parser.ProcessInBound("\r\nDo you wish to feed the tribbles? ")
+	parser.ProcessInBound("\r\nThe tribbles are ")
+	if got := parser.GetParserStats().UnrecognizedPrompts; got != 1 {
+		t.Errorf("Expected 1 unrecognized prompt, got %d", got)
+	}
+}
+
+func TestTWXParser_ParserStatsCountsRecoveredErrors(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	parser.errorRecoveryHandler("test", func() error {
+		panic("bad line")
+	})
+	if got := parser.GetParserStats().ErrorsRecovered; got != 1 {
+		t.Errorf("Expected 1 recovered error, got %d", got)
+	}
+}
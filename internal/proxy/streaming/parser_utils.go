@@ -137,31 +137,16 @@ func (p *TWXParser) classFromTradePattern(pattern string) int {
 		pattern = pattern[:idx]
 	}
 
-	switch pattern {
-	case "BBS":
-		return 1
-	case "BSB":
-		return 2
-	case "SBB":
-		return 3
-	case "SSB":
-		return 4
-	case "SBS":
-		return 5
-	case "BSS":
-		return 6
-	case "SSS":
-		return 7
-	case "BBB":
-		return 8
-	case "???":
+	// Stardock's trade pattern isn't shown
+	if pattern == "???" {
 		return 9
-	default:
-		return 0
 	}
+	return p.portClassFromPattern(pattern)
 }
 
-// parseIntSafe safely parses an integer, returning 0 on error
+// parseIntSafe safely parses an integer, returning 0 on error. Commas are stripped first,
+// since the game groups thousands in credits, experience, density and product amounts, so
+// every game number is parsed through here.
 func (p *TWXParser) parseIntSafe(s string) int {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -177,12 +162,6 @@ func (p *TWXParser) parseIntSafe(s string) int {
 	return 0
 }
 
-// parseIntSafeWithCommas parses integers that may contain commas
-func (p *TWXParser) parseIntSafeWithCommas(s string) int {
-	s = strings.ReplaceAll(s, ",", "")
-	return p.parseIntSafe(s)
-}
-
 // stringContainsWord checks if a string contains a whole word (not partial)
 func (p *TWXParser) stringContainsWord(s, word string) bool {
 	s = strings.ToLower(s)
@@ -2,6 +2,9 @@ package streaming
 
 import (
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
@@ -19,6 +22,10 @@ type ExternalScriptEngine interface {
 	ActivateTriggers() error
 	ProcessAutoText(text string) error
 	UpdateCurrentLine(text string) error
+	ProcessSectorComplete(sector int) error
+	ProcessSectorArrival(sector int) error
+	ProcessEvent(eventName string) error
+	GetRunningScriptCount() int
 }
 
 // scriptEngineAdapter adapts between external and internal interfaces
@@ -46,6 +53,22 @@ func (a *scriptEngineAdapter) UpdateCurrentLine(text string) error {
 	return a.engine.UpdateCurrentLine(text)
 }
 
+func (a *scriptEngineAdapter) ProcessSectorComplete(sector int) error {
+	return a.engine.ProcessSectorComplete(sector)
+}
+
+func (a *scriptEngineAdapter) ProcessSectorArrival(sector int) error {
+	return a.engine.ProcessSectorArrival(sector)
+}
+
+func (a *scriptEngineAdapter) ProcessEvent(eventName string) error {
+	return a.engine.ProcessEvent(eventName)
+}
+
+func (a *scriptEngineAdapter) GetRunningScriptCount() int {
+	return a.engine.GetRunningScriptCount()
+}
+
 // ScriptManager interface for script processing
 type ScriptManager interface {
 	ProcessGameLine(line string) (bool, error)
@@ -75,7 +98,8 @@ type Pipeline struct {
 	gameDetector  GameDetector // Game detection
 
 	// State
-	running bool
+	running atomic.Bool
+	writeMu sync.Mutex // Held while data is parsed, so Drain can wait for it
 
 	// Metrics
 	bytesProcessed   uint64
@@ -108,19 +132,47 @@ func NewPipeline(tuiAPI api.TuiAPI, getDatabaseFunc func() database.Database, sc
 
 // Start begins the streaming pipeline
 func (p *Pipeline) Start() {
-	p.running = true
+	p.running.Store(true)
 
 	// No goroutine needed - processing is now synchronous like TWX
 }
 
 // Stop gracefully shuts down the pipeline
 func (p *Pipeline) Stop() {
-	p.running = false
+	p.running.Store(false)
+}
+
+// Drain stops the pipeline once the data being parsed is done, so that chunk's writes are
+// committed. Returns false if that takes longer than timeout, e.g. while a script waits on
+// input; the pipeline still stops after the chunk.
+func (p *Pipeline) Drain(timeout time.Duration) bool {
+	p.running.Store(false)
+
+	done := make(chan struct{})
+	go func() {
+		p.writeMu.Lock()
+		defer p.writeMu.Unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
 }
 
 // Write feeds raw data into the pipeline
 func (p *Pipeline) Write(data []byte) {
-	if !p.running {
+	if !p.running.Load() {
+		return
+	}
+
+	// Drain waits for the lock to know the data has been parsed
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if !p.running.Load() {
 		return
 	}
 
@@ -146,20 +198,10 @@ func (p *Pipeline) processDataSync(rawData []byte) {
 			p.gameDetector.ProcessLine(string(decoded))
 		}
 
-		// Split into lines to process synchronously like TWX for scripts and parsing
-		lines := strings.Split(string(decoded), "\n")
-
-		for i, line := range lines {
-			// Skip empty lines except the last one (which might be a partial line)
-			if line == "" && i < len(lines)-1 {
-				continue
-			}
-
-			// SINGLE PROCESSING PATH like TWX Pascal: let TWX parser handle everything
-			// This will update CURRENTLINE AND fire script triggers in correct sequence
-			if p.twxParser != nil {
-				p.twxParser.ProcessInBound(line)
-			}
+		// SINGLE PROCESSING PATH like TWX Pascal: let TWX parser handle everything
+		// This will update CURRENTLINE AND fire script triggers in correct sequence
+		if p.twxParser != nil {
+			processLines(p.twxParser, string(decoded))
 		}
 
 		// Send full decoded data to TUI
@@ -171,11 +213,30 @@ func (p *Pipeline) processDataSync(rawData []byte) {
 	}
 }
 
+// processLines splits decoded server text into lines and parses them synchronously like
+// TWX, for scripts and parsing
+func processLines(parser *TWXParser, decoded string) {
+	lines := strings.Split(decoded, "\n")
+	for i, line := range lines {
+		// Skip empty lines except the last one (which might be a partial line)
+		if line == "" && i < len(lines)-1 {
+			continue
+		}
+		parser.ProcessInBound(line)
+	}
+}
+
 // SendTelnetNegotiation sends initial telnet negotiation
 func (p *Pipeline) SendTelnetNegotiation() error {
 	return p.telnetHandler.SendInitialNegotiation()
 }
 
+// ResetTelnet clears the telnet state of the previous connection, so a command it cut off
+// or the options it agreed on don't carry over to the next one
+func (p *Pipeline) ResetTelnet() {
+	p.telnetHandler.Reset()
+}
+
 // GetMetrics returns pipeline performance metrics
 func (p *Pipeline) GetMetrics() (bytesProcessed, batchesProcessed uint64) {
 	return p.bytesProcessed, p.batchesProcessed
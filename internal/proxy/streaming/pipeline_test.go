@@ -0,0 +1,28 @@
+package streaming
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPipelineDrainWaitsForDataBeingParsed(t *testing.T) {
+	p := &Pipeline{}
+	p.Start()
+
+	// Data is being parsed
+	p.writeMu.Lock()
+	if p.Drain(20 * time.Millisecond) {
+		t.Error("Expected Drain to time out while data is parsed")
+	}
+	p.writeMu.Unlock()
+
+	if !p.Drain(time.Second) {
+		t.Error("Expected Drain to finish once the data was parsed")
+	}
+	if p.running.Load() {
+		t.Error("Expected the drained pipeline to be stopped")
+	}
+
+	// Later data is dropped; parsing it would need the telnet handler this pipeline lacks
+	p.Write([]byte("Command [TL=00:00:00]:[1] (?=Help)? : "))
+}
@@ -0,0 +1,104 @@
+package streaming
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultPortClassesFile is read from the working directory when no other port classes
+// file is configured. A missing file is not an error.
+const DefaultPortClassesFile = "twist_port_classes.json"
+
+// PortClasses maps a port's trade pattern to its class index. A pattern has one letter per
+// product, fuel ore, organics then equipment: B if the port buys it, S if it sells it.
+type PortClasses map[string]int
+
+// DefaultPortClasses returns the stock TW2002 port classes
+func DefaultPortClasses() PortClasses {
+	return PortClasses{
+		"BBS": 1,
+		"BSB": 2,
+		"SBB": 3,
+		"SSB": 4,
+		"SBS": 5,
+		"BSS": 6,
+		"SSS": 7,
+		"BBB": 8,
+	}
+}
+
+// LoadPortClasses reads port classes for a server with custom classes from a JSON file. The
+// file is an object of trade pattern to class, used in place of the stock class for those
+// patterns:
+//
+//	{"BBB": 10, "SSS": 11}
+func LoadPortClasses(path string) (PortClasses, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var classes PortClasses
+	if err := json.Unmarshal(data, &classes); err != nil {
+		return nil, fmt.Errorf("failed to parse port classes %s: %w", path, err)
+	}
+
+	var errs []error
+	for pattern, class := range classes {
+		if !validTradePattern(pattern) {
+			errs = append(errs, fmt.Errorf("pattern %q is not three B or S letters", pattern))
+		}
+		if class <= 0 {
+			errs = append(errs, fmt.Errorf("pattern %q has class %d, classes start at 1", pattern, class))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid port classes in %s: %w", path, err)
+	}
+
+	return classes, nil
+}
+
+// validTradePattern returns true if the pattern is a B or S for each of the three products
+func validTradePattern(pattern string) bool {
+	if len(pattern) != 3 {
+		return false
+	}
+	for _, c := range pattern {
+		if c != 'B' && c != 'S' {
+			return false
+		}
+	}
+	return true
+}
+
+// tradePattern formats which products a port buys as a trade pattern, like "BBS"
+func tradePattern(buyOre, buyOrg, buyEquip bool) string {
+	var pattern strings.Builder
+	for _, buys := range []bool{buyOre, buyOrg, buyEquip} {
+		if buys {
+			pattern.WriteByte('B')
+		} else {
+			pattern.WriteByte('S')
+		}
+	}
+	return pattern.String()
+}
+
+// SetPortClasses sets the classes of a server's custom port classes, on top of the stock
+// classes. Sector displays, port reports and CIM port reports all read classes from it.
+func (p *TWXParser) SetPortClasses(custom PortClasses) {
+	classes := DefaultPortClasses()
+	for pattern, class := range custom {
+		classes[strings.ToUpper(pattern)] = class
+	}
+	p.portClasses = classes
+}
+
+// portClassFromPattern returns the class of ports trading in a pattern, or 0 if no class has it
+func (p *TWXParser) portClassFromPattern(pattern string) int {
+	return p.portClasses[pattern]
+}
@@ -0,0 +1,73 @@
+package streaming
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPortClasses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "port_classes.json")
+	if err := os.WriteFile(path, []byte(`{"SSS": 11, "BBB": 10}`), 0644); err != nil {
+		t.Fatalf("Failed to write port classes: %v", err)
+	}
+	classes, err := LoadPortClasses(path)
+	if err != nil {
+		t.Fatalf("LoadPortClasses failed: %v", err)
+	}
+	if len(classes) != 2 || classes["SSS"] != 11 || classes["BBB"] != 10 {
+		t.Errorf("Unexpected port classes %v", classes)
+	}
+
+	for _, invalid := range []string{`{"SSX": 11}`, `{"SS": 11}`, `{"SSS": 0}`, `["SSS"]`} {
+		if err := os.WriteFile(path, []byte(invalid), 0644); err != nil {
+			t.Fatalf("Failed to write port classes: %v", err)
+		}
+		if _, err := LoadPortClasses(path); err == nil {
+			t.Errorf("Expected %s to be rejected", invalid)
+		}
+	}
+}
+
+func TestTWXParser_CustomPortClasses(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	parser.SetPortClasses(PortClasses{"SSS": 11})
+
+	// CIM port reports have no class, so it comes from the pattern
+	parser.ProcessString(": \r")
+	parser.ProcessString("1234 5000 60% 3000 80% 2000 90%\r")
+	parser.ProcessString("1235 -5000 60% -3000 80% 2000 90%\r")
+
+	db := parser.GetDatabase()
+	if port, err := db.LoadPort(1234); err != nil || port.ClassIndex != 11 {
+		t.Errorf("Expected the SSS port to be custom class 11, got %d (err %v)", port.ClassIndex, err)
+	}
+	if port, err := db.LoadPort(1235); err != nil || port.ClassIndex != 1 {
+		t.Errorf("Expected the BBS port to keep stock class 1, got %d (err %v)", port.ClassIndex, err)
+	}
+	if got := parser.classFromTradePattern("SSSx2"); got != 11 {
+		t.Errorf("Expected the port report pattern SSS to be class 11, got %d", got)
+	}
+}
+
+func TestTWXParser_SectorDisplayPortPattern(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Nova Station, Class 12 (SSB)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[286] (?=Help)? : ")
+
+	port, err := parser.GetDatabase().LoadPort(286)
+	if err != nil {
+		t.Fatalf("LoadPort failed: %v", err)
+	}
+	if port.ClassIndex != 12 {
+		t.Errorf("Expected class 12, got %d", port.ClassIndex)
+	}
+	if port.BuyProduct != [3]bool{false, false, true} {
+		t.Errorf("Expected an SSB port to buy only equipment, got %v", port.BuyProduct)
+	}
+}
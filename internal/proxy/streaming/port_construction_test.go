@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestParseConstructionDays(t *testing.T) {
+	tests := []struct {
+		line   string
+		days   int
+		wantOK bool
+	}{
+		{"          (Under Construction - 5 days left)", 5, true},
+		{"          (Under Construction 12 days left)", 12, true},
+		{"          (under construction - 1 day left)", 1, true},
+		{"          Federation Station", 0, false},
+	}
+
+	for _, tt := range tests {
+		days, ok := parseConstructionDays(tt.line)
+		if days != tt.days || ok != tt.wantOK {
+			t.Errorf("parseConstructionDays(%q) = %d, %v, want %d, %v", tt.line, days, ok, tt.days, tt.wantOK)
+		}
+	}
+}
+
+func TestTWXParser_PortUnderConstruction(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	prompt := "\r\nCommand [TL=00:00:00]:[286] (?=Help)? : "
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Nova Station, Class 4 (SSB)\r\n" +
+		"          (Under Construction 5 days left)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+
+	port, err := db.GetPortInfo(286)
+	if err != nil || port == nil {
+		t.Fatalf("GetPortInfo failed: %v", err)
+	}
+	if port.BuildTime != 5 {
+		t.Errorf("Expected 5 days of construction left, got %d", port.BuildTime)
+	}
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Nova Station, Class 4 (SSB)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+
+	port, err = db.GetPortInfo(286)
+	if err != nil || port == nil {
+		t.Fatalf("GetPortInfo failed: %v", err)
+	}
+	if port.BuildTime != 0 {
+		t.Errorf("Expected a fully built port, got %d days left", port.BuildTime)
+	}
+}
@@ -1,6 +1,7 @@
 package streaming
 
 import (
+	"strconv"
 	"strings"
 	"twist/internal/api"
 	"twist/internal/log"
@@ -20,6 +21,67 @@ func (p *TWXParser) initializePortData(portName string) {
 	// Phase 3: Product data clearing no longer needed with trackers
 }
 
+// commerceReportPrefix starts the port report shown when docking
+const commerceReportPrefix = "Commerce report for "
+
+// extractCommerceReportName returns the port name from a commerce report line. The name
+// ends at the last ": " before the report's clock time, since port names may contain colons.
+// Without a recognizable time, the name ends at the last colon.
+func extractCommerceReportName(line string) string {
+	start := strings.Index(line, commerceReportPrefix)
+	if start == -1 {
+		return ""
+	}
+	rest := line[start+len(commerceReportPrefix):]
+
+	for end := strings.LastIndex(rest, ": "); end != -1; end = strings.LastIndex(rest[:end], ": ") {
+		if fields := strings.Fields(rest[end+2:]); len(fields) > 0 && isClockTime(fields[0]) {
+			return strings.TrimSpace(rest[:end])
+		}
+	}
+
+	if end := strings.LastIndex(rest, ":"); end != -1 {
+		rest = rest[:end]
+	}
+	return strings.TrimSpace(rest)
+}
+
+// isClockTime reports whether s looks like "10:02" or "10:02:07"
+func isClockTime(s string) bool {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+	for _, part := range parts {
+		if len(part) == 0 || len(part) > 2 {
+			return false
+		}
+		for _, char := range part {
+			if char < '0' || char > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseConstructionDays extracts the days left from a port construction notice such as
+// "(Under Construction - 5 days left)". ok is false when line isn't a construction notice.
+func parseConstructionDays(line string) (days int, ok bool) {
+	lineLower := strings.ToLower(line)
+	start := strings.Index(lineLower, "construction")
+	if start == -1 {
+		return 0, false
+	}
+
+	for _, field := range strings.Fields(line[start:]) {
+		if n, err := strconv.Atoi(strings.Trim(field, "()-,.")); err == nil && n >= 0 {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
 // handlePortCommodity processes commodity lines when in port context
 func (p *TWXParser) handlePortCommodity(line string) {
 	// Only process commodity lines when we're in port display mode
@@ -102,7 +164,7 @@ func (p *TWXParser) parsePortCommodityLine(line string) {
 	log.Info("PORT: parsed values", "status", status, "quantity_str", quantityStr, "percent_str", percentStr)
 
 	// Parse values
-	quantity := p.parseIntSafeWithCommas(quantityStr)
+	quantity := p.parseIntSafe(quantityStr)
 	percent := p.parseIntSafe(percentStr)
 	isBuying := strings.EqualFold(status, "Buying")
 
@@ -177,6 +239,11 @@ func (p *TWXParser) processLineInPortContext(line string) {
 		p.parseTurnsFromPortLine(line)
 	}
 
+	// Pattern 6: Construction notice for a port that isn't fully built
+	if days, ok := parseConstructionDays(line); ok && p.portTracker != nil {
+		p.portTracker.SetBuildTime(days)
+	}
+
 	// Pattern 5: Command prompt - exit port context
 	if strings.Contains(line, "Command [") {
 		log.Info("PORT: Found Command prompt line", "line", line)
@@ -206,7 +273,7 @@ func (p *TWXParser) parsePlayerStatsFromPortLine(line string) {
 
 					// Save and fire event with fresh database read
 					p.errorRecoveryHandler("savePlayerStatsFromPort", func() error {
-						err := p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+						err := p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 						if err == nil && p.tuiAPI != nil {
 							if fullPlayerStats, dbErr := p.GetDatabase().GetPlayerStatsInfo(); dbErr == nil {
 								p.firePlayerStatsEventDirect(fullPlayerStats)
@@ -221,10 +288,11 @@ func (p *TWXParser) parsePlayerStatsFromPortLine(line string) {
 						log.Info("PORT: Executing port tracker after player stats update", "update_count", len(updates))
 						log.Info("PORT: Port tracker updates", "updates", updates)
 						p.errorRecoveryHandler("executePortTrackerAfterStats", func() error {
-							err := p.portTracker.Execute(p.GetDatabase().GetDB())
+							err := p.portTracker.Execute(p.GetDatabase().GetExecutor())
 							if err != nil {
 								log.Info("PORT: Failed to execute port tracker", "error", err)
 							} else {
+								p.counters.portsSaved.Add(1)
 								log.Info("PORT: Successfully executed port tracker after player stats")
 							}
 							return err
@@ -290,7 +358,7 @@ func (p *TWXParser) parseExperienceFromPortLine(line string) {
 					// Save player stats to database and fire event
 					// Execute tracker and fire fresh database event
 					p.errorRecoveryHandler("savePlayerStatsFromPort", func() error {
-						err := p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+						err := p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 						if err == nil && p.tuiAPI != nil {
 							if fullPlayerStats, dbErr := p.GetDatabase().GetPlayerStatsInfo(); dbErr == nil {
 								p.firePlayerStatsEventDirect(fullPlayerStats)
@@ -325,7 +393,7 @@ func (p *TWXParser) parseTurnsFromPortLine(line string) {
 					// Save player stats to database and fire event
 					// Execute tracker and fire fresh database event
 					p.errorRecoveryHandler("savePlayerStatsFromPort", func() error {
-						err := p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+						err := p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 						if err == nil && p.tuiAPI != nil {
 							if fullPlayerStats, dbErr := p.GetDatabase().GetPlayerStatsInfo(); dbErr == nil {
 								p.firePlayerStatsEventDirect(fullPlayerStats)
@@ -356,7 +424,7 @@ func (p *TWXParser) exitPortContext() {
 // savePortData saves port data to the database
 func (p *TWXParser) savePortData() {
 
-	if p.GetDatabase() == nil || p.portSectorIndex <= 0 {
+	if !p.HasDatabase() || p.portSectorIndex <= 0 {
 		return
 	}
 
@@ -367,10 +435,11 @@ func (p *TWXParser) savePortData() {
 
 		// Execute the port tracker to save data to database
 		if p.portTracker.HasUpdates() {
-			err := p.portTracker.Execute(p.GetDatabase().GetDB())
+			err := p.portTracker.Execute(p.GetDatabase().GetExecutor())
 			if err != nil {
 				log.Info("PORT: Failed to execute port tracker", "error", err)
 			} else {
+				p.counters.portsSaved.Add(1)
 				log.Info("PORT: Successfully executed port tracker")
 
 				// Fire OnPortUpdated API event with fresh database read
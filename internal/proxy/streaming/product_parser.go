@@ -85,7 +85,7 @@ func (p *TWXParser) parseStandardProductFormat(line string, product *ProductInfo
 	// Get quantity (should be after status)
 	if statusIndex+1 < len(parts) {
 		quantityStr := parts[statusIndex+1]
-		product.Quantity = p.parseIntSafeWithCommas(quantityStr)
+		product.Quantity = p.parseIntSafe(quantityStr)
 	}
 
 	// Get percentage (last part should be like "100%")
@@ -116,7 +116,7 @@ func (p *TWXParser) parseAlternateProductFormat(line string, product *ProductInf
 	}
 
 	// Format: "1000 buying at 15"
-	product.Quantity = p.parseIntSafeWithCommas(parts[0])
+	product.Quantity = p.parseIntSafe(parts[0])
 
 	if strings.EqualFold(parts[1], "buying") {
 		product.Buying = true
@@ -171,7 +171,7 @@ func (p *TWXParser) extractProductInfo(line string, productType ProductType) {
 	for _, part := range parts {
 		// Look for quantities (numbers with commas)
 		if strings.Contains(part, ",") || p.isNumeric(part) {
-			if num := p.parseIntSafeWithCommas(part); num > 0 {
+			if num := p.parseIntSafe(part); num > 0 {
 				product.Quantity = num
 			}
 		}
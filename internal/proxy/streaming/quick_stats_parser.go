@@ -15,8 +15,8 @@ type QuickStatsDisplay struct {
 func (p *TWXParser) setupQuickStatsHandlers() {
 	// Quick stats patterns - detect lines containing separator character
 	// Format: " Sect 1│Turns 1,600│Creds 10,000│Figs 30│Shlds 0│..."
-	p.AddHandler("│", p.handleQuickStatsLine)
-	p.AddHandler(" Ship", p.handleQuickStatsLine) // Ship line format variant
+	p.AddHandler("│", HandlerPriorityDefault, p.handleQuickStatsLine)
+	p.AddHandler(" Ship", HandlerPriorityDefault, p.handleQuickStatsLine) // Ship line format variant
 }
 
 // initQuickStatsDisplay initializes quick stats parsing state
@@ -53,9 +53,9 @@ func (p *TWXParser) completeQuickStatsSession() {
 	p.quickStatsDisplay.Active = false
 	p.quickStatsDisplay.Complete = true
 
-	// Execute SQL update with ONLY discovered fields
-	if p.playerStatsTracker != nil && p.playerStatsTracker.HasUpdates() {
-		err := p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+	// Execute SQL update with ONLY discovered fields; dropped if the game database went away
+	if p.playerStatsTracker != nil && p.playerStatsTracker.HasUpdates() && p.HasDatabase() {
+		err := p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 		if err != nil {
 			log.Info("QUICK_STATS: Failed to update player stats", "error", err)
 			return
@@ -89,7 +89,12 @@ func (p *TWXParser) checkQuickStatsEnd(line string) {
 }
 
 // handleQuickStatsLine processes quick stats lines with separator-based format
-// Format: " Sect 1│Turns 1,600│Creds 10,000│Figs 30│Shlds 0│Hlds 40│Ore 0│Org 0│Equ 0"
+// (mirrors TWX Pascal ProcessQuickStats). The display wraps over several lines, each
+// handled as it arrives; the session is saved when the display ends:
+//
+//	Sect 1│Turns 1,600│Creds 10,000│Figs 30│Shlds 0│Hlds 40│Ore 0│Org 0
+//	Equ 0│Col 0│Phot 0│Armd 0│Lmpt 0│GTorp 0│TWarp No│Clks 0│Beacns 0│AtmDt 0
+//	Crbo 0│EPrb 0│MDis 0│PsPrb No│PlScn No│LRS Holo│Aln 0│Exp 0│Ship 1 MerCru
 func (p *TWXParser) handleQuickStatsLine(line string) {
 	defer p.recoverFromPanic("handleQuickStatsLine")
 
@@ -143,15 +148,18 @@ func (p *TWXParser) processQuickStatField(key, val string, parts []string) {
 	switch key {
 	case "Turns":
 		p.playerStatsTracker.SetCorp(0) // No corp displayed if player not member
-		p.playerStatsTracker.SetTurns(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetTurns(p.parseIntSafe(val))
 	case "Creds":
-		p.playerStatsTracker.SetCredits(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetCredits(p.parseIntSafe(val))
 	case "Figs":
-		p.playerStatsTracker.SetFighters(p.parseIntSafeWithCommas(val))
+		// Newer server skins abbreviate large counts, e.g. "1.5K"
+		if fighters := p.parseFighterQuantity(val); fighters != invalidFighterQuantity {
+			p.playerStatsTracker.SetFighters(fighters)
+		}
 	case "Shlds":
-		p.playerStatsTracker.SetShields(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetShields(p.parseIntSafe(val))
 	case "Crbo":
-		p.playerStatsTracker.SetCorbomite(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetCorbomite(p.parseIntSafe(val))
 	case "Hlds":
 		p.playerStatsTracker.SetTotalHolds(p.parseIntSafe(val))
 	case "Ore":
@@ -181,9 +189,9 @@ func (p *TWXParser) processQuickStatField(key, val string, parts []string) {
 	case "MDis":
 		p.playerStatsTracker.SetMineDisr(p.parseIntSafe(val))
 	case "Aln":
-		p.playerStatsTracker.SetAlignment(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetAlignment(p.parseIntSafe(val))
 	case "Exp":
-		p.playerStatsTracker.SetExperience(p.parseIntSafeWithCommas(val))
+		p.playerStatsTracker.SetExperience(p.parseIntSafe(val))
 	case "Corp":
 		p.playerStatsTracker.SetCorp(p.parseIntSafe(val))
 	case "TWarp":
@@ -0,0 +1,48 @@
+package streaming
+
+import "sync"
+
+// maxRecentLines is how many complete lines the parser remembers for bug reports
+const maxRecentLines = 50
+
+// recentLines remembers the last complete lines received from the server, as text or with
+// what the parser made of them, so a sector dump can include the text it was parsed from
+// and the terminal can be redrawn. Lines are added on the pipeline goroutine and read from
+// the menu and TUI, so access is locked.
+type recentLines[T any] struct {
+	mutex sync.Mutex
+	lines []T
+}
+
+// add records a complete line, dropping the oldest once the buffer is full
+func (r *recentLines[T]) add(line T) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.lines) == maxRecentLines {
+		copy(r.lines, r.lines[1:])
+		r.lines = r.lines[:maxRecentLines-1]
+	}
+	r.lines = append(r.lines, line)
+}
+
+// snapshot returns a copy of the remembered lines, oldest first
+func (r *recentLines[T]) snapshot() []T {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]T(nil), r.lines...)
+}
+
+// RecentLines returns the last complete lines received from the server, oldest first,
+// with ANSI codes stripped
+func (p *TWXParser) RecentLines() []string {
+	return p.recentLines.snapshot()
+}
+
+// RecentANSILines returns the last complete lines received from the server, oldest first,
+// with their ANSI codes. Each line starts by setting the colors it was shown in, even when
+// they were set on an earlier line or by a sequence split across chunks, so any line can be
+// displayed on its own.
+func (p *TWXParser) RecentANSILines() []string {
+	return p.recentANSILines.snapshot()
+}
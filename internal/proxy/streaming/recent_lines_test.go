@@ -0,0 +1,85 @@
+package streaming
+
+import (
+	"fmt"
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_RecentLines(t *testing.T) {
+	parser := NewTWXParser(nil, nil)
+
+	parser.ProcessString("\x1b[1;32mSector  : 12\x1b[0m in uncharted space.\r\nWarps to Sector(s) :  8 - 13\r\nCommand [TL=00:00:00]:")
+	lines := parser.RecentLines()
+	if len(lines) != 2 || lines[0] != "Sector  : 12 in uncharted space." || lines[1] != "Warps to Sector(s) :  8 - 13" {
+		t.Fatalf("Expected the two complete lines without ANSI codes, got %q", lines)
+	}
+
+	// Only the newest lines are kept
+	for i := 0; i < maxRecentLines+5; i++ {
+		parser.ProcessString(fmt.Sprintf("line %d\r\n", i))
+	}
+	lines = parser.RecentLines()
+	if len(lines) != maxRecentLines || lines[0] != "line 5" || lines[maxRecentLines-1] != fmt.Sprintf("line %d", maxRecentLines+4) {
+		t.Errorf("Expected lines 5 to %d, got %q .. %q", maxRecentLines+4, lines[0], lines[len(lines)-1])
+	}
+}
+
+func TestTWXParser_RecentANSILinesKeepColorAcrossChunks(t *testing.T) {
+	parser := NewTWXParser(nil, nil)
+
+	// Both color sequences are split by chunk boundaries, and the green runs onto the next line
+	parser.ProcessString("\x1b[1;3")
+	parser.ProcessString("2mSector  : 12\r\nWarps\x1b[0")
+	parser.ProcessString(";36m to Sector(s) :  8\r\nCommand [TL=00:00:00]:")
+	parser.ProcessString("\r\n")
+
+	expected := []string{
+		"\x1b[0m\x1b[1;32mSector  : 12",
+		"\x1b[0;1;32mWarps\x1b[0;36m to Sector(s) :  8",
+		"\x1b[0;36mCommand [TL=00:00:00]:",
+	}
+	lines := parser.RecentANSILines()
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], lines[i])
+		}
+	}
+
+	if stripped := parser.RecentLines(); len(stripped) != 3 || stripped[1] != "Warps to Sector(s) :  8" {
+		t.Errorf("Expected the stripped lines to be unaffected, got %q", stripped)
+	}
+}
+
+func TestTWXParser_RecentParsedLines(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+
+	parser.ProcessString("\x1b[1;32mSector  : 12\x1b[0m in uncharted space.\r\n")
+	parser.ProcessString("Warps to Sector(s) :  8 - 13\r\nCommand [TL=00:00:00]:[12] (?=Help)? : \r\n")
+
+	lines := parser.RecentParsedLines()
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 parsed lines, got %+v", lines)
+	}
+	if lines[0].Raw != "\x1b[1;32mSector  : 12\x1b[0m in uncharted space." || lines[0].Stripped != "Sector  : 12 in uncharted space." {
+		t.Errorf("Expected the raw and stripped sector line, got %+v", lines[0])
+	}
+	if lines[0].Display != DisplaySector || lines[1].Display != DisplaySector {
+		t.Errorf("Expected the sector display detected, got %v and %v", lines[0].Display, lines[1].Display)
+	}
+	if lines[2].Display != DisplayNone {
+		t.Errorf("Expected the prompt to end the sector display, got %v", lines[2].Display)
+	}
+	if DisplayPortCIM.String() != "PortCIM" {
+		t.Errorf("Expected PortCIM, got %q", DisplayPortCIM.String())
+	}
+}
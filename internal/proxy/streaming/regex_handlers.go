@@ -0,0 +1,43 @@
+package streaming
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// stardockPattern matches the StarDock line of the 'V' screen by column
+// (Pascal: Copy(Line, 14, 8) = 'StarDock' and Copy(Line, 37, 6) = 'sector')
+const stardockPattern = `^.{13}StarDock.{15,19}sector \d`
+
+// RegexPatternHandler holds a compiled pattern and its handler
+type RegexPatternHandler struct {
+	Pattern *regexp.Regexp
+	Handler PatternHandler
+}
+
+// AddRegexHandler adds a handler for complete lines matching a regular expression, for
+// lines a fixed substring can't pick out, such as column-aligned screens or server skins
+// that vary the wording. The pattern is compiled once here. Regex handlers are tried in
+// the order they were added, before the substring handlers.
+func (p *TWXParser) AddRegexHandler(pattern string, handler PatternHandler) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid handler pattern %q: %w", pattern, err)
+	}
+	p.regexHandlers = append(p.regexHandlers, RegexPatternHandler{
+		Pattern: re,
+		Handler: handler,
+	})
+	return nil
+}
+
+// checkRegexPatterns runs the first regex handler matching the line. Returns true if one did.
+func (p *TWXParser) checkRegexPatterns(line string) bool {
+	for _, rh := range p.regexHandlers {
+		if rh.Pattern.MatchString(line) {
+			rh.Handler(line)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package streaming
+
+import (
+	"testing"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_AddRegexHandler(t *testing.T) {
+	var matched []string
+	parser := &TWXParser{}
+	if err := parser.AddRegexHandler(`^<(\w+)> Sector \d+ `, func(line string) { matched = append(matched, "regex:"+line) }); err != nil {
+		t.Fatalf("AddRegexHandler failed: %v", err)
+	}
+	parser.AddHandler("Sector", HandlerPriorityDefault, func(line string) { matched = append(matched, "substring:"+line) })
+
+	parser.checkPatterns("<Skin> Sector 12 is quiet")
+	parser.checkPatterns("Sector 12 is quiet")
+
+	if len(matched) != 2 || matched[0] != "regex:<Skin> Sector 12 is quiet" || matched[1] != "substring:Sector 12 is quiet" {
+		t.Errorf("Expected the regex handler to take its line ahead of the substring handler, got %v", matched)
+	}
+}
+
+func TestTWXParser_AddRegexHandlerInvalid(t *testing.T) {
+	parser := &TWXParser{}
+	if err := parser.AddRegexHandler(`Sector (\d+`, func(line string) {}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if len(parser.regexHandlers) != 0 {
+		t.Errorf("Expected the invalid pattern not to be added, got %d handlers", len(parser.regexHandlers))
+	}
+}
+
+// BenchmarkTWXParser_CheckPatterns measures pattern matching of lines no handler takes,
+// which every regex handler and substring handler is tried against
+func BenchmarkTWXParser_CheckPatterns(b *testing.B) {
+	lines := []string{
+		"You have 20 turns left.",
+		"             Ferrengal                  sector 1234.",
+		"Fuel Ore   Buying    2890    100%       0",
+		"<Re-Display>",
+		"For getting caught your alignment went down by 15 points.",
+	}
+
+	for _, bench := range []struct {
+		name  string
+		regex bool
+	}{{"substring", false}, {"default", true}} {
+		b.Run(bench.name, func(b *testing.B) {
+			parser := NewTWXParser(func() database.Database { return nil }, nil)
+			if !bench.regex {
+				parser.regexHandlers = nil
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				parser.checkPatterns(lines[i%len(lines)])
+			}
+		})
+	}
+}
@@ -18,12 +18,27 @@ type ScriptEngine interface {
 
 	// UpdateCurrentLine updates the CURRENTLINE system constant (TWX compatibility)
 	UpdateCurrentLine(text string) error
+
+	// ProcessSectorComplete fires sector triggers for a completed sector (Twist extension)
+	ProcessSectorComplete(sector int) error
+
+	// ProcessSectorArrival fires arrival triggers for the sector the player arrived in (Twist extension)
+	ProcessSectorArrival(sector int) error
+
+	// ProcessEvent fires event triggers set for the named event (mirrors Pascal TWXInterpreter.ProgramEvent)
+	ProcessEvent(eventName string) error
+
+	// GetRunningScriptCount returns the number of scripts running to handle events
+	GetRunningScriptCount() int
 }
 
 // ScriptEventProcessor implements script event firing functionality
 type ScriptEventProcessor struct {
 	scriptEngine ScriptEngine
 	enabled      bool
+
+	// Called before running scripts handle an event, so they see the parser's writes
+	beforeFire func()
 }
 
 // NewScriptEventProcessor creates a new script event processor
@@ -45,11 +60,19 @@ func (sep *ScriptEventProcessor) IsEnabled() bool {
 	return sep.enabled && sep.scriptEngine != nil
 }
 
+// prepare runs the beforeFire hook if a script is running to handle the event
+func (sep *ScriptEventProcessor) prepare() {
+	if sep.beforeFire != nil && sep.scriptEngine.GetRunningScriptCount() > 0 {
+		sep.beforeFire()
+	}
+}
+
 // FireTextEvent fires a text event (mirrors Pascal TWXInterpreter.TextEvent)
 func (sep *ScriptEventProcessor) FireTextEvent(text string, blockExtended bool) error {
 	if !sep.IsEnabled() {
 		return nil
 	}
+	sep.prepare()
 
 	// In Pascal TWX, blockExtended parameter controls whether extended characters are processed
 	// For now, we'll process all text events
@@ -66,6 +89,7 @@ func (sep *ScriptEventProcessor) FireTextLineEvent(line string, blockExtended bo
 	if !sep.IsEnabled() {
 		return false, nil
 	}
+	sep.prepare()
 
 	return sep.scriptEngine.ProcessTextLine(line)
 }
@@ -75,6 +99,7 @@ func (sep *ScriptEventProcessor) FireActivateTriggers() error {
 	if !sep.IsEnabled() {
 		return nil
 	}
+	sep.prepare()
 
 	if err := sep.scriptEngine.ActivateTriggers(); err != nil {
 		return err
@@ -88,6 +113,7 @@ func (sep *ScriptEventProcessor) FireAutoTextEvent(text string, blockExtended bo
 	if !sep.IsEnabled() {
 		return nil
 	}
+	sep.prepare()
 
 	if err := sep.scriptEngine.ProcessAutoText(text); err != nil {
 		return err
@@ -96,6 +122,41 @@ func (sep *ScriptEventProcessor) FireAutoTextEvent(text string, blockExtended bo
 	return nil
 }
 
+// FireSectorCompleteEvent fires sector triggers for a sector the parser has finished
+// reading. Sectors complete while their last line, or the prompt after them, is parsed, so
+// sector triggers fire before that line's TextLineEvent, TextEvent and ActivateTriggers.
+func (sep *ScriptEventProcessor) FireSectorCompleteEvent(sector int) error {
+	if !sep.IsEnabled() {
+		return nil
+	}
+	sep.prepare()
+
+	return sep.scriptEngine.ProcessSectorComplete(sector)
+}
+
+// FireSectorArrivalEvent fires arrival triggers for the sector the player has arrived in.
+// Arrival follows the sector display completing, so arrival triggers fire after its sector
+// triggers.
+func (sep *ScriptEventProcessor) FireSectorArrivalEvent(sector int) error {
+	if !sep.IsEnabled() {
+		return nil
+	}
+	sep.prepare()
+
+	return sep.scriptEngine.ProcessSectorArrival(sector)
+}
+
+// FireProgramEvent fires the event triggers scripts set with setEventTrigger for the named
+// event
+func (sep *ScriptEventProcessor) FireProgramEvent(eventName string) error {
+	if !sep.IsEnabled() {
+		return nil
+	}
+	sep.prepare()
+
+	return sep.scriptEngine.ProcessEvent(eventName)
+}
+
 // ProcessLineWithScriptEvents processes a complete line with all appropriate script events
 // This mirrors the Pascal TWX logic where multiple events are fired for each line
 func (sep *ScriptEventProcessor) ProcessLineWithScriptEvents(line string) error {
@@ -1,16 +1,21 @@
 package streaming
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 	"twist/internal/proxy/database"
 )
 
 // MockScriptEngine implements ScriptEngine interface for testing
 type MockScriptEngine struct {
-	textEvents     []string
-	textLineEvents []string
-	autoTextEvents []string
-	triggersCalled int
+	textEvents       []string
+	textLineEvents   []string
+	autoTextEvents   []string
+	triggersCalled   int
+	completedSectors []int
+	arrivedSectors   []int
+	programEvents    []string
 }
 
 func NewMockScriptEngine() *MockScriptEngine {
@@ -48,6 +53,25 @@ func (m *MockScriptEngine) UpdateCurrentLine(text string) error {
 	return nil
 }
 
+func (m *MockScriptEngine) ProcessSectorComplete(sector int) error {
+	m.completedSectors = append(m.completedSectors, sector)
+	return nil
+}
+
+func (m *MockScriptEngine) ProcessSectorArrival(sector int) error {
+	m.arrivedSectors = append(m.arrivedSectors, sector)
+	return nil
+}
+
+func (m *MockScriptEngine) ProcessEvent(eventName string) error {
+	m.programEvents = append(m.programEvents, eventName)
+	return nil
+}
+
+func (m *MockScriptEngine) GetRunningScriptCount() int {
+	return 1
+}
+
 func TestScriptEventProcessor_Creation(t *testing.T) {
 	mockEngine := NewMockScriptEngine()
 	processor := NewScriptEventProcessor(mockEngine)
@@ -342,3 +366,85 @@ func TestTWXParser_PascalIntegrationBehavior(t *testing.T) {
 		t.Errorf("Expected triggers to be called %d times, got %d", len(testLines), mockEngine.triggersCalled)
 	}
 }
+
+func TestTWXParser_SectorCompleteFiresSectorTriggers(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	parser := NewTWXParser(func() database.Database { return db }, nil)
+	mockEngine := NewMockScriptEngine()
+	parser.SetScriptEngine(mockEngine)
+
+	parser.ProcessInBound("\r\nSector  : 705 in uncharted space.\r")
+	parser.ProcessInBound("Warps to Sector(s) :  279\r")
+	if len(mockEngine.completedSectors) != 0 {
+		t.Fatalf("Expected no completed sectors before the prompt, got %v", mockEngine.completedSectors)
+	}
+
+	parser.ProcessInBound("\rCommand [TL=00:00:00]:[705] (?=Help)? : ")
+	if len(mockEngine.completedSectors) != 1 || mockEngine.completedSectors[0] != 705 {
+		t.Errorf("Expected sector 705 to complete once, got %v", mockEngine.completedSectors)
+	}
+}
+
+func TestTWXParser_ArrivalTriggersSkipProbesAndRedisplays(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	mockEngine := NewMockScriptEngine()
+	parser.SetScriptEngine(mockEngine)
+
+	visit := func(sector string) {
+		parser.ProcessInBound("\r\nSector  : " + sector + " in uncharted space.\r\n" +
+			"Warps to Sector(s) :  1 - 2 - 3\r\n" +
+			"Command [TL=00:00:00]:[" + sector + "] (?=Help)? : ")
+	}
+
+	visit("1")
+	visit("2")
+	visit("2") // A re-display of the same sector isn't an arrival
+	// A probe completes sectors the player never enters
+	parser.ProcessInBound("\r\nProbe entering sector : 40\r\n" +
+		"\r\nSector  : 40 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  41\r\n" +
+		"Probe Self Destructs\r\n" +
+		"Command [TL=00:00:00]:[2] (?=Help)? : ")
+	visit("3")
+
+	if expected := []int{1, 2, 3}; !reflect.DeepEqual(mockEngine.arrivedSectors, expected) {
+		t.Errorf("Expected arrivals %v, got %v", expected, mockEngine.arrivedSectors)
+	}
+	if !slices.Contains(mockEngine.completedSectors, 40) {
+		t.Errorf("Expected the probed sector to still complete for sector triggers, got %v", mockEngine.completedSectors)
+	}
+}
+
+func TestTWXParser_ArrivalTriggersSkipHoloScannedSectors(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	mockEngine := NewMockScriptEngine()
+	parser.SetScriptEngine(mockEngine)
+
+	parser.ProcessInBound("\r\nSector  : 2921 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  3212 - 7656\r\n" +
+		"Command [TL=00:00:00]:[2921] (?=Help)? : ")
+	// A holo scan displays the adjacent sectors while the player stays put
+	parser.ProcessInBound("S\r\nSelect (H)olo Scan or (D)ensity Scan or (Q)uit? [D] H\r\n" +
+		"\r\nLong Range Scan\r\n" +
+		"\r\nSector  : 3212 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  2921\r\n" +
+		"\r\nSector  : 7656 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  2921\r\n" +
+		"\r\nCommand [TL=00:00:00]:[2921] (?=Help)? : ")
+
+	if expected := []int{2921}; !reflect.DeepEqual(mockEngine.arrivedSectors, expected) {
+		t.Errorf("Expected arrivals %v, got %v", expected, mockEngine.arrivedSectors)
+	}
+	for _, sector := range []int{3212, 7656} {
+		if !slices.Contains(mockEngine.completedSectors, sector) {
+			t.Errorf("Expected holo-scanned sector %d to still complete for sector triggers, got %v", sector, mockEngine.completedSectors)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package streaming
+
+import (
+	"strings"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+)
+
+// Program events fired when a sector display lists alien occupants, for scripts to catch
+// with setEventTrigger. Ferrengi attack on sight, so they get their own event for scripts
+// that route around them.
+const (
+	EventAliensSighted   = "Aliens sighted"
+	EventFerrengiSighted = "Ferrengi sighted"
+)
+
+// sectorAliens counts the alien occupants listed by the sector display being parsed. They
+// are listed like traders, one per line with the ship each flies on the line after:
+//
+//	Aliens  : Ferrengi Berserker Grax, w/ 1,500 ftrs,
+//	           in Ferrengi Assault Trader (Ferrengi Assault Trader)
+//	        Kovani Raider Tesh, w/ 40 ftrs,
+//	           in Kovani Scout (Kovani Scout)
+type sectorAliens struct {
+	count    int
+	ferrengi bool
+}
+
+// alienType returns the type stored for the occupants, empty if there are none
+func (a sectorAliens) alienType() string {
+	switch {
+	case a.count == 0:
+		return ""
+	case a.ferrengi:
+		return database.AlienTypeFerrengi
+	default:
+		return database.AlienTypeOther
+	}
+}
+
+// handleSectorAliens parses the first alien listed in a sector display
+func (p *TWXParser) handleSectorAliens(line string) {
+	p.sectorPosition = SectorPosAliens
+	p.addAlienOccupant(strings.TrimPrefix(line, "Aliens  : "))
+}
+
+// handleAlienContinuation parses the lines after "Aliens  : ", each either another alien or
+// the ship the one before it flies
+func (p *TWXParser) handleAlienContinuation(line string) {
+	if p.getParameter(line, 1) == "in" {
+		if strings.Contains(line, "Ferrengi") && p.sectorAliens.count > 0 {
+			p.sectorAliens.ferrengi = true
+			p.recordSectorAliens()
+		}
+		return
+	}
+	p.addAlienOccupant(line)
+}
+
+// addAlienOccupant counts one alien from its "<name>, w/ <n> ftrs," entry
+func (p *TWXParser) addAlienOccupant(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return
+	}
+	p.sectorAliens.count++
+	if strings.Contains(entry, "Ferrengi") {
+		p.sectorAliens.ferrengi = true
+	}
+	p.recordSectorAliens()
+}
+
+// recordSectorAliens passes the occupants counted so far to the sector tracker
+func (p *TWXParser) recordSectorAliens() {
+	if p.sectorTracker != nil {
+		p.sectorTracker.SetAliens(p.sectorAliens.count, p.sectorAliens.alienType())
+	}
+}
+
+// fireAliensSighted fires the program event for the aliens the completed sector display
+// listed, if it listed any
+func (p *TWXParser) fireAliensSighted() {
+	if p.sectorAliens.count == 0 || p.scriptEventProcessor == nil {
+		return
+	}
+
+	event := EventAliensSighted
+	if p.sectorAliens.ferrengi {
+		event = EventFerrengiSighted
+	}
+	log.Info("TWX_PARSER: Aliens in sector", "sector", p.currentSectorIndex, "count", p.sectorAliens.count, "type", p.sectorAliens.alienType())
+	if err := p.scriptEventProcessor.FireProgramEvent(event); err != nil {
+		log.Error("Error firing aliens event", "error", err, "sector", p.currentSectorIndex, "event", event)
+	}
+}
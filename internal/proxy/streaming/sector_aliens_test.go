@@ -0,0 +1,73 @@
+package streaming
+
+import (
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_SectorAliens(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	engine := NewMockScriptEngine()
+	parser.SetScriptEngine(engine)
+
+	parser.ProcessInBound("\r\nSector  : 512 in uncharted space.\r\n" +
+		"Aliens  : Ferrengi Berserker Grax, w/ 1,500 ftrs,\r\n" +
+		"           in Ferrengi Assault Trader (Ferrengi Assault Trader)\r\n" +
+		"        Kovani Raider Tesh, w/ 40 ftrs,\r\n" +
+		"           in Kovani Scout (Kovani Scout)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[512] (?=Help)? : ")
+
+	sector, err := parser.GetDatabase().LoadSector(512)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if sector.AlienCount != 2 || sector.AlienType != database.AlienTypeFerrengi {
+		t.Errorf("Expected 2 Ferrengi, got %d %q", sector.AlienCount, sector.AlienType)
+	}
+	if len(engine.programEvents) != 1 || engine.programEvents[0] != EventFerrengiSighted {
+		t.Errorf("Expected one %q event, got %v", EventFerrengiSighted, engine.programEvents)
+	}
+
+	// A later display without aliens clears them
+	parser.ProcessInBound("\r\nSector  : 512 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[512] (?=Help)? : ")
+
+	sector, err = parser.GetDatabase().LoadSector(512)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if sector.AlienCount != 0 || sector.AlienType != "" {
+		t.Errorf("Expected the aliens to be cleared, got %d %q", sector.AlienCount, sector.AlienType)
+	}
+	if len(engine.programEvents) != 1 {
+		t.Errorf("Expected no event without aliens, got %v", engine.programEvents)
+	}
+}
+
+func TestTWXParser_SectorOtherAliens(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	engine := NewMockScriptEngine()
+	parser.SetScriptEngine(engine)
+
+	parser.ProcessInBound("\r\nSector  : 77 in uncharted space.\r\n" +
+		"Aliens  : Kovani Raider Tesh, w/ 40 ftrs,\r\n" +
+		"           in Kovani Scout (Kovani Scout)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[77] (?=Help)? : ")
+
+	sector, err := parser.GetDatabase().LoadSector(77)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if sector.AlienCount != 1 || sector.AlienType != database.AlienTypeOther {
+		t.Errorf("Expected 1 alien, got %d %q", sector.AlienCount, sector.AlienType)
+	}
+	if len(engine.programEvents) != 1 || engine.programEvents[0] != EventAliensSighted {
+		t.Errorf("Expected one %q event, got %v", EventAliensSighted, engine.programEvents)
+	}
+}
@@ -0,0 +1,63 @@
+package streaming
+
+import "sync"
+
+// SectorHistorySize is how many visited sectors the parser remembers for backtracking
+const SectorHistorySize = 20
+
+// sectorHistory is a ring buffer of the sectors the player was in, as shown by the command
+// prompt, so probe-discovered and holo-scanned sectors never appear. A repeat of the latest
+// sector isn't recorded. The parser adds to it on the pipeline goroutine and the TUI reads it
+// through the proxy, so it has its own lock.
+type sectorHistory struct {
+	mutex   sync.RWMutex
+	sectors [SectorHistorySize]int
+	next    int // Slot the next sector is written to
+	count   int
+}
+
+// add records the sector the player is in, unless it is the one last recorded
+func (h *sectorHistory) add(sector int) {
+	if sector <= 0 {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.count > 0 && h.sectors[(h.next+SectorHistorySize-1)%SectorHistorySize] == sector {
+		return
+	}
+	h.sectors[h.next] = sector
+	h.next = (h.next + 1) % SectorHistorySize
+	h.count = min(h.count+1, SectorHistorySize)
+}
+
+// recent returns the recorded sectors, most recent first
+func (h *sectorHistory) recent() []int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	sectors := make([]int, h.count)
+	for i := range sectors {
+		sectors[i] = h.sectors[(h.next+SectorHistorySize-1-i)%SectorHistorySize]
+	}
+	return sectors
+}
+
+// clear forgets every recorded sector
+func (h *sectorHistory) clear() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.next = 0
+	h.count = 0
+}
+
+// RecentSectors returns the last sectors the player was in, most recent (the current sector)
+// first, without consecutive repeats
+func (p *TWXParser) RecentSectors() []int {
+	return p.visitedSectors.recent()
+}
+
+// AtCommandPrompt reports whether the server's output ends at the Command prompt, where
+// typing a sector number moves there. It is safe to call from any goroutine.
+func (p *TWXParser) AtCommandPrompt() bool {
+	return p.atCommandPrompt.Load()
+}
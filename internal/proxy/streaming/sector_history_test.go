@@ -0,0 +1,70 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTWXParser_RecentSectors(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	visit := func(sector string) {
+		parser.ProcessInBound("\r\nSector  : " + sector + " in uncharted space.\r\n" +
+			"Warps to Sector(s) :  1 - 2 - 3\r\n" +
+			"Command [TL=00:00:00]:[" + sector + "] (?=Help)? : ")
+	}
+
+	visit("1")
+	visit("2")
+	visit("2") // A re-display of the same sector isn't a new entry
+	// A probe shows sectors the player never enters
+	parser.ProcessInBound("\r\nProbe entering sector : 40\r\n" +
+		"\r\nSector  : 40 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  41\r\n" +
+		"Probe Self Destructs\r\n" +
+		"Command [TL=00:00:00]:[2] (?=Help)? : ")
+	visit("3")
+
+	expected := []int{3, 2, 1}
+	if got := parser.RecentSectors(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected recent sectors %v, got %v", expected, got)
+	}
+
+	parser.Reset()
+	if got := parser.RecentSectors(); len(got) != 0 {
+		t.Errorf("Expected Reset to clear recent sectors, got %v", got)
+	}
+}
+
+func TestSectorHistoryKeepsLatest(t *testing.T) {
+	var history sectorHistory
+	for sector := 1; sector <= SectorHistorySize+5; sector++ {
+		history.add(sector)
+	}
+
+	recent := history.recent()
+	if len(recent) != SectorHistorySize {
+		t.Fatalf("Expected %d sectors, got %d", SectorHistorySize, len(recent))
+	}
+	if recent[0] != SectorHistorySize+5 || recent[len(recent)-1] != 6 {
+		t.Errorf("Expected sectors %d down to 6, got %v", SectorHistorySize+5, recent)
+	}
+}
+
+func TestTWXParser_AtCommandPrompt(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	if parser.AtCommandPrompt() {
+		t.Error("Expected no Command prompt before any output")
+	}
+	parser.ProcessInBound("\r\nCommand [TL=00:00:00]:[1] (?=Help)? : ")
+	if !parser.AtCommandPrompt() {
+		t.Error("Expected the Command prompt to be seen")
+	}
+	parser.ProcessInBound("\r\n<Port>\r\n\r\nEnter your choice [T] ? ")
+	if parser.AtCommandPrompt() {
+		t.Error("Expected another prompt to end the Command prompt")
+	}
+}
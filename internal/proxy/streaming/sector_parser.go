@@ -1,21 +1,6 @@
 package streaming
 
-import (
-	"strconv"
-	"strings"
-)
-
-// isNumeric checks if a string represents a valid number
-func isNumeric(s string) bool {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return false
-	}
-	// Remove commas for number parsing
-	s = strings.ReplaceAll(s, ",", "")
-	_, err := strconv.Atoi(s)
-	return err == nil
-}
+import "strings"
 
 // ============================================================================
 // DETAILED SECTOR DATA PARSING (Mirrors TWX Pascal sector parsing logic)
@@ -150,11 +135,11 @@ func (p *TWXParser) handleShipContinuation(line string) {
 		return
 	}
 
-	// 2. Ship data that might be split across lines (ship types, alignments, etc.)
-	if strings.Contains(line, "(") && strings.Contains(line, ")") {
-		// This might be ship type or alignment information
-		// For collection tracker approach, we only care about complete ship entries
-		// Incomplete ship data spanning lines is handled by parseShipLine when it gets the full line
+	// 2. The ship type on its own line below the ship it belongs to
+	if strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")") {
+		if p.sectorCollections != nil {
+			p.sectorCollections.SetLastShipType(strings.TrimSpace(line[1 : len(line)-1]))
+		}
 		return
 	}
 
@@ -498,7 +483,7 @@ func (p *TWXParser) parseSectorMines(line string) {
 		// Parse quantity and type (e.g., "100 Limpet Mines")
 		parts := strings.Fields(mineStr)
 		if len(parts) >= 3 {
-			mine.Quantity = p.parseIntSafeWithCommas(parts[0])
+			mine.Quantity = p.parseIntSafe(parts[0])
 			mine.Type = parts[1] // "Armid" or "Limpet"
 		}
 
@@ -523,7 +508,7 @@ func (p *TWXParser) parseSectorFighters(line string) {
 		return
 	}
 
-	quantity := p.parseIntSafeWithCommas(parts[0])
+	quantity := p.parseIntSafe(parts[0])
 
 	// Extract owner
 	owner := ""
@@ -634,6 +619,8 @@ func (p *TWXParser) handleSectorContinuation(line string) {
 		p.handlePlanetContinuation(line)
 	case SectorPosMines:
 		p.handleMineContinuation(line)
+	case SectorPosAliens:
+		p.handleAlienContinuation(line)
 	default:
 	}
 }
@@ -685,7 +672,7 @@ func (p *TWXParser) handleTraderContinuation(line string) {
 
 			// Phase 4.5: Traders tracked via collection trackers only
 			if p.sectorCollections != nil {
-				p.sectorCollections.AddTrader(trader.Name, trader.ShipName, trader.ShipType, trader.Fighters)
+				p.sectorCollections.AddTrader(trader.Name, trader.ShipName, trader.ShipType, trader.Fighters, trader.Alignment)
 			}
 
 			// Reset currentTrader to prevent duplicate addition in sectorCompleted
@@ -715,7 +702,7 @@ func (p *TWXParser) handleTraderContinuation(line string) {
 
 			// Phase 4.5: Traders tracked via collection trackers only
 			if p.sectorCollections != nil {
-				p.sectorCollections.AddTrader(trader.Name, trader.ShipName, trader.ShipType, trader.Fighters)
+				p.sectorCollections.AddTrader(trader.Name, trader.ShipName, trader.ShipType, trader.Fighters, trader.Alignment)
 			}
 
 			// Reset currentTrader to prevent duplicate addition in sectorCompleted
@@ -825,7 +812,7 @@ func (p *TWXParser) handleMineContinuation(line string) {
 	parts := strings.Fields(line)
 	if len(parts) >= 3 {
 		mine := MineInfo{Owner: owner}
-		mine.Quantity = p.parseIntSafeWithCommas(parts[0])
+		mine.Quantity = p.parseIntSafe(parts[0])
 		mine.Type = parts[1] // "Armid" or "Limpet"
 
 		// Phase 4.5: Mines tracked directly to database (no intermediate collection)
@@ -834,48 +821,17 @@ func (p *TWXParser) handleMineContinuation(line string) {
 
 // handlePortContinuation handles port-specific continuation lines (mirrors Pascal lines 785-786)
 func (p *TWXParser) handlePortContinuation(line string) {
-
 	// Pascal: FCurrentSector.SPort.BuildTime := StrToIntSafe(GetParameter(Line, 4))
-	// However, the actual format varies, so we need to be flexible in parsing
-
-	buildTime := -1
-
-	// Try Pascal GetParameter(Line, 4) first for exact compatibility
-	param4 := p.getParameter(line, 4)
-	if param4 != "" && isNumeric(param4) {
-		pascalParam4 := p.parseIntSafe(param4)
-		buildTime = pascalParam4
-	} else {
-		// Fall back to flexible parsing for common formats
-		fields := strings.Fields(line)
-		for i, field := range fields {
-			// Look for numeric values that could be build time
-			if isNumeric(field) {
-				val := p.parseIntSafe(field)
-				if val >= 0 && val <= 9999 { // Reasonable build time range
-					// Check if this looks like a build time context
-					if i > 0 && (strings.Contains(strings.ToLower(fields[i-1]), "build") ||
-						strings.Contains(strings.ToLower(fields[i-1]), "time")) {
-						buildTime = val
-						break
-					}
-					// Also check if previous field contains ":"
-					if i > 0 && strings.HasSuffix(fields[i-1], ":") {
-						buildTime = val
-						break
-					}
-				}
-			}
-		}
+	// Matching the construction notice itself also covers "(Under Construction 5 days left)"
+	// and similar variants where the days aren't the fourth word.
+	buildTime, ok := parseConstructionDays(line)
+	if !ok {
+		return
 	}
 
-	if buildTime >= 0 {
-		// Store build time in current sector's port data
-		// Phase 3: Port build time tracked via PortTracker
-		if p.portTracker != nil {
-			p.portTracker.SetBuildTime(buildTime)
-		}
-	} else {
+	// Phase 3: Port build time tracked via PortTracker
+	if p.portTracker != nil {
+		p.portTracker.SetBuildTime(buildTime)
 	}
 }
 
@@ -963,9 +919,10 @@ func (p *TWXParser) handleSectorPorts(line string) {
 
 	// Parse port data (mirrors TWX Pascal logic from lines 671-703)
 	if strings.Contains(line, "<=-DANGER-=>") {
-		// Port is destroyed - set Dead flag
+		// Port is destroyed - set Dead flag and drop the products it no longer trades
 		if p.portTracker != nil {
-			p.portTracker.SetDead(true)
+			p.portTracker.SetDead(true).SetBuildTime(0)
+			p.portTracker.SetProductAmounts(0, 0, 0).SetProductPercents(0, 0, 0)
 		}
 		return
 	}
@@ -984,22 +941,26 @@ func (p *TWXParser) handleSectorPorts(line string) {
 
 	portName := strings.TrimSpace(portInfo[:classPos])
 
-	// Extract class number (Pascal: StrToIntSafe(Copy(Line, Pos(', Class', Line) + 8, 1)))
+	// Extract class number (Pascal: StrToIntSafe(Copy(Line, Pos(', Class', Line) + 8, 1))),
+	// reading every digit since servers with custom port classes can have classes past 9
 	classNum := 0
 	if classPos+8 < len(portInfo) {
-		classStr := string(portInfo[classPos+8])
+		classStr := portInfo[classPos+8:]
+		if end := strings.IndexFunc(classStr, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+			classStr = classStr[:end]
+		}
 		classNum = p.parseIntSafe(classStr)
 	}
 
 	// Parse buy/sell indicators from end of line (Pascal logic: lines 685-698)
-	// Format: "Port Name, Class 1 Port BBS" (last 3 chars indicate buy/sell)
+	// Format: "Port Name, Class 1 (BBS)" (the 3 chars before the closing parenthesis indicate buy/sell)
 	buyOre := false
 	buyOrg := false
 	buyEquip := false
 
-	if len(portInfo) >= 3 {
+	if pattern := strings.TrimSuffix(strings.TrimSpace(portInfo), ")"); len(pattern) >= 3 {
 		// Get last 3 characters for trade pattern
-		tradePattern := portInfo[len(portInfo)-3:]
+		tradePattern := pattern[len(pattern)-3:]
 
 		// Pascal logic: if (Line[length(Line) - 3] = 'B')
 		if len(tradePattern) >= 3 {
@@ -1016,7 +977,7 @@ func (p *TWXParser) handleSectorPorts(line string) {
 
 	// Phase 3: Store port information using straight-sql tracker
 	if p.portTracker != nil {
-		p.portTracker.SetName(portName).SetClassIndex(classNum).SetBuildTime(0)
+		p.portTracker.SetName(portName).SetClassIndex(classNum).SetBuildTime(0).SetDead(false)
 		p.portTracker.SetBuyProducts(buyOre, buyOrg, buyEquip)
 		// debug.Info("PORT: Tracker updated", "name", portName, "class", classNum, "buy_ore", buyOre, "buy_org", buyOrg, "buy_equip", buyEquip)
 	}
@@ -1024,28 +985,9 @@ func (p *TWXParser) handleSectorPorts(line string) {
 
 // determinePortClassFromPattern determines port class from buy/sell pattern (mirrors Pascal logic)
 func (p *TWXParser) determinePortClassFromPattern(buyOre, buyOrg, buyEquip bool) int {
-	// Mirror Pascal logic from ProcessPortLine (lines 1055-1062)
-	// BBS = Class 1, BSB = Class 2, SBB = Class 3, etc.
-
-	if buyOre && buyOrg && !buyEquip {
-		return 1 // BBS
-	} else if buyOre && !buyOrg && buyEquip {
-		return 2 // BSB
-	} else if !buyOre && buyOrg && buyEquip {
-		return 3 // SBB
-	} else if !buyOre && !buyOrg && buyEquip {
-		return 4 // SSB
-	} else if !buyOre && buyOrg && !buyEquip {
-		return 5 // SBS
-	} else if buyOre && !buyOrg && !buyEquip {
-		return 6 // BSS
-	} else if !buyOre && !buyOrg && !buyEquip {
-		return 7 // SSS
-	} else if buyOre && buyOrg && buyEquip {
-		return 8 // BBB
-	}
-
-	return 0 // Unknown pattern
+	// Mirror Pascal logic from ProcessPortLine (lines 1055-1062), through the port class
+	// table so servers with custom classes parse too: BBS = Class 1, BSB = Class 2, etc.
+	return p.portClassFromPattern(tradePattern(buyOre, buyOrg, buyEquip))
 }
 
 func (p *TWXParser) handleSectorPlanets(line string) {
@@ -0,0 +1,52 @@
+package streaming
+
+import (
+	"testing"
+
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_SectorShips(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	parser.ProcessInBound("\r\nSector  : 845 in uncharted space.\r\n" +
+		"Ships   : Mercury [Owned by Cosmic Pirates], w/ 1,000 ftrs,\r\n" +
+		"           (Scout Marauder)\r\n" +
+		"        Lucky Star [Owned by Joe Trader], w/ 250 ftrs,\r\n" +
+		"           (Merchant Freighter)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[845] (?=Help)? : ")
+
+	sector, err := parser.GetDatabase().LoadSector(845)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	expected := []database.TShip{
+		{Name: "Mercury", Owner: "Cosmic Pirates", ShipType: "Scout Marauder", Figs: 1000},
+		{Name: "Lucky Star", Owner: "Joe Trader", ShipType: "Merchant Freighter", Figs: 250},
+	}
+	if len(sector.Ships) != len(expected) {
+		t.Fatalf("Expected %d ships, got %+v", len(expected), sector.Ships)
+	}
+	for i, ship := range expected {
+		if sector.Ships[i] != ship {
+			t.Errorf("Expected ship %d to be %+v, got %+v", i, ship, sector.Ships[i])
+		}
+	}
+
+	// A later display with one ship replaces the list
+	parser.ProcessInBound("\r\nSector  : 845 in uncharted space.\r\n" +
+		"Ships   : Lucky Star [Owned by Joe Trader], w/ 200 ftrs,\r\n" +
+		"           (Merchant Freighter)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" +
+		"Command [TL=00:00:00]:[845] (?=Help)? : ")
+
+	sector, err = parser.GetDatabase().LoadSector(845)
+	if err != nil {
+		t.Fatalf("LoadSector failed: %v", err)
+	}
+	if len(sector.Ships) != 1 || sector.Ships[0].Name != "Lucky Star" || sector.Ships[0].Figs != 200 {
+		t.Errorf("Expected only Lucky Star with 200 fighters, got %+v", sector.Ships)
+	}
+}
@@ -0,0 +1,74 @@
+package streaming
+
+import (
+	"sync"
+
+	"twist/internal/api"
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+)
+
+// sessionSectors holds the sectors first explored this session: since the parser was
+// created for the connection, or last reset for a game switch. The parser adds to it on
+// the pipeline goroutine and the TUI reads it through the proxy, so it has its own lock.
+type sessionSectors struct {
+	mutex   sync.RWMutex
+	sectors map[int]bool
+}
+
+// add records a sector as first explored this session
+func (s *sessionSectors) add(sector int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.sectors == nil {
+		s.sectors = make(map[int]bool)
+	}
+	s.sectors[sector] = true
+}
+
+// contains returns true if the sector was first explored this session
+func (s *sessionSectors) contains(sector int) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.sectors[sector]
+}
+
+// count returns how many sectors were first explored this session
+func (s *sessionSectors) count() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.sectors)
+}
+
+// clear forgets every sector, starting a new session
+func (s *sessionSectors) clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sectors = nil
+}
+
+// recordSessionSector adds a sector about to be saved as explored to the session's sectors
+// if the database didn't already have it as explored
+func (p *TWXParser) recordSessionSector(sectorNum int) {
+	if sectorNum <= 0 || p.sessionSectors.contains(sectorNum) {
+		return
+	}
+	explored, err := p.GetDatabase().LoadExploredStatus([]int{sectorNum})
+	if err != nil {
+		log.Info("SECTOR: Failed to load explored status for session tracking", "sector", sectorNum, "error", err)
+		return
+	}
+	if explored[sectorNum] != database.EtHolo {
+		p.sessionSectors.add(sectorNum)
+	}
+}
+
+// IsNewThisSession returns true if the sector was first explored this session
+func (p *TWXParser) IsNewThisSession(sectorNum int) bool {
+	return p.sessionSectors.contains(sectorNum)
+}
+
+// MarkSessionSector sets a sector's NewThisSession flag, which the database can't know
+func (p *TWXParser) MarkSessionSector(info *api.SectorInfo) {
+	info.NewThisSession = p.sessionSectors.contains(info.Number)
+}
@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"testing"
+
+	"twist/internal/api"
+	"twist/internal/proxy/database"
+)
+
+func TestTWXParser_SessionSectors(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	// Sector 20 was explored in an earlier session
+	known := database.NULLSector()
+	known.Warp[0] = 10
+	known.Explored = database.EtHolo
+	if err := parser.GetDatabase().SaveSector(known, 20); err != nil {
+		t.Fatalf("SaveSector failed: %v", err)
+	}
+
+	parser.ProcessInBound("\r\nSector  : 10 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  20\r\n" +
+		"Command [TL=00:00:00]:[10] (?=Help)? : ")
+	parser.ProcessInBound("\r\nSector  : 20 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  10\r\n" +
+		"Command [TL=00:00:00]:[20] (?=Help)? : ")
+	// Revisiting a sector first explored this session keeps it new
+	parser.ProcessInBound("\r\nSector  : 10 in uncharted space.\r\n" +
+		"Warps to Sector(s) :  20\r\n" +
+		"Command [TL=00:00:00]:[10] (?=Help)? : ")
+
+	if !parser.IsNewThisSession(10) {
+		t.Error("Expected sector 10 to be new this session")
+	}
+	if parser.IsNewThisSession(20) {
+		t.Error("Expected sector 20, explored before, not to be new this session")
+	}
+
+	info := api.SectorInfo{Number: 10}
+	parser.MarkSessionSector(&info)
+	if !info.NewThisSession {
+		t.Error("Expected MarkSessionSector to flag sector 10")
+	}
+
+	parser.Reset()
+	if parser.IsNewThisSession(10) {
+		t.Error("Expected Reset to clear the session's sectors")
+	}
+}
@@ -0,0 +1,121 @@
+package streaming
+
+import (
+	"sync"
+
+	"twist/internal/api"
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+)
+
+// sessionStats holds the player's turns and credits when the session started and as last
+// seen, and how many ports the database knew of at the start. Like sessionSectors it is
+// written on the pipeline goroutine and read by the TUI, so it has its own lock.
+type sessionStats struct {
+	mutex        sync.Mutex
+	statsSeen    bool
+	startTurns   int
+	startCredits int
+	turns        int
+	credits      int
+	portsCounted bool
+	startPorts   int
+
+	// The last two player stats snapshots, for what the latest update changed
+	latest      api.PlayerStatsInfo
+	previous    api.PlayerStatsInfo
+	hasPrevious bool
+}
+
+// recordPlayerStats keeps the latest stats and the ones before them, and the first turns and
+// credits as the baseline
+func (s *sessionStats) recordPlayerStats(stats api.PlayerStatsInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.statsSeen {
+		s.statsSeen = true
+		s.startTurns = stats.Turns
+		s.startCredits = stats.Credits
+	} else {
+		s.previous = s.latest
+		s.hasPrevious = true
+	}
+	s.latest = stats
+	s.turns = stats.Turns
+	s.credits = stats.Credits
+}
+
+// countStartPorts records the ports the database knows of, if they weren't counted yet
+func (s *sessionStats) countStartPorts(db database.Database) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.portsCounted {
+		s.countPorts(db)
+	}
+}
+
+// recountStartPorts records the ports the database knows of now as the session's start
+func (s *sessionStats) recountStartPorts(db database.Database) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.countPorts(db)
+}
+
+// countPorts counts the database's ports as the baseline
+// This method assumes the caller already holds the mutex lock
+func (s *sessionStats) countPorts(db database.Database) {
+	s.portsCounted = false
+	count, err := db.CountPorts()
+	if err != nil {
+		log.Info("SESSION: Failed to count ports for session stats", "error", err)
+		return
+	}
+	s.startPorts = count
+	s.portsCounted = true
+}
+
+// clear forgets the baselines, starting a new session
+func (s *sessionStats) clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.statsSeen = false
+	s.portsCounted = false
+	s.latest = api.PlayerStatsInfo{}
+	s.previous = api.PlayerStatsInfo{}
+	s.hasPrevious = false
+}
+
+// GetSessionStats returns what the player has done this session: turns spent and credits
+// gained since the first player stats seen, and the sectors explored and ports found since
+// the session started
+func (p *TWXParser) GetSessionStats() api.SessionStats {
+	stats := api.SessionStats{SectorsExplored: p.sessionSectors.count()}
+
+	db, dbErr := p.Database()
+	if dbErr == nil {
+		p.sessionStats.countStartPorts(db)
+	}
+
+	s := &p.sessionStats
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.statsSeen {
+		stats.TurnsSpent = s.startTurns - s.turns
+		stats.CreditsChange = s.credits - s.startCredits
+	}
+	if s.portsCounted && dbErr == nil {
+		if count, err := db.CountPorts(); err == nil && count > s.startPorts {
+			stats.PortsDiscovered = count - s.startPorts
+		}
+	}
+	return stats
+}
+
+// GetPlayerStatsDelta returns the player stats last sent to the TUI and the ones sent before
+// them this session
+func (p *TWXParser) GetPlayerStatsDelta() api.PlayerStatsDelta {
+	s := &p.sessionStats
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return api.PlayerStatsDelta{Previous: s.previous, Current: s.latest, HasPrevious: s.hasPrevious}
+}
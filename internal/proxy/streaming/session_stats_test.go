@@ -0,0 +1,63 @@
+package streaming
+
+import (
+	"testing"
+
+	"twist/internal/api"
+)
+
+func TestTWXParser_SessionStats(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	prompt := "Command [TL=00:00:00]:[286] (?=Help)? : "
+
+	// A port known before the session started isn't counted as discovered
+	parser.ProcessInBound("\r\nSector  : 54 in uncharted space.\r\n" +
+		"Ports   : Old Faithful, Class 1 (BBS)\r\n" +
+		"Warps to Sector(s) :  286\r\n" + prompt)
+	parser.Reset()
+	parser.firePlayerStatsEventDirect(api.PlayerStatsInfo{Turns: 100, Credits: 5000})
+
+	parser.ProcessInBound("\r\nSector  : 286 in uncharted space.\r\n" +
+		"Ports   : Sol, Class 2 (BSB)\r\n" +
+		"Warps to Sector(s) :  54 - 801\r\n" + prompt)
+	parser.firePlayerStatsEventDirect(api.PlayerStatsInfo{Turns: 90, Credits: 3500})
+
+	expected := api.SessionStats{TurnsSpent: 10, CreditsChange: -1500, SectorsExplored: 1, PortsDiscovered: 1}
+	if stats := parser.GetSessionStats(); stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	// A reset for a new game starts the counts again
+	parser.Reset()
+	if stats := parser.GetSessionStats(); stats != (api.SessionStats{}) {
+		t.Errorf("Expected no session stats after a reset, got %+v", stats)
+	}
+}
+
+func TestTWXParser_PlayerStatsDelta(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+
+	parser.firePlayerStatsEventDirect(api.PlayerStatsInfo{Turns: 100, Credits: 5000, Fighters: 30})
+	delta := parser.GetPlayerStatsDelta()
+	if delta.HasPrevious || delta.Current.Turns != 100 || delta.TurnsSpent() != 0 || delta.CreditsChange() != 0 {
+		t.Errorf("Expected only the first update without changes, got %+v", delta)
+	}
+
+	parser.firePlayerStatsEventDirect(api.PlayerStatsInfo{Turns: 97, Credits: 6200, Fighters: 30})
+	parser.firePlayerStatsEventDirect(api.PlayerStatsInfo{Turns: 96, Credits: 5900, Fighters: 25})
+	delta = parser.GetPlayerStatsDelta()
+	if !delta.HasPrevious || delta.Previous.Fighters != 30 || delta.Current.Fighters != 25 {
+		t.Errorf("Expected the last two updates, got %+v", delta)
+	}
+	if delta.TurnsSpent() != 1 || delta.CreditsChange() != -300 {
+		t.Errorf("Expected 1 turn spent and 300 credits lost, got %d and %d", delta.TurnsSpent(), delta.CreditsChange())
+	}
+
+	// A new game starts over
+	parser.Reset()
+	if delta := parser.GetPlayerStatsDelta(); delta != (api.PlayerStatsDelta{}) {
+		t.Errorf("Expected no player stats after a reset, got %+v", delta)
+	}
+}
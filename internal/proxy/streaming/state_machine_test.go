@@ -2,6 +2,7 @@ package streaming
 
 import (
 	"testing"
+	"twist/internal/api"
 	"twist/internal/proxy/database"
 )
 
@@ -404,3 +405,79 @@ func TestStateMachineWorkflow(t *testing.T) {
 		}
 	})
 }
+
+func TestParserWithoutDatabase(t *testing.T) {
+	// Game data arriving before game detection loads a database must not panic
+	parser := NewTWXParser(func() database.Database { return nil }, nil)
+
+	if _, err := parser.Database(); err != ErrDatabaseNotInitialized {
+		t.Errorf("Expected ErrDatabaseNotInitialized, got %v", err)
+	}
+	if parser.HasDatabase() {
+		t.Error("Expected HasDatabase to be false without a database")
+	}
+
+	parser.ProcessInBound("Sector  : 1234 in Sol\r")
+	parser.ProcessInBound("Warps to Sector(s) :  2 - 3\r")
+	parser.ProcessInBound("Command [TL=00:00:00]:[1234] (?=Help)? : ")
+	parser.Finalize()
+
+	if parser.GetDatabase() != nil {
+		t.Error("Expected nil database from GetDatabase")
+	}
+}
+
+// playerStatsRecorder counts player stats events; other TuiAPI methods are not expected
+type playerStatsRecorder struct {
+	api.TuiAPI
+	events int
+}
+
+func (r *playerStatsRecorder) OnPlayerStatsUpdated(stats api.PlayerStatsInfo) {
+	r.events++
+}
+
+func TestTWXParser_DatabaseUnloadedMidDisplay(t *testing.T) {
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(":memory:"); err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.CloseDatabase()
+
+	// The game database goes away while an info display and a quick stats display are open
+	var current database.Database = db
+	recorder := &playerStatsRecorder{}
+	parser := NewTWXParser(func() database.Database { return current }, recorder)
+	if !parser.HasDatabase() {
+		t.Fatal("Expected HasDatabase to be true with a database")
+	}
+
+	parser.ProcessInBound("\r\n<Info>\r\n\r\nTurns left     : 19993\r\n")
+	parser.ProcessInBound("\r\n Sect 286│Turns 20,000│Creds 374,916\r\n")
+	current = nil
+	parser.ProcessInBound("\r\n\r\nCommand [TL=00:00:00]:[286] (?=Help)? : ")
+
+	// The stream ends with another info display still open
+	parser.ProcessInBound("\r\n<Info>\r\n\r\nTurns left     : 19990\r\n")
+	parser.Finalize()
+
+	if parser.HasDatabase() {
+		t.Error("Expected HasDatabase to be false after the database was unloaded")
+	}
+	if parser.infoDisplay.Active {
+		t.Error("Expected Finalize to end the info display")
+	}
+	if recorder.events != 0 {
+		t.Errorf("Expected no player stats events, got %d", recorder.events)
+	}
+	if recovered := parser.GetParserStats().ErrorsRecovered; recovered != 0 {
+		t.Errorf("Expected no recovered panics, got %d", recovered)
+	}
+	stats, err := db.GetPlayerStatsInfo()
+	if err != nil {
+		t.Fatalf("Failed to read player stats: %v", err)
+	}
+	if stats.Turns != 0 || stats.Credits != 0 {
+		t.Errorf("Expected nothing saved after the database was unloaded, got turns %d and credits %d", stats.Turns, stats.Credits)
+	}
+}
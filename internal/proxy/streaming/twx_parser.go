@@ -1,8 +1,13 @@
 package streaming
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"twist/internal/ansi"
 	"twist/internal/api"
@@ -36,6 +41,7 @@ const (
 	SectorPosShips
 	SectorPosMines
 	SectorPosTraders
+	SectorPosAliens
 )
 
 // PatternHandler is called when a pattern is matched
@@ -43,10 +49,18 @@ type PatternHandler func(line string)
 
 // OrderedPatternHandler holds a pattern and its handler in order
 type OrderedPatternHandler struct {
-	Pattern string
-	Handler PatternHandler
+	Pattern  string
+	Priority int
+	Handler  PatternHandler
 }
 
+// Handler priorities. Handlers are tried highest priority first, then longest pattern
+// first, so a specific pattern wins over a catch-all whatever order they were added in.
+const (
+	HandlerPriorityFallback = -10 // Catch-all patterns such as the CIM prompt's ": "
+	HandlerPriorityDefault  = 0
+)
+
 // PlayerStats type removed - using api.PlayerStatsInfo directly (straight-sql pattern)
 
 // FighterType represents the type of deployed fighters
@@ -191,6 +205,9 @@ type CurrentSector struct {
 	NavHaz        int      // Navigation hazard percentage
 }
 
+// defaultMaxHistorySize is how many messages the in-memory history keeps by default
+const defaultMaxHistorySize = 1000
+
 // TWXParser implements the TWX-style stream parser with buffering for partial lines
 type TWXParser struct {
 	// Buffering for partial lines (like TWX Pascal implementation)
@@ -199,6 +216,7 @@ type TWXParser struct {
 	rawANSILine     string
 	inANSI          bool
 	ansiStripper    *ansi.StreamingStripper // Handles ANSI sequences across chunks
+	ansiColor       ansi.ColorState         // Colors in effect at the start of currentANSILine
 
 	// State tracking (mirrors TWX Pascal state)
 	currentDisplay          DisplayType
@@ -242,12 +260,45 @@ type TWXParser struct {
 	messageHistory []MessageHistory
 	maxHistorySize int
 
+	// Last complete lines received, for sector dumps in bug reports
+	recentLines recentLines[string]
+
+	// The same lines with their ANSI codes, each starting in the colors it was shown in
+	recentANSILines recentLines[string]
+
+	// The same lines as received, with the display state each left the parser in
+	recentParsedLines recentLines[ParsedLine]
+
+	// Counts of lines, saves and failures, see GetParserStats
+	counters parserCounters
+
+	// Resets a display that never ended, see display_watchdog.go
+	watchdog displayWatchdog
+
+	// Where prompts no handler recognizes are logged, nil if they aren't
+	promptLog *unrecognizedPromptLog
+
+	// Port class for each trade pattern, see SetPortClasses
+	portClasses PortClasses
+
 	// Temporary storage for trader being parsed (minimal intermediate data)
 	currentTrader TraderInfo
 
+	// Alien occupants listed by the sector display being parsed
+	sectorAliens sectorAliens
+
+	// Program event last fired by a stop prompt, so it fires once per stop (see autopilot_events.go)
+	stopPromptFired string
+
+	// Sectors of the course being plotted, reported when it ends (see course_plot.go)
+	courseSectors []int
+
 	// Pattern handlers (ordered slice to ensure deterministic processing)
 	handlers []OrderedPatternHandler
 
+	// Regular expression handlers, tried before the pattern handlers (see regex_handlers.go)
+	regexHandlers []RegexPatternHandler
+
 	// Position tracking
 	position int64
 	lastChar rune
@@ -265,23 +316,99 @@ type TWXParser struct {
 	observers         []IObserver
 	eventBus          IEventBus
 	scriptInterpreter IScriptInterpreter
+
+	// Set while game data is skipped for lack of a database, so the warning is logged once
+	databaseMissingLogged bool
+
+	// Session holding the transaction opened for the chunk being processed, nil if none, and
+	// the database it was opened on (see chunk_transaction.go)
+	chunkDB           database.Database
+	chunkSource       database.Database
+	lineSavepointOpen bool
+
+	// Database the parser last wrote to, used to reset state when the game switches
+	activeDB database.Database
+
+	// Sector last sent to the TUI as the current sector, so repeats aren't re-sent
+	lastNotifiedSector int
+
+	// Sector last passed to script arrival triggers, so redisplays don't fire them again
+	lastArrivedSector int
+
+	// Exploration statuses looked up during the current density scan, and the sector the
+	// scan was made from until its neighbourhood has been loaded (see density_explored.go)
+	densityExplored map[int]database.TSectorExploredType
+	densityOrigin   int
+
+	// Sectors first explored since the last reset (see session_sectors.go)
+	sessionSectors sessionSectors
+
+	// Turns, credits and ports at the start of the session (see session_stats.go)
+	sessionStats sessionStats
+
+	// Sectors the player was last in, for backtracking (see sector_history.go)
+	visitedSectors sectorHistory
+
+	// Set while the server's output ends at the Command prompt (see sector_history.go)
+	atCommandPrompt atomic.Bool
+
+	// Sectors changed by the CIM download in progress (see cim_burst.go)
+	cimBurst cimBurst
 }
 
-// GetDatabase returns the database instance, panicking if it's nil
-func (p *TWXParser) GetDatabase() database.Database {
+// ErrDatabaseNotInitialized is returned when game data arrives before a game database is loaded
+var ErrDatabaseNotInitialized = errors.New("game database not initialized - game detection has not loaded a database for this connection")
+
+// Database returns the database instance, or ErrDatabaseNotInitialized if none is loaded yet.
+// While a chunk is processed it returns the chunk's transaction, so it's for the parsing
+// goroutine only; other callers use loadedDatabase.
+func (p *TWXParser) Database() (database.Database, error) {
+	db, err := p.loadedDatabase()
+	if err == nil && p.chunkDB != nil && db == p.chunkSource {
+		return p.chunkDB, nil
+	}
+	return db, err
+}
+
+// loadedDatabase returns the database game detection has loaded, outside any chunk transaction
+func (p *TWXParser) loadedDatabase() (database.Database, error) {
 	if p.getDatabaseFunc == nil {
-		log.Error("CRITICAL: getDatabaseFunc is nil in TWXParser", "parser", p)
-		panic("getDatabaseFunc is nil - proxy initialization failed")
+		return nil, fmt.Errorf("%w: no database accessor configured", ErrDatabaseNotInitialized)
 	}
 	db := p.getDatabaseFunc()
 	if db == nil {
-		log.Error("CRITICAL: Database returned nil from getDatabaseFunc", "func", p.getDatabaseFunc)
-		panic("Database is nil - game detector should have set this up")
+		return nil, ErrDatabaseNotInitialized
 	}
-	log.Debug("Database retrieved successfully", "db", db)
+	return db, nil
+}
+
+// GetDatabase returns the database instance, or nil if none is loaded yet.
+// Callers outside the line-processing path should use Database to get the error.
+func (p *TWXParser) GetDatabase() database.Database {
+	// No database is the normal state until a game is selected, so this isn't logged
+	db, _ := p.Database()
 	return db
 }
 
+// HasDatabase reports whether a game database is loaded, so handlers can skip database work
+func (p *TWXParser) HasDatabase() bool {
+	return p.getDatabaseFunc != nil && p.getDatabaseFunc() != nil
+}
+
+// databaseReady reports whether game data can be parsed, logging once while the database is missing
+func (p *TWXParser) databaseReady() bool {
+	if !p.HasDatabase() {
+		if !p.databaseMissingLogged {
+			_, err := p.Database()
+			log.Warn("TWXParser: skipping game data parsing", "error", err)
+			p.databaseMissingLogged = true
+		}
+		return false
+	}
+	p.databaseMissingLogged = false
+	return true
+}
+
 // NewTWXParser creates a new TWX-style parser with database accessor and TUI API
 func NewTWXParser(getDatabaseFunc func() database.Database, tuiAPI api.TuiAPI) *TWXParser {
 	parser := &TWXParser{
@@ -299,7 +426,7 @@ func NewTWXParser(getDatabaseFunc func() database.Database, tuiAPI api.TuiAPI) *
 		handlers:               make([]OrderedPatternHandler, 0),
 		position:               0,
 		lastChar:               0,
-		maxHistorySize:         1000,
+		maxHistorySize:         defaultMaxHistorySize,
 		getDatabaseFunc:        getDatabaseFunc, // Database accessor
 		tuiAPI:                 tuiAPI,          // Optional TUI API
 		// Version detection fields
@@ -312,11 +439,17 @@ func NewTWXParser(getDatabaseFunc func() database.Database, tuiAPI api.TuiAPI) *
 		scriptEventProcessor: NewScriptEventProcessor(nil),
 		// Initialize observer pattern
 		observers: make([]IObserver, 0),
+		// Stock TW2002 port classes until a server's own are set
+		portClasses: DefaultPortClasses(),
+		// Reset displays that never end
+		watchdog: displayWatchdog{limit: DefaultDisplayWatchdogLines},
 	}
 
 	// Initialize event bus and script interpreter
 	parser.eventBus = NewEventBus()
 	parser.scriptInterpreter = NewScriptInterpreter(parser.eventBus)
+	parser.subscribeSectorTriggers()
+	parser.scriptEventProcessor.beforeFire = parser.shareChunkWrites
 
 	// Initialize info display
 	parser.initInfoDisplay()
@@ -335,6 +468,7 @@ func NewTWXParser(getDatabaseFunc func() database.Database, tuiAPI api.TuiAPI) *
 func (p *TWXParser) SetScriptEngine(scriptEngine ScriptEngine) {
 	if p.scriptEventProcessor == nil {
 		p.scriptEventProcessor = NewScriptEventProcessor(scriptEngine)
+		p.scriptEventProcessor.beforeFire = p.shareChunkWrites
 	} else {
 		p.scriptEventProcessor.SetScriptEngine(scriptEngine)
 	}
@@ -345,68 +479,76 @@ func (p *TWXParser) GetScriptEventProcessor() *ScriptEventProcessor {
 	return p.scriptEventProcessor
 }
 
-// AddHandler adds a pattern handler
-func (p *TWXParser) AddHandler(pattern string, handler PatternHandler) {
+// AddHandler adds a pattern handler. Handlers with a higher priority are tried first,
+// then those with longer patterns; handlers that tie stay in the order they were added.
+func (p *TWXParser) AddHandler(pattern string, priority int, handler PatternHandler) {
 	p.handlers = append(p.handlers, OrderedPatternHandler{
-		Pattern: pattern,
-		Handler: handler,
+		Pattern:  pattern,
+		Priority: priority,
+		Handler:  handler,
+	})
+	sort.SliceStable(p.handlers, func(i, j int) bool {
+		if p.handlers[i].Priority != p.handlers[j].Priority {
+			return p.handlers[i].Priority > p.handlers[j].Priority
+		}
+		return len(p.handlers[i].Pattern) > len(p.handlers[j].Pattern)
 	})
 }
 
 // setupDefaultHandlers sets up the core TWX pattern handlers
 func (p *TWXParser) setupDefaultHandlers() {
 	// Command prompts
-	p.AddHandler("Command [TL=", p.handleCommandPrompt)
-	p.AddHandler("Computer command [TL=", p.handleComputerPrompt)
-	p.AddHandler("Probe entering sector :", p.handleProbePrompt)
-	p.AddHandler("Probe Self Destructs", p.handleProbePrompt)
-	p.AddHandler("Stop in this sector", p.handleStopPrompt)
-	p.AddHandler("Engage the Autopilot?", p.handleStopPrompt)
-	// Sector data (must be before CIM detection to avoid false matches)
-	p.AddHandler("Sector  : ", p.handleSectorStart)
-	p.AddHandler("Sector  :", p.handleSectorStart) // Handle variant without space before colon
-	p.AddHandler("Warps to Sector(s) :", p.handleSectorWarps)
-	p.AddHandler("Beacon  : ", p.handleSectorBeacon)
-	p.AddHandler("Ports   : ", p.handleSectorPorts)
-	p.AddHandler("Planets : ", p.handleSectorPlanets)
-	p.AddHandler("Traders : ", p.handleSectorTraders)
-	p.AddHandler("Ships   : ", p.handleSectorShips)
-	p.AddHandler("Fighters: ", p.handleSectorFighters)
-	p.AddHandler("NavHaz  : ", p.handleSectorNavHaz)
-	p.AddHandler("Mines   : ", p.handleSectorMines)
-
-	p.AddHandler(": ", p.handleCIMPrompt)
+	p.AddHandler("Command [TL=", HandlerPriorityDefault, p.handleCommandPrompt)
+	p.AddHandler("Computer command [TL=", HandlerPriorityDefault, p.handleComputerPrompt)
+	p.AddHandler("Probe entering sector :", HandlerPriorityDefault, p.handleProbePrompt)
+	p.AddHandler("Probe Self Destructs", HandlerPriorityDefault, p.handleProbePrompt)
+	p.AddHandler("Stop in this sector", HandlerPriorityDefault, p.handleStopPrompt)
+	p.AddHandler("Engage the Autopilot?", HandlerPriorityDefault, p.handleStopPrompt)
+	// Sector data
+	p.AddHandler("Sector  : ", HandlerPriorityDefault, p.handleSectorStart)
+	p.AddHandler("Sector  :", HandlerPriorityDefault, p.handleSectorStart) // Handle variant without space before colon
+	p.AddHandler("Warps to Sector(s) :", HandlerPriorityDefault, p.handleSectorWarps)
+	p.AddHandler("Beacon  : ", HandlerPriorityDefault, p.handleSectorBeacon)
+	p.AddHandler("Ports   : ", HandlerPriorityDefault, p.handleSectorPorts)
+	p.AddHandler("Planets : ", HandlerPriorityDefault, p.handleSectorPlanets)
+	p.AddHandler("Traders : ", HandlerPriorityDefault, p.handleSectorTraders)
+	p.AddHandler("Ships   : ", HandlerPriorityDefault, p.handleSectorShips)
+	p.AddHandler("Aliens  : ", HandlerPriorityDefault, p.handleSectorAliens)
+	p.AddHandler("Fighters: ", HandlerPriorityDefault, p.handleSectorFighters)
+	p.AddHandler("NavHaz  : ", HandlerPriorityDefault, p.handleSectorNavHaz)
+	p.AddHandler("Mines   : ", HandlerPriorityDefault, p.handleSectorMines)
+
+	p.AddHandler(": ", HandlerPriorityFallback, p.handleCIMPrompt)
 
 	// Port data
-	p.AddHandler("Docking...", p.handlePortDocking)
-	p.AddHandler("Commerce report for ", p.handlePortReport)
-	p.AddHandler("What sector is the port in? ", p.handlePortCR)
-
-	// Density scanner
-	p.AddHandler("Relative Density", p.handleDensityStart)
+	p.AddHandler("Docking...", HandlerPriorityDefault, p.handlePortDocking)
+	p.AddHandler("Commerce report for ", HandlerPriorityDefault, p.handlePortReport)
+	p.AddHandler("What sector is the port in? ", HandlerPriorityDefault, p.handlePortCR)
 
 	// Warp lanes
-	p.AddHandler("The shortest path (", p.handleWarpLaneStart)
-	p.AddHandler("  TO > ", p.handleWarpLaneStart)
+	p.AddHandler("The shortest path (", HandlerPriorityDefault, p.handleWarpLaneStart)
+	p.AddHandler("  TO > ", HandlerPriorityDefault, p.handleWarpLaneStart)
 
 	// Fighter scan
-	p.AddHandler("Deployed  Fighter  Scan", p.handleFigScanStart)
+	p.AddHandler("Deployed  Fighter  Scan", HandlerPriorityDefault, p.handleFigScanStart)
 
 	// Version detection
-	p.AddHandler("TradeWars Game", p.handleTWGSVersion)
-	p.AddHandler("Trade Wars 2002 Game", p.handleTW2002Version)
+	p.AddHandler("TradeWars Game", HandlerPriorityDefault, p.handleTWGSVersion)
+	p.AddHandler("Trade Wars 2002 Game", HandlerPriorityDefault, p.handleTW2002Version)
 
 	// Citadel treasury detection (mirrors Pascal: Copy(Line, 1, 25) = 'Citadel treasury contains')
-	p.AddHandler("Citadel treasury contains", p.handleCitadelTreasury)
+	p.AddHandler("Citadel treasury contains", HandlerPriorityDefault, p.handleCitadelTreasury)
 
 	// Messages and transmissions
-	p.AddHandler("Incoming transmission from", p.handleTransmission)
-	p.AddHandler("Continuing transmission from", p.handleTransmission)
-	p.AddHandler("Deployed Fighters Report Sector", p.handleFighterReport)
-	p.AddHandler("Shipboard Computers ", p.handleComputerReport)
+	p.AddHandler("Incoming transmission from", HandlerPriorityDefault, p.handleTransmission)
+	p.AddHandler("Continuing transmission from", HandlerPriorityDefault, p.handleTransmission)
+	p.AddHandler("Deployed Fighters Report Sector", HandlerPriorityDefault, p.handleFighterReport)
+	p.AddHandler("Shipboard Computers ", HandlerPriorityDefault, p.handleComputerReport)
 
-	// Stardock detection from 'V' screen (Pascal: Copy(Line, 14, 8) = 'StarDock')
-	// Note: We register the pattern differently since we need position-specific matching
+	// Stardock detection from 'V' screen, which needs position-specific matching
+	if err := p.AddRegexHandler(stardockPattern, p.handleStardockDetection); err != nil {
+		log.Error("Invalid built-in parser pattern", "pattern", stardockPattern, "error", err)
+	}
 }
 
 // ProcessInBound processes incoming data (main entry point, like TWX Pascal)
@@ -414,6 +556,15 @@ func (p *TWXParser) ProcessInBound(data string) {
 	// Note: Text events are fired in processLine() for complete, processed lines
 	// not here for raw chunks which may contain partial data or ANSI codes
 
+	// Don't carry a partial display from the previous game into a newly loaded database
+	p.resetOnDatabaseSwitch()
+
+	// Report an ended CIM download once this chunk's writes are committed
+	defer p.flushCIMBurst()
+
+	// Batch all tracker writes from this chunk into one transaction
+	defer p.endChunkTransaction(p.beginChunkTransaction())
+
 	// Remove null chars
 	data = strings.ReplaceAll(data, "\x00", "")
 	p.rawANSILine = data
@@ -451,6 +602,10 @@ func (p *TWXParser) ProcessInBound(data string) {
 		p.currentLine = completeLine
 		p.currentANSILine = completeANSILine
 
+		// Keep the line as shown, starting in the colors earlier lines left set
+		p.recentANSILines.add(p.ansiColor.Prefix() + completeANSILine)
+		p.ansiColor.Write(completeANSILine)
+
 		// TextLineEvent is fired in processLine, not here (Pascal ProcessInBound behavior)
 
 		// Process the complete line WITHOUT error recovery to see actual error
@@ -478,6 +633,7 @@ func (p *TWXParser) ProcessInBound(data string) {
 	// Store remaining partial data
 	p.currentLine = line
 	p.currentANSILine = ansiLine
+	p.atCommandPrompt.Store(strings.HasPrefix(line, "Command ["))
 
 	// Fire AutoTextEvent for prompts only if there's remaining data (Pascal TWX behavior)
 	// Pascal: only fires AutoTextEvent at end of ProcessInBound for partial/prompt data
@@ -491,28 +647,41 @@ func (p *TWXParser) ProcessInBound(data string) {
 		p.FireAutoTextEvent(p.currentLine, false)
 
 		// Process partial line for prompts (key TWX feature!)
-		p.processPrompt(p.currentLine)
+		if !p.processPrompt(p.currentLine) && looksLikePrompt(p.currentLine) {
+			p.unrecognizedPrompt(p.currentLine)
+		}
 	}
 }
 
 // Finalize processes any remaining data and completes pending sectors
 func (p *TWXParser) Finalize() {
+	defer p.flushCIMBurst()
+	defer p.endChunkTransaction(p.beginChunkTransaction())
+
 	// If there's remaining data in currentLine, process it as a final line
 	if p.currentLine != "" {
 		p.processLine(p.currentLine)
 		p.processPrompt(p.currentLine)
 	}
 
-	// Complete any pending info display
+	// Complete any pending info display, or drop it if the game database went away
 	if p.infoDisplay.Active {
-		log.Info("INFO_PARSER: Finalize() completing active info display")
-		p.completeInfoDisplay()
+		if p.HasDatabase() {
+			log.Info("INFO_PARSER: Finalize() completing active info display")
+			p.completeInfoDisplay()
+		} else {
+			p.infoDisplay.Active = false
+			p.playerStatsTracker = nil
+		}
 	}
 
 	// Complete any pending sector
-	if !p.sectorSaved && p.currentSectorIndex > 0 {
+	if !p.sectorSaved && p.currentSectorIndex > 0 && p.databaseReady() {
 		p.sectorCompleted()
 	}
+
+	// The stream ended, so any CIM download did too
+	p.endCIMBlock()
 }
 
 // stripANSI removes ANSI escape sequences (mirrors TWX Pascal logic)
@@ -526,20 +695,59 @@ func (p *TWXParser) stripANSI(s *string) {
 
 // processLine processes a complete line (mirrors TWX Pascal ProcessLine)
 func (p *TWXParser) processLine(line string) {
+	defer p.endLineSavepoint(p.beginLineSavepoint())
+	p.recentLines.add(line)
+	defer p.recordParsedLine(line)
+	p.counters.linesProcessed.Add(1)
+
+	// Game data parsing needs the database; script events still fire without one
+	consumed := p.databaseReady() && p.parseGameLine(line)
+	p.checkDisplayWatchdog()
+	if !p.inCIMDisplay() {
+		p.endCIMBlock()
+	}
+	if consumed {
+		return
+	}
+
+	// Update CURRENTLINE system constant before firing script events (matches TWX Pascal ProcessInBound sequence)
+	// Skip empty lines to prevent overwriting meaningful content
+	if strings.TrimSpace(line) != "" {
+		p.UpdateCurrentLine(line)
+	}
+
+	// Fire TextLineEvent as in Pascal TWX ProcessLine (mirrors Pascal TWXInterpreter.TextLineEvent)
+	textLineTriggerFired, err := p.FireTextLineEvent(line, false)
+	if err != nil {
+		log.Error("Error firing TextLineEvent", "error", err, "line", line)
+	}
 
+	// If a TextLineTrigger fired, skip Text event processing (waitfor) - matches TWX behavior
+	if !textLineTriggerFired {
+		// Always check for prompts (this fires TextEvent)
+		p.processPrompt(line)
+	}
+
+	// Reactivate script triggers as in Pascal TWX ProcessLine (mirrors Pascal TWXInterpreter.ActivateTriggers)
+	p.ActivateTriggers()
+}
+
+// parseGameLine runs the game data parsers for a complete line. Returns true if the
+// line was fully consumed and script events should not fire (Pascal early exits).
+func (p *TWXParser) parseGameLine(line string) bool {
 	// Handle message continuations (mirrors TWX Pascal logic)
 	if p.currentMessage != "" {
 		if line != "" {
 			p.handleMessageLine(line)
 			p.currentMessage = ""
 		}
-		return
+		return true
 	}
 
 	// Handle direct messages
 	if strings.HasPrefix(line, "R ") || strings.HasPrefix(line, "F ") {
 		p.handleMessageLine(line)
-		return
+		return true
 	}
 	if strings.HasPrefix(line, "P ") {
 		// Skip "P indicates" messages
@@ -547,7 +755,7 @@ func (p *TWXParser) processLine(line string) {
 		if len(parts) < 2 || parts[1] != "indicates" {
 			p.handleMessageLine(line)
 		}
-		return
+		return true
 	}
 
 	// Pascal TWX pattern matching - check independent patterns that can coexist
@@ -558,11 +766,12 @@ func (p *TWXParser) processLine(line string) {
 		p.handleCommandPrompt(line)
 	}
 
-	// Check density scanner independently (Pascal: Copy(Line, 27, 16) = 'Relative Density')
-	if strings.Contains(line, "Relative Density") {
-		p.currentDisplay = DisplayDensity
+	// Check density scanner independently (Pascal: Copy(Line, 27, 16) = 'Relative Density'),
+	// wherever the server puts the heading
+	if isDensityScanHeader(line) {
+		p.startDensityDisplay()
 		// Pascal TWX returns early after setting mode, so we do the same
-		return
+		return true
 	}
 	// Handle continuation based on current display state
 	switch p.currentDisplay {
@@ -586,62 +795,40 @@ func (p *TWXParser) processLine(line string) {
 	// Check for info display end and quick stats end before other processing
 	p.checkInfoDisplayEnd(line)
 	p.checkQuickStatsEnd(line)
-
-	// Update CURRENTLINE system constant before firing script events (matches TWX Pascal ProcessInBound sequence)
-	// Skip empty lines to prevent overwriting meaningful content
-	if strings.TrimSpace(line) != "" {
-		p.UpdateCurrentLine(line)
-	}
-
-	// Fire TextLineEvent as in Pascal TWX ProcessLine (mirrors Pascal TWXInterpreter.TextLineEvent)
-	textLineTriggerFired, err := p.FireTextLineEvent(line, false)
-	if err != nil {
-		log.Error("Error firing TextLineEvent", "error", err, "line", line)
-	}
-
-	// If a TextLineTrigger fired, skip Text event processing (waitfor) - matches TWX behavior
-	if !textLineTriggerFired {
-		// Always check for prompts (this fires TextEvent)
-		p.processPrompt(line)
-	}
-
-	// Reactivate script triggers as in Pascal TWX ProcessLine (mirrors Pascal TWXInterpreter.ActivateTriggers)
-	p.ActivateTriggers()
+	return false
 }
 
-// processPrompt handles prompts that may not end in newlines (key TWX feature)
-func (p *TWXParser) processPrompt(line string) {
+// processPrompt handles prompts that may not end in newlines (key TWX feature). Returns
+// true if a prompt handler recognised the line.
+func (p *TWXParser) processPrompt(line string) bool {
 	if line == "" {
-		return
+		return false
 	}
+	defer p.endLineSavepoint(p.beginLineSavepoint())
 
 	// Fire TextEvent as in Pascal TWX ProcessPrompt (mirrors Pascal TWXInterpreter.TextEvent)
 	p.FireTextEvent(line, false)
 
+	// Prompt handlers record game data, which needs the database
+	if !p.databaseReady() {
+		return false
+	}
+
 	// Check for prompt patterns
 	for _, ph := range p.handlers {
 		if strings.HasPrefix(line, ph.Pattern) {
 			ph.Handler(line)
-			return
+			return true
 		}
 	}
+	return false
 }
 
 // checkPatterns checks for pattern matches in complete lines
 func (p *TWXParser) checkPatterns(line string) {
-	// Check for Stardock detection from 'V' screen first (Pascal: Copy(Line, 14, 8) = 'StarDock' and Copy(Line, 37, 6) = 'sector')
-	// Pascal uses 1-indexed strings, so position 14 = index 13, position 37 = index 36
-	// Need exact position matching as in Pascal for reliable detection
-	if len(line) >= 42 {
-		// Check exact position 14 for "StarDock" (index 13)
-		if len(line) >= 21 && line[13:21] == "StarDock" {
-			// Check position 37 for "sector" (index 36) with some flexibility for exact spacing
-			// Based on test pattern, "sector" should be around position 39 (0-indexed)
-			if len(line) >= 45 && strings.Contains(line[36:46], "sector") {
-				p.handleStardockDetection(line)
-				return
-			}
-		}
+	// Position-specific patterns such as the 'V' screen's Stardock line come first
+	if p.checkRegexPatterns(line) {
+		return
 	}
 
 	for _, ph := range p.handlers {
@@ -655,6 +842,14 @@ func (p *TWXParser) checkPatterns(line string) {
 // Handler implementations (core TWX parsing logic)
 
 func (p *TWXParser) handleCommandPrompt(line string) {
+	// Leaving the port: save what the commerce report showed before the display resets
+	if p.currentDisplay == DisplayPort || p.currentDisplay == DisplayPortCR {
+		p.exitPortContext()
+	}
+
+	// The move that stopped at a prompt is over
+	p.stopPromptFired = ""
+
 	// Clear all probe state when we get back to command prompt (back to normal player interaction)
 	if p.probeMode || len(p.probeDiscoveredSectors) > 0 {
 		p.probeMode = false
@@ -687,7 +882,7 @@ func (p *TWXParser) handleCommandPrompt(line string) {
 				// Ensure the current sector exists in the database
 				sectorTracker := NewSectorTracker(sectorNum)
 				p.errorRecoveryHandler("ensureCurrentSectorExists", func() error {
-					return sectorTracker.Execute(p.GetDatabase().GetDB())
+					return sectorTracker.Execute(p.GetDatabase().GetExecutor())
 				})
 
 				// Update current sector using straight-sql tracker
@@ -696,18 +891,12 @@ func (p *TWXParser) handleCommandPrompt(line string) {
 				}
 				p.playerStatsTracker.SetCurrentSector(sectorNum)
 				p.errorRecoveryHandler("savePlayerStatsToDatabase", func() error {
-					return p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+					return p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 				})
 
-				// Fire OnCurrentSectorChanged event for the player's actual current sector
-				// This ensures the TUI is notified when the player returns to their actual location
-				if p.tuiAPI != nil {
-					freshSectorInfo, err := p.GetDatabase().GetSectorInfo(sectorNum)
-					if err == nil {
-						log.Info("TWX_PARSER: Firing OnCurrentSectorChanged for player's current sector from command prompt", "sector", sectorNum)
-						p.tuiAPI.OnCurrentSectorChanged(freshSectorInfo)
-					}
-				}
+				// Notify the TUI of the player's actual current sector, e.g. after a probe
+				p.visitedSectors.add(sectorNum)
+				p.notifyCurrentSectorChanged(sectorNum, "commandPrompt", false)
 			}
 		}
 	} else {
@@ -725,18 +914,12 @@ func (p *TWXParser) handleCommandPrompt(line string) {
 				// Ensure the current sector exists in the database
 				sectorTracker := NewSectorTracker(sectorNum)
 				p.errorRecoveryHandler("ensureCurrentSectorExists", func() error {
-					return sectorTracker.Execute(p.GetDatabase().GetDB())
+					return sectorTracker.Execute(p.GetDatabase().GetExecutor())
 				})
 
-				// Fire OnCurrentSectorChanged event for the player's actual current sector
-				// This ensures the TUI is notified when the player returns to their actual location
-				if p.tuiAPI != nil {
-					freshSectorInfo, err := p.GetDatabase().GetSectorInfo(sectorNum)
-					if err == nil {
-						log.Info("TWX_PARSER: Firing OnCurrentSectorChanged for player's current sector from command prompt", "sector", sectorNum)
-						p.tuiAPI.OnCurrentSectorChanged(freshSectorInfo)
-					}
-				}
+				// Notify the TUI of the player's actual current sector, e.g. after a probe
+				p.visitedSectors.add(sectorNum)
+				p.notifyCurrentSectorChanged(sectorNum, "commandPrompt", false)
 
 				// Update current sector using straight-sql tracker
 				if p.playerStatsTracker == nil {
@@ -744,7 +927,7 @@ func (p *TWXParser) handleCommandPrompt(line string) {
 				}
 				p.playerStatsTracker.SetCurrentSector(sectorNum)
 				p.errorRecoveryHandler("savePlayerStatsToDatabase", func() error {
-					return p.playerStatsTracker.Execute(p.GetDatabase().GetDB())
+					return p.playerStatsTracker.Execute(p.GetDatabase().GetExecutor())
 				})
 			}
 		}
@@ -835,6 +1018,7 @@ func (p *TWXParser) handleStopPrompt(line string) {
 		p.sectorCompleted()
 	}
 	p.currentDisplay = DisplayNone
+	p.fireStopPromptEvent(line)
 }
 
 func (p *TWXParser) handleCIMPrompt(line string) {
@@ -847,6 +1031,7 @@ func (p *TWXParser) handleCIMPrompt(line string) {
 	// Pascal: // begin CIM download
 	// Pascal: FCurrentDisplay := dCIM;
 	log.Info("CIM: handleCIMPrompt called, resetting lastWarp to 0", "previous_lastWarp", p.lastWarp)
+	p.endCIMBlock()
 	p.currentDisplay = DisplayCIM
 	p.lastWarp = 0
 }
@@ -886,6 +1071,7 @@ func (p *TWXParser) handleSectorStart(line string) {
 			// Start new discovered field session
 			log.Info("SECTOR_TRACKER_LIFECYCLE: Creating new sectorTracker", "sector", sectorNum, "previous_tracker_nil", p.sectorTracker == nil)
 			p.sectorTracker = NewSectorTracker(sectorNum)
+			p.sectorTracker.SetAliens(0, "") // Cleared unless the display lists aliens
 			p.sectorCollections = NewSectorCollections(sectorNum)
 			p.portTracker = NewPortTracker(sectorNum)
 
@@ -913,15 +1099,13 @@ func (p *TWXParser) handlePortReport(line string) {
 	p.portSectorIndex = p.currentSectorIndex
 
 	// Create or recreate portTracker for this port trading session
-	if p.portTracker == nil {
-		p.portTracker = NewPortTracker(p.currentSectorIndex)
-		log.Info("PORT: Created new portTracker for port trading session", "sector", p.currentSectorIndex)
+	if p.portTracker == nil || p.portTracker.sectorIndex != p.portSectorIndex {
+		p.portTracker = NewPortTracker(p.portSectorIndex)
+		log.Info("PORT: Created new portTracker for port trading session", "sector", p.portSectorIndex)
 	}
 
-	// Extract port name from "Commerce report for PORT_NAME:"
-	colonPos := strings.Index(line, ":")
-	if colonPos > 20 {
-		portName := strings.TrimSpace(line[20:colonPos])
+	// Extract port name from "Commerce report for PORT_NAME: 10:02:07 PM Sun Aug 17, 2053"
+	if portName := extractCommerceReportName(line); portName != "" {
 		log.Info("PORT: Extracted port name", "port_name", portName)
 
 		// Initialize port data for current sector
@@ -946,18 +1130,11 @@ func (p *TWXParser) handlePortCR(line string) {
 	}
 }
 
-func (p *TWXParser) handleDensityStart(line string) {
-	// Pascal: if (Copy(Line, 27, 16) = 'Relative Density') then
-	// Check position 26 in 0-indexed Go (27-1), length 16
-	if len(line) >= 42 && line[26:42] == "Relative Density" {
-		p.currentDisplay = DisplayDensity
-	}
-}
-
 func (p *TWXParser) handleWarpLaneStart(line string) {
 	log.Info("WARP: handleWarpLaneStart called, resetting lastWarp to 0", "previous_lastWarp", p.lastWarp)
 	p.currentDisplay = DisplayWarpLane
 	p.lastWarp = 0
+	p.courseSectors = nil
 }
 
 func (p *TWXParser) handleFigScanStart(line string) {
@@ -1232,6 +1409,7 @@ func (p *TWXParser) processWarpLine(line string) {
 
 	parts := strings.Split(line, " >")
 	lastSect := p.lastWarp
+	var sectors []int
 
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
@@ -1240,8 +1418,13 @@ func (p *TWXParser) processWarpLine(line string) {
 			}
 			lastSect = curSect
 			p.lastWarp = curSect
+			sectors = append(sectors, curSect)
 		}
 	}
+
+	if !p.addCourseSectors(sectors) {
+		p.fireCoursePlotted()
+	}
 }
 
 func (p *TWXParser) processCIMLine(line string) {
@@ -1325,7 +1508,14 @@ func (p *TWXParser) processWarpCIMLine(line string) {
 	if err := p.GetDatabase().SaveSector(sector, sectorNum); err != nil {
 		return
 	}
+	p.counters.sectorsSaved.Add(1)
 
+	// The CIM lists the sector's whole warp list, so none of its warps are inferred
+	if err := p.GetDatabase().ConfirmSectorWarps(sectorNum); err != nil {
+		log.Debug("TWX_PARSER: Failed to confirm CIM sector warps", "sector", sectorNum, "error", err)
+	}
+
+	p.fireSectorWarpsUpdated(sectorNum, sector.Warp)
 }
 
 // processPortCIMLine processes port CIM data (mirrors Pascal ProcessCIMLine lines 570-611)
@@ -1386,6 +1576,7 @@ func (p *TWXParser) processPortCIMLine(line string) {
 	// Store enhanced port CIM data to database
 	p.storePortCIMData(sectorNum, oreAmount, orePercent, buyOre,
 		orgAmount, orgPercent, buyOrg, equipAmount, equipPercent, buyEquip, portClass)
+	p.addCIMSector(sectorNum)
 }
 
 // getCIMValue extracts a parameter value from CIM data (mirrors Pascal GetCIMValue function)
@@ -1431,6 +1622,7 @@ func (p *TWXParser) ensureSectorExistsAndSavePort(port database.TPort, sectorNum
 	if err := p.GetDatabase().SavePort(port, sectorNum); err != nil {
 		return fmt.Errorf("failed to save port for sector %d: %w", sectorNum, err)
 	}
+	p.counters.portsSaved.Add(1)
 
 	log.Info("PORT: Successfully saved port data", "sector", sectorNum, "port_name", port.Name, "class", port.ClassIndex)
 
@@ -1463,11 +1655,13 @@ func (p *TWXParser) ensureSectorExistsAndSavePortWithVisited(port database.TPort
 	if err := p.GetDatabase().SaveSector(sector, sectorNum); err != nil {
 		return fmt.Errorf("failed to save sector %d: %w", sectorNum, err)
 	}
+	p.counters.sectorsSaved.Add(1)
 
 	// Save port data
 	if err := p.GetDatabase().SavePort(port, sectorNum); err != nil {
 		return fmt.Errorf("failed to save port for sector %d: %w", sectorNum, err)
 	}
+	p.counters.portsSaved.Add(1)
 
 	// Fire any necessary events (consistent with other database operations)
 	// This ensures proper notification flow like other database saves
@@ -1499,6 +1693,8 @@ func (p *TWXParser) resetCurrentSector() {
 	// Phase 4.5: Intermediate object collections removed - using trackers only
 	p.currentSectorWarps = [6]int{0, 0, 0, 0, 0, 0}
 	p.sectorPosition = SectorPosNormal
+	p.sectorAliens = sectorAliens{}
+	p.stopPromptFired = ""
 }
 
 // storePortCIMData stores complete port CIM data to database
@@ -1585,6 +1781,7 @@ func (p *TWXParser) processDensityLine(line string) {
 	if err := p.GetDatabase().SaveSector(sector, sectorNum); err != nil {
 		panic(fmt.Sprintf("Critical database error in processDensityLine SaveSector for sector %d: %v", sectorNum, err))
 	}
+	p.counters.sectorsSaved.Add(1)
 }
 
 // processDensityLineTracker processes density scanner data using straight-sql tracker approach
@@ -1669,16 +1866,13 @@ func (p *TWXParser) processDensityLineTracker(line string) {
 	} else {
 		// Different sector - set exploration status based on density scan discovery
 		// Check current exploration status first to preserve higher statuses
-		currentSector, err := p.GetDatabase().LoadSector(sectorNum)
-		var currentExplored database.TSectorExploredType
-		if err == nil {
-			currentExplored = currentSector.Explored
-		}
+		currentExplored := p.densityExploredStatus(sectorNum)
 
 		// Only set to EtDensity if current status is EtNo or EtCalc (preserve EtHolo)
 		if currentExplored == database.EtNo || currentExplored == database.EtCalc {
 			densityTracker.SetExplored(int(database.EtDensity))
 			densityTracker.SetConstellation("??? (Density only)")
+			p.densityExplored[sectorNum] = database.EtDensity
 		}
 	}
 
@@ -1686,10 +1880,11 @@ func (p *TWXParser) processDensityLineTracker(line string) {
 
 	// Execute density tracker immediately (standalone updates)
 	if densityTracker != nil && densityTracker.HasUpdates() {
-		err := densityTracker.Execute(p.GetDatabase().GetDB())
+		err := densityTracker.Execute(p.GetDatabase().GetExecutor())
 		if err != nil {
 			log.Info("DENSITY: Failed to update sector fields", "error", err)
 		} else {
+			p.counters.sectorsSaved.Add(1)
 			log.Info("DENSITY: Successfully updated sector with density scan data", "sector", sectorNum)
 		}
 	}
@@ -1755,22 +1950,26 @@ func (p *TWXParser) processFigScanLine(line string) {
 
 }
 
-// parseFighterQuantity parses fighter quantities with T/M/B multipliers
-// Examples: "1000", "10T", "5M", "2B"
+// invalidFighterQuantity is returned by parseFighterQuantity for malformed quantities
+const invalidFighterQuantity = -1
+
+// parseFighterQuantity parses fighter quantities with K/T/M/B multipliers, rounding
+// decimal forms to the nearest fighter
+// Examples: "1,000", "10T", "1.5K", "2.3M", "2B"
 func (p *TWXParser) parseFighterQuantity(quantityStr string) int {
 	quantityStr = strings.ReplaceAll(quantityStr, ",", "")
 
 	if quantityStr == "" {
-		return 0
+		return invalidFighterQuantity
 	}
 
 	// Check for multiplier suffix
 	lastChar := quantityStr[len(quantityStr)-1]
-	var multiplier int = 1
+	var multiplier float64
 	var numStr string
 
 	switch lastChar {
-	case 'T', 't':
+	case 'K', 'k', 'T', 't':
 		multiplier = 1000
 		numStr = quantityStr[:len(quantityStr)-1]
 	case 'M', 'm':
@@ -1784,12 +1983,22 @@ func (p *TWXParser) parseFighterQuantity(quantityStr string) int {
 		numStr = quantityStr
 	}
 
-	baseQty := p.parseIntSafe(numStr)
-	if baseQty < 0 {
-		return -1
+	// Only plain decimals: ParseFloat would also accept signs, exponents and "Inf"
+	if numStr == "" || numStr == "." || strings.Count(numStr, ".") > 1 ||
+		strings.Trim(numStr, "0123456789.") != "" {
+		return invalidFighterQuantity
 	}
 
-	return baseQty * multiplier
+	baseQty, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return invalidFighterQuantity
+	}
+
+	quantity := math.Round(baseQty * multiplier)
+	if quantity >= math.MaxInt64 {
+		return invalidFighterQuantity
+	}
+	return int(quantity)
 }
 
 // resetFighterDatabase resets all fighter data (mirrors TWX Pascal ResetFigDatabase)
@@ -1808,54 +2017,26 @@ func (p *TWXParser) resetFighterDatabase() {
 	}
 }
 
-// resetFighterDatabasePascalCompliant implements the Pascal TWX ResetFigDatabase logic exactly
+// resetFighterDatabasePascalCompliant implements the Pascal TWX ResetFigDatabase logic:
+// clear personal and corp fighters in every sector from 11 up except Stardock. Pascal
+// loaded and saved each sector; a single UPDATE does the same without the round trips.
 func (p *TWXParser) resetFighterDatabasePascalCompliant() error {
 	defer p.recoverFromPanic("resetFighterDatabasePascalCompliant")
 
-	// Pascal: for i:= 11 to TWXDatabase.DBHeader.Sectors do
-	totalSectors := p.GetDatabase().GetSectors()
-	if totalSectors <= 10 {
-		return nil
+	db, err := p.Database()
+	if err != nil {
+		return err
 	}
 
-	// Find Stardock sector by checking for Stardock planets
+	// Pascal: if (i <> TWXDatabase.DBHeader.Stardock) then
 	stardockSector := p.findStardockSector()
 
-	sectorsProcessed := 0
-	sectorsReset := 0
-
-	// Iterate through sectors starting from 11 (Pascal convention)
-	for i := 11; i <= totalSectors; i++ {
-		// Pascal: if (i <> TWXDatabase.DBHeader.Stardock) then
-		if i == stardockSector {
-			continue
-		}
-
-		// Pascal: Sect := TWXDatabase.LoadSector(i);
-		sector, err := p.GetDatabase().LoadSector(i)
-		if err != nil {
-			continue
-		}
-
-		sectorsProcessed++
-
-		// Pascal: if (Sect.Figs.Owner = 'yours') or (Sect.Figs.Owner = 'belong to your Corp') then
-		if p.isPersonalOrCorpFighter(sector.Figs.Owner) {
-			// Pascal: Sect.Figs.Quantity := 0;
-			// Pascal: Sect.Figs.FigType := ftNone;
-			sector.Figs.Quantity = 0
-			sector.Figs.Owner = ""
-			sector.Figs.FigType = 3 // ftNone
-
-			// Pascal: TWXDatabase.SaveSector(Sect, i);
-			if err := p.GetDatabase().SaveSector(sector, i); err != nil {
-				continue
-			}
-
-			sectorsReset++
-		}
+	sectorsReset, err := db.ResetPersonalCorpFightersExcept(stardockSector)
+	if err != nil {
+		return err
 	}
 
+	log.Info("FIGHTER_RESET: cleared personal and corp fighters", "sectors", sectorsReset, "stardock", stardockSector)
 	return nil
 }
 
@@ -1881,23 +2062,6 @@ func (p *TWXParser) findStardockSector() int {
 	return -1 // No Stardock to exclude
 }
 
-// isPersonalOrCorpFighter checks if the fighter owner indicates personal or corporate fighters
-func (p *TWXParser) isPersonalOrCorpFighter(owner string) bool {
-	if owner == "" {
-		return false
-	}
-
-	ownerLower := strings.ToLower(owner)
-
-	// Pascal exact matching
-	return owner == "yours" ||
-		owner == "belong to your Corp" ||
-		ownerLower == "yours" ||
-		ownerLower == "belong to your corp" ||
-		strings.Contains(ownerLower, "your corp") ||
-		strings.Contains(ownerLower, "your corporation")
-}
-
 // handleStardockDetection processes Stardock detection from 'V' screen (mirrors Pascal lines 1234-1264)
 func (p *TWXParser) handleStardockDetection(line string) {
 
@@ -1964,6 +2128,7 @@ func (p *TWXParser) setupStardockSector(sectorNum int) {
 	if err := p.GetDatabase().SaveSector(sector, sectorNum); err != nil {
 		return
 	}
+	p.counters.sectorsSaved.Add(1)
 
 	// Phase 2: Create separate port data for Stargate
 	port := database.TPort{
@@ -2076,6 +2241,7 @@ func (p *TWXParser) sectorCompleted() {
 				log.Error("SECTOR_TRACKER_LIFECYCLE: sectorTracker became nil during EtCalc!", "sector", p.currentSectorIndex)
 			}
 		} else {
+			p.recordSessionSector(p.currentSectorIndex)
 			log.Info("SECTOR_TRACKER_LIFECYCLE: About to SetExplored (EtHolo)", "sector", p.currentSectorIndex, "tracker_nil_check", p.sectorTracker == nil)
 			if p.sectorTracker != nil {
 				p.sectorTracker.SetExplored(int(database.EtHolo)) // Mark current sector as visited by player
@@ -2088,7 +2254,7 @@ func (p *TWXParser) sectorCompleted() {
 		log.Info("SECTOR_TRACKER_LIFECYCLE: About to check HasUpdates", "sector", p.currentSectorIndex, "tracker_nil_check", p.sectorTracker == nil)
 		if p.sectorTracker != nil && p.sectorTracker.HasUpdates() {
 			log.Info("SECTOR_TRACKER_LIFECYCLE: About to Execute", "sector", p.currentSectorIndex)
-			db := p.GetDatabase().GetDB()
+			db := p.GetDatabase().GetExecutor()
 			log.Info("SECTOR_TRACKER_LIFECYCLE: Database connection", "sector", p.currentSectorIndex, "db_nil", db == nil)
 			if db == nil {
 				log.Error("SECTOR_TRACKER_LIFECYCLE: Database connection is nil!", "sector", p.currentSectorIndex)
@@ -2096,6 +2262,8 @@ func (p *TWXParser) sectorCompleted() {
 				err := p.sectorTracker.Execute(db)
 				if err != nil {
 					log.Info("SECTOR_PARSER: Failed to update sector fields", "error", err)
+				} else {
+					p.counters.sectorsSaved.Add(1)
 				}
 			}
 		} else if p.sectorTracker == nil {
@@ -2106,7 +2274,7 @@ func (p *TWXParser) sectorCompleted() {
 	}
 
 	if p.sectorCollections != nil && p.sectorCollections.HasData() {
-		err := p.sectorCollections.Execute(p.GetDatabase().GetDB())
+		err := p.sectorCollections.Execute(p.GetDatabase().GetExecutor())
 		if err != nil {
 			log.Info("SECTOR_PARSER: Failed to update sector collections", "error", err)
 		}
@@ -2114,10 +2282,11 @@ func (p *TWXParser) sectorCompleted() {
 
 	// Phase 3: Execute port tracker for straight-sql approach
 	if p.portTracker != nil && p.portTracker.HasUpdates() {
-		err := p.portTracker.Execute(p.GetDatabase().GetDB())
+		err := p.portTracker.Execute(p.GetDatabase().GetExecutor())
 		if err != nil {
 			log.Info("PORT_PARSER: Failed to update port fields", "error", err)
 		} else {
+			p.counters.portsSaved.Add(1)
 			// Phase 3: Fire OnPortUpdated API event with fresh database read
 			if p.tuiAPI != nil {
 				portInfo, err := p.GetDatabase().GetPortInfo(p.currentSectorIndex)
@@ -2138,18 +2307,13 @@ func (p *TWXParser) sectorCompleted() {
 	// Phase 2: Exploration status now handled by tracker system above
 	// Legacy saveSectorProbeData/saveSectorVisited calls removed
 
+	p.fireAliensSighted()
+
 	// Fire TUI current sector change event (but not for probe-discovered sectors or probe mode)
 	isProbeDiscovered := p.probeDiscoveredSectors[p.currentSectorIndex]
 	shouldSuppressEvent := p.probeMode || isProbeDiscovered
-	if p.tuiAPI != nil && !shouldSuppressEvent {
-		// Phase 2: Use fresh database read for basic API event
-		freshSectorInfo, err := p.GetDatabase().GetSectorInfo(p.currentSectorIndex)
-		if err == nil {
-			log.Info("TWX_PARSER: Firing OnCurrentSectorChanged [SOURCE: sectorCompleted]", "sector", freshSectorInfo.Number, "probe_mode", p.probeMode, "probe_discovered", isProbeDiscovered)
-			p.tuiAPI.OnCurrentSectorChanged(freshSectorInfo)
-		} else {
-			log.Info("TWX_PARSER: Failed to read fresh sector info for API event", "error", err)
-		}
+	if !shouldSuppressEvent {
+		p.notifyCurrentSectorChanged(p.currentSectorIndex, "sectorCompleted", true)
 	} else if p.tuiAPI != nil {
 		log.Info("TWX_PARSER: Suppressing OnCurrentSectorChanged [SOURCE: sectorCompleted]", "sector", p.currentSectorIndex, "probe_mode", p.probeMode, "probe_discovered", isProbeDiscovered)
 	}
@@ -2196,6 +2360,8 @@ func (p *TWXParser) Reset() {
 	p.currentANSILine = ""
 	p.rawANSILine = ""
 	p.inANSI = false
+	p.ansiStripper.Reset()
+	p.ansiColor.Reset()
 	p.currentDisplay = DisplayNone
 	p.sectorPosition = SectorPosNormal
 	p.currentSectorIndex = 0
@@ -2206,6 +2372,18 @@ func (p *TWXParser) Reset() {
 	p.position = 0
 	p.lastChar = 0
 	p.currentTrader = TraderInfo{} // Reset current trader
+	p.lastNotifiedSector = 0
+	p.lastArrivedSector = 0
+	p.densityExplored = nil
+	p.densityOrigin = 0
+	p.sessionSectors.clear()
+	p.sessionStats.clear()
+	if p.activeDB != nil && p.activeDB.GetDatabaseOpen() {
+		p.sessionStats.recountStartPorts(p.activeDB)
+	}
+	p.visitedSectors.clear()
+	p.stopPromptFired = ""
+	p.cimBurst = cimBurst{}
 	log.Info("RESET: Full parser reset completed", "current_lastWarp", p.lastWarp)
 }
 
@@ -2274,15 +2452,6 @@ func (p *TWXParser) ProcessChunk(data []byte) {
 	p.ProcessInBound(string(data))
 }
 
-// processQuickStats parses the QuickStats line (mirrors TWX Pascal ProcessQuickStats)
-// Format: Sect 1│Turns 1,600│Creds 10,000│Figs 30│Shlds 0│Hlds 40│Ore 0│Org 0│Equ 0
-//
-//	Col 0│Phot 0│Armd 0│Lmpt 0│GTorp 0│TWarp No│Clks 0│Beacns 0│AtmDt 0│Crbo 0
-//	EPrb 0│MDis 0│PsPrb No│PlScn No│LRS None,Dens,Holo│Aln 0│Exp 0│Ship 1 MerCru
-
-// parseIntSafeWithCommas parses integers that may contain commas
-// parseIntSafeWithCommas is now implemented in parser_utils.go
-
 // handleMessageLine processes message content (mirrors TWX Pascal message handling)
 func (p *TWXParser) handleMessageLine(line string) {
 	// Use enhanced message line handling with database integration
@@ -2351,8 +2520,15 @@ func (p *TWXParser) parseWarpConnections(warpData string) {
 		p.sectorTracker.SetWarps(warps)
 	}
 
+	// The sector's own warp list confirms any of its warps that were only inferred
+	if err := p.GetDatabase().ConfirmSectorWarps(p.currentSectorIndex); err != nil {
+		log.Debug("TWX_PARSER: Failed to confirm sector warps", "sector", p.currentSectorIndex, "error", err)
+	}
+
 	// Update reverse warp connections in database for advanced pathfinding
 	p.updateReverseWarpConnections(p.currentSectorIndex, warps[:warpIndex])
+
+	p.fireSectorWarpsUpdated(p.currentSectorIndex, warps)
 }
 
 // validateWarpSector validates that a warp sector number is reasonable
@@ -2407,7 +2583,8 @@ func (p *TWXParser) updateReverseWarpConnections(fromSector int, warps []int) {
 	}
 }
 
-// addProbeWarp adds a one-way warp connection discovered by probe movement
+// addProbeWarp adds a one-way warp connection discovered by probe movement. No reverse
+// warp is inferred, and a warp that was only inferred is now confirmed.
 func (p *TWXParser) addProbeWarp(fromSector, toSector int) {
 	log.Info("PROBE WARP: addProbeWarp called", "from_sector", fromSector, "to_sector", toSector)
 
@@ -2416,6 +2593,7 @@ func (p *TWXParser) addProbeWarp(fromSector, toSector int) {
 	fromTracker := NewSectorTracker(fromSector)
 
 	// Load existing warps from database to preserve them
+	var newWarps [6]int
 	if sectorInfo, err := p.GetDatabase().LoadSector(fromSector); err == nil {
 		// Set existing warps plus the new one
 		existingWarps := sectorInfo.Warp
@@ -2428,24 +2606,43 @@ func (p *TWXParser) addProbeWarp(fromSector, toSector int) {
 			} else if existingWarps[i] == toSector {
 				// Warp already exists, no need to add it again
 				log.Info("PROBE WARP: Warp already exists", "from_sector", fromSector, "to_sector", toSector)
+				if err := p.GetDatabase().ConfirmWarp(fromSector, toSector); err != nil {
+					log.Debug("PROBE WARP: Failed to confirm warp", "from_sector", fromSector, "to_sector", toSector, "error", err)
+				}
 				return
 			}
 		}
 
-		fromTracker.SetWarps(existingWarps)
+		newWarps = existingWarps
 	} else {
 		// No existing sector, create new one with just this warp
-		newWarps := [6]int{toSector, 0, 0, 0, 0, 0}
-		fromTracker.SetWarps(newWarps)
+		newWarps = [6]int{toSector, 0, 0, 0, 0, 0}
 	}
+	fromTracker.SetWarps(newWarps)
 
 	// Execute the tracker to save the warp
-	err := fromTracker.Execute(p.GetDatabase().GetDB())
+	err := fromTracker.Execute(p.GetDatabase().GetExecutor())
 	if err != nil {
 		log.Info("PROBE WARP: Failed to save probe warp", "from_sector", fromSector, "to_sector", toSector, "error", err)
 		return
 	}
 	log.Info("PROBE WARP: Successfully saved probe warp", "from_sector", fromSector, "to_sector", toSector)
+
+	p.fireSectorWarpsUpdated(fromSector, newWarps)
+}
+
+// fireSectorWarpsUpdated notifies the TUI that a sector's warp list changed. During a CIM
+// download the sector is reported with the rest of the download when it ends.
+func (p *TWXParser) fireSectorWarpsUpdated(sectorNum int, warps [6]int) {
+	if p.tuiAPI == nil || sectorNum <= 0 {
+		return
+	}
+	if p.inCIMDisplay() {
+		p.addCIMSector(sectorNum)
+		return
+	}
+	log.Debug("TWX_PARSER: Firing OnSectorWarpsUpdated", "sector", sectorNum, "warps", warps)
+	p.tuiAPI.OnSectorWarpsUpdated(sectorNum, warps)
 }
 
 // addReverseWarp adds a reverse warp connection (mirrors Pascal AddWarp method)
@@ -2485,9 +2682,13 @@ func (p *TWXParser) addReverseWarp(toSector, fromSector int) {
 			sector.Explored = 1 // EtCalc
 		}
 
-		// Save updated sector
-		if err := p.GetDatabase().SaveSector(sector, toSector); err != nil {
-		} else {
+		// Save updated sector, remembering the warp was only inferred so one-way warps
+		// can be told apart from confirmed ones
+		if err := p.GetDatabase().SaveSector(sector, toSector); err == nil {
+			p.counters.sectorsSaved.Add(1)
+			if err := p.GetDatabase().MarkWarpInferred(toSector, fromSector); err != nil {
+				log.Debug("TWX_PARSER: Failed to mark reverse warp inferred", "from_sector", toSector, "to_sector", fromSector, "error", err)
+			}
 		}
 	}
 }
@@ -2517,6 +2718,24 @@ func (p *TWXParser) SetEventBus(bus IEventBus) {
 		p.scriptInterpreter = NewScriptInterpreter(bus)
 	}
 
+	if bus != nil {
+		p.subscribeSectorTriggers()
+	}
+}
+
+// subscribeSectorTriggers passes EventSectorComplete from the event bus to script sector
+// triggers. The bus delivers synchronously, so triggers run inside sectorCompleted.
+func (p *TWXParser) subscribeSectorTriggers() {
+	p.eventBus.Subscribe(EventSectorComplete, func(event Event) {
+		data, _ := event.Data.(map[string]interface{})
+		sector, ok := data["sector"].(int)
+		if !ok || p.scriptEventProcessor == nil {
+			return
+		}
+		if err := p.scriptEventProcessor.FireSectorCompleteEvent(sector); err != nil {
+			log.Error("Error firing sector triggers", "error", err, "sector", sector)
+		}
+	})
 }
 
 // GetEventBus returns the current event bus
@@ -2774,6 +2993,7 @@ func (p *TWXParser) fireTraderDataEvent(sectorNumber int, traders []TraderInfo)
 // firePlayerStatsEventDirect fires a player statistics update event using API PlayerStatsInfo directly
 // This is used by the straight-sql pattern where we read fresh data from database
 func (p *TWXParser) firePlayerStatsEventDirect(stats api.PlayerStatsInfo) {
+	p.sessionStats.recordPlayerStats(stats)
 	if p.tuiAPI != nil {
 		// Fire the event with fresh database data
 		p.tuiAPI.OnPlayerStatsUpdated(stats)
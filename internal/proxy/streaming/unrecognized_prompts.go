@@ -0,0 +1,101 @@
+package streaming
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"twist/internal/log"
+)
+
+// unrecognized_prompts.go - Samples of prompts no handler knew, from servers whose menus
+// aren't handled yet, for extending the handler table and game detection
+
+// unrecognizedPromptInterval is how long a prompt that was logged stays out of the log
+const unrecognizedPromptInterval = 10 * time.Minute
+
+// maxRememberedPrompts bounds the prompts remembered for rate limiting; the oldest are
+// forgotten once it is reached
+const maxRememberedPrompts = 500
+
+// promptNumbers matches the numbers in a prompt, which are ignored when telling whether
+// two prompts are the same, so "[TL=00:01:02]" and "[TL=00:01:07]" are one prompt
+var promptNumbers = regexp.MustCompile(`\d+`)
+
+// unrecognizedPromptLog appends each unrecognized prompt to its file, at most once per
+// unrecognizedPromptInterval
+type unrecognizedPromptLog struct {
+	path       string
+	lastLogged map[string]time.Time
+}
+
+// newUnrecognizedPromptLog creates a log that writes to path
+func newUnrecognizedPromptLog(path string) *unrecognizedPromptLog {
+	return &unrecognizedPromptLog{path: path, lastLogged: make(map[string]time.Time)}
+}
+
+// SetUnrecognizedPromptLog sets the file prompts no handler recognizes are logged to. An
+// empty path stops logging them.
+func (p *TWXParser) SetUnrecognizedPromptLog(path string) {
+	if path == "" {
+		p.promptLog = nil
+		return
+	}
+	p.promptLog = newUnrecognizedPromptLog(path)
+	log.Info("Logging unrecognized prompts", "file", path)
+}
+
+// unrecognizedPrompt counts and logs a prompt no handler recognized. Before a game is
+// detected no handler is tried, so those prompts are only logged; they are the server
+// menus game detection doesn't know.
+func (p *TWXParser) unrecognizedPrompt(prompt string) {
+	inGame := p.databaseReady()
+	if inGame {
+		p.counters.unrecognizedPrompts.Add(1)
+	}
+	if p.promptLog != nil {
+		p.promptLog.record(prompt, inGame)
+	}
+}
+
+// record logs the prompt unless it was logged within unrecognizedPromptInterval, noting
+// whether it was seen in the game or before one was detected
+func (l *unrecognizedPromptLog) record(prompt string, inGame bool) {
+	prompt = strings.TrimSpace(prompt)
+	key := promptNumbers.ReplaceAllString(prompt, "#")
+	now := time.Now()
+	if last, ok := l.lastLogged[key]; ok && now.Sub(last) < unrecognizedPromptInterval {
+		return
+	}
+	if len(l.lastLogged) >= maxRememberedPrompts {
+		l.forgetOldest()
+	}
+	l.lastLogged[key] = now
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		log.Warn("Could not open unrecognized prompts log", "file", l.path, "error", err)
+		return
+	}
+	defer file.Close()
+	where := "menu"
+	if inGame {
+		where = "game"
+	}
+	// %q keeps control characters visible
+	fmt.Fprintf(file, "%s %s %q\n", now.Format("2006-01-02 15:04:05"), where, prompt)
+}
+
+// forgetOldest drops the prompt logged longest ago
+func (l *unrecognizedPromptLog) forgetOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for key, logged := range l.lastLogged {
+		if oldestKey == "" || logged.Before(oldest) {
+			oldestKey, oldest = key, logged
+		}
+	}
+	delete(l.lastLogged, oldestKey)
+}
@@ -0,0 +1,67 @@
+package streaming
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTWXParser_UnrecognizedPromptLog(t *testing.T) {
+	parser := NewTestTWXParser()
+	defer parser.GetDatabase().CloseDatabase()
+	path := filepath.Join(t.TempDir(), "prompts.log")
+	parser.SetUnrecognizedPromptLog(path)
+
+	parser.ProcessInBound("\r\nCommand [TL=00:00:00]:[286] (?=Help)? : ")
+	parser.ProcessInBound("\r\nFeed the tribbles [42 left]? ")
+	parser.ProcessInBound("\r\nFeed the tribbles [41 left]? ")
+	parser.ProcessInBound("\r\nThe tribbles are ")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading prompt log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 || !strings.HasSuffix(lines[0], ` game "Feed the tribbles [42 left]?"`) {
+		t.Fatalf("Expected only the first tribbles prompt to be logged, got %q", lines)
+	}
+
+	// A repeat is logged again once the interval has passed
+	for key := range parser.promptLog.lastLogged {
+		parser.promptLog.lastLogged[key] = time.Now().Add(-unrecognizedPromptInterval)
+	}
+	parser.ProcessInBound("\r\nFeed the tribbles [40 left]? ")
+	data, _ = os.ReadFile(path)
+	if got := strings.Count(string(data), "Feed the tribbles"); got != 2 {
+		t.Errorf("Expected the prompt to be logged again after the interval, got %d entries:\n%s", got, data)
+	}
+
+	// Without a log set nothing is written
+	parser.SetUnrecognizedPromptLog("")
+	parser.ProcessInBound("\r\nRelease the hounds? ")
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), "hounds") {
+		t.Errorf("Expected nothing logged once the log is cleared, got:\n%s", data)
+	}
+}
+
+func TestTWXParser_UnrecognizedPromptLogBeforeGame(t *testing.T) {
+	parser := NewTWXParser(nil, nil)
+	path := filepath.Join(t.TempDir(), "prompts.log")
+	parser.SetUnrecognizedPromptLog(path)
+
+	parser.ProcessInBound("\r\nSelect your adventure: ")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading prompt log: %v", err)
+	}
+	if !strings.Contains(string(data), ` menu "Select your adventure:"`) {
+		t.Errorf("Expected the server menu prompt to be logged, got:\n%s", data)
+	}
+	if got := parser.GetParserStats().UnrecognizedPrompts; got != 0 {
+		t.Errorf("Expected prompts before a game not to be counted, got %d", got)
+	}
+}
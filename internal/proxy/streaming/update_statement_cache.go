@@ -0,0 +1,49 @@
+package streaming
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// updateStatements caches the UPDATE statement text built for each update shape: the
+// table plus the set of discovered columns. A CIM download or a run of sector displays
+// repeats the same few shapes hundreds of times, so the query builder runs once per shape.
+var updateStatements sync.Map // shape key -> SQL text
+
+// buildTrackerUpdate returns the UPDATE statement and arguments for a tracker's discovered
+// fields. timestampColumn is set to CURRENT_TIMESTAMP and keyColumn selects the row.
+func buildTrackerUpdate(table string, updates map[string]interface{}, timestampColumn, keyColumn string, key interface{}) (string, []interface{}, error) {
+	columns := make([]string, 0, len(updates))
+	for column := range updates {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	// Argument order matches the builder: SET values by column name, then the row key
+	args := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		args = append(args, updates[column])
+	}
+	args = append(args, key)
+
+	shape := table + ":" + strings.Join(columns, ",")
+	if cached, ok := updateStatements.Load(shape); ok {
+		return cached.(string), args, nil
+	}
+
+	query, _, err := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Update(table).
+		SetMap(updates).
+		Set(timestampColumn, squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where(squirrel.Eq{keyColumn: key}).
+		ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+
+	updateStatements.Store(shape, query)
+	return query, args, nil
+}
@@ -0,0 +1,39 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildTrackerUpdateReusesShape(t *testing.T) {
+	first, firstArgs, err := buildTrackerUpdate("sectors", map[string]interface{}{
+		ColSectorNavHaz:        5,
+		ColSectorConstellation: "uncharted space",
+	}, "update_time", "sector_index", 10)
+	if err != nil {
+		t.Fatalf("buildTrackerUpdate failed: %v", err)
+	}
+
+	expected := "UPDATE sectors SET constellation = $1, nav_haz = $2, update_time = CURRENT_TIMESTAMP WHERE sector_index = $3"
+	if first != expected {
+		t.Errorf("Expected %q, got %q", expected, first)
+	}
+	if !reflect.DeepEqual(firstArgs, []interface{}{"uncharted space", 5, 10}) {
+		t.Errorf("Unexpected args %v", firstArgs)
+	}
+
+	// Same shape, different values: same statement text, new arguments
+	second, secondArgs, err := buildTrackerUpdate("sectors", map[string]interface{}{
+		ColSectorConstellation: "The Federation",
+		ColSectorNavHaz:        0,
+	}, "update_time", "sector_index", 20)
+	if err != nil {
+		t.Fatalf("buildTrackerUpdate failed: %v", err)
+	}
+	if second != first {
+		t.Errorf("Expected cached statement %q, got %q", first, second)
+	}
+	if !reflect.DeepEqual(secondArgs, []interface{}{"The Federation", 0, 20}) {
+		t.Errorf("Unexpected args %v", secondArgs)
+	}
+}
@@ -1,9 +1,8 @@
 package streaming
 
 import (
-	"database/sql"
-	"github.com/Masterminds/squirrel"
 	"twist/internal/log"
+	"twist/internal/proxy/database"
 )
 
 // PlayerStatsTracker tracks discovered player stat fields during parsing
@@ -257,13 +256,11 @@ func (p *PlayerStatsTracker) HasUpdates() bool {
 
 // Execute writes discovered fields to database using Squirrel query builder
 // Only fields that were actually parsed/discovered are updated
-func (p *PlayerStatsTracker) Execute(db *sql.DB) error {
+func (p *PlayerStatsTracker) Execute(db database.Executor) error {
 	if len(p.updates) == 0 {
 		return nil // No updates to perform
 	}
 
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-
 	// Ensure player_stats record exists (single row table with id=1)
 	_, err := db.Exec("INSERT OR IGNORE INTO player_stats (id) VALUES (1)")
 	if err != nil {
@@ -271,13 +268,8 @@ func (p *PlayerStatsTracker) Execute(db *sql.DB) error {
 		return err
 	}
 
-	// Build dynamic UPDATE query with only discovered fields
-	query := psql.Update("player_stats").
-		SetMap(p.updates).
-		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
-		Where(squirrel.Eq{"id": 1})
-
-	sql, args, err := query.ToSql()
+	// Build dynamic UPDATE query with only discovered fields (cached per field set)
+	sql, args, err := buildTrackerUpdate("player_stats", p.updates, "updated_at", "id", 1)
 	if err != nil {
 		log.Info("Failed to build player stats update query", "error", err)
 		return err
@@ -389,6 +381,13 @@ func (s *SectorTracker) SetExplored(explored int) *SectorTracker {
 	return s
 }
 
+// SetAliens records the alien occupants discovered during parsing
+func (s *SectorTracker) SetAliens(count int, alienType string) *SectorTracker {
+	s.updates[ColSectorAliensCount] = count
+	s.updates[ColSectorAliensType] = alienType
+	return s
+}
+
 // HasUpdates returns true if any fields were discovered during parsing
 func (s *SectorTracker) HasUpdates() bool {
 	return len(s.updates) > 0
@@ -396,13 +395,11 @@ func (s *SectorTracker) HasUpdates() bool {
 
 // Execute writes discovered fields to database using Squirrel query builder
 // Only fields that were actually parsed/discovered are updated
-func (s *SectorTracker) Execute(db *sql.DB) error {
+func (s *SectorTracker) Execute(db database.Executor) error {
 	if len(s.updates) == 0 {
 		return nil // No updates to perform
 	}
 
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-
 	// Ensure sector record exists (UPSERT pattern)
 	_, err := db.Exec("INSERT OR IGNORE INTO sectors (sector_index) VALUES (?)", s.sectorIndex)
 	if err != nil {
@@ -410,13 +407,8 @@ func (s *SectorTracker) Execute(db *sql.DB) error {
 		return err
 	}
 
-	// Build dynamic UPDATE query with only discovered fields
-	query := psql.Update("sectors").
-		SetMap(s.updates).
-		Set("update_time", squirrel.Expr("CURRENT_TIMESTAMP")).
-		Where(squirrel.Eq{"sector_index": s.sectorIndex})
-
-	sql, args, err := query.ToSql()
+	// Build dynamic UPDATE query with only discovered fields (cached per field set)
+	sql, args, err := buildTrackerUpdate("sectors", s.updates, "update_time", "sector_index", s.sectorIndex)
 	if err != nil {
 		log.Info("Failed to build sector update query", "sector", s.sectorIndex, "error", err)
 		return err
@@ -625,13 +617,11 @@ func (p *PortTracker) SetEquipmentBuying(buying bool) *PortTracker {
 
 // Execute writes discovered fields to database using Squirrel query builder
 // Only fields that were actually parsed/discovered are updated
-func (p *PortTracker) Execute(db *sql.DB) error {
+func (p *PortTracker) Execute(db database.Executor) error {
 	if len(p.updates) == 0 {
 		return nil // No updates to perform
 	}
 
-	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-
 	// Ensure port record exists (UPSERT pattern)
 	_, err := db.Exec("INSERT OR IGNORE INTO ports (sector_index) VALUES (?)", p.sectorIndex)
 	if err != nil {
@@ -639,13 +629,8 @@ func (p *PortTracker) Execute(db *sql.DB) error {
 		return err
 	}
 
-	// Build dynamic UPDATE query with only discovered fields
-	query := psql.Update("ports").
-		SetMap(p.updates).
-		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
-		Where(squirrel.Eq{"sector_index": p.sectorIndex})
-
-	sql, args, err := query.ToSql()
+	// Build dynamic UPDATE query with only discovered fields (cached per field set)
+	sql, args, err := buildTrackerUpdate("ports", p.updates, "updated_at", "sector_index", p.sectorIndex)
 	if err != nil {
 		log.Info("Failed to build port update query", "sector", p.sectorIndex, "error", err)
 		return err
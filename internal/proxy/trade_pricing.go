@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"errors"
+	"os"
+
+	"twist/internal/log"
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/menu"
+)
+
+// loadTradePricing gives the terminal menu a server's economy constants from a JSON file,
+// the default file if path is empty. Without a file the menu keeps the stock pricing.
+func loadTradePricing(tmm *menu.TerminalMenuManager, path string) {
+	explicit := path != ""
+	if !explicit {
+		path = database.DefaultTradePricingFile
+	}
+
+	pricing, err := database.LoadTradePricing(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		log.Warn("Proxy: trade pricing not loaded", "path", path, "error", err)
+		return
+	}
+
+	tmm.SetTradePricing(pricing)
+	log.Info("Proxy: loaded trade pricing", "path", path, "prices", pricing.BasePrice, "margin", pricing.Margin)
+}
@@ -0,0 +1,198 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+	"twist/internal/api"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Terminal requested for SSH sessions: the size and type the game expects of a telnet client
+const (
+	sshTerminalType = "ansi"
+	sshRows         = 25
+	sshColumns      = 80
+)
+
+// errSSHDeadline is returned by the deadline methods, which an SSH session doesn't support
+var errSSHDeadline = errors.New("deadlines are not supported on SSH connections")
+
+// dialSSH logs in over SSH and opens a shell on a terminal, which carries the game session
+func dialSSH(address string, opts api.TransportOptions) (net.Conn, error) {
+	if opts.User == "" {
+		return nil, errors.New("SSH needs a user name")
+	}
+	auth, err := sshAuthMethods(opts)
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := sshHostKeyCallback(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rawConn, err := net.DialTimeout("tcp", address, DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the handshake; the session itself has no deadline
+	rawConn.SetDeadline(time.Now().Add(DialTimeout))
+	clientConn, chans, reqs, err := ssh.NewClientConn(rawConn, address, &ssh.ClientConfig{
+		User:            opts.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		rawConn.Close()
+		return nil, fmt.Errorf("SSH handshake with %s failed: %w", address, err)
+	}
+	rawConn.SetDeadline(time.Time{})
+
+	client := ssh.NewClient(clientConn, chans, reqs)
+	conn, err := openSSHShell(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("SSH session on %s failed: %w", address, err)
+	}
+	return conn, nil
+}
+
+// sshAuthMethods returns the ways to log in the options allow: the key file, then the password
+func sshAuthMethods(opts api.TransportOptions) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if opts.KeyFile != "" {
+		signer, err := loadSSHKey(opts.KeyFile, opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if opts.Password != "" {
+		password := opts.Password
+		methods = append(methods,
+			ssh.Password(password),
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = password
+				}
+				return answers, nil
+			}))
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("SSH needs a password or a key file")
+	}
+	return methods, nil
+}
+
+// loadSSHKey reads a private key, unlocking it with passphrase if it is protected
+func loadSSHKey(path, passphrase string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("SSH key %s is protected; give its passphrase as the password", path)
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid SSH key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// sshHostKeyCallback checks the server's host key against the known hosts file, unless
+// opts.SkipVerify is set
+func sshHostKeyCallback(opts api.TransportOptions) (ssh.HostKeyCallback, error) {
+	if opts.SkipVerify {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	path := opts.KnownHostsFile
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("no known hosts file to check the SSH host key against: %w", err)
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	check, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH known hosts %s: %w", path, err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := check(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) == 0 {
+				return fmt.Errorf("host key for %s is not in %s; add it (ssh-keyscan) or turn off verification", hostname, path)
+			}
+			return fmt.Errorf("host key for %s does not match the one in %s; the server may be impersonated", hostname, path)
+		}
+		return err
+	}, nil
+}
+
+// sshConn is an SSH shell session used as the game connection
+type sshConn struct {
+	client  *ssh.Client
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stdout  io.Reader
+}
+
+// openSSHShell starts a shell on a terminal and wraps its input and output as a net.Conn
+func openSSHShell(client *ssh.Client) (*sshConn, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	if err := session.RequestPty(sshTerminalType, sshRows, sshColumns, ssh.TerminalModes{}); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("terminal request refused: %w", err)
+	}
+	if err := session.Shell(); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("shell request refused: %w", err)
+	}
+	return &sshConn{client: client, session: session, stdin: stdin, stdout: stdout}, nil
+}
+
+func (c *sshConn) Read(b []byte) (int, error)  { return c.stdout.Read(b) }
+func (c *sshConn) Write(b []byte) (int, error) { return c.stdin.Write(b) }
+
+// Close ends the session and the SSH connection
+func (c *sshConn) Close() error {
+	c.session.Close()
+	return c.client.Close()
+}
+
+func (c *sshConn) LocalAddr() net.Addr                { return c.client.LocalAddr() }
+func (c *sshConn) RemoteAddr() net.Addr               { return c.client.RemoteAddr() }
+func (c *sshConn) SetDeadline(t time.Time) error      { return errSSHDeadline }
+func (c *sshConn) SetReadDeadline(t time.Time) error  { return errSSHDeadline }
+func (c *sshConn) SetWriteDeadline(t time.Time) error { return errSSHDeadline }
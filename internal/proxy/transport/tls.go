@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"twist/internal/api"
+)
+
+// dialTLS connects over TCP and completes a TLS handshake, verifying the server's
+// certificate against the system roots unless opts.SkipVerify is set
+func dialTLS(address string, opts api.TransportOptions) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	rawConn, err := net.DialTimeout("tcp", address, DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := tls.Client(rawConn, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: opts.SkipVerify,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+	if err := conn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		var verifyErr *tls.CertificateVerificationError
+		if errors.As(err, &verifyErr) {
+			return nil, fmt.Errorf("TLS handshake with %s failed: server certificate not trusted (turn off verification for self-signed servers): %w", address, err)
+		}
+		return nil, fmt.Errorf("TLS handshake with %s failed: %w", address, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,27 @@
+// Package transport connects to game servers over plain TCP, TLS or SSH, so the rest of the
+// proxy only ever sees a net.Conn
+package transport
+
+import (
+	"fmt"
+	"net"
+	"time"
+	"twist/internal/api"
+)
+
+// DialTimeout is how long connecting, including any TLS or SSH handshake, may take
+const DialTimeout = 15 * time.Second
+
+// Dial connects to address over the transport opts selects
+func Dial(address string, opts api.TransportOptions) (net.Conn, error) {
+	switch opts.Kind {
+	case "", api.TransportTCP:
+		return net.DialTimeout("tcp", address, DialTimeout)
+	case api.TransportTLS:
+		return dialTLS(address, opts)
+	case api.TransportSSH:
+		return dialSSH(address, opts)
+	default:
+		return nil, fmt.Errorf("unknown transport %q (use %s, %s or %s)", opts.Kind, api.TransportTCP, api.TransportTLS, api.TransportSSH)
+	}
+}
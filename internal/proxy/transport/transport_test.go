@@ -0,0 +1,184 @@
+package transport
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"twist/internal/api"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// readGreeting reads what the server sends on connecting
+func readGreeting(t *testing.T, conn net.Conn, want string) {
+	t.Helper()
+	buf := make([]byte, len(want))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("Failed to read greeting: %v", err)
+	}
+	if string(buf) != want {
+		t.Errorf("Expected greeting %q, got %q", want, buf)
+	}
+}
+
+func TestDialTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Write([]byte("TWGS"))
+			conn.Close()
+		}
+	}()
+
+	conn, err := Dial(listener.Addr().String(), api.TransportOptions{})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+	readGreeting(t, conn, "TWGS")
+
+	if _, err := Dial(listener.Addr().String(), api.TransportOptions{Kind: "carrier-pigeon"}); err == nil {
+		t.Error("Expected an unknown transport to fail")
+	}
+}
+
+func TestDialTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(nil)
+	server.StartTLS()
+	defer server.Close()
+	address := server.Listener.Addr().String()
+
+	// The test server's certificate is self-signed
+	_, err := Dial(address, api.TransportOptions{Kind: api.TransportTLS})
+	if err == nil || !strings.Contains(err.Error(), "TLS handshake") || !strings.Contains(err.Error(), "not trusted") {
+		t.Fatalf("Expected an untrusted certificate error, got %v", err)
+	}
+
+	conn, err := Dial(address, api.TransportOptions{Kind: api.TransportTLS, SkipVerify: true})
+	if err != nil {
+		t.Fatalf("Dial with verification off failed: %v", err)
+	}
+	conn.Close()
+}
+
+// startSSHServer runs an SSH server accepting one user's password, whose shell writes greeting
+func startSSHServer(t *testing.T, password, greeting string) (string, ssh.PublicKey) {
+	t.Helper()
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("Failed to create host key signer: %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, given []byte) (*ssh.Permissions, error) {
+			if meta.User() == "trader" && string(given) == password {
+				return nil, nil
+			}
+			return nil, io.EOF
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config, greeting)
+		}
+	}()
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+// serveSSH accepts one session and answers its terminal and shell requests
+func serveSSH(conn net.Conn, config *ssh.ServerConfig, greeting string) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range requests {
+				req.Reply(req.Type == "pty-req" || req.Type == "shell", nil)
+				if req.Type == "shell" {
+					channel.Write([]byte(greeting))
+				}
+			}
+		}()
+	}
+}
+
+func TestDialSSH(t *testing.T) {
+	address, _ := startSSHServer(t, "secret", "Trade Wars")
+
+	conn, err := Dial(address, api.TransportOptions{Kind: api.TransportSSH, User: "trader", Password: "secret", SkipVerify: true})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	readGreeting(t, conn, "Trade Wars")
+	conn.Close()
+
+	_, err = Dial(address, api.TransportOptions{Kind: api.TransportSSH, User: "trader", Password: "wrong", SkipVerify: true})
+	if err == nil || !strings.Contains(err.Error(), "SSH handshake") {
+		t.Errorf("Expected a handshake error for a wrong password, got %v", err)
+	}
+
+	if _, err := Dial(address, api.TransportOptions{Kind: api.TransportSSH, User: "trader", SkipVerify: true}); err == nil {
+		t.Error("Expected SSH without a password or key to fail")
+	}
+}
+
+func TestDialSSHChecksHostKey(t *testing.T) {
+	address, hostKey := startSSHServer(t, "secret", "Trade Wars")
+	knownHosts := t.TempDir() + "/known_hosts"
+	opts := api.TransportOptions{Kind: api.TransportSSH, User: "trader", Password: "secret", KnownHostsFile: knownHosts}
+
+	if _, err := Dial(address, opts); err == nil || !strings.Contains(err.Error(), "known hosts") {
+		t.Errorf("Expected a missing known hosts file to fail, got %v", err)
+	}
+
+	writeKnownHosts(t, knownHosts, "")
+	if _, err := Dial(address, opts); err == nil || !strings.Contains(err.Error(), "is not in") {
+		t.Errorf("Expected an unknown host key to fail, got %v", err)
+	}
+
+	writeKnownHosts(t, knownHosts, knownhosts.Line([]string{knownhosts.Normalize(address)}, hostKey))
+	conn, err := Dial(address, opts)
+	if err != nil {
+		t.Fatalf("Dial with a known host key failed: %v", err)
+	}
+	conn.Close()
+}
+
+func writeKnownHosts(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write known hosts: %v", err)
+	}
+}
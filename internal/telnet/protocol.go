@@ -1,7 +1,5 @@
 package telnet
 
-import ()
-
 // Telnet command constants
 const (
 	IAC  = 0xFF // Interpret As Command
@@ -21,10 +19,36 @@ const (
 	NAWS              = 0x1F // Negotiate About Window Size
 )
 
+// parseState is where the handler is within a telnet command. Commands can be split across
+// reads from the server, so the state carries over from one ProcessData call to the next.
+type parseState int
+
+const (
+	stateData        parseState = iota // Plain data
+	stateIAC                           // After IAC
+	stateNegotiation                   // After IAC and DO, DONT, WILL or WONT, waiting for the option
+	stateSub                           // Inside a subnegotiation
+	stateSubIAC                        // After IAC inside a subnegotiation
+)
+
+// maxSubnegotiation is the most bytes skipped for one subnegotiation. A server that never
+// sends IAC SE would otherwise have all the game text after it discarded.
+const maxSubnegotiation = 1024
+
 // Handler manages telnet protocol negotiation
 type Handler struct {
 	writer func([]byte) error
 
+	// Command parsing state, kept between reads
+	state       parseState
+	negotiation byte // DO, DONT, WILL or WONT awaiting its option
+	subLength   int  // Bytes skipped in the current subnegotiation
+
+	// Options agreed on, so a request for what is already in effect isn't answered again,
+	// which would make both ends loop acknowledging each other
+	localOptions  map[byte]bool // Options we perform (we said WILL)
+	remoteOptions map[byte]bool // Options the server performs (we said DO)
+
 	// SAUCE detection state
 	sauceBuffer []byte
 	sauceTarget []byte
@@ -33,11 +57,24 @@ type Handler struct {
 // NewHandler creates a new telnet protocol handler
 func NewHandler(writer func([]byte) error) *Handler {
 	return &Handler{
-		writer:      writer,
-		sauceTarget: []byte{0x1A, 'S', 'A', 'U', 'C', 'E', '0', '0'},
+		writer:        writer,
+		localOptions:  make(map[byte]bool),
+		remoteOptions: make(map[byte]bool),
+		sauceTarget:   []byte{0x1A, 'S', 'A', 'U', 'C', 'E', '0', '0'},
 	}
 }
 
+// Reset forgets the command being parsed and the options agreed on, for a new connection to
+// the server. Data from the old connection must not be processed after it.
+func (h *Handler) Reset() {
+	h.state = stateData
+	h.negotiation = 0
+	h.subLength = 0
+	h.localOptions = make(map[byte]bool)
+	h.remoteOptions = make(map[byte]bool)
+	h.sauceBuffer = nil
+}
+
 // SendInitialNegotiation sends the initial telnet option negotiations
 func (h *Handler) SendInitialNegotiation() error {
 
@@ -59,60 +96,78 @@ func (h *Handler) SendInitialNegotiation() error {
 		if err := h.writer(cmd); err != nil {
 			return err
 		}
+		h.recordOption(cmd[1], cmd[2])
 	}
 
 	return nil
 }
 
-// ProcessData filters telnet commands from incoming data and returns clean text
+// recordOption notes the option state a command we sent asks for
+func (h *Handler) recordOption(cmd byte, option byte) {
+	switch cmd {
+	case WILL:
+		h.localOptions[option] = true
+	case WONT:
+		h.localOptions[option] = false
+	case DO:
+		h.remoteOptions[option] = true
+	case DONT:
+		h.remoteOptions[option] = false
+	}
+}
+
+// ProcessData filters telnet commands from incoming data and returns clean text. A command
+// cut off at the end of the data is finished from the start of the next call's data.
 func (h *Handler) ProcessData(data []byte) []byte {
 	var result []byte
-	i := 0
 
-	for i < len(data) {
-		if data[i] == IAC && i+1 < len(data) {
-			cmd := data[i+1]
+	for _, b := range data {
+		switch h.state {
+		case stateData:
+			if b == IAC {
+				h.state = stateIAC
+			} else {
+				result = append(result, b)
+			}
 
-			switch cmd {
+		case stateIAC:
+			switch b {
 			case DONT, DO, WONT, WILL:
 				// Three-byte commands: IAC + command + option
-				if i+2 < len(data) {
-					option := data[i+2]
-					h.handleNegotiation(cmd, option)
-					i += 3
-				} else {
-					// Incomplete command, skip what we have
-					i = len(data)
-				}
-
+				h.negotiation = b
+				h.state = stateNegotiation
 			case SB:
 				// Subnegotiation: skip until IAC SE
-				i += 2
-				for i < len(data) {
-					if data[i] == IAC && i+1 < len(data) && data[i+1] == SE {
-						i += 2 // Skip IAC SE
-						break
-					}
-					i++
-				}
-
-			case SE:
-				// Subnegotiation end (should be handled above)
-				i += 2
-
+				h.state = stateSub
+				h.subLength = 0
 			case IAC:
 				// Escaped IAC (0xFF 0xFF represents literal 0xFF)
 				result = append(result, IAC)
-				i += 2
-
+				h.state = stateData
 			default:
-				// Other two-byte commands
-				i += 2
+				// Other two-byte commands, such as go ahead
+				h.state = stateData
+			}
+
+		case stateNegotiation:
+			h.handleNegotiation(h.negotiation, b)
+			h.state = stateData
+
+		case stateSub:
+			h.subLength++
+			if b == IAC {
+				h.state = stateSubIAC
+			} else if h.subLength > maxSubnegotiation {
+				// Unterminated: treat what follows as data again
+				h.state = stateData
+			}
+
+		case stateSubIAC:
+			if b == SE {
+				h.state = stateData
+			} else {
+				h.state = stateSub // An escaped IAC within the subnegotiation
 			}
-		} else {
-			// Regular data byte
-			result = append(result, data[i])
-			i++
 		}
 	}
 
@@ -158,7 +213,8 @@ func (h *Handler) filterSAUCE(data []byte) []byte {
 	return result
 }
 
-// handleNegotiation processes telnet option negotiations
+// handleNegotiation processes telnet option negotiations. Requests to change an option are
+// answered; requests for the state an option is already in are not.
 func (h *Handler) handleNegotiation(cmd byte, option byte) {
 
 	var response []byte
@@ -166,48 +222,46 @@ func (h *Handler) handleNegotiation(cmd byte, option byte) {
 	switch cmd {
 	case DO: // Server wants us to enable option
 		switch option {
-		case ECHO:
-			// We don't want to echo, let server handle it
-			response = []byte{IAC, WONT, ECHO}
-		case SUPPRESS_GO_AHEAD:
-			// We support suppress go ahead
-			response = []byte{IAC, WILL, SUPPRESS_GO_AHEAD}
-		case TERMINAL_TYPE:
-			// We support terminal type
-			response = []byte{IAC, WILL, TERMINAL_TYPE}
-		case NAWS:
-			// We support window size negotiation
-			response = []byte{IAC, WILL, NAWS}
+		case SUPPRESS_GO_AHEAD, TERMINAL_TYPE, NAWS:
+			// Options we support
+			if !h.localOptions[option] {
+				response = []byte{IAC, WILL, option}
+			}
 		default:
-			// Don't support unknown options
+			// We don't want to echo, let server handle it; and don't support unknown options
 			response = []byte{IAC, WONT, option}
 		}
 
 	case DONT: // Server doesn't want us to use option
-		// Acknowledge by saying we won't
-		response = []byte{IAC, WONT, option}
+		// Acknowledge by saying we won't, if we were
+		if h.localOptions[option] {
+			response = []byte{IAC, WONT, option}
+		}
 
 	case WILL: // Server will enable option
 		switch option {
-		case ECHO:
-			// Good, server will handle echo
-			response = []byte{IAC, DO, ECHO}
-		case SUPPRESS_GO_AHEAD:
-			// Good, server will suppress go ahead
-			response = []byte{IAC, DO, SUPPRESS_GO_AHEAD}
+		case ECHO, SUPPRESS_GO_AHEAD:
+			// Good, server will handle echo or suppress go ahead
+			if !h.remoteOptions[option] {
+				response = []byte{IAC, DO, option}
+			}
 		default:
 			// Don't care about other options server enables
 			response = []byte{IAC, DONT, option}
 		}
 
 	case WONT: // Server won't enable option
-		// Acknowledge
-		response = []byte{IAC, DONT, option}
+		// Acknowledge, if it was on
+		if h.remoteOptions[option] {
+			response = []byte{IAC, DONT, option}
+		}
 	}
 
-	if response != nil {
+	if response != nil && h.writer != nil {
 		if err := h.writer(response); err != nil {
 			// Failed to send response
+			return
 		}
+		h.recordOption(response[1], response[2])
 	}
 }
@@ -0,0 +1,142 @@
+package telnet
+
+import (
+	"bytes"
+	"testing"
+)
+
+// newRecordingHandler returns a handler whose replies to the server are collected in sent
+func newRecordingHandler(sent *[][]byte) *Handler {
+	return NewHandler(func(data []byte) error {
+		*sent = append(*sent, append([]byte(nil), data...))
+		return nil
+	})
+}
+
+func TestProcessDataStripsCommandsFromText(t *testing.T) {
+	var sent [][]byte
+	h := newRecordingHandler(&sent)
+
+	data := []byte("Command [TL=00:00:00]")
+	data = append(data, IAC, WILL, ECHO)
+	data = append(data, ":[845] ("...)
+	data = append(data, IAC, SB, TERMINAL_TYPE, 1, IAC, SE)
+	data = append(data, "?=Help)? : "...)
+	data = append(data, IAC, 0xF9) // Go ahead
+	data = append(data, IAC, IAC)
+
+	got := h.ProcessData(data)
+	want := append([]byte("Command [TL=00:00:00]:[845] (?=Help)? : "), IAC)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(sent) != 1 || !bytes.Equal(sent[0], []byte{IAC, DO, ECHO}) {
+		t.Errorf("Expected DO ECHO in reply to WILL ECHO, got %v", sent)
+	}
+}
+
+func TestProcessDataCommandsSplitAcrossReads(t *testing.T) {
+	var sent [][]byte
+	h := newRecordingHandler(&sent)
+
+	// Every split point of a negotiation and a subnegotiation between two pieces of text
+	full := []byte("Sector  : 1")
+	full = append(full, IAC, WILL, SUPPRESS_GO_AHEAD)
+	full = append(full, IAC, SB, NAWS, 0, 80, 0, 24, IAC, SE)
+	full = append(full, " in The Sol System."...)
+	want := "Sector  : 1 in The Sol System."
+
+	for split := 0; split <= len(full); split++ {
+		sent = nil
+		h = newRecordingHandler(&sent)
+		got := append(h.ProcessData(full[:split]), h.ProcessData(full[split:])...)
+		if string(got) != want {
+			t.Errorf("Split at %d: expected %q, got %q", split, want, got)
+		}
+		if len(sent) != 1 || !bytes.Equal(sent[0], []byte{IAC, DO, SUPPRESS_GO_AHEAD}) {
+			t.Errorf("Split at %d: expected one DO SGA reply, got %v", split, sent)
+		}
+	}
+}
+
+func TestNegotiationEchoAndSuppressGoAhead(t *testing.T) {
+	var sent [][]byte
+	h := newRecordingHandler(&sent)
+	if err := h.SendInitialNegotiation(); err != nil {
+		t.Fatalf("SendInitialNegotiation failed: %v", err)
+	}
+	sent = nil
+
+	// The server agreeing to what we asked for needs no reply
+	h.ProcessData([]byte{IAC, WILL, ECHO, IAC, WILL, SUPPRESS_GO_AHEAD, IAC, DO, SUPPRESS_GO_AHEAD})
+	if len(sent) != 0 {
+		t.Errorf("Expected no replies to acknowledgements, got %v", sent)
+	}
+
+	// Refusals and changes of mind are answered once
+	for _, tt := range []struct {
+		in, want []byte
+	}{
+		{[]byte{IAC, DO, ECHO}, []byte{IAC, WONT, ECHO}},
+		{[]byte{IAC, WONT, ECHO}, []byte{IAC, DONT, ECHO}},
+		{[]byte{IAC, WONT, ECHO}, nil},
+		{[]byte{IAC, WILL, ECHO}, []byte{IAC, DO, ECHO}},
+		{[]byte{IAC, DONT, SUPPRESS_GO_AHEAD}, []byte{IAC, WONT, SUPPRESS_GO_AHEAD}},
+		{[]byte{IAC, DONT, SUPPRESS_GO_AHEAD}, nil},
+		{[]byte{IAC, DO, SUPPRESS_GO_AHEAD}, []byte{IAC, WILL, SUPPRESS_GO_AHEAD}},
+		{[]byte{IAC, WILL, 0x2A}, []byte{IAC, DONT, 0x2A}},
+	} {
+		sent = nil
+		if text := h.ProcessData(tt.in); len(text) != 0 {
+			t.Errorf("%v: expected no text, got %q", tt.in, text)
+		}
+		if tt.want == nil {
+			if len(sent) != 0 {
+				t.Errorf("%v: expected no reply, got %v", tt.in, sent)
+			}
+		} else if len(sent) != 1 || !bytes.Equal(sent[0], tt.want) {
+			t.Errorf("%v: expected reply %v, got %v", tt.in, tt.want, sent)
+		}
+	}
+}
+
+func TestProcessDataWithoutWriter(t *testing.T) {
+	h := NewHandler(nil)
+	got := h.ProcessData(append([]byte("Password? "), IAC, WILL, ECHO))
+	if string(got) != "Password? " {
+		t.Errorf("Expected the command to be stripped, got %q", got)
+	}
+}
+
+func TestResetForgetsPreviousConnection(t *testing.T) {
+	var sent [][]byte
+	h := newRecordingHandler(&sent)
+	if err := h.SendInitialNegotiation(); err != nil {
+		t.Fatalf("SendInitialNegotiation failed: %v", err)
+	}
+	// The old connection ends partway through a command
+	h.ProcessData([]byte{IAC, SB, TERMINAL_TYPE, 1})
+
+	h.Reset()
+	sent = nil
+	got := h.ProcessData(append([]byte("Login: "), IAC, WILL, ECHO))
+	if string(got) != "Login: " {
+		t.Errorf("Expected the new connection's text, got %q", got)
+	}
+	// Options agreed on the old connection are negotiated again
+	if len(sent) != 1 || !bytes.Equal(sent[0], []byte{IAC, DO, ECHO}) {
+		t.Errorf("Expected DO ECHO in reply to WILL ECHO, got %v", sent)
+	}
+}
+
+func TestProcessDataUnterminatedSubnegotiation(t *testing.T) {
+	h := NewHandler(nil)
+	data := []byte{IAC, SB, TERMINAL_TYPE}
+	data = append(data, bytes.Repeat([]byte{'x'}, maxSubnegotiation)...)
+	h.ProcessData(data)
+
+	got := h.ProcessData([]byte("Command [TL=00:00:00]"))
+	if string(got) != "Command [TL=00:00:00]" {
+		t.Errorf("Expected text after an unterminated subnegotiation, got %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package theme
+
+import "github.com/gdamore/tcell/v2"
+
+// ColorTheme is a theme that replaces the Telix default, panel and map node colors. Dialogs,
+// menus and the terminal keep the Telix colors - game output is ANSI art drawn for a black
+// screen. Theme files and the bundled light and high contrast themes are ColorThemes.
+type ColorTheme struct {
+	*TelixTheme
+	name     string
+	defaults DefaultColors
+	panel    PanelColors
+	mapNodes MapNodeColors
+}
+
+// bundledThemes returns the built-in themes in the order they are cycled through
+func bundledThemes() []Theme {
+	return []Theme{NewTelixTheme(), NewLightTheme(), NewHighContrastTheme()}
+}
+
+// NewLightTheme creates the bundled light theme: dark text on light panels and map
+func NewLightTheme() *ColorTheme {
+	paper := tcell.NewHexColor(0xF0F0F0)
+	ink := tcell.NewHexColor(0x202020)
+	return &ColorTheme{
+		TelixTheme: NewTelixTheme(),
+		name:       "light",
+		defaults: DefaultColors{
+			Background: paper,
+			Foreground: ink,
+			Waiting:    DOSDarkGray,
+		},
+		panel: PanelColors{
+			Background: paper,
+			Foreground: ink,
+			Border:     DOSDarkGray,
+			Title:      ink,
+			HeaderBg:   paper,
+			HeaderFg:   ink,
+		},
+		mapNodes: MapNodeColors{
+			Current:    "gold",
+			Trader:     "lightskyblue",
+			Port:       "palegreen",
+			Visited:    "gainsboro",
+			Scanned:    "lavender",
+			Unexplored: "mistyrose",
+		},
+	}
+}
+
+// NewHighContrastTheme creates the bundled high contrast theme: white on black with
+// saturated map colors
+func NewHighContrastTheme() *ColorTheme {
+	return &ColorTheme{
+		TelixTheme: NewTelixTheme(),
+		name:       "high-contrast",
+		defaults: DefaultColors{
+			Background: DOSBlack,
+			Foreground: DOSWhite,
+			Waiting:    DOSLightGray,
+		},
+		panel: PanelColors{
+			Background: DOSBlack,
+			Foreground: DOSWhite,
+			Border:     DOSYellow,
+			Title:      DOSYellow,
+			HeaderBg:   DOSBlack,
+			HeaderFg:   DOSWhite,
+		},
+		mapNodes: MapNodeColors{
+			Current:    "yellow",
+			Trader:     "cyan",
+			Port:       "green",
+			Visited:    "white",
+			Scanned:    "magenta",
+			Unexplored: "red",
+		},
+	}
+}
+
+// Name returns the theme name
+func (t *ColorTheme) Name() string {
+	return t.name
+}
+
+// DefaultColors returns the theme's default colors
+func (t *ColorTheme) DefaultColors() DefaultColors {
+	return t.defaults
+}
+
+// PanelColors returns the theme's panel colors
+func (t *ColorTheme) PanelColors() PanelColors {
+	return t.panel
+}
+
+// MapNodeColors returns the theme's map node fill colors
+func (t *ColorTheme) MapNodeColors() MapNodeColors {
+	return t.mapNodes
+}
@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestThemeManager_CycleTheme(t *testing.T) {
+	tm := NewThemeManager()
+
+	var names []string
+	for range 4 {
+		names = append(names, tm.CycleTheme().Name())
+	}
+	want := []string{"light", "high-contrast", "telix", "light"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("Expected cycle %v, got %v", want, names)
+		}
+	}
+
+	// A theme file joins the cycle after the bundled themes
+	if err := tm.LoadThemeFile(writeThemeFile(t, validThemeFile)); err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+	if name := tm.CycleTheme().Name(); name != "telix" {
+		t.Errorf("Expected the cycle to wrap from the file theme to telix, got %q", name)
+	}
+	tm.CycleTheme()
+	tm.CycleTheme()
+	if name := tm.CycleTheme().Name(); name != "amber" {
+		t.Errorf("Expected the file theme after the bundled themes, got %q", name)
+	}
+}
+
+func TestBundledThemes(t *testing.T) {
+	for _, bundled := range bundledThemes() {
+		nodes := bundled.MapNodeColors()
+		if nodes.Current == "" || nodes.Trader == "" || nodes.Port == "" || nodes.Visited == "" || nodes.Scanned == "" || nodes.Unexplored == "" {
+			t.Errorf("%s: missing map node colors %+v", bundled.Name(), nodes)
+		}
+		if bundled.DefaultColors().Background == bundled.DefaultColors().Foreground {
+			t.Errorf("%s: text matches its background", bundled.Name())
+		}
+		if bundled.PanelColors().Background == bundled.PanelColors().Foreground {
+			t.Errorf("%s: panel text matches its background", bundled.Name())
+		}
+	}
+}
+
+func TestThemeChoice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultChoiceFile)
+
+	if _, err := LoadChoice(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no choice before one is saved, got %v", err)
+	}
+
+	if err := SaveChoice(path, "high-contrast"); err != nil {
+		t.Fatalf("SaveChoice failed: %v", err)
+	}
+	name, err := LoadChoice(path)
+	if err != nil {
+		t.Fatalf("LoadChoice failed: %v", err)
+	}
+	if name != "high-contrast" {
+		t.Errorf("Expected high-contrast, got %q", name)
+	}
+}
@@ -0,0 +1,23 @@
+package theme
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultChoiceFile remembers the theme picked while Twist runs, so the next launch uses it
+const DefaultChoiceFile = "twist_theme_choice"
+
+// SaveChoice records the name of the chosen theme
+func SaveChoice(path, name string) error {
+	return os.WriteFile(path, []byte(name+"\n"), 0644)
+}
+
+// LoadChoice returns the theme name recorded by SaveChoice
+func LoadChoice(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,175 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultThemeFile is the user theme read from the working directory at startup, if present
+const DefaultThemeFile = "twist_theme.json"
+
+// themeFileKeys lists every section and color key a theme file must define
+var themeFileKeys = map[string][]string{
+	"default":   {"background", "foreground", "waiting"},
+	"panel":     {"background", "foreground", "border", "title", "header_bg", "header_fg"},
+	"map_nodes": {"current", "trader", "port", "visited", "unexplored"},
+}
+
+// themeFileOptionalKeys lists colors added after theme files were in use, which a file may
+// leave out, with the key in the same section each one defaults to
+var themeFileOptionalKeys = map[string]map[string]string{
+	"map_nodes": {"scanned": "unexplored"},
+}
+
+// LoadThemeFile reads a theme file, checking that it defines every required color and no
+// unknown ones. The optional map_nodes "scanned" color, for sectors only seen by a density
+// scan, defaults to the unexplored color.
+//
+// The file names the theme and gives every color as a name ("yellow") or hex ("#ffff00"):
+//
+//	{"name": "amber",
+//	 "default": {"background": "#000000", "foreground": "#ffb000", "waiting": "#805800"},
+//	 "panel": {"background": "#000000", "foreground": "#ffb000", "border": "#ffb000",
+//	           "title": "#ffb000", "header_bg": "#000000", "header_fg": "#ffb000"},
+//	 "map_nodes": {"current": "yellow", "trader": "#ffd080", "port": "#ffc040",
+//	               "visited": "gray", "unexplored": "#804000"}}
+func LoadThemeFile(path string) (*ColorTheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %s: %w", path, err)
+	}
+
+	var name string
+	sections := make(map[string]map[string]string)
+	for key, value := range raw {
+		if key == "name" {
+			if err := json.Unmarshal(value, &name); err != nil {
+				return nil, fmt.Errorf("failed to parse theme %s: name: %w", path, err)
+			}
+			continue
+		}
+		var section map[string]string
+		if err := json.Unmarshal(value, &section); err != nil {
+			return nil, fmt.Errorf("failed to parse theme %s: %s: %w", path, key, err)
+		}
+		sections[key] = section
+	}
+
+	colors, err := validateThemeFile(name, sections)
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+
+	return &ColorTheme{
+		TelixTheme: NewTelixTheme(),
+		name:       name,
+		defaults: DefaultColors{
+			Background: colors["default.background"],
+			Foreground: colors["default.foreground"],
+			Waiting:    colors["default.waiting"],
+		},
+		panel: PanelColors{
+			Background: colors["panel.background"],
+			Foreground: colors["panel.foreground"],
+			Border:     colors["panel.border"],
+			Title:      colors["panel.title"],
+			HeaderBg:   colors["panel.header_bg"],
+			HeaderFg:   colors["panel.header_fg"],
+		},
+		mapNodes: MapNodeColors{
+			Current:    hexColor(colors["map_nodes.current"]),
+			Trader:     hexColor(colors["map_nodes.trader"]),
+			Port:       hexColor(colors["map_nodes.port"]),
+			Visited:    hexColor(colors["map_nodes.visited"]),
+			Scanned:    hexColor(colors["map_nodes.scanned"]),
+			Unexplored: hexColor(colors["map_nodes.unexplored"]),
+		},
+	}, nil
+}
+
+// validateThemeFile checks the theme name and colors, returning the colors keyed "section.key"
+func validateThemeFile(name string, sections map[string]map[string]string) (map[string]tcell.Color, error) {
+	var errs []error
+	if name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	for _, bundled := range bundledThemes() {
+		if name == bundled.Name() {
+			errs = append(errs, fmt.Errorf("name %q is used by a built-in theme", name))
+		}
+	}
+
+	for _, section := range sortedKeys(sections) {
+		if _, known := themeFileKeys[section]; !known {
+			errs = append(errs, fmt.Errorf("unknown section %q", section))
+		}
+	}
+
+	colors := make(map[string]tcell.Color)
+	for _, section := range sortedKeys(themeFileKeys) {
+		values, ok := sections[section]
+		if !ok {
+			errs = append(errs, fmt.Errorf("missing section %q", section))
+			continue
+		}
+
+		known := make(map[string]bool, len(themeFileKeys[section])+len(themeFileOptionalKeys[section]))
+		parseColor := func(key string) {
+			value := values[key]
+			color := tcell.GetColor(value)
+			if color == tcell.ColorDefault {
+				errs = append(errs, fmt.Errorf("%s.%s: unknown color %q", section, key, value))
+				return
+			}
+			colors[section+"."+key] = color
+		}
+		for _, key := range themeFileKeys[section] {
+			known[key] = true
+			if _, ok := values[key]; !ok {
+				errs = append(errs, fmt.Errorf("%s: missing color %q", section, key))
+				continue
+			}
+			parseColor(key)
+		}
+		for _, key := range sortedKeys(themeFileOptionalKeys[section]) {
+			known[key] = true
+			if _, ok := values[key]; !ok {
+				colors[section+"."+key] = colors[section+"."+themeFileOptionalKeys[section][key]]
+				continue
+			}
+			parseColor(key)
+		}
+		for _, key := range sortedKeys(values) {
+			if !known[key] {
+				errs = append(errs, fmt.Errorf("%s: unknown key %q", section, key))
+			}
+		}
+	}
+
+	return colors, errors.Join(errs...)
+}
+
+// sortedKeys returns the map's keys in order, so validation errors are reported consistently
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hexColor formats a color the way graphviz expects it
+func hexColor(color tcell.Color) string {
+	return fmt.Sprintf("#%06x", color.Hex())
+}
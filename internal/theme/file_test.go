@@ -0,0 +1,125 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const validThemeFile = `{
+	"name": "amber",
+	"default": {"background": "#000000", "foreground": "#ffb000", "waiting": "#805800"},
+	"panel": {"background": "#101010", "foreground": "#ffb000", "border": "#ffb000",
+	          "title": "#ffb000", "header_bg": "#000000", "header_fg": "#ffb000"},
+	"map_nodes": {"current": "yellow", "trader": "#ffd080", "port": "#ffc040",
+	              "visited": "gray", "unexplored": "#804000"}
+}`
+
+func writeThemeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultThemeFile)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write theme file: %v", err)
+	}
+	return path
+}
+
+func TestLoadThemeFile(t *testing.T) {
+	fileTheme, err := LoadThemeFile(writeThemeFile(t, validThemeFile))
+	if err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+
+	if fileTheme.Name() != "amber" {
+		t.Errorf("Expected theme name amber, got %q", fileTheme.Name())
+	}
+	if fileTheme.DefaultColors().Foreground != tcell.NewHexColor(0xffb000) {
+		t.Errorf("Unexpected default foreground %v", fileTheme.DefaultColors().Foreground)
+	}
+	if fileTheme.PanelColors().Background != tcell.NewHexColor(0x101010) {
+		t.Errorf("Unexpected panel background %v", fileTheme.PanelColors().Background)
+	}
+	if nodes := fileTheme.MapNodeColors(); nodes.Current != "#ffff00" || nodes.Port != "#ffc040" {
+		t.Errorf("Unexpected map node colors %+v", nodes)
+	}
+	// The optional scanned color defaults to the unexplored color
+	if nodes := fileTheme.MapNodeColors(); nodes.Scanned != "#804000" {
+		t.Errorf("Expected scanned sectors to use the unexplored color, got %q", nodes.Scanned)
+	}
+	// Colors the file doesn't cover come from the built-in theme
+	if fileTheme.MenuColors() != NewTelixTheme().MenuColors() {
+		t.Error("Expected menu colors from the built-in theme")
+	}
+}
+
+func TestLoadThemeFile_ScannedColor(t *testing.T) {
+	content := strings.Replace(validThemeFile, `"unexplored": "#804000"`, `"unexplored": "#804000", "scanned": "#a06000"`, 1)
+	fileTheme, err := LoadThemeFile(writeThemeFile(t, content))
+	if err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+	if nodes := fileTheme.MapNodeColors(); nodes.Scanned != "#a06000" {
+		t.Errorf("Expected the scanned color from the file, got %q", nodes.Scanned)
+	}
+}
+
+func TestLoadThemeFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"missing name", strings.Replace(validThemeFile, `"name": "amber",`, "", 1), "name is required"},
+		{"built-in name", strings.Replace(validThemeFile, `"amber"`, `"telix"`, 1), "built-in theme"},
+		{"bundled name", strings.Replace(validThemeFile, `"amber"`, `"light"`, 1), "built-in theme"},
+		{"missing key", strings.Replace(validThemeFile, `"waiting": "#805800"`, `"extra": "#805800"`, 1), `missing color "waiting"`},
+		{"unknown key", strings.Replace(validThemeFile, `"waiting": "#805800"`, `"waiting": "#805800", "extra": "red"`, 1), `unknown key "extra"`},
+		{"bad color", strings.Replace(validThemeFile, `"#ffc040"`, `"not-a-color"`, 1), `unknown color "not-a-color"`},
+		{"unknown section", strings.Replace(validThemeFile, `"name": "amber",`, `"name": "amber", "menus": {},`, 1), `unknown section "menus"`},
+		{"not json", "{", "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadThemeFile(writeThemeFile(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestThemeManager_LoadThemeFile(t *testing.T) {
+	tm := NewThemeManager()
+	path := writeThemeFile(t, validThemeFile)
+
+	if err := tm.LoadThemeFile(path); err != nil {
+		t.Fatalf("LoadThemeFile failed: %v", err)
+	}
+	if tm.Current().Name() != "amber" {
+		t.Errorf("Expected amber to be current, got %q", tm.Current().Name())
+	}
+
+	// A broken file keeps the current theme
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write theme file: %v", err)
+	}
+	if err := tm.LoadThemeFile(path); err == nil {
+		t.Error("Expected a broken theme file to fail")
+	}
+	if tm.Current().Name() != "amber" {
+		t.Errorf("Expected amber to stay current, got %q", tm.Current().Name())
+	}
+
+	// Removing the file restores the built-in theme
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove theme file: %v", err)
+	}
+	if err := tm.LoadThemeFile(path); err != nil {
+		t.Fatalf("LoadThemeFile without a file failed: %v", err)
+	}
+	if tm.Current().Name() != "telix" {
+		t.Errorf("Expected the built-in theme, got %q", tm.Current().Name())
+	}
+}
@@ -130,6 +130,18 @@ func (t *TelixTheme) SectorMapColors() SectorMapColors {
 	}
 }
 
+// MapNodeColors returns the sector map node fill colors
+func (t *TelixTheme) MapNodeColors() MapNodeColors {
+	return MapNodeColors{
+		Current:    "yellow",
+		Trader:     "lightblue",
+		Port:       "lightgreen",
+		Visited:    "gray",
+		Scanned:    "thistle",
+		Unexplored: "lightcoral",
+	}
+}
+
 // BorderStyle returns the border styling
 func (t *TelixTheme) BorderStyle() BorderStyle {
 	return BorderStyle{
@@ -1,7 +1,10 @@
 package theme
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
 	"twist/internal/components"
@@ -81,6 +84,16 @@ type SectorMapColors struct {
 	MapBackground   tcell.Color // Background for the entire map area
 }
 
+// MapNodeColors defines graphviz fill colors for sector map nodes
+type MapNodeColors struct {
+	Current    string // The sector the player is in
+	Trader     string // Visited sector with traders
+	Port       string // Visited sector with a port
+	Visited    string // Other visited sectors
+	Scanned    string // Sectors only seen by a density scan
+	Unexplored string // Sectors only known from warps
+}
+
 // BorderStyle defines border styling options
 type BorderStyle struct {
 	Color      tcell.Color
@@ -101,6 +114,7 @@ type Theme interface {
 	StatusColors() StatusColors
 	PanelColors() PanelColors
 	SectorMapColors() SectorMapColors
+	MapNodeColors() MapNodeColors
 
 	// Border styling
 	BorderStyle() BorderStyle
@@ -112,8 +126,10 @@ type Theme interface {
 
 // ThemeManager manages theme selection and application
 type ThemeManager struct {
+	mu           sync.RWMutex // Themes can be reloaded while maps render in the background
 	currentTheme Theme
 	themes       map[string]Theme
+	order        []string // Theme names in registration order, for cycling
 }
 
 // NewThemeManager creates a new theme manager
@@ -123,7 +139,9 @@ func NewThemeManager() *ThemeManager {
 	}
 
 	// Register built-in themes
-	tm.RegisterTheme(NewTelixTheme())
+	for _, theme := range bundledThemes() {
+		tm.RegisterTheme(theme)
+	}
 
 	// Set default theme
 	tm.SetTheme("telix")
@@ -133,11 +151,18 @@ func NewThemeManager() *ThemeManager {
 
 // RegisterTheme registers a new theme
 func (tm *ThemeManager) RegisterTheme(theme Theme) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if _, exists := tm.themes[theme.Name()]; !exists {
+		tm.order = append(tm.order, theme.Name())
+	}
 	tm.themes[theme.Name()] = theme
 }
 
 // SetTheme sets the current theme by name
 func (tm *ThemeManager) SetTheme(name string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
 	if theme, exists := tm.themes[name]; exists {
 		tm.currentTheme = theme
 		return nil
@@ -147,11 +172,46 @@ func (tm *ThemeManager) SetTheme(name string) error {
 
 // Current returns the current theme
 func (tm *ThemeManager) Current() Theme {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
 	return tm.currentTheme
 }
 
+// CycleTheme switches to the theme registered after the current one, wrapping around to
+// the first, and returns it
+func (tm *ThemeManager) CycleTheme() Theme {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	next := 0
+	for i, name := range tm.order {
+		if tm.currentTheme != nil && name == tm.currentTheme.Name() {
+			next = (i + 1) % len(tm.order)
+			break
+		}
+	}
+	tm.currentTheme = tm.themes[tm.order[next]]
+	return tm.currentTheme
+}
+
+// LoadThemeFile loads a theme file and makes it the current theme. A missing file
+// restores the built-in theme, so deleting the file and reloading undoes it.
+func (tm *ThemeManager) LoadThemeFile(path string) error {
+	fileTheme, err := LoadThemeFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tm.SetTheme(NewTelixTheme().Name())
+	}
+	if err != nil {
+		return err
+	}
+
+	tm.RegisterTheme(fileTheme)
+	return tm.SetTheme(fileTheme.Name())
+}
+
 // Available returns list of available theme names
 func (tm *ThemeManager) Available() []string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
 	names := make([]string, 0, len(tm.themes))
 	for name := range tm.themes {
 		names = append(names, name)
@@ -30,7 +30,10 @@ func (pc *ProxyClient) Connect(address string, tuiAPI coreapi.TuiAPI) error {
 
 func (pc *ProxyClient) ConnectWithScript(address string, tuiAPI coreapi.TuiAPI, scriptName string) error {
 	// Use Connect function with ConnectOptions to load initial script
-	connectOpts := &coreapi.ConnectOptions{ScriptName: scriptName}
+	return pc.ConnectWithOptions(address, tuiAPI, &coreapi.ConnectOptions{ScriptName: scriptName})
+}
+
+func (pc *ProxyClient) ConnectWithOptions(address string, tuiAPI coreapi.TuiAPI, connectOpts *coreapi.ConnectOptions) error {
 	proxyAPI := factory.Connect(address, tuiAPI, connectOpts)
 
 	// Store the connected API instance
@@ -62,6 +65,19 @@ func (pc *ProxyClient) SendData(data []byte) error {
 	return pc.currentAPI.SendData(data)
 }
 
+// CloseDatabase closes the connected game's database once the output being parsed is saved
+func (pc *ProxyClient) CloseDatabase() error {
+	if pc.currentAPI == nil {
+		return errors.New("not connected")
+	}
+	return pc.currentAPI.CloseDatabase()
+}
+
+// TerminalMenus lists the proxy's terminal menus, whether or not a connection is open
+func (pc *ProxyClient) TerminalMenus() []coreapi.TerminalMenuInfo {
+	return factory.TerminalMenus()
+}
+
 func (pc *ProxyClient) GetCurrentAPI() coreapi.ProxyAPI {
 	return pc.currentAPI
 }
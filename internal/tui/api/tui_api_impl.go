@@ -9,6 +9,7 @@ import (
 type TwistApp interface {
 	HandleConnectionStatusChanged(status coreapi.ConnectionStatus, address string)
 	HandleConnectionError(err error)
+	HandleConnectionStateChanged(state coreapi.ConnectionState)
 	HandleTerminalData(data []byte)
 	HandleScriptStatusChanged(status coreapi.ScriptStatusInfo)
 	HandleScriptError(scriptName string, err error)
@@ -18,6 +19,11 @@ type TwistApp interface {
 	HandleTraderDataUpdated(sectorNumber int, traders []coreapi.TraderInfo)
 	HandlePlayerStatsUpdated(stats coreapi.PlayerStatsInfo)
 	HandleSectorUpdated(sectorInfo coreapi.SectorInfo)
+	HandleSectorWarpsUpdated(sector int, warps [6]int)
+	HandleMapInvalidated(sectors []int)
+	HandleCoursePlotted(sectors []int)
+	HandleGameDetectionFailed(serverHost, serverPort string)
+	HandleGameSelectionMenu(menu coreapi.GameMenuInfo)
 }
 
 // TuiApiImpl implements TuiAPI as a thin orchestration layer
@@ -51,6 +57,10 @@ func (tui *TuiApiImpl) OnConnectionError(err error) {
 	go tui.app.HandleConnectionError(err)
 }
 
+func (tui *TuiApiImpl) OnConnectionStateChanged(state coreapi.ConnectionState) {
+	go tui.app.HandleConnectionStateChanged(state)
+}
+
 func (tui *TuiApiImpl) OnData(data []byte) {
 	// Log raw data chunks for debugging
 	log.LogDataChunk("<", data)
@@ -106,6 +116,30 @@ func (tui *TuiApiImpl) OnSectorUpdated(sectorInfo coreapi.SectorInfo) {
 	go tui.app.HandleSectorUpdated(sectorInfo)
 }
 
+// Sector warps event handler - called when only warp data changes (probe, sector display)
+func (tui *TuiApiImpl) OnSectorWarpsUpdated(sector int, warps [6]int) {
+	go tui.app.HandleSectorWarpsUpdated(sector, warps)
+}
+
+// Map invalidation handler - called once when a CIM download has changed many sectors
+func (tui *TuiApiImpl) OnMapInvalidated(sectors []int) {
+	go tui.app.HandleMapInvalidated(sectors)
+}
+
+// Course handler - called when the game plots a course
+func (tui *TuiApiImpl) OnCoursePlotted(sectors []int) {
+	go tui.app.HandleCoursePlotted(sectors)
+}
+
+// Game detection failure handler - called when no known prompt was seen after connecting
+func (tui *TuiApiImpl) OnGameDetectionFailed(serverHost, serverPort string) {
+	go tui.app.HandleGameDetectionFailed(serverHost, serverPort)
+}
+
+func (tui *TuiApiImpl) OnGameSelectionMenu(menu coreapi.GameMenuInfo) {
+	go tui.app.HandleGameSelectionMenu(menu)
+}
+
 // processDataLoop runs in a single goroutine to process all terminal data sequentially
 func (tui *TuiApiImpl) processDataLoop() {
 	for {
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 	coreapi "twist/internal/api"
 	twistComponents "twist/internal/components"
@@ -11,7 +12,10 @@ import (
 	"twist/internal/tui/api"
 	"twist/internal/tui/components"
 	"twist/internal/tui/handlers"
+	"twist/internal/tui/keymap"
 	"twist/internal/tui/menus"
+	"twist/internal/tui/profiles"
+	"twist/internal/tui/termcap"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -39,6 +43,12 @@ type TwistApp struct {
 	// Input handling
 	inputHandler    *handlers.InputHandler
 	globalShortcuts *twistComponents.GlobalShortcutManager
+	keymap          *keymap.Keymap
+
+	// Saved connection profiles; nil if the profiles file couldn't be read
+	profiles *profiles.Profiles
+	// Profile to connect to once the current connection is down (switching servers)
+	pendingProfile *profiles.Profile
 
 	// Menu system
 	menuManager *menus.MenuManager
@@ -56,9 +66,45 @@ type TwistApp struct {
 	// Update channels
 	terminalUpdateChan chan struct{}
 
+	// Batches rapid sector events (holo scans, probes) into one panel update per window
+	sectorUpdates *sectorUpdateCoalescer
+
 	// Initial script to load on connection
 	initialScript string
 
+	// Opt-in per-server game database when no game is detected (see ConnectOptions)
+	serverDatabaseFallback bool
+
+	// How often the game database is checkpointed (see ConnectOptions)
+	checkpointInterval time.Duration
+
+	// Lines a parser display may run without saving anything (see ConnectOptions)
+	displayWatchdogLines int
+
+	// Game to select at the server's game selection prompt (see ConnectOptions)
+	gameLetter string
+
+	// How old sector data must be before it is flagged as stale (see ConnectOptions)
+	staleSectorAge time.Duration
+
+	// How old sector data must be before its traders and ships are pruned (see ConnectOptions)
+	pruneVolatileAfter time.Duration
+
+	// Redialing the server when the connection drops (see ConnectOptions)
+	reconnect coreapi.ReconnectOptions
+
+	// File of economy constants for trade profit estimates (see ConnectOptions)
+	tradePricingPath string
+
+	// File of custom port classes (see ConnectOptions)
+	portClassesPath string
+
+	// File unrecognized prompts are logged to, empty to not log them (see ConnectOptions)
+	unrecognizedPromptsPath string
+
+	// Server given on the command line, connected to at startup instead of the dialog
+	startupAddress string
+
 	// Version information
 	version string
 	commit  string
@@ -68,6 +114,12 @@ type TwistApp struct {
 // NewApplication creates and configures the tview application
 func NewApplication() *TwistApp {
 
+	// The user's theme must be current before any component takes its colors
+	loadUserTheme()
+
+	// The terminal is asked what it can show before the screen takes over its input
+	termcap.Detect()
+
 	// Create the main application
 	app := tview.NewApplication()
 
@@ -101,6 +153,8 @@ func NewApplication() *TwistApp {
 		statusComponent:    statusComp,
 		inputHandler:       inputHandler,
 		globalShortcuts:    twistComponents.NewGlobalShortcutManager(),
+		keymap:             loadKeymap(),
+		profiles:           loadProfiles(),
 		menuManager:        menuManager,
 		sixelLayer:         sixelLayer,
 		panelsVisible:      false, // Start with panels hidden
@@ -113,6 +167,7 @@ func NewApplication() *TwistApp {
 	// Create API layer - proxy instances created per connection via static Connect()
 	twistApp.proxyClient = api.NewProxyClient()
 	twistApp.tuiAPI = api.NewTuiAPI(twistApp)
+	twistApp.sectorUpdates = newSectorUpdateCoalescer(DefaultSectorUpdateCoalesceInterval, twistApp.applySectorUpdateBatch)
 
 	// Set up terminal update callback for TerminalComponent
 	terminalComp.SetChangedFunc(func() {
@@ -164,11 +219,11 @@ func NewApplication() *TwistApp {
 	twistApp.registerMenuShortcuts() // Register all menu shortcuts globally
 	// twistApp.startUpdateWorker() // Commented out - appears to be unused legacy code causing double redraws
 
-	// Auto-show connection dialog on startup for easy testing
+	// Auto-show the profiles or connection dialog on startup
 	go func() {
 		// Small delay to ensure UI is fully initialized
 		twistApp.app.QueueUpdateDraw(func() {
-			twistApp.showConnectionDialog()
+			twistApp.showStartupDialog()
 		})
 	}()
 
@@ -393,34 +448,29 @@ func (ta *TwistApp) setupInputHandling() {
 	ta.app.SetInputCapture(ta.handleGlobalKeys)
 }
 
-// registerMenuShortcuts registers all menu item shortcuts globally at startup
+// registerMenuShortcuts shows the keymap's keys next to the menus and items they trigger.
+// The keys themselves are handled by handleBoundKey.
 func (ta *TwistApp) registerMenuShortcuts() {
-
-	// Register Session menu shortcuts
-	sessionItems := []twistComponents.MenuItem{
-		{Label: "Connect", Shortcut: ""},
-		{Label: "Recent Connections", Shortcut: ""},
-		{Label: "Disconnect", Shortcut: ""},
-		{Label: "Save Session", Shortcut: ""},
-		{Label: "Quit", Shortcut: "Alt+Q"},
+	shortcuts := []struct {
+		menu, item string
+		action     keymap.Action
+	}{
+		{"Session", "", keymap.SessionMenu},
+		{"View", "", keymap.ViewMenu},
+		{"Scripts", "", keymap.ScriptsMenu},
+		{"Terminal", "", keymap.TerminalMenu},
+		{"Help", "", keymap.HelpMenu},
+		{"Session", "Connect", keymap.Connect},
+		{"Session", "Disconnect", keymap.Disconnect},
+		{"Session", "Quit", keymap.Quit},
+		{"View", "Panels", keymap.TogglePanels},
+		{"View", "Next Theme", keymap.NextTheme},
+		{"Help", "Keyboard Shortcuts", keymap.Help},
 	}
 
-	for _, item := range sessionItems {
-		if item.Shortcut != "" {
-			label := item.Label // Capture for closure
-			shortcut := item.Shortcut
-			ta.globalShortcuts.RegisterShortcut(shortcut, func() {
-				// Handle the menu item action
-				switch label {
-				case "Quit":
-					ta.exit()
-					// Add other menu item actions as needed
-				}
-			})
-		}
+	for _, shortcut := range shortcuts {
+		ta.menuManager.SetShortcut(shortcut.menu, shortcut.item, ta.keymap.Key(shortcut.action))
 	}
-
-	// TODO: Register shortcuts for other menus (Edit, View, Terminal, Help) as they get shortcuts
 }
 
 // SetInitialScript sets the script to load on connection
@@ -428,6 +478,104 @@ func (ta *TwistApp) SetInitialScript(scriptName string) {
 	ta.initialScript = scriptName
 }
 
+// SetServerDatabaseFallback makes connections start one game database per server when
+// the game command prompt appears before any game was detected
+func (ta *TwistApp) SetServerDatabaseFallback(enabled bool) {
+	ta.serverDatabaseFallback = enabled
+}
+
+// SetCheckpointInterval sets how often the game database is checkpointed, so a copy of its
+// file alone is current. Zero uses the proxy's default; negative turns it off.
+func (ta *TwistApp) SetCheckpointInterval(interval time.Duration) {
+	ta.checkpointInterval = interval
+}
+
+// SetDisplayWatchdogLines sets how many lines the parser lets a screen run without saving
+// anything before resetting it. Zero turns the watchdog off.
+func (ta *TwistApp) SetDisplayWatchdogLines(lines int) {
+	ta.displayWatchdogLines = lines
+	if lines <= 0 {
+		ta.displayWatchdogLines = -1
+	}
+}
+
+// SetGameLetter makes connections select the game with this letter the first time the
+// server's game selection prompt lists it. Empty leaves the choice to the user.
+func (ta *TwistApp) SetGameLetter(letter string) {
+	ta.gameLetter = letter
+}
+
+// SetTradePricingFile makes connections estimate trade profits with the base prices and
+// margin in this JSON file. Empty uses twist_trade_pricing.json if present.
+func (ta *TwistApp) SetTradePricingFile(path string) {
+	ta.tradePricingPath = path
+}
+
+// SetPortClassesFile makes connections class ports by the trade patterns in this JSON file.
+// Empty uses twist_port_classes.json if present.
+func (ta *TwistApp) SetPortClassesFile(path string) {
+	ta.portClassesPath = path
+}
+
+// SetUnrecognizedPromptsFile makes connections append prompts nothing recognized to this
+// file. Empty doesn't log them.
+func (ta *TwistApp) SetUnrecognizedPromptsFile(path string) {
+	ta.unrecognizedPromptsPath = path
+}
+
+// SetPruneVolatileAfter makes connections clear traders, ships and foreign fighters from
+// sectors last seen longer ago than age when the game database loads. Zero keeps them.
+func (ta *TwistApp) SetPruneVolatileAfter(age time.Duration) {
+	ta.pruneVolatileAfter = age
+}
+
+// SetReconnect makes connections redial the server up to maxAttempts times when it drops
+// the connection, waiting interval before the first attempt and twice as long after each
+// failure. Zero attempts never reconnect; a zero interval uses the default.
+func (ta *TwistApp) SetReconnect(maxAttempts int, interval time.Duration) {
+	ta.reconnect = coreapi.ReconnectOptions{MaxAttempts: maxAttempts, Interval: interval}
+}
+
+// CloseDatabase saves what has been parsed and closes the game database, for shutdowns that
+// can't disconnect cleanly. It does nothing without a connection.
+func (ta *TwistApp) CloseDatabase() {
+	if err := ta.proxyClient.CloseDatabase(); err != nil {
+		log.Debug("Game database not closed", "error", err)
+	}
+}
+
+// SetStaleSectorThreshold sets how old a sector's data must be before the sector map dims
+// it and the terminal menu's sector display shows its age. Zero turns the indicator off.
+func (ta *TwistApp) SetStaleSectorThreshold(threshold time.Duration) {
+	ta.panelComponent.SetStaleSectorThreshold(threshold)
+	ta.staleSectorAge = threshold
+	if threshold <= 0 {
+		ta.staleSectorAge = -1
+	}
+}
+
+// SetSectorMapMode picks how the sector map is drawn: "sixel", "text", or "auto" to draw
+// sixels if the terminal reported them at startup
+func (ta *TwistApp) SetSectorMapMode(mode string) error {
+	switch mode {
+	case components.MapModeSixel:
+		ta.panelComponent.SetTextMap(false)
+	case components.MapModeText:
+		ta.panelComponent.SetTextMap(true)
+	case components.MapModeAuto:
+		ta.panelComponent.SetTextMap(!termcap.Current().Sixel)
+	default:
+		return fmt.Errorf("unknown sector map mode %q: use auto, sixel or text", mode)
+	}
+	return nil
+}
+
+// SetSectorUpdateCoalesceInterval sets how long sector events are collected before the
+// panels are updated. Zero applies every event immediately.
+func (ta *TwistApp) SetSectorUpdateCoalesceInterval(interval time.Duration) {
+	ta.sectorUpdates.SetInterval(interval)
+}
+
 // SetVersionInfo sets the version information for display
 func (ta *TwistApp) SetVersionInfo(version, commit, date string) {
 	ta.version = version
@@ -447,6 +595,28 @@ func (ta *TwistApp) Run() error {
 
 // connect establishes connection to the game server
 func (ta *TwistApp) connect(address string) {
+	ta.connectWithOptions(address, ta.connectOptions())
+}
+
+// connectOptions returns the options every connection is made with
+func (ta *TwistApp) connectOptions() *coreapi.ConnectOptions {
+	return &coreapi.ConnectOptions{
+		ScriptName:              ta.initialScript,
+		ServerDatabaseFallback:  ta.serverDatabaseFallback,
+		UnrecognizedPromptsPath: ta.unrecognizedPromptsPath,
+		CheckpointInterval:      ta.checkpointInterval,
+		DisplayWatchdogLines:    ta.displayWatchdogLines,
+		GameLetter:              ta.gameLetter,
+		StaleSectorAge:          ta.staleSectorAge,
+		PruneVolatileAfter:      ta.pruneVolatileAfter,
+		Reconnect:               ta.reconnect,
+		TradePricingPath:        ta.tradePricingPath,
+		PortClassesPath:         ta.portClassesPath,
+	}
+}
+
+// connectWithOptions establishes connection to the game server with the given options
+func (ta *TwistApp) connectWithOptions(address string, connectOpts *coreapi.ConnectOptions) {
 	// Close modal immediately
 	if ta.modalVisible {
 		ta.closeModal()
@@ -454,7 +624,7 @@ func (ta *TwistApp) connect(address string) {
 
 	// Use API layer exclusively - connection should be non-blocking
 	// Proxy will call HandleConnecting, then HandleConnectionEstablished/HandleConnectionError
-	if err := ta.proxyClient.ConnectWithScript(address, ta.tuiAPI, ta.initialScript); err != nil {
+	if err := ta.proxyClient.ConnectWithOptions(address, ta.tuiAPI, connectOpts); err != nil {
 		// Handle immediate validation errors
 		ta.connected = false
 		ta.serverAddress = ""
@@ -569,6 +739,9 @@ func (ta *TwistApp) HandleConnectionStatusChanged(status coreapi.ConnectionStatu
 
 				// Ensure terminal keeps focus after disconnection
 				ta.app.SetFocus(ta.terminalComponent.GetView())
+
+				// Finish switching to another profile
+				ta.connectPendingProfile()
 			}
 		})
 	}()
@@ -602,6 +775,21 @@ func (ta *TwistApp) HandleConnectionError(err error) {
 	}()
 }
 
+// HandleConnectionStateChanged shows the proxy's connection lifecycle in the status bar
+func (ta *TwistApp) HandleConnectionStateChanged(state coreapi.ConnectionState) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("PANIC recovered in connection state callback", "function", "HandleConnectionStateChanged", "error", r)
+			}
+		}()
+
+		ta.app.QueueUpdateDraw(func() {
+			ta.statusComponent.SetConnectionState(state)
+		})
+	}()
+}
+
 func (ta *TwistApp) HandleTerminalData(data []byte) {
 	// Add error recovery to catch any panics in terminal processing
 	defer func() {
@@ -652,7 +840,12 @@ func (ta *TwistApp) HandleDatabaseStateChanged(info coreapi.DatabaseStateInfo) {
 
 		ta.app.QueueUpdateDraw(func() {
 			// Update status bar to show active game information
-			ta.statusComponent.SetGameInfo(info.GameName, info.ServerHost, info.ServerPort, info.IsLoaded)
+			ta.statusComponent.SetGameInfo(info.GameLetter, info.GameName, info.ServerHost, info.ServerPort, info.IsLoaded)
+
+			// Sectors cached from the previous database don't apply to this one
+			if ta.panelComponent != nil {
+				ta.panelComponent.ClearSectorCache()
+			}
 
 			// Show/hide panels based on database loading state
 			if info.IsLoaded {
@@ -669,18 +862,76 @@ func (ta *TwistApp) HandleDatabaseStateChanged(info coreapi.DatabaseStateInfo) {
 	}()
 }
 
-// HandleCurrentSectorChanged processes sector change events
-func (ta *TwistApp) HandleCurrentSectorChanged(sectorInfo coreapi.SectorInfo) {
+// HandleGameSelectionMenu notes the games the server offers; the user picks one in the
+// terminal as usual, or the configured game letter already did
+func (ta *TwistApp) HandleGameSelectionMenu(menu coreapi.GameMenuInfo) {
+	games := make([]string, len(menu.Games))
+	for i, game := range menu.Games {
+		games[i] = game.Letter + ") " + game.Name
+	}
+	log.Info("TwistApp: server is waiting at the game selection prompt", "host", menu.ServerHost, "port", menu.ServerPort, "games", strings.Join(games, ", "))
+}
+
+// HandleGameDetectionFailed offers to start a game database when no known prompt was detected
+func (ta *TwistApp) HandleGameDetectionFailed(serverHost, serverPort string) {
+	const startLabel = "Start game DB"
+
 	ta.app.QueueUpdateDraw(func() {
-		// Update panels directly with sector info (no need to re-fetch)
-		if ta.panelComponent != nil && ta.proxyClient.IsConnected() {
-			ta.refreshPanelDataWithInfo(sectorInfo)
+		// Don't stack on top of a dialog the user is already working with
+		if ta.modalVisible || !ta.proxyClient.IsConnected() {
+			log.Warn("TwistApp: game detection failed, not showing prompt", "host", serverHost, "port", serverPort, "modal_visible", ta.modalVisible)
+			return
 		}
+
+		message := fmt.Sprintf("No game was detected on %s:%s.\n\nStart a game database for this server?", serverHost, serverPort)
+		modal := tview.NewModal().
+			SetText(message).
+			AddButtons([]string{startLabel, "Not now"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				ta.closeModal()
+				if buttonLabel == startLabel {
+					go ta.startServerDatabase()
+				}
+			})
+		modal.SetTitle("Game Detection")
+
+		ta.pages.AddPage("message-modal", modal, true, true)
+		ta.modalVisible = true
 	})
 }
 
+// startServerDatabase asks the proxy to start a game database without game detection
+func (ta *TwistApp) startServerDatabase() {
+	proxyAPI := ta.proxyClient.GetCurrentAPI()
+	if proxyAPI == nil {
+		return
+	}
+
+	if err := proxyAPI.StartServerDatabase(); err != nil {
+		log.Error("TwistApp: failed to start game database", "error", err)
+		ta.app.QueueUpdateDraw(func() {
+			ta.showMessage("Could not start game database: "+err.Error(), "Game Detection")
+		})
+	}
+}
+
+// HandleCurrentSectorChanged processes sector change events
+func (ta *TwistApp) HandleCurrentSectorChanged(sectorInfo coreapi.SectorInfo) {
+	// Flushed immediately when the sector actually changes, coalesced otherwise
+	ta.sectorUpdates.AddCurrentSector(sectorInfo)
+}
+
 // HandlePortUpdated processes port information update events
 func (ta *TwistApp) HandlePortUpdated(portInfo coreapi.PortInfo) {
+	// A destroyed port changes its map label, so that sector is redrawn
+	if portInfo.Dead {
+		if proxyAPI := ta.proxyClient.GetCurrentAPI(); proxyAPI != nil {
+			if sectorInfo, err := proxyAPI.GetSectorInfo(portInfo.SectorID); err == nil {
+				ta.sectorUpdates.AddSectorUpdate(sectorInfo)
+			}
+		}
+		return
+	}
 
 	ta.app.QueueUpdateDraw(func() {
 		// Port updates don't affect map visualization (which only cares about warps)
@@ -715,12 +966,50 @@ func (ta *TwistApp) HandlePlayerStatsUpdated(stats coreapi.PlayerStatsInfo) {
 
 // HandleSectorUpdated processes sector data update events (e.g. from etherprobe)
 func (ta *TwistApp) HandleSectorUpdated(sectorInfo coreapi.SectorInfo) {
+	ta.sectorUpdates.AddSectorUpdate(sectorInfo)
+}
+
+// HandleSectorWarpsUpdated processes warp-only updates (e.g. probe warp data)
+func (ta *TwistApp) HandleSectorWarpsUpdated(sector int, warps [6]int) {
+	// Keep the map live as probes explore, even for sectors other than the current one
+	ta.sectorUpdates.AddSectorWarps(sector, warps)
+}
+
+// HandleMapInvalidated reloads the sectors a CIM download changed and redraws the map once
+func (ta *TwistApp) HandleMapInvalidated(sectors []int) {
 	ta.app.QueueUpdateDraw(func() {
-		// Update sector data in maps without changing current sector focus
-		if ta.panelComponent != nil && ta.proxyClient.IsConnected() {
-			log.Info("TwistApp: Handling sector data update", "sector", sectorInfo.Number)
-			ta.panelComponent.UpdateSectorData(sectorInfo)
+		if ta.panelComponent == nil || !ta.proxyClient.IsConnected() {
+			return
+		}
+		log.Debug("TwistApp: Map invalidated", "sectors", len(sectors))
+		ta.panelComponent.InvalidateSectors(sectors)
+	})
+}
+
+// HandleCoursePlotted highlights the course the game plotted on the sector map
+func (ta *TwistApp) HandleCoursePlotted(sectors []int) {
+	ta.app.QueueUpdateDraw(func() {
+		if ta.panelComponent == nil || !ta.proxyClient.IsConnected() {
+			return
+		}
+		ta.panelComponent.HighlightRoute(sectors)
+	})
+}
+
+// applySectorUpdateBatch applies one window of coalesced sector events to the panels
+func (ta *TwistApp) applySectorUpdateBatch(batch sectorUpdateBatch) {
+	ta.app.QueueUpdateDraw(func() {
+		if ta.panelComponent == nil || !ta.proxyClient.IsConnected() {
+			return
+		}
+
+		log.Debug("TwistApp: Applying coalesced sector updates", "events", batch.events, "sectors", len(batch.sectors), "warps", len(batch.warps), "has_current", batch.current != nil)
+
+		// Current sector first so a sector change resets the map before the batch schedules its redraw
+		if batch.current != nil {
+			ta.refreshPanelDataWithInfo(*batch.current)
 		}
+		ta.panelComponent.UpdateSectorBatch(batch.sectors, batch.warps)
 	})
 }
 
@@ -814,6 +1103,8 @@ func (ta *TwistApp) closeModal() {
 	ta.pages.RemovePage("dropdown-menu")
 	ta.pages.RemovePage("connection-dialog")
 	ta.pages.RemovePage("burst-input-dialog")
+	ta.pages.RemovePage("text-input-dialog")
+	ta.pages.RemovePage("profile-dialog")
 }
 
 // startUpdateWorker starts the background update worker
@@ -850,7 +1141,12 @@ func (ta *TwistApp) handleGlobalKeys(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
-	// Check global shortcuts first (including menu shortcuts like Alt+Q)
+	// Keys bound in the keymap come before menu item shortcuts
+	if ta.handleBoundKey(event) {
+		return nil
+	}
+
+	// Check shortcuts registered by open menus
 	if ta.globalShortcuts.HandleKeyEvent(event) {
 		return nil
 	}
@@ -868,46 +1164,22 @@ func (ta *TwistApp) handleGlobalKeys(event *tcell.EventKey) *tcell.EventKey {
 		}
 	}
 
-	// F1 key for help
-	if event.Key() == tcell.KeyF1 {
-		ta.showHelpModal()
-		return nil
-	}
-
-	// Pass to input handler for menu Alt+keys and other keys
+	// Pass to input handler for other keys
 	return ta.inputHandler.HandleKeyEvent(event)
 }
 
-// showHelpModal displays help information
-func (ta *TwistApp) showHelpModal() {
-	// Close any existing dropdown menus before showing help modal
-	ta.pages.RemovePage("dropdown-menu")
-	if ta.menuComponent.IsDropdownVisible() {
-		ta.menuComponent.HideDropdown()
+// showCurrentSector displays the current sector's stored data in the terminal
+func (ta *TwistApp) showCurrentSector() {
+	proxyAPI := ta.proxyClient.GetCurrentAPI()
+	if proxyAPI == nil {
+		return
 	}
 
-	helpText := "TWIST Terminal Interface\n\n" +
-		"Menu Navigation:\n" +
-		"Alt+S = Session menu\n" +
-		"Alt+V = View menu\n" +
-		"Alt+T = Terminal menu\n" +
-		"Alt+H = Help menu\n" +
-		"Alt+C = Connect\n" +
-		"Alt+D = Disconnect\n" +
-		"Alt+Q = Quit\n\n" +
-		"Function Keys:\n" +
-		"F1 = Help (this screen)\n" +
-		"ESC = Close dialogs or stop all scripts\n\n" +
-		"Script management is available in the View menu."
-
-	modal := tview.NewModal().
-		SetText(helpText).
-		AddButtons([]string{"Close"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			ta.closeModal()
-		})
-	ta.pages.AddPage("help-modal", modal, true, true)
-	ta.modalVisible = true
+	go func() {
+		if err := proxyAPI.ShowCurrentSector(); err != nil {
+			log.Warn("TwistApp: failed to show current sector", "error", err)
+		}
+	}()
 }
 
 // showDropdownMenu displays a dropdown menu below the menu bar
@@ -1099,6 +1371,18 @@ func (ta *TwistApp) ClearTerminal() {
 	}
 }
 
+// ReplayTerminal clears the terminal and writes the lines to it, so output can be redrawn
+// from the proxy's copy in its original colors
+func (ta *TwistApp) ReplayTerminal(lines []string) {
+	if ta.terminalComponent == nil {
+		return
+	}
+	ta.terminalComponent.Clear()
+	if len(lines) > 0 {
+		ta.terminalComponent.Write([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+	}
+}
+
 // ShowModal displays a modal dialog
 func (ta *TwistApp) ShowModal(title, text string, buttons []string, callback func(int, string)) {
 	// Check if dropdown is visible and close it first
@@ -1162,6 +1446,28 @@ func (ta *TwistApp) ShowInputDialog(pageName string, dialog interface{}) {
 	}
 }
 
+// ShowListModal displays a list to pick from. The modal is closed before callback runs,
+// so the callback can show another dialog.
+func (ta *TwistApp) ShowListModal(title string, items []string, callback func(string)) {
+	ta.modalVisible = true
+	ta.inputHandler.SetModalVisible(true)
+
+	ta.pages.RemovePage("dropdown-menu")
+	if ta.menuComponent.IsDropdownVisible() {
+		ta.menuComponent.HideDropdown()
+	}
+
+	modalList := components.NewModalList(title, items, func(selected string) {
+		ta.closeModal()
+		callback(selected)
+	})
+	modalList.SetDoneFunc(func() {
+		ta.closeModal()
+	})
+
+	ta.pages.AddPage("modal", modalList.GetView(), true, true)
+}
+
 // CloseModal closes the currently displayed modal
 func (ta *TwistApp) CloseModal() {
 	ta.closeModal()
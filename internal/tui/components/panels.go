@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 	"twist/internal/api"
 	"twist/internal/log"
 	"twist/internal/theme"
@@ -110,6 +111,46 @@ func (pc *PanelComponent) RenderSixelGraphics() {
 	}
 }
 
+// SetStaleSectorThreshold sets how old a sector's data must be before the map dims it.
+// Zero turns the indicator off.
+func (pc *PanelComponent) SetStaleSectorThreshold(threshold time.Duration) {
+	if pc.graphvizMap != nil {
+		pc.graphvizMap.SetStaleThreshold(threshold)
+	}
+}
+
+// ChangeMapDepth shows more or fewer warp hops on the sector map and returns the new depth
+func (pc *PanelComponent) ChangeMapDepth(delta int) int {
+	if pc.graphvizMap == nil {
+		return 0
+	}
+	return pc.graphvizMap.SetMapDepth(pc.graphvizMap.MapDepth() + delta)
+}
+
+// ToggleSessionHighlight turns the tint on sectors first explored this session on or off,
+// returning true if it is now on
+func (pc *PanelComponent) ToggleSessionHighlight() bool {
+	if pc.graphvizMap == nil {
+		return false
+	}
+	return pc.graphvizMap.ToggleSessionHighlight()
+}
+
+// FocusMapSector centers the sector map on a sector without changing the current sector.
+// Zero goes back to following the player.
+func (pc *PanelComponent) FocusMapSector(sector int) {
+	if pc.graphvizMap != nil {
+		pc.graphvizMap.FocusSector(sector)
+	}
+}
+
+// SetTextMap draws the sector map as text instead of sixel graphics
+func (pc *PanelComponent) SetTextMap(text bool) {
+	if pc.graphvizMap != nil {
+		pc.graphvizMap.SetTextMode(text)
+	}
+}
+
 // SetProxyAPI sets the API reference for accessing game data
 func (pc *PanelComponent) SetProxyAPI(proxyAPI api.ProxyAPI) {
 	pc.proxyAPI = proxyAPI
@@ -430,18 +471,14 @@ func (pc *PanelComponent) UpdatePlayerStats(stats api.PlayerStatsInfo) {
 	info.WriteString(formatLine("Equipment", fmt.Sprintf("%d", stats.EquHolds)))
 	info.WriteString(formatLine("Colonists", fmt.Sprintf("%d", stats.ColHolds)))
 
-	empty := stats.TotalHolds - stats.OreHolds - stats.OrgHolds - stats.EquHolds - stats.ColHolds
-	if empty < 0 {
-		empty = 0
-	}
-	info.WriteString(formatLine("Empty", fmt.Sprintf("%d", empty)))
+	info.WriteString(formatLine("Empty", fmt.Sprintf("%d", stats.EmptyHolds)))
 
 	// Ship Info section
 	info.WriteString("\n[yellow]Ship Info[-]\n")
 	info.WriteString(formatLine("Ship Type", stats.ShipClass))
 	info.WriteString(formatLine("Fighters", fmt.Sprintf("%d", stats.Fighters)))
 	info.WriteString(formatLine("Shields", fmt.Sprintf("%d", stats.Shields)))
-	info.WriteString(formatLine("Holds", fmt.Sprintf("%d/%d", stats.TotalHolds-empty, stats.TotalHolds)))
+	info.WriteString(formatLine("Holds", fmt.Sprintf("%d/%d", stats.TotalHolds-stats.EmptyHolds, stats.TotalHolds)))
 	info.WriteString(formatLine("Photons", fmt.Sprintf("%d", stats.Photons)))
 	info.WriteString(formatLine("Armids", fmt.Sprintf("%d", stats.Armids)))
 	info.WriteString(formatLine("Limpets", fmt.Sprintf("%d", stats.Limpets)))
@@ -474,6 +511,43 @@ func (pc *PanelComponent) UpdateSectorData(sector api.SectorInfo) {
 	}
 }
 
+// UpdateSectorBatch applies a coalesced set of sector and warp updates to the active map
+func (pc *PanelComponent) UpdateSectorBatch(sectors []api.SectorInfo, warps map[int][6]int) {
+	if pc.useGraphviz && pc.graphvizMap != nil {
+		pc.graphvizMap.UpdateSectorBatch(sectors, warps)
+		return
+	}
+	for _, sector := range sectors {
+		pc.UpdateSectorData(sector)
+	}
+}
+
+// InvalidateSectors reloads sectors that changed in bulk (a CIM download) and redraws the
+// active map once
+func (pc *PanelComponent) InvalidateSectors(sectors []int) {
+	if pc.useGraphviz && pc.graphvizMap != nil {
+		pc.graphvizMap.InvalidateSectors(sectors)
+	} else if pc.sixelMap != nil {
+		pc.sixelMap.LoadRealMapData()
+	} else if pc.sectorMap != nil {
+		pc.sectorMap.LoadRealMapData()
+	}
+}
+
+// HighlightRoute shows a course on the sector map until the player moves
+func (pc *PanelComponent) HighlightRoute(sectors []int) {
+	if pc.useGraphviz && pc.graphvizMap != nil {
+		pc.graphvizMap.HighlightRoute(sectors)
+	}
+}
+
+// ClearSectorCache drops the sector map's cached sectors when the game database changes
+func (pc *PanelComponent) ClearSectorCache() {
+	if pc.graphvizMap != nil {
+		pc.graphvizMap.ClearSectorCache()
+	}
+}
+
 // SetTraderInfoText sets custom text in the trader info panel
 func (pc *PanelComponent) SetTraderInfoText(text string) {
 	pc.leftView.SetText(text)
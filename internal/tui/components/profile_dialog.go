@@ -0,0 +1,138 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+	"twist/internal/api"
+	"twist/internal/theme"
+	"twist/internal/tui/profiles"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ProfileDialog is a form for adding or editing a connection profile
+type ProfileDialog struct {
+	form           *tview.Form
+	callback       func(profiles.Profile)
+	transport      string
+	cancelCallback func()
+}
+
+// NewProfileDialog creates a dialog filled in from profile. callback receives the edited
+// profile when Save is pressed; validating it is up to the caller.
+func NewProfileDialog(title string, profile profiles.Profile, callback func(profiles.Profile), cancelCallback func()) *ProfileDialog {
+	pd := &ProfileDialog{
+		callback:       callback,
+		cancelCallback: cancelCallback,
+		transport:      profile.Transport,
+	}
+
+	pd.form = theme.NewForm()
+	pd.form.SetTitle(" " + title + " ")
+	pd.form.SetTitleAlign(tview.AlignCenter)
+	pd.form.SetBorder(true)
+	pd.form.SetBorderPadding(1, 1, 2, 2)
+
+	port := ""
+	if profile.Port != 0 {
+		port = strconv.Itoa(profile.Port)
+	}
+	pd.form.AddInputField("Name:", profile.Name, 40, nil, nil)
+	pd.form.AddInputField("Host:", profile.Host, 40, nil, nil)
+	pd.form.AddInputField("Port:", port, 6, tview.InputFieldInteger, nil)
+	pd.form.AddInputField("Game:", profile.Game, 40, nil, nil)
+	pd.form.AddInputField("Script:", profile.Script, 40, nil, nil)
+
+	transports := []string{api.TransportTCP, api.TransportTLS, api.TransportSSH}
+	selected := 0
+	for i, kind := range transports {
+		if kind == profile.Transport {
+			selected = i
+		}
+	}
+	pd.form.AddDropDown("Transport:", transports, selected, func(option string, _ int) {
+		pd.transport = option
+	})
+	pd.form.AddInputField("User:", profile.User, 40, nil, nil)
+	pd.form.AddPasswordField("Password:", profile.Password, 40, '*', nil)
+	pd.form.AddInputField("Key File:", profile.KeyFile, 40, nil, nil)
+	pd.form.AddCheckbox("Skip Verify:", profile.SkipVerify, nil)
+
+	pd.form.AddButton("Save", func() {
+		if pd.callback != nil {
+			pd.callback(pd.profile())
+		}
+	})
+
+	pd.form.AddButton("Cancel", func() {
+		if pd.cancelCallback != nil {
+			pd.cancelCallback()
+		}
+	})
+	pd.form.SetButtonsAlign(tview.AlignCenter)
+
+	pd.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if pd.cancelCallback != nil {
+				pd.cancelCallback()
+			}
+			return nil
+		}
+		return event
+	})
+
+	return pd
+}
+
+// profile returns the profile as currently entered. An empty port uses the default.
+func (pd *ProfileDialog) profile() profiles.Profile {
+	text := func(label string) string {
+		return strings.TrimSpace(pd.form.GetFormItemByLabel(label).(*tview.InputField).GetText())
+	}
+	port, _ := strconv.Atoi(text("Port:"))
+	transport := pd.transport
+	if transport == api.TransportTCP {
+		transport = ""
+	}
+	return profiles.Profile{
+		Name:       text("Name:"),
+		Host:       text("Host:"),
+		Port:       port,
+		Game:       text("Game:"),
+		Script:     text("Script:"),
+		Transport:  transport,
+		User:       text("User:"),
+		Password:   pd.form.GetFormItemByLabel("Password:").(*tview.InputField).GetText(),
+		KeyFile:    text("Key File:"),
+		SkipVerify: pd.form.GetFormItemByLabel("Skip Verify:").(*tview.Checkbox).IsChecked(),
+	}
+}
+
+// SetDoneFunc sets a function to call when the dialog should be closed
+func (pd *ProfileDialog) SetDoneFunc(handler func()) InputDialog {
+	pd.form.SetCancelFunc(handler)
+	return pd
+}
+
+// GetView returns the main view component
+func (pd *ProfileDialog) GetView() tview.Primitive {
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(pd.form, 60, 0, true).
+			AddItem(nil, 0, 1, false), 25, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	currentTheme := theme.Current()
+	flex.SetBackgroundColor(currentTheme.DialogColors().Background)
+
+	return flex
+}
+
+// GetForm returns the internal form component
+func (pd *ProfileDialog) GetForm() *tview.Form {
+	return pd.form
+}
@@ -383,7 +383,7 @@ func (smc *SectorMapComponent) renderSectorRow(builder *strings.Builder, grid ma
 					if info.HasPort {
 						// Get actual port type from API
 						if portData, err := smc.proxyAPI.GetPortInfo(sector); err == nil && portData != nil {
-							portInfo = fmt.Sprintf("  (%s)  ", portData.ClassType.String()[:3]) // Show port type as "(BBS)"
+							portInfo = fmt.Sprintf("%-9s", "  ("+portData.Label()+")") // Show port type as "(BBS)"
 						} else {
 							portInfo = fmt.Sprintf("   (P)   ") // Port exists but couldn't get details
 						}
@@ -0,0 +1,26 @@
+package components
+
+import (
+	"github.com/goccy/go-graphviz"
+)
+
+// Avoided sector styling - red dashed border so avoided sectors stand out from explored state colors
+const (
+	avoidBorderColor = "red"
+	avoidPenWidth    = 4.0
+)
+
+// isAvoidedSector returns true if the cached sector data marks the sector as avoided
+func (gsm *GraphvizSectorMap) isAvoidedSector(sector int) bool {
+	sectorInfo, exists := gsm.sectorData[sector]
+	return exists && sectorInfo.Avoided
+}
+
+// applyAvoidNodeStyle styles a node's border if the sector is on the avoid list
+func (gsm *GraphvizSectorMap) applyAvoidNodeStyle(node *graphviz.Node, sector int) {
+	if gsm.isAvoidedSector(sector) {
+		node.SetColor(avoidBorderColor)
+		node.SetPenWidth(avoidPenWidth)
+		node.SetStyle("filled,rounded,dashed")
+	}
+}
@@ -0,0 +1,41 @@
+package components
+
+import "twist/internal/tui/termcap"
+
+// mapTitleRows is how many rows the title takes at the top of the map panel; the sixel is
+// drawn below them
+const mapTitleRows = 1
+
+// Typical terminal font metrics, used to estimate the cell size when the terminal doesn't
+// report it
+const (
+	estimatedFontSize       = 11.0 // Points
+	estimatedDPI            = 96.0
+	estimatedCharWidthRatio = 0.6  // Monospace width ratio
+	estimatedLineHeight     = 0.85 // Line height ratio
+)
+
+// cellPixelSize returns the size of a character cell in pixels: as the terminal reported
+// it at startup if it did, otherwise estimated from typical font metrics. exact is false
+// for an estimate.
+func cellPixelSize() (width, height float64, exact bool) {
+	if caps := termcap.Current(); caps.CellWidth > 0 && caps.CellHeight > 0 {
+		return float64(caps.CellWidth), float64(caps.CellHeight), true
+	}
+	pixelsPerPoint := estimatedDPI / 72.0
+	return estimatedFontSize * pixelsPerPoint * estimatedCharWidthRatio,
+		estimatedFontSize * pixelsPerPoint * estimatedLineHeight, false
+}
+
+// sixelRegionRect returns the cells a map image of imageWidth by imageHeight pixels covers
+// in a panel at x, y of width by height cells, below the title. With the cell size known
+// (cellWidth and cellHeight above zero) the region is the cells the image actually covers,
+// otherwise the whole panel.
+func sixelRegionRect(x, y, width, height, imageWidth, imageHeight, cellWidth, cellHeight int) (int, int, int, int) {
+	regionWidth, regionHeight := width, height-mapTitleRows
+	if cellWidth > 0 && cellHeight > 0 {
+		regionWidth = min(regionWidth, (imageWidth+cellWidth-1)/cellWidth)
+		regionHeight = min(regionHeight, (imageHeight+cellHeight-1)/cellHeight)
+	}
+	return x, y + mapTitleRows, regionWidth, regionHeight
+}
@@ -0,0 +1,30 @@
+package components
+
+import "twist/internal/log"
+
+// Map depth limits - the map shows up to five warp hops around the current sector.
+// Shallower maps are quicker to lay out and easier to read in small panels.
+const (
+	minMapDepth = 1
+	maxMapDepth = 5
+)
+
+// SetMapDepth sets how many warp hops the map shows, clamped to the supported range, and
+// returns the depth used
+func (gsm *GraphvizSectorMap) SetMapDepth(depth int) int {
+	depth = max(minMapDepth, min(depth, maxMapDepth))
+	if depth == gsm.mapDepth {
+		return depth
+	}
+
+	gsm.mapDepth = depth
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+	log.Info("GraphvizSectorMap: Map depth changed", "depth", depth)
+	return depth
+}
+
+// MapDepth returns how many warp hops the map shows
+func (gsm *GraphvizSectorMap) MapDepth() int {
+	return gsm.mapDepth
+}
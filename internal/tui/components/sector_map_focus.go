@@ -0,0 +1,42 @@
+package components
+
+import "twist/internal/log"
+
+// FocusSector centers the map on any sector, showing its neighborhood instead of the
+// player's, without changing the current sector: the YOU node still marks where the
+// player is if it is in view. Zero or the current sector goes back to following the
+// player, as does the player moving.
+func (gsm *GraphvizSectorMap) FocusSector(sector int) {
+	if sector <= 0 || sector == gsm.currentSector {
+		sector = 0
+	}
+	if sector == gsm.focusSector {
+		return
+	}
+
+	gsm.focusSector = sector
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+	gsm.sectorLevels = make(map[int]int) // Levels are counted from the new root
+
+	// Hide the region while regenerating to prevent overlap
+	if gsm.sixelLayer != nil {
+		gsm.sixelLayer.SetRegionVisible(gsm.regionID, false)
+	}
+	log.Info("GraphvizSectorMap: Focus changed", "sector", sector, "current_sector", gsm.currentSector)
+}
+
+// FocusedSector returns the sector the map is centered on instead of the current sector,
+// or zero if it follows the player
+func (gsm *GraphvizSectorMap) FocusedSector() int {
+	return gsm.focusSector
+}
+
+// rootSector returns the sector the map is built around: the focused sector, if any,
+// otherwise the current sector
+func (gsm *GraphvizSectorMap) rootSector() int {
+	if gsm.focusSector > 0 {
+		return gsm.focusSector
+	}
+	return gsm.currentSector
+}
@@ -15,10 +15,12 @@ import (
 	"os/exec"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 	"twist/internal/api"
 	"twist/internal/log"
 	"twist/internal/theme"
+	"twist/internal/tui/termcap"
 
 	"github.com/BourgeoisBear/rasterm"
 	"github.com/dominikbraun/graph"
@@ -43,8 +45,10 @@ type lruCacheItem struct {
 	data *CachedGraphData
 }
 
-// LRUCache implements a simple LRU cache with maximum size
+// LRUCache implements a simple LRU cache with maximum size. It is safe for concurrent
+// use, since async map generation stores images while the UI goroutine reads them.
 type LRUCache struct {
+	mu      sync.Mutex
 	maxSize int
 	items   map[string]*list.Element
 	order   *list.List
@@ -61,6 +65,9 @@ func NewLRUCache(maxSize int) *LRUCache {
 
 // Get retrieves a value from the cache, marking it as recently used
 func (c *LRUCache) Get(key string) (*CachedGraphData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if element, exists := c.items[key]; exists {
 		// Move to front (most recently used)
 		c.order.MoveToFront(element)
@@ -71,6 +78,9 @@ func (c *LRUCache) Get(key string) (*CachedGraphData, bool) {
 
 // Put stores a value in the cache
 func (c *LRUCache) Put(key string, data *CachedGraphData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if element, exists := c.items[key]; exists {
 		// Update existing item and move to front
 		element.Value.(*lruCacheItem).data = data
@@ -100,8 +110,14 @@ type GraphvizSectorMap struct {
 	*tview.Box
 	proxyAPI      api.ProxyAPI
 	currentSector int
+	focusSector   int // Sector the map is centered on instead of the current one (see sector_map_focus.go)
 	sectorData    map[int]api.SectorInfo
-	sectorLevels  map[int]int // Track which level each sector is at (0=current, 1-5=hop levels)
+	sectorLevels  map[int]int // Track which level each sector is at (0=current, 1-mapDepth=hop levels)
+	mapDepth      int         // Number of warp hops shown around the current sector (see sector_map_depth.go)
+
+	// Sectors whose sectorData holds full info rather than a warp-only placeholder; these
+	// only need their warps re-read when the graph is rebuilt (see graphSectorInfo)
+	sectorInfoLoaded map[int]bool
 
 	// Content-hash based LRU caching
 	graphCache     *LRUCache // LRU cache keyed by MD5 hash of DOT content
@@ -119,6 +135,23 @@ type GraphvizSectorMap struct {
 	debounceTimer  *time.Timer
 	pendingRedraw  bool
 	debounceDelay  time.Duration
+	generations    int // Number of async graph generations (neato runs) started
+
+	// Route overlay (see sector_map_route.go)
+	routeSectors map[int]bool    // Sectors on the highlighted route
+	routeEdges   map[string]bool // Warps on the highlighted route, keyed by routeEdgeKey
+
+	// Sectors last seen longer ago than this are dimmed (see sector_map_stale.go)
+	staleAfter time.Duration
+
+	// Sectors first explored this session are tinted while this is on (see sector_map_session.go)
+	highlightSession bool
+
+	// Terminals without sixels get the map as text (see sector_map_text.go)
+	textMode  bool
+	textLines []textMapLine
+
+	themeChanges int // Number of ApplyTheme calls, so images drawn in the old theme are discarded
 }
 
 // NewGraphvizSectorMap creates a new graphviz-based sector map component
@@ -134,16 +167,23 @@ func NewGraphvizSectorMap(sixelLayer *SixelLayer, app *tview.Application) *Graph
 	box.SetTitleColor(panelColors.Title)
 
 	gsm := &GraphvizSectorMap{
-		Box:           box,
-		sectorData:    make(map[int]api.SectorInfo),
-		sectorLevels:  make(map[int]int),
-		graphCache:    NewLRUCache(100), // Initialize LRU cache with max size 100
-		needsRedraw:   true,
-		hasBorder:     false, // No border, just background
-		sixelLayer:    sixelLayer,
-		regionID:      "sector_map",           // Unique ID for this component
-		debounceDelay: 200 * time.Millisecond, // 200ms debounce delay for rapid updates
-		app:           app,                    // Store app reference for async updates
+		Box:              box,
+		sectorData:       make(map[int]api.SectorInfo),
+		sectorLevels:     make(map[int]int),
+		sectorInfoLoaded: make(map[int]bool),
+		routeSectors:     make(map[int]bool),
+		routeEdges:       make(map[string]bool),
+		graphCache:       NewLRUCache(100), // Initialize LRU cache with max size 100
+		needsRedraw:      true,
+		hasBorder:        false, // No border, just background
+		sixelLayer:       sixelLayer,
+		regionID:         "sector_map",           // Unique ID for this component
+		debounceDelay:    200 * time.Millisecond, // 200ms debounce delay for rapid updates
+		app:              app,                    // Store app reference for async updates
+		staleAfter:       defaultStaleSectorAge,
+		mapDepth:         maxMapDepth,
+		highlightSession: true,
+		textMode:         !termcap.Current().Sixel,
 	}
 	gsm.SetBorder(false).SetTitle("")
 	return gsm
@@ -174,8 +214,9 @@ func (gsm *GraphvizSectorMap) Draw(screen tcell.Screen) {
 	needsGeneration := gsm.needsRedraw || gsm.pendingRedraw
 
 	if needsGeneration && !gsm.isGenerating {
-		if gsm.currentSector > 0 && gsm.proxyAPI != nil && gsm.app != nil {
-			log.Info("GraphvizSectorMap.Draw: Starting async generation", "sector", gsm.currentSector)
+		if gsm.rootSector() > 0 && gsm.proxyAPI != nil && gsm.app != nil {
+			gsm.generations++
+			log.Info("GraphvizSectorMap.Draw: Starting async generation", "sector", gsm.rootSector(), "generation", gsm.generations)
 			gsm.isGenerating = true // Mark that we're generating
 
 			// Clear the region before generating new content to prevent artifacts
@@ -185,18 +226,25 @@ func (gsm *GraphvizSectorMap) Draw(screen tcell.Screen) {
 			}
 
 			// Move expensive generation to background goroutine
+			themeChanges := gsm.themeChanges
+			textMode := gsm.textMode
 			go func() {
 				// Generate new graphviz image
 				g, err := gsm.buildSectorGraph()
-				if err == nil {
+				if err == nil && textMode {
+					// The text map needs no image, just the sectors the graph was built from
+					lines := gsm.textMapLines()
+					gsm.app.QueueUpdateDraw(func() {
+						gsm.textLines = lines
+						gsm.finishGeneration(themeChanges)
+					})
+				} else if err == nil {
 					_, err := gsm.generateGraphvizImage(g, width, height)
 					if err == nil {
 						// Update UI on main thread
 						gsm.app.QueueUpdateDraw(func() {
 							// Image data is now cached in LRU cache, cachedImage/cachedSixel set by generateGraphvizImage
-							gsm.needsRedraw = false
-							gsm.pendingRedraw = false
-							gsm.isGenerating = false // Mark generation complete
+							gsm.finishGeneration(themeChanges)
 						})
 					} else {
 						log.Info("GraphvizSectorMap.AsyncGen: Error generating image", "error", err)
@@ -216,6 +264,11 @@ func (gsm *GraphvizSectorMap) Draw(screen tcell.Screen) {
 		}
 	}
 
+	if gsm.textMode {
+		gsm.drawTextMap(screen, x, y, width, height)
+		return
+	}
+
 	// Register sixel region with the layer if we have cached image
 	if gsm.currentHashKey != "" && gsm.sixelLayer != nil {
 		gsm.registerSixelRegion(x, y, width, height)
@@ -266,12 +319,15 @@ func (gsm *GraphvizSectorMap) registerSixelRegion(x, y, width, height int) {
 		gsm.graphCache.Put(gsm.currentHashKey, cached) // Update cache with sixel data
 	}
 
-	// Register with the sixel layer
+	// Register with the sixel layer, covering the cells the image takes if the cell size is known
+	caps := termcap.Current()
+	regionX, regionY, regionWidth, regionHeight := sixelRegionRect(x, y, width, height,
+		cached.Width, cached.Height, caps.CellWidth, caps.CellHeight)
 	region := &SixelRegion{
-		X:         x,
-		Y:         y + 1, // Minimal offset to avoid title overlap
-		Width:     width,
-		Height:    height - 1, // Minimal height adjustment
+		X:         regionX,
+		Y:         regionY,
+		Width:     regionWidth,
+		Height:    regionHeight,
 		SixelData: cached.SixelData,
 		Visible:   true,
 	}
@@ -348,6 +404,8 @@ func (gsm *GraphvizSectorMap) drawStatusText(screen tcell.Screen, x, y, width, h
 func (gsm *GraphvizSectorMap) UpdateCurrentSector(sectorNumber int) {
 	if gsm.currentSector != sectorNumber {
 		gsm.currentSector = sectorNumber
+		gsm.focusSector = 0 // Moving goes back to following the player
+		gsm.ClearRoute()
 		gsm.needsRedraw = true
 		gsm.sectorLevels = make(map[int]int) // Clear sector levels for fresh tracking
 		// Note: Don't clear sectorData or graphCache - let hash-based caching handle it
@@ -365,10 +423,13 @@ func (gsm *GraphvizSectorMap) UpdateCurrentSectorWithInfo(sectorInfo api.SectorI
 
 	// Always update the sector data first
 	gsm.sectorData[sectorInfo.Number] = sectorInfo
+	gsm.sectorInfoLoaded[sectorInfo.Number] = true
 
 	if gsm.currentSector != sectorInfo.Number {
 		// Current sector changed - force redraw
 		gsm.currentSector = sectorInfo.Number
+		gsm.focusSector = 0 // Moving goes back to following the player
+		gsm.ClearRoute()
 		gsm.needsRedraw = true
 		gsm.currentHashKey = ""              // Clear current hash key
 		gsm.sectorLevels = make(map[int]int) // Clear sector levels for fresh tracking
@@ -389,18 +450,97 @@ func (gsm *GraphvizSectorMap) UpdateCurrentSectorWithInfo(sectorInfo api.SectorI
 func (gsm *GraphvizSectorMap) UpdateSectorData(sectorInfo api.SectorInfo) {
 	// Update the sector data in our cache
 	gsm.sectorData[sectorInfo.Number] = sectorInfo
+	gsm.sectorInfoLoaded[sectorInfo.Number] = true
 
 	// If this sector is part of the currently displayed map, check if we need a redraw
 	// but don't change the current sector focus
-	if gsm.currentSector > 0 {
+	if gsm.rootSector() > 0 {
 		// Only check for redraw if the updated sector is within our display range
 		// (current sector or connected sectors)
-		if sectorInfo.Number == gsm.currentSector || gsm.isSectorInDisplayRange(sectorInfo.Number) {
+		if sectorInfo.Number == gsm.rootSector() || gsm.isSectorInDisplayRange(sectorInfo.Number) {
 			gsm.scheduleRedrawWithDebounce(sectorInfo.Number, "UpdateSectorData")
 		}
 	}
 }
 
+// UpdateSectorBatch applies a coalesced set of sector and warp-only updates, scheduling
+// at most one redraw for the whole batch instead of one per sector
+func (gsm *GraphvizSectorMap) UpdateSectorBatch(sectors []api.SectorInfo, warps map[int][6]int) {
+	for _, sectorInfo := range sectors {
+		gsm.sectorData[sectorInfo.Number] = sectorInfo
+		gsm.sectorInfoLoaded[sectorInfo.Number] = true
+	}
+	for sectorNumber, sectorWarps := range warps {
+		gsm.sectorData[sectorNumber] = gsm.mergeSectorWarps(sectorNumber, sectorWarps)
+		gsm.reloadReverseInferredWarps(sectorNumber)
+	}
+
+	if gsm.rootSector() > 0 && len(sectors)+len(warps) > 0 {
+		gsm.scheduleRedrawWithDebounce(gsm.rootSector(), "UpdateSectorBatch")
+	}
+}
+
+// InvalidateSectors forgets the cached info of sectors that changed in bulk (a CIM download),
+// so they are reloaded, and regenerates the map once
+func (gsm *GraphvizSectorMap) InvalidateSectors(sectors []int) {
+	for _, sectorNumber := range sectors {
+		delete(gsm.sectorInfoLoaded, sectorNumber)
+	}
+	if gsm.currentSector > 0 && len(sectors) > 0 {
+		gsm.currentHashKey = ""
+		gsm.needsRedraw = true
+	}
+}
+
+// ClearSectorCache forgets all cached sector info, for when another game database is loaded
+// or the database is closed: the cached sectors belong to the old database, and those marked
+// loaded would otherwise only have their warps read from the new one
+func (gsm *GraphvizSectorMap) ClearSectorCache() {
+	gsm.sectorData = make(map[int]api.SectorInfo)
+	gsm.sectorInfoLoaded = make(map[int]bool)
+	gsm.sectorLevels = make(map[int]int)
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+}
+
+// mergeSectorWarps returns the cached sector data with its warp list replaced
+func (gsm *GraphvizSectorMap) mergeSectorWarps(sectorNumber int, warps [6]int) api.SectorInfo {
+	sectorInfo, exists := gsm.sectorData[sectorNumber]
+	if !exists {
+		sectorInfo = api.SectorInfo{Number: sectorNumber}
+	}
+
+	warpList := make([]int, 0, 6)
+	for _, warp := range warps {
+		if warp > 0 {
+			warpList = append(warpList, warp)
+		}
+	}
+	sectorInfo.Warps = warpList
+
+	// Warps are confirmed or inferred by what the parser sees of the sectors they lead to,
+	// which doesn't come with an update for this sector, so the marks are read again
+	if gsm.proxyAPI != nil {
+		if inferred, err := gsm.proxyAPI.GetInferredWarps(sectorNumber); err == nil {
+			sectorInfo.InferredWarps = inferred
+			return sectorInfo
+		}
+	}
+
+	// Only warps still listed can still be inferred
+	var inferred []int
+	for _, warp := range sectorInfo.InferredWarps {
+		for _, listed := range warpList {
+			if warp == listed {
+				inferred = append(inferred, warp)
+				break
+			}
+		}
+	}
+	sectorInfo.InferredWarps = inferred
+	return sectorInfo
+}
+
 // scheduleRedrawWithDebounce schedules a redraw with debouncing to prevent rapid-fire updates
 func (gsm *GraphvizSectorMap) scheduleRedrawWithDebounce(sectorNumber int, source string) {
 	now := time.Now()
@@ -471,6 +611,8 @@ func (gsm *GraphvizSectorMap) LoadRealMapData() {
 
 	if gsm.currentSector != playerInfo.CurrentSector {
 		gsm.currentSector = playerInfo.CurrentSector
+		gsm.focusSector = 0
+		gsm.ClearRoute()
 		gsm.needsRedraw = true
 		gsm.currentHashKey = ""              // Clear current hash key
 		gsm.sectorLevels = make(map[int]int) // Clear sector levels for fresh tracking
@@ -484,15 +626,19 @@ func (gsm *GraphvizSectorMap) buildSectorGraph() (graph.Graph[int, int], error)
 	// Create a new directed graph with proper hash function
 	g := graph.New(func(i int) int { return i }, graph.Directed())
 
+	// The graph is rooted at the focused sector, if any, otherwise the current one
+	root := gsm.rootSector()
+
 	// Always get fresh current sector info for consistent graph building
-	currentInfo, err := gsm.proxyAPI.GetSectorInfo(gsm.currentSector)
+	currentInfo, err := gsm.proxyAPI.GetSectorInfo(root)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current sector info: %w", err)
 	}
-	gsm.sectorData[gsm.currentSector] = currentInfo
+	gsm.sectorData[root] = currentInfo
+	gsm.sectorInfoLoaded[root] = true
 
 	// Add current sector as vertex
-	err = g.AddVertex(gsm.currentSector)
+	err = g.AddVertex(root)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add current sector vertex: %w", err)
 	}
@@ -503,158 +649,99 @@ func (gsm *GraphvizSectorMap) buildSectorGraph() (graph.Graph[int, int], error)
 
 	// Clear and initialize sector levels tracking
 	gsm.sectorLevels = make(map[int]int)
-	gsm.sectorLevels[gsm.currentSector] = 0 // Current sector is level 0
+	gsm.sectorLevels[root] = 0 // Current sector is level 0
 
 	// Step 1: Add all first-level vertices and edges from current sector
+	frontier := make([]int, 0, len(currentInfo.Warps))
 	for _, warpSector := range currentInfo.Warps {
 		if warpSector <= 0 {
 			continue
 		}
-		g.AddVertex(warpSector)                  // Ignore errors - vertex might already exist
-		g.AddEdge(gsm.currentSector, warpSector) // Ignore errors - edge might already exist
-		gsm.sectorLevels[warpSector] = 1         // First level sectors
-	}
-	processed[gsm.currentSector] = true
-
-	// Step 2: Fetch warp info for all first-level sectors and add their connections
-	secondLevelSectors := make([]int, 0)
-	for _, warpSector := range currentInfo.Warps {
-		if warpSector <= 0 || processed[warpSector] {
-			continue
-		}
-
-		// Get warp sector info
-		warpInfo, err := gsm.proxyAPI.GetSectorInfo(warpSector)
-		if err != nil {
-			continue // Skip sectors we can't get info for
-		}
-		gsm.sectorData[warpSector] = warpInfo
-		processed[warpSector] = true
-
-		// Add all connections from this sector
-		for _, targetSector := range warpInfo.Warps {
-			if targetSector <= 0 {
+		g.AddVertex(warpSector)          // Ignore errors - vertex might already exist
+		g.AddEdge(root, warpSector)      // Ignore errors - edge might already exist
+		gsm.sectorLevels[warpSector] = 1 // First level sectors
+		frontier = append(frontier, warpSector)
+	}
+	processed[root] = true
+
+	// Each following step fetches warp info for the previous level's sectors and adds
+	// their connections, until the map depth is reached
+	for level := 2; level <= gsm.mapDepth; level++ {
+		nextLevel := make([]int, 0)
+		for _, sector := range frontier {
+			if sector <= 0 || processed[sector] {
 				continue
 			}
-			g.AddVertex(targetSector)           // Ignore errors - vertex might already exist
-			g.AddEdge(warpSector, targetSector) // Ignore errors - edge might already exist
-
-			// Track sectors for next level processing if not already processed
-			if !processed[targetSector] {
-				secondLevelSectors = append(secondLevelSectors, targetSector)
-				// Set level for new sectors if not already set
-				if _, exists := gsm.sectorLevels[targetSector]; !exists {
-					gsm.sectorLevels[targetSector] = 2 // Second level sectors
-				}
-			}
-		}
-	}
-
-	// Step 3: Fetch warp info for all second-level sectors and add their connections (3rd level)
-	thirdLevelSectors := make([]int, 0)
-	for _, secondLevelSector := range secondLevelSectors {
-		if secondLevelSector <= 0 || processed[secondLevelSector] {
-			continue
-		}
-
-		// Get second-level sector info
-		secondLevelInfo, err := gsm.proxyAPI.GetSectorInfo(secondLevelSector)
-		if err != nil {
-			continue // Skip sectors we can't get info for
-		}
-		gsm.sectorData[secondLevelSector] = secondLevelInfo
-		processed[secondLevelSector] = true
 
-		// Add all connections from this second-level sector (creating 3rd level)
-		for _, thirdLevelSector := range secondLevelInfo.Warps {
-			if thirdLevelSector <= 0 {
-				continue
+			sectorInfo, err := gsm.graphSectorInfo(sector)
+			if err != nil {
+				continue // Skip sectors we can't get info for
 			}
-			g.AddVertex(thirdLevelSector)                  // Ignore errors - vertex might already exist
-			g.AddEdge(secondLevelSector, thirdLevelSector) // Ignore errors - edge might already exist
-
-			// Track sectors for next level processing if not already processed
-			if !processed[thirdLevelSector] {
-				thirdLevelSectors = append(thirdLevelSectors, thirdLevelSector)
-				// Set level for new sectors if not already set
-				if _, exists := gsm.sectorLevels[thirdLevelSector]; !exists {
-					gsm.sectorLevels[thirdLevelSector] = 3 // Third level sectors
+			gsm.sectorData[sector] = sectorInfo
+			processed[sector] = true
+
+			// Add all connections from this sector
+			for _, targetSector := range sectorInfo.Warps {
+				if targetSector <= 0 {
+					continue
+				}
+				g.AddVertex(targetSector)       // Ignore errors - vertex might already exist
+				g.AddEdge(sector, targetSector) // Ignore errors - edge might already exist
+
+				// Track sectors for next level processing if not already processed
+				if !processed[targetSector] {
+					nextLevel = append(nextLevel, targetSector)
+					// Set level for new sectors if not already set
+					if _, exists := gsm.sectorLevels[targetSector]; !exists {
+						gsm.sectorLevels[targetSector] = level
+					}
 				}
 			}
 		}
+		frontier = nextLevel
 	}
 
-	// Step 4: Fetch warp info for all third-level sectors and add their connections (4th level)
-	fourthLevelSectors := make([]int, 0)
-	for _, thirdLevelSector := range thirdLevelSectors {
-		if thirdLevelSector <= 0 || processed[thirdLevelSector] {
+	// Outermost sectors aren't expanded; store basic info for any without cached data.
+	// This prevents infinite expansion while allowing recursive connections
+	for _, sector := range frontier {
+		if processed[sector] {
 			continue
 		}
-
-		// Get third-level sector info
-		thirdLevelInfo, err := gsm.proxyAPI.GetSectorInfo(thirdLevelSector)
-		if err != nil {
-			continue // Skip sectors we can't get info for
-		}
-		gsm.sectorData[thirdLevelSector] = thirdLevelInfo
-		processed[thirdLevelSector] = true
-
-		// Add all connections from this third-level sector (creating 4th level)
-		for _, fourthLevelSector := range thirdLevelInfo.Warps {
-			if fourthLevelSector <= 0 {
-				continue
-			}
-			g.AddVertex(fourthLevelSector)                 // Ignore errors - vertex might already exist
-			g.AddEdge(thirdLevelSector, fourthLevelSector) // Ignore errors - edge might already exist
-
-			// Track sectors for next level processing if not already processed
-			if !processed[fourthLevelSector] {
-				fourthLevelSectors = append(fourthLevelSectors, fourthLevelSector)
-				// Set level for new sectors if not already set
-				if _, exists := gsm.sectorLevels[fourthLevelSector]; !exists {
-					gsm.sectorLevels[fourthLevelSector] = 4 // Fourth level sectors
-				}
-			}
+		if _, exists := gsm.sectorData[sector]; !exists {
+			gsm.sectorData[sector] = api.SectorInfo{Number: sector}
 		}
 	}
 
-	// Step 5: Fetch warp info for all fourth-level sectors and add their connections (5th level)
-	for _, fourthLevelSector := range fourthLevelSectors {
-		if fourthLevelSector <= 0 || processed[fourthLevelSector] {
-			continue
-		}
+	return g, nil
+}
 
-		// Get fourth-level sector info
-		fourthLevelInfo, err := gsm.proxyAPI.GetSectorInfo(fourthLevelSector)
+// graphSectorInfo returns a sector's info for building the graph. Sectors whose full info
+// is already cached (and kept current by sector events) only have their warps re-read;
+// others are loaded in full once.
+func (gsm *GraphvizSectorMap) graphSectorInfo(sectorNumber int) (api.SectorInfo, error) {
+	if gsm.sectorInfoLoaded[sectorNumber] {
+		warps, err := gsm.proxyAPI.GetSectorWarps(sectorNumber)
 		if err != nil {
-			continue // Skip sectors we can't get info for
+			return api.SectorInfo{}, err
 		}
-		gsm.sectorData[fourthLevelSector] = fourthLevelInfo
-		processed[fourthLevelSector] = true
+		return gsm.mergeSectorWarps(sectorNumber, warps), nil
+	}
 
-		// Add all connections from this fourth-level sector (creating 5th level)
-		for _, fifthLevelSector := range fourthLevelInfo.Warps {
-			if fifthLevelSector <= 0 {
-				continue
-			}
-			g.AddVertex(fifthLevelSector)                  // Ignore errors - vertex might already exist
-			g.AddEdge(fourthLevelSector, fifthLevelSector) // Ignore errors - edge might already exist
-
-			// Store basic info for fifth-level sectors only if not already processed
-			// This prevents infinite expansion while allowing recursive connections
-			if !processed[fifthLevelSector] {
-				if _, exists := gsm.sectorData[fifthLevelSector]; !exists {
-					gsm.sectorData[fifthLevelSector] = api.SectorInfo{Number: fifthLevelSector}
-				}
-				// Set level for new sectors if not already set - this is the outermost level
-				if _, exists := gsm.sectorLevels[fifthLevelSector]; !exists {
-					gsm.sectorLevels[fifthLevelSector] = 5 // Fifth level sectors (outermost)
-				}
-			}
-		}
+	sectorInfo, err := gsm.proxyAPI.GetSectorInfo(sectorNumber)
+	if err != nil {
+		return api.SectorInfo{}, err
 	}
+	gsm.sectorInfoLoaded[sectorNumber] = true
+	return sectorInfo, nil
+}
 
-	return g, nil
+// unvisitedFill returns the fill for a sector the player hasn't visited or holo scanned:
+// the scanned color if a density scan has seen it, otherwise the unexplored color
+func unvisitedFill(sectorInfo api.SectorInfo, nodeColors theme.MapNodeColors) string {
+	if sectorInfo.Explored == api.ExploredDensity {
+		return nodeColors.Scanned
+	}
+	return nodeColors.Unexplored
 }
 
 // generateGraphvizImage creates a PNG image from the graph using graphviz
@@ -676,14 +763,16 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 	// Get theme colors for consistent styling
 	currentTheme := theme.Current()
 	defaultColors := currentTheme.DefaultColors()
+	nodeColors := currentTheme.MapNodeColors()
 
 	// Use neato engine with increased spacing for better layout
 	gvGraph.SetLayout("neato")                                              // Force-directed layout engine
 	gvGraph.SetBackgroundColor(gsm.colorToString(defaultColors.Background)) // Use theme's default background
 	gvGraph.SetDPI(150.0)                                                   // Higher DPI for better border rendering
 
-	// Set default edge color to white for visibility on black background
-	_, err = gvGraph.Attr(int(cgraph.EDGE), "color", "white")
+	// Set default edge color to stand out from the background (white on dark themes)
+	lines := lineColor(defaultColors.Background)
+	_, err = gvGraph.Attr(int(cgraph.EDGE), "color", lines)
 	if err != nil {
 	}
 
@@ -694,7 +783,7 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 	_, err = gvGraph.Attr(int(cgraph.NODE), "penwidth", "3")
 	if err != nil {
 	}
-	_, err = gvGraph.Attr(int(cgraph.NODE), "color", "white")
+	_, err = gvGraph.Attr(int(cgraph.NODE), "color", lines)
 	if err != nil {
 	}
 
@@ -728,6 +817,7 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 	}
 	sort.Ints(sectors)
 
+	now := time.Now()
 	for _, sector := range sectors {
 		// Create node with sector information
 		sectorInfo, exists := gsm.sectorData[sector]
@@ -735,7 +825,7 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 		var label, fillColor string
 		if sector == gsm.currentSector {
 			label = fmt.Sprintf("YOU\\n%d", sector)
-			fillColor = "yellow"
+			fillColor = nodeColors.Current
 		} else if exists && sectorInfo.Visited {
 			// Truly visited sector - player has been here (EtHolo)
 			if sectorInfo.HasTraders > 0 {
@@ -744,7 +834,7 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 					// Get actual port type from API
 					if gsm.proxyAPI != nil {
 						if portData, err := gsm.proxyAPI.GetPortInfo(sector); err == nil && portData != nil {
-							portType = portData.ClassType.String() // Show actual port type like "BBS"
+							portType = portData.Label() // Show actual port type like "BBS"
 						} else {
 							portType = "PORT" // Port exists but couldn't get details
 						}
@@ -755,13 +845,13 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 					portType = fmt.Sprintf("T%d", sectorInfo.HasTraders)
 				}
 				label = fmt.Sprintf("%d\\n(%s)", sector, portType)
-				fillColor = "lightblue"
+				fillColor = nodeColors.Trader
 			} else if sectorInfo.HasPort {
 				// Sector has port but no traders
 				var portType string
 				if gsm.proxyAPI != nil {
 					if portData, err := gsm.proxyAPI.GetPortInfo(sector); err == nil && portData != nil {
-						portType = portData.ClassType.String() // Show actual port type like "BSB"
+						portType = portData.Label() // Show actual port type like "BSB"
 					} else {
 						portType = "PORT" // Port exists but couldn't get details
 					}
@@ -769,15 +859,15 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 					portType = "PORT" // No API access
 				}
 				label = fmt.Sprintf("%d\\n(%s)", sector, portType)
-				fillColor = "lightgreen"
+				fillColor = nodeColors.Port
 			} else {
 				label = fmt.Sprintf("%d", sector)
-				fillColor = "gray"
+				fillColor = nodeColors.Visited
 			}
 		} else {
-			// Unexplored sector - only known from warp references
+			// Unexplored sector - only known from warp references or a density scan
 			label = fmt.Sprintf("%d", sector)
-			fillColor = "lightcoral"
+			fillColor = unvisitedFill(sectorInfo, nodeColors)
 		}
 
 		node, err := gvGraph.CreateNodeByName(fmt.Sprintf("s%d", sector))
@@ -792,12 +882,18 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 		node.SetFontSize(18.0)     // Large readable font
 		node.SetFontColor("black") // Black text on colored background
 
-		// Apply dotted border style only to 5th level (outermost) sectors
-		if level, exists := gsm.sectorLevels[sector]; exists && level == 5 {
+		// Apply dotted border style only to outermost level sectors
+		if level, exists := gsm.sectorLevels[sector]; exists && level == gsm.mapDepth {
 			node.SetStyle("filled,rounded,dotted")
 		} else {
 			node.SetStyle("filled,rounded")
 		}
+		gsm.applyTraderNodeStyle(node, sector)
+		gsm.applyNoteNodeStyle(node, sector)
+		gsm.applyStaleNodeStyle(node, sector, now)
+		gsm.applyAvoidNodeStyle(node, sector)
+		gsm.applyRouteNodeStyle(node, sector)
+		gsm.applySessionNodeStyle(node, sector, fillColor)
 
 		gvNodes[sector] = node
 	}
@@ -866,6 +962,8 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 				edge.SetDir("forward")      // Default to unidirectional
 				edge.SetArrowHead("normal") // Standard arrow shape
 			}
+			gsm.applyInferredEdgeStyle(edge, source, target)
+			gsm.applyRouteEdgeStyle(edge, source, target)
 
 			edgeCount++
 		}
@@ -981,30 +1079,25 @@ func (gsm *GraphvizSectorMap) generateGraphvizImage(g graph.Graph[int, int], com
 	// Calculate the scale needed to achieve our target font size
 	fontScale := targetFontSizePixels / graphvizFontPixels
 
-	// Calculate panel size in pixels using typical terminal character dimensions
-	terminalFontSize := 11.0 // Typical terminal font size
-	terminalDPI := 96.0      // Standard screen DPI
-	charWidthRatio := 0.6    // Monospace width ratio
-	lineHeightRatio := 0.85  // Line height ratio
+	// Calculate panel size in pixels from the terminal's character cell size
+	charWidthPixels, charHeightPixels, exactCellSize := cellPixelSize()
 
-	pixelsPerPoint := terminalDPI / 72.0
-	charHeightPixels := terminalFontSize * pixelsPerPoint * lineHeightRatio
-	charWidthPixels := terminalFontSize * pixelsPerPoint * charWidthRatio
-
-	adjustedHeight := componentHeight - 1 // Reserve space for title
+	adjustedHeight := componentHeight - mapTitleRows // Reserve space for title
 	panelPixelWidth := int(float64(componentWidth) * charWidthPixels)
 	panelPixelHeight := int(float64(adjustedHeight) * charHeightPixels)
 
-	// Ensure panel dimensions are strictly bounded by component dimensions
-	// This prevents any possibility of the image exceeding terminal bounds
-	maxAllowedWidth := componentWidth * 8   // Conservative character width estimate
-	maxAllowedHeight := adjustedHeight * 16 // Conservative character height estimate
+	// An estimated cell size may be too big for the terminal's font, so bound the panel
+	// by conservative character dimensions to keep the image within the panel
+	if !exactCellSize {
+		maxAllowedWidth := componentWidth * 8   // Conservative character width estimate
+		maxAllowedHeight := adjustedHeight * 16 // Conservative character height estimate
 
-	if panelPixelWidth > maxAllowedWidth {
-		panelPixelWidth = maxAllowedWidth
-	}
-	if panelPixelHeight > maxAllowedHeight {
-		panelPixelHeight = maxAllowedHeight
+		if panelPixelWidth > maxAllowedWidth {
+			panelPixelWidth = maxAllowedWidth
+		}
+		if panelPixelHeight > maxAllowedHeight {
+			panelPixelHeight = maxAllowedHeight
+		}
 	}
 
 	// Use the font-based scale as our primary scale
@@ -1239,7 +1332,7 @@ func drawContentBorders(panelImg *image.RGBA, contentBounds image.Rectangle, off
 
 // generateDOTContentHash creates a DOT content hash without generating the full image
 func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
-	if gsm.currentSector <= 0 || gsm.proxyAPI == nil {
+	if gsm.rootSector() <= 0 || gsm.proxyAPI == nil {
 		return "", fmt.Errorf("no current sector or proxy API")
 	}
 
@@ -1267,15 +1360,17 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 	// Apply same graph settings for consistent hashing
 	currentTheme := theme.Current()
 	defaultColors := currentTheme.DefaultColors()
+	nodeColors := currentTheme.MapNodeColors()
 
 	gvGraph.SetLayout("neato")
 	gvGraph.SetBackgroundColor(gsm.colorToString(defaultColors.Background))
 	gvGraph.SetDPI(150.0)
 
-	gvGraph.Attr(int(cgraph.EDGE), "color", "white")
+	lines := lineColor(defaultColors.Background)
+	gvGraph.Attr(int(cgraph.EDGE), "color", lines)
 	gvGraph.Attr(int(cgraph.NODE), "style", "filled,rounded")
 	gvGraph.Attr(int(cgraph.NODE), "penwidth", "3")
-	gvGraph.Attr(int(cgraph.NODE), "color", "white")
+	gvGraph.Attr(int(cgraph.NODE), "color", lines)
 
 	gvGraph.SetOverlap(false)
 	gvGraph.SetSplines("true")
@@ -1299,20 +1394,21 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 	}
 	sort.Ints(sectors)
 
+	now := time.Now()
 	for _, sector := range sectors {
 		sectorInfo, exists := gsm.sectorData[sector]
 
 		var label, fillColor string
 		if sector == gsm.currentSector {
 			label = fmt.Sprintf("YOU\\\\n%d", sector)
-			fillColor = "yellow"
+			fillColor = nodeColors.Current
 		} else if exists && sectorInfo.Visited {
 			if sectorInfo.HasTraders > 0 {
 				var portType string
 				if sectorInfo.HasPort {
 					if gsm.proxyAPI != nil {
 						if portData, err := gsm.proxyAPI.GetPortInfo(sector); err == nil && portData != nil {
-							portType = portData.ClassType.String()
+							portType = portData.Label()
 						} else {
 							portType = "PORT"
 						}
@@ -1323,12 +1419,12 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 					portType = fmt.Sprintf("T%d", sectorInfo.HasTraders)
 				}
 				label = fmt.Sprintf("%d\\\\n(%s)", sector, portType)
-				fillColor = "lightblue"
+				fillColor = nodeColors.Trader
 			} else if sectorInfo.HasPort {
 				var portType string
 				if gsm.proxyAPI != nil {
 					if portData, err := gsm.proxyAPI.GetPortInfo(sector); err == nil && portData != nil {
-						portType = portData.ClassType.String()
+						portType = portData.Label()
 					} else {
 						portType = "PORT"
 					}
@@ -1336,14 +1432,14 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 					portType = "PORT"
 				}
 				label = fmt.Sprintf("%d\\\\n(%s)", sector, portType)
-				fillColor = "lightgreen"
+				fillColor = nodeColors.Port
 			} else {
 				label = fmt.Sprintf("%d", sector)
-				fillColor = "gray"
+				fillColor = nodeColors.Visited
 			}
 		} else {
 			label = fmt.Sprintf("%d", sector)
-			fillColor = "lightcoral"
+			fillColor = unvisitedFill(sectorInfo, nodeColors)
 		}
 
 		node, err := gvGraph.CreateNodeByName(fmt.Sprintf("s%d", sector))
@@ -1357,11 +1453,17 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 		node.SetFontSize(18.0)
 		node.SetFontColor("black")
 
-		if level, exists := gsm.sectorLevels[sector]; exists && level == 5 {
+		if level, exists := gsm.sectorLevels[sector]; exists && level == gsm.mapDepth {
 			node.SetStyle("filled,rounded,dotted")
 		} else {
 			node.SetStyle("filled,rounded")
 		}
+		gsm.applyTraderNodeStyle(node, sector)
+		gsm.applyNoteNodeStyle(node, sector)
+		gsm.applyStaleNodeStyle(node, sector, now)
+		gsm.applyAvoidNodeStyle(node, sector)
+		gsm.applyRouteNodeStyle(node, sector)
+		gsm.applySessionNodeStyle(node, sector, fillColor)
 
 		gvNodes[sector] = node
 	}
@@ -1423,6 +1525,8 @@ func (gsm *GraphvizSectorMap) generateDOTContentHash() (string, error) {
 				edge.SetDir("forward")
 				edge.SetArrowHead("normal")
 			}
+			gsm.applyInferredEdgeStyle(edge, source, target)
+			gsm.applyRouteEdgeStyle(edge, source, target)
 		}
 	}
 
@@ -0,0 +1,277 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"twist/internal/api"
+	"twist/internal/theme"
+)
+
+// warpOnlyProxyAPI serves sector info and warps from fixed data and counts each kind of lookup
+type warpOnlyProxyAPI struct {
+	api.ProxyAPI
+	sectors    map[int]api.SectorInfo
+	infoCalls  int
+	warpsCalls int
+}
+
+func (f *warpOnlyProxyAPI) GetSectorInfo(sectorNum int) (api.SectorInfo, error) {
+	f.infoCalls++
+	return f.sectors[sectorNum], nil
+}
+
+func (f *warpOnlyProxyAPI) GetSectorWarps(sectorNum int) ([6]int, error) {
+	f.warpsCalls++
+	var warps [6]int
+	copy(warps[:], f.sectors[sectorNum].Warps)
+	return warps, nil
+}
+
+func (f *warpOnlyProxyAPI) GetInferredWarps(sectorNum int) ([]int, error) {
+	return f.sectors[sectorNum].InferredWarps, nil
+}
+
+func TestBuildSectorGraphReadsOnlyWarpsForCachedSectors(t *testing.T) {
+	proxyAPI := &warpOnlyProxyAPI{sectors: map[int]api.SectorInfo{
+		1: {Number: 1, Warps: []int{2}},
+		2: {Number: 2, Warps: []int{1, 3}, HasPort: true},
+		3: {Number: 3, Warps: []int{2}},
+	}}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(proxyAPI)
+	gsm.currentSector = 1
+
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if proxyAPI.infoCalls != 3 || proxyAPI.warpsCalls != 0 {
+		t.Fatalf("first build should load full info for every sector, got %d info and %d warps lookups", proxyAPI.infoCalls, proxyAPI.warpsCalls)
+	}
+
+	// A new warp out of sector 2 is picked up on rebuild without reloading its info; only
+	// the current sector and the newly reachable sector 4 are loaded in full
+	proxyAPI.sectors[2] = api.SectorInfo{Number: 2, Warps: []int{1, 3, 4}, HasPort: true}
+	proxyAPI.sectors[4] = api.SectorInfo{Number: 4, Warps: []int{2}}
+	proxyAPI.infoCalls = 0
+
+	g, err := gsm.buildSectorGraph()
+	if err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if proxyAPI.infoCalls != 2 || proxyAPI.warpsCalls != 2 {
+		t.Errorf("rebuild should re-read only warps for cached sectors, got %d info and %d warps lookups", proxyAPI.infoCalls, proxyAPI.warpsCalls)
+	}
+	if _, err := g.Edge(2, 4); err != nil {
+		t.Errorf("expected new warp 2 -> 4 in graph: %v", err)
+	}
+	if !gsm.sectorData[2].HasPort {
+		t.Errorf("cached sector details should be kept when only warps are re-read")
+	}
+}
+
+func TestInvalidateSectorsReloadsThemOnce(t *testing.T) {
+	proxyAPI := &warpOnlyProxyAPI{sectors: map[int]api.SectorInfo{
+		1: {Number: 1, Warps: []int{2, 3}},
+		2: {Number: 2, Warps: []int{1}},
+		3: {Number: 3, Warps: []int{1}},
+	}}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(proxyAPI)
+	gsm.currentSector = 1
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+
+	// A CIM download found a port in sector 2
+	proxyAPI.sectors[2] = api.SectorInfo{Number: 2, Warps: []int{1}, HasPort: true}
+	proxyAPI.infoCalls = 0
+	gsm.currentHashKey = "cached"
+	gsm.needsRedraw = false
+	gsm.InvalidateSectors([]int{2, 99})
+	if gsm.currentHashKey != "" || !gsm.needsRedraw {
+		t.Error("invalidating sectors should redraw the map")
+	}
+
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if proxyAPI.infoCalls != 2 {
+		t.Errorf("expected only the current and invalidated sectors to be reloaded, got %d info lookups", proxyAPI.infoCalls)
+	}
+	if !gsm.sectorData[2].HasPort {
+		t.Error("expected sector 2's reloaded info to be used")
+	}
+}
+
+func TestBuildSectorGraphMapDepth(t *testing.T) {
+	// A line of sectors 1 - 2 - 3 - 4 - 5 - 6 - 7
+	sectors := make(map[int]api.SectorInfo)
+	for i := 1; i <= 7; i++ {
+		warps := []int{}
+		if i > 1 {
+			warps = append(warps, i-1)
+		}
+		if i < 7 {
+			warps = append(warps, i+1)
+		}
+		sectors[i] = api.SectorInfo{Number: i, Warps: warps}
+	}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(&warpOnlyProxyAPI{sectors: sectors})
+	gsm.currentSector = 1
+
+	for _, tt := range []struct{ depth, outermost int }{{5, 6}, {2, 3}, {1, 2}, {0, 2}, {9, 6}} {
+		gsm.SetMapDepth(tt.depth)
+		g, err := gsm.buildSectorGraph()
+		if err != nil {
+			t.Fatalf("buildSectorGraph failed: %v", err)
+		}
+		order, _ := g.Order()
+		if order != tt.outermost {
+			t.Errorf("depth %d: expected sectors 1-%d on the map, got %d sectors", tt.depth, tt.outermost, order)
+		}
+		if level := gsm.sectorLevels[tt.outermost]; level != gsm.MapDepth() {
+			t.Errorf("depth %d: expected sector %d on the outermost level %d, got %d", tt.depth, tt.outermost, gsm.MapDepth(), level)
+		}
+	}
+}
+
+func TestUnvisitedFillDistinguishesDensityScans(t *testing.T) {
+	nodeColors := theme.MapNodeColors{Scanned: "thistle", Unexplored: "lightcoral"}
+
+	tests := []struct {
+		explored api.Explored
+		want     string
+	}{
+		{api.ExploredNo, "lightcoral"},
+		{api.ExploredCalc, "lightcoral"},
+		{api.ExploredDensity, "thistle"},
+	}
+	for _, tt := range tests {
+		if got := unvisitedFill(api.SectorInfo{Explored: tt.explored}, nodeColors); got != tt.want {
+			t.Errorf("Explored %d: expected %s, got %s", tt.explored, tt.want, got)
+		}
+	}
+}
+
+func TestFocusSectorRootsGraphWithoutMoving(t *testing.T) {
+	// A line of sectors 1 - 2 - 3 - 4
+	proxyAPI := &warpOnlyProxyAPI{sectors: map[int]api.SectorInfo{
+		1: {Number: 1, Warps: []int{2}},
+		2: {Number: 2, Warps: []int{1, 3}},
+		3: {Number: 3, Warps: []int{2, 4}},
+		4: {Number: 4, Warps: []int{3}},
+	}}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(proxyAPI)
+	gsm.currentSector = 1
+	gsm.SetMapDepth(1)
+
+	gsm.needsRedraw = false
+	gsm.FocusSector(4)
+	if gsm.currentSector != 1 || gsm.FocusedSector() != 4 || !gsm.needsRedraw {
+		t.Fatalf("expected the map to focus sector 4 and redraw with the player still in 1, got current %d focus %d", gsm.currentSector, gsm.FocusedSector())
+	}
+	g, err := gsm.buildSectorGraph()
+	if err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if order, _ := g.Order(); order != 2 || gsm.sectorLevels[4] != 0 || gsm.sectorLevels[3] != 1 {
+		t.Errorf("expected sectors 4 and 3 rooted at 4, got %d sectors with levels %v", order, gsm.sectorLevels)
+	}
+
+	// Focusing near the player keeps the player's node in view
+	gsm.FocusSector(2)
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if level, ok := gsm.sectorLevels[1]; !ok || level != 1 {
+		t.Errorf("expected the current sector one hop from the focus, got %v", gsm.sectorLevels)
+	}
+
+	// Moving follows the player again
+	gsm.UpdateCurrentSector(3)
+	if gsm.FocusedSector() != 0 || gsm.rootSector() != 3 {
+		t.Errorf("expected moving to clear the focus, got focus %d", gsm.FocusedSector())
+	}
+
+	gsm.FocusSector(2)
+	gsm.FocusSector(0)
+	if gsm.rootSector() != 3 {
+		t.Errorf("expected focusing 0 to center on the player, got %d", gsm.rootSector())
+	}
+}
+
+func TestSixelRegionRect(t *testing.T) {
+	for _, tt := range []struct {
+		name                    string
+		imageWidth, imageHeight int
+		cellWidth, cellHeight   int
+		wantWidth, wantHeight   int
+	}{
+		{"cell size unknown", 400, 300, 0, 0, 40, 19},
+		{"image fills the panel", 400, 380, 10, 20, 40, 19},
+		{"image covers part of a cell", 395, 210, 10, 20, 40, 11},
+		{"image larger than the panel", 800, 800, 10, 20, 40, 19},
+	} {
+		x, y, width, height := sixelRegionRect(5, 2, 40, 20, tt.imageWidth, tt.imageHeight, tt.cellWidth, tt.cellHeight)
+		if x != 5 || y != 3 || width != tt.wantWidth || height != tt.wantHeight {
+			t.Errorf("%s: expected 5,3 %dx%d, got %d,%d %dx%d", tt.name, tt.wantWidth, tt.wantHeight, x, y, width, height)
+		}
+	}
+}
+
+func TestLRUCacheConcurrentUse(t *testing.T) {
+	cache := NewLRUCache(10)
+
+	// Async map generation stores images while the UI reads them and a theme change clears them
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("%d-%d", g, i%20)
+				cache.Put(key, &CachedGraphData{Width: i})
+				cache.Get(key)
+				if i%50 == 0 {
+					cache.Clear()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if len(cache.items) > 10 || cache.order.Len() != len(cache.items) {
+		t.Errorf("Expected at most 10 consistent entries, got %d items and %d in order", len(cache.items), cache.order.Len())
+	}
+}
+
+func TestClearSectorCacheReloadsFromNewDatabase(t *testing.T) {
+	proxyAPI := &warpOnlyProxyAPI{sectors: map[int]api.SectorInfo{
+		1: {Number: 1, Warps: []int{2}},
+		2: {Number: 2, Warps: []int{1}, HasPort: true},
+	}}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(proxyAPI)
+	gsm.currentSector = 1
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+
+	// Another game's database has no port in sector 2
+	proxyAPI.sectors[2] = api.SectorInfo{Number: 2, Warps: []int{1}}
+	proxyAPI.infoCalls = 0
+	gsm.ClearSectorCache()
+
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if proxyAPI.infoCalls != 2 {
+		t.Errorf("expected every sector to be reloaded, got %d info lookups", proxyAPI.infoCalls)
+	}
+	if gsm.sectorData[2].HasPort {
+		t.Error("expected sector 2's info from the new database")
+	}
+}
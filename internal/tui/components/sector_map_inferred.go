@@ -0,0 +1,52 @@
+package components
+
+import (
+	"github.com/goccy/go-graphviz"
+)
+
+// Inferred warp styling - dashed so warps only assumed from their reverse stand out from
+// warps the game has shown
+const inferredEdgeStyle = "dashed"
+
+// isInferredWarp returns true if the cached sector data marks the warp from one sector to
+// the other as only inferred
+func (gsm *GraphvizSectorMap) isInferredWarp(from, to int) bool {
+	sectorInfo, exists := gsm.sectorData[from]
+	if !exists {
+		return false
+	}
+	for _, warp := range sectorInfo.InferredWarps {
+		if warp == to {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadReverseInferredWarps re-reads the inferred marks of the cached sectors a sector
+// warps to. A new warp out of it infers the warp back, so the other end of each edge can
+// change without an update of its own.
+func (gsm *GraphvizSectorMap) reloadReverseInferredWarps(sectorNumber int) {
+	if gsm.proxyAPI == nil {
+		return
+	}
+	for _, warp := range gsm.sectorData[sectorNumber].Warps {
+		sectorInfo, exists := gsm.sectorData[warp]
+		if !exists {
+			continue
+		}
+		inferred, err := gsm.proxyAPI.GetInferredWarps(warp)
+		if err != nil {
+			continue
+		}
+		sectorInfo.InferredWarps = inferred
+		gsm.sectorData[warp] = sectorInfo
+	}
+}
+
+// applyInferredEdgeStyle dashes an edge if either of its directions is an inferred warp
+func (gsm *GraphvizSectorMap) applyInferredEdgeStyle(edge *graphviz.Edge, source, target int) {
+	if gsm.isInferredWarp(source, target) || gsm.isInferredWarp(target, source) {
+		edge.SetStyle(inferredEdgeStyle)
+	}
+}
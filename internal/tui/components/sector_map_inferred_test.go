@@ -0,0 +1,59 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+)
+
+func TestInferredWarps(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, Warps: []int{2, 3}}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, Warps: []int{1}, InferredWarps: []int{1}}
+
+	if !gsm.isInferredWarp(2, 1) {
+		t.Error("expected the reverse warp 2 -> 1 to be inferred")
+	}
+	if gsm.isInferredWarp(1, 2) || gsm.isInferredWarp(1, 3) || gsm.isInferredWarp(4, 1) {
+		t.Error("warps the game has shown should not be inferred")
+	}
+
+	// A warp-only update keeps the inferred marks of warps still listed
+	merged := gsm.mergeSectorWarps(2, [6]int{1, 5})
+	if len(merged.InferredWarps) != 1 || merged.InferredWarps[0] != 1 {
+		t.Errorf("expected 1 to stay inferred, got %v", merged.InferredWarps)
+	}
+	merged = gsm.mergeSectorWarps(2, [6]int{5})
+	if len(merged.InferredWarps) != 0 {
+		t.Errorf("expected no inferred warps once 1 is gone, got %v", merged.InferredWarps)
+	}
+}
+
+func TestInferredWarpsReloadedWithWarps(t *testing.T) {
+	proxyAPI := &warpOnlyProxyAPI{sectors: map[int]api.SectorInfo{
+		1: {Number: 1, Warps: []int{2}},
+		2: {Number: 2, Warps: []int{1}, InferredWarps: []int{1}},
+	}}
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetProxyAPI(proxyAPI)
+	gsm.currentSector = 1
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+
+	// A probe saw the warp 2 -> 1 without sector 2 being updated
+	proxyAPI.sectors[2] = api.SectorInfo{Number: 2, Warps: []int{1}}
+	if _, err := gsm.buildSectorGraph(); err != nil {
+		t.Fatalf("buildSectorGraph failed: %v", err)
+	}
+	if gsm.isInferredWarp(2, 1) {
+		t.Error("expected the confirmed warp 2 -> 1 to be read again on rebuild")
+	}
+
+	// Sector 1 gets a new warp to 3, which infers the warp 3 -> 1 in the cached sector 3
+	gsm.sectorData[3] = api.SectorInfo{Number: 3, Warps: []int{4}}
+	proxyAPI.sectors[3] = api.SectorInfo{Number: 3, Warps: []int{1, 4}, InferredWarps: []int{1}}
+	gsm.UpdateSectorBatch(nil, map[int][6]int{1: {2, 3}})
+	if !gsm.isInferredWarp(3, 1) {
+		t.Error("expected the other end of the new warp to be read again")
+	}
+}
@@ -0,0 +1,27 @@
+package components
+
+import (
+	"github.com/goccy/go-graphviz"
+)
+
+// Noted sector styling - magenta double border so flagged sectors stand out from explored
+// state colors. The double border stays visible when an avoid or route color is applied.
+const (
+	noteBorderColor = "magenta"
+	notePeripheries = 2
+)
+
+// isNotedSector returns true if the cached sector data has a player note. The current
+// sector keeps its "YOU" highlight instead.
+func (gsm *GraphvizSectorMap) isNotedSector(sector int) bool {
+	sectorInfo, exists := gsm.sectorData[sector]
+	return exists && sectorInfo.Note != "" && sector != gsm.currentSector
+}
+
+// applyNoteNodeStyle styles a node's border if the sector has a note
+func (gsm *GraphvizSectorMap) applyNoteNodeStyle(node *graphviz.Node, sector int) {
+	if gsm.isNotedSector(sector) {
+		node.SetColor(noteBorderColor)
+		node.SetPeripheries(notePeripheries)
+	}
+}
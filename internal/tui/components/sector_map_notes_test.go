@@ -0,0 +1,24 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+)
+
+func TestNotedSectorStyling(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, Note: "home"}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, Note: "enemy planet"}
+	gsm.sectorData[3] = api.SectorInfo{Number: 3}
+
+	if gsm.isNotedSector(1) {
+		t.Error("current sector should keep the YOU highlight instead of the note style")
+	}
+	if !gsm.isNotedSector(2) {
+		t.Error("expected sector 2 to be styled as noted")
+	}
+	if gsm.isNotedSector(3) || gsm.isNotedSector(4) {
+		t.Error("sectors without notes should not be styled as noted")
+	}
+}
@@ -0,0 +1,88 @@
+package components
+
+import (
+	"fmt"
+	"twist/internal/log"
+
+	"github.com/goccy/go-graphviz"
+)
+
+// Route overlay styling - bright color and thicker pen so the route stands out
+const (
+	routeHighlightColor = "magenta"
+	routeNodePenWidth   = 6.0
+	routeEdgePenWidth   = 4.0
+	routeEdgeArrowSize  = 1.2
+)
+
+// HighlightRoute marks the listed sectors and the warps between consecutive
+// sectors as a route overlay on the map. Passing an empty slice clears it.
+// The highlight is cleared automatically when the current sector changes.
+func (gsm *GraphvizSectorMap) HighlightRoute(sectors []int) {
+	gsm.routeSectors = make(map[int]bool)
+	gsm.routeEdges = make(map[string]bool)
+
+	for i, sector := range sectors {
+		if sector <= 0 {
+			continue
+		}
+		gsm.routeSectors[sector] = true
+		if i > 0 && sectors[i-1] > 0 {
+			gsm.routeEdges[routeEdgeKey(sectors[i-1], sector)] = true
+		}
+	}
+
+	log.Info("GraphvizSectorMap: Route highlight updated", "sectors", len(gsm.routeSectors), "edges", len(gsm.routeEdges))
+
+	// The route is part of the DOT content, so force a fresh hash and redraw
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+}
+
+// ClearRoute removes any route highlight from the map
+func (gsm *GraphvizSectorMap) ClearRoute() {
+	if !gsm.hasRoute() {
+		return
+	}
+	gsm.HighlightRoute(nil)
+}
+
+// hasRoute returns true if a route overlay is currently active
+func (gsm *GraphvizSectorMap) hasRoute() bool {
+	return len(gsm.routeSectors) > 0
+}
+
+// isRouteSector returns true if the sector is part of the highlighted route
+func (gsm *GraphvizSectorMap) isRouteSector(sector int) bool {
+	return gsm.routeSectors[sector]
+}
+
+// isRouteEdge returns true if the warp between the two sectors is part of the highlighted route
+func (gsm *GraphvizSectorMap) isRouteEdge(source, target int) bool {
+	return gsm.routeEdges[routeEdgeKey(source, target)]
+}
+
+// applyRouteNodeStyle styles a node's border if it lies on the highlighted route
+func (gsm *GraphvizSectorMap) applyRouteNodeStyle(node *graphviz.Node, sector int) {
+	if gsm.isRouteSector(sector) {
+		node.SetColor(routeHighlightColor)
+		node.SetPenWidth(routeNodePenWidth)
+	}
+}
+
+// applyRouteEdgeStyle styles an edge if it lies on the highlighted route
+func (gsm *GraphvizSectorMap) applyRouteEdgeStyle(edge *graphviz.Edge, source, target int) {
+	if gsm.isRouteEdge(source, target) {
+		edge.SetColor(routeHighlightColor)
+		edge.SetPenWidth(routeEdgePenWidth)
+		edge.SetArrowSize(routeEdgeArrowSize)
+	}
+}
+
+// routeEdgeKey creates an order-independent key for a warp between two sectors
+func routeEdgeKey(a, b int) string {
+	if a < b {
+		return fmt.Sprintf("%d-%d", a, b)
+	}
+	return fmt.Sprintf("%d-%d", b, a)
+}
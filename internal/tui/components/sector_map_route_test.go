@@ -0,0 +1,49 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+)
+
+func TestHighlightRouteMarksSectorsAndEdges(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.currentHashKey = "cached"
+
+	gsm.HighlightRoute([]int{1, 5, 9})
+
+	for _, sector := range []int{1, 5, 9} {
+		if !gsm.isRouteSector(sector) {
+			t.Errorf("expected sector %d to be on route", sector)
+		}
+	}
+	if gsm.isRouteSector(2) {
+		t.Errorf("sector 2 should not be on route")
+	}
+	if !gsm.isRouteEdge(1, 5) || !gsm.isRouteEdge(9, 5) {
+		t.Errorf("expected consecutive route sectors to form route edges")
+	}
+	if gsm.isRouteEdge(1, 9) {
+		t.Errorf("non-consecutive sectors should not form a route edge")
+	}
+	if gsm.currentHashKey != "" || !gsm.needsRedraw {
+		t.Errorf("highlighting a route should invalidate the cached hash and force a redraw")
+	}
+}
+
+func TestHighlightRouteClearedOnSectorChange(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.HighlightRoute([]int{1, 2, 3})
+
+	// Same sector keeps the route
+	gsm.UpdateCurrentSector(1)
+	if !gsm.hasRoute() {
+		t.Fatalf("route should survive an update for the same sector")
+	}
+
+	gsm.UpdateCurrentSectorWithInfo(api.SectorInfo{Number: 2})
+	if gsm.hasRoute() {
+		t.Errorf("route should be cleared when the current sector changes")
+	}
+}
@@ -0,0 +1,40 @@
+package components
+
+import (
+	"github.com/goccy/go-graphviz"
+
+	"twist/internal/log"
+)
+
+// Session sector styling - sectors first explored this session fade from their usual fill
+// into a tint, so the ground covered since connecting stands out without hiding port colors
+const sessionTintColor = "palegreen"
+
+// ToggleSessionHighlight turns the tint on sectors first explored this session on or off,
+// returning true if it is now on
+func (gsm *GraphvizSectorMap) ToggleSessionHighlight() bool {
+	gsm.highlightSession = !gsm.highlightSession
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+	log.Info("GraphvizSectorMap: Session highlight changed", "enabled", gsm.highlightSession)
+	return gsm.highlightSession
+}
+
+// isSessionSector returns true if the sector is tinted as first explored this session. The
+// current sector keeps its own highlight.
+func (gsm *GraphvizSectorMap) isSessionSector(sector int) bool {
+	if !gsm.highlightSession || sector == gsm.currentSector {
+		return false
+	}
+	return gsm.sectorData[sector].NewThisSession
+}
+
+// applySessionNodeStyle blends the session tint into a node's fill if the sector was first
+// explored this session
+func (gsm *GraphvizSectorMap) applySessionNodeStyle(node *graphviz.Node, sector int, fillColor string) {
+	if !gsm.isSessionSector(sector) {
+		return
+	}
+	node.SetFillColor(fillColor + ":" + sessionTintColor)
+	node.SetGradientAngle(90)
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+)
+
+func TestSessionSectorStyling(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, Visited: true, NewThisSession: true}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, Visited: true, NewThisSession: true}
+	gsm.sectorData[3] = api.SectorInfo{Number: 3, Visited: true}
+
+	if gsm.isSessionSector(1) {
+		t.Error("current sector should keep the YOU highlight")
+	}
+	if !gsm.isSessionSector(2) {
+		t.Error("expected sector 2 to be tinted as new this session")
+	}
+	if gsm.isSessionSector(3) || gsm.isSessionSector(4) {
+		t.Error("sectors explored before this session should not be tinted")
+	}
+
+	gsm.currentHashKey = "cached"
+	gsm.needsRedraw = false
+	if gsm.ToggleSessionHighlight() {
+		t.Error("expected the highlight to start on and toggle off")
+	}
+	if gsm.isSessionSector(2) {
+		t.Error("sectors should not be tinted with the highlight off")
+	}
+	if gsm.currentHashKey != "" || !gsm.needsRedraw {
+		t.Error("toggling the highlight should redraw the map")
+	}
+	if !gsm.ToggleSessionHighlight() {
+		t.Error("expected the highlight to toggle back on")
+	}
+}
@@ -316,7 +316,7 @@ func (smc *SixelSectorMapComponent) drawSectorLabelOnCanvas(canvas *SixelCanvas,
 	if info.HasPort && smc.proxyAPI != nil {
 		portInfo, err := smc.proxyAPI.GetPortInfo(sectorNum)
 		if err == nil && portInfo != nil {
-			portText := fmt.Sprintf("(%s)", portInfo.ClassType.String())
+			portText := fmt.Sprintf("(%s)", portInfo.Label())
 			portTextWidth := len(portText) * 6
 			portTextX := x - portTextWidth/2
 			portTextY := y + 2 // Below the sector number
@@ -0,0 +1,49 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-graphviz"
+)
+
+// Stale sector styling - sectors whose data hasn't been refreshed in a while get dimmed text
+// and an outside label with their age in days, so old port and fighter info isn't trusted blindly
+const (
+	defaultStaleSectorAge = 7 * 24 * time.Hour
+	staleFontColor        = "gray35"
+)
+
+// SetStaleThreshold sets how old a sector's data must be before it is dimmed on the map.
+// Zero turns the indicator off.
+func (gsm *GraphvizSectorMap) SetStaleThreshold(threshold time.Duration) {
+	if threshold < 0 {
+		threshold = 0
+	}
+	if gsm.staleAfter == threshold {
+		return
+	}
+	gsm.staleAfter = threshold
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+}
+
+// isStaleSector returns true if the cached sector data is older than the stale threshold.
+// The current sector was just seen, and sectors never seen have nothing to go stale.
+func (gsm *GraphvizSectorMap) isStaleSector(sector int, now time.Time) bool {
+	if gsm.staleAfter <= 0 || sector == gsm.currentSector {
+		return false
+	}
+	sectorInfo, exists := gsm.sectorData[sector]
+	return exists && !sectorInfo.LastSeen.IsZero() && sectorInfo.Age(now) > gsm.staleAfter
+}
+
+// applyStaleNodeStyle dims a node and labels it with its age if the sector data is stale
+func (gsm *GraphvizSectorMap) applyStaleNodeStyle(node *graphviz.Node, sector int, now time.Time) {
+	if !gsm.isStaleSector(sector, now) {
+		return
+	}
+	days := int(gsm.sectorData[sector].Age(now) / (24 * time.Hour))
+	node.SetFontColor(staleFontColor)
+	node.SetXLabel(fmt.Sprintf("%dd", days))
+}
@@ -0,0 +1,41 @@
+package components
+
+import (
+	"testing"
+	"time"
+	"twist/internal/api"
+)
+
+func TestStaleSectorStyling(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, LastSeen: now.AddDate(0, 0, -30)}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, LastSeen: now.AddDate(0, 0, -10)}
+	gsm.sectorData[3] = api.SectorInfo{Number: 3, LastSeen: now.AddDate(0, 0, -1)}
+	gsm.sectorData[4] = api.SectorInfo{Number: 4}
+
+	if gsm.isStaleSector(1, now) {
+		t.Error("current sector should never be styled as stale")
+	}
+	if !gsm.isStaleSector(2, now) {
+		t.Error("expected sector 2 to be stale with the default threshold")
+	}
+	if gsm.isStaleSector(3, now) {
+		t.Error("recently seen sectors should not be stale")
+	}
+	if gsm.isStaleSector(4, now) || gsm.isStaleSector(5, now) {
+		t.Error("sectors never seen should not be styled as stale")
+	}
+
+	gsm.SetStaleThreshold(12 * time.Hour)
+	if !gsm.isStaleSector(3, now) {
+		t.Error("expected sector 3 to be stale with a 12 hour threshold")
+	}
+
+	gsm.SetStaleThreshold(0)
+	if gsm.isStaleSector(2, now) {
+		t.Error("a zero threshold should turn the stale indicator off")
+	}
+}
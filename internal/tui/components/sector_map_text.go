@@ -0,0 +1,159 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"twist/internal/log"
+	"twist/internal/theme"
+)
+
+// Sector map drawing modes, chosen at startup
+const (
+	MapModeAuto  = "auto"  // Sixels if the terminal reported them at startup, otherwise text
+	MapModeSixel = "sixel" // Graphviz image drawn with sixels
+	MapModeText  = "text"  // Adjacency list drawn with characters
+)
+
+// textMapLegend explains the sector flags in the text map
+const textMapLegend = "P port  T traders  X avoided"
+
+// textMapKind is what a line of the text map shows, which picks its colors
+type textMapKind int
+
+const (
+	textMapHeading textMapKind = iota
+	textMapCurrent
+	textMapPort
+	textMapSector
+)
+
+// textMapLine is one line of the text map
+type textMapLine struct {
+	text string
+	kind textMapKind
+}
+
+// SetTextMode draws the map as text instead of a sixel image, for terminals that can't
+// show sixels
+func (gsm *GraphvizSectorMap) SetTextMode(text bool) {
+	gsm.textMode = text
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+	if text && gsm.sixelLayer != nil {
+		gsm.sixelLayer.ClearRegion(gsm.regionID)
+		gsm.sixelLayer.SetRegionVisible(gsm.regionID, false)
+	}
+	log.Info("GraphvizSectorMap: Text mode changed", "enabled", text)
+}
+
+// textMapLines lists the sectors around the current one by warp hops, each with its flags
+// and warps. It reads the sector data and levels left by buildSectorGraph.
+func (gsm *GraphvizSectorMap) textMapLines() []textMapLine {
+	byLevel := make(map[int][]int)
+	maxLevel := 0
+	for sector, level := range gsm.sectorLevels {
+		byLevel[level] = append(byLevel[level], sector)
+		maxLevel = max(maxLevel, level)
+	}
+
+	var lines []textMapLine
+	for level := 0; level <= maxLevel; level++ {
+		sectors := byLevel[level]
+		if len(sectors) == 0 {
+			continue
+		}
+		sort.Ints(sectors)
+
+		heading := "Here"
+		if level == 0 && gsm.focusSector > 0 {
+			heading = fmt.Sprintf("Sector %d", gsm.focusSector)
+		} else if level == 1 {
+			heading = "1 hop"
+		} else if level > 1 {
+			heading = fmt.Sprintf("%d hops", level)
+		}
+		lines = append(lines, textMapLine{text: heading, kind: textMapHeading})
+
+		for _, sector := range sectors {
+			lines = append(lines, gsm.textMapSectorLine(sector))
+		}
+	}
+	if len(lines) > 0 {
+		lines = append(lines, textMapLine{}, textMapLine{text: textMapLegend, kind: textMapHeading})
+	}
+	return lines
+}
+
+// textMapSectorLine shows a sector's number, flags and warps, e.g. " 1234 PT > 12 45"
+func (gsm *GraphvizSectorMap) textMapSectorLine(sector int) textMapLine {
+	info := gsm.sectorData[sector]
+
+	var flags strings.Builder
+	if info.HasPort {
+		flags.WriteByte('P')
+	}
+	if info.HasTraders > 0 {
+		flags.WriteByte('T')
+	}
+	if info.Avoided {
+		flags.WriteByte('X')
+	}
+
+	warps := make([]string, 0, len(info.Warps))
+	for _, warp := range info.Warps {
+		if warp > 0 {
+			warps = append(warps, strconv.Itoa(warp))
+		}
+	}
+
+	text := fmt.Sprintf(" %5d %-3s", sector, flags.String())
+	if len(warps) > 0 {
+		text += "> " + strings.Join(warps, " ")
+	}
+
+	kind := textMapSector
+	switch {
+	case sector == gsm.currentSector:
+		kind = textMapCurrent
+	case info.HasPort:
+		kind = textMapPort
+	}
+	return textMapLine{text: strings.TrimRight(text, " "), kind: kind}
+}
+
+// drawTextMap draws the text map lines in the panel, cutting off what doesn't fit
+func (gsm *GraphvizSectorMap) drawTextMap(screen tcell.Screen, x, y, width, height int) {
+	mapColors := theme.Current().SectorMapColors()
+	background := tcell.StyleDefault.Background(mapColors.MapBackground)
+	styles := map[textMapKind]tcell.Style{
+		textMapHeading: background.Foreground(mapColors.ConnectionLine),
+		textMapCurrent: background.Foreground(mapColors.CurrentSectorFg).Background(mapColors.CurrentSectorBg),
+		textMapPort:    background.Foreground(mapColors.PortSectorFg),
+		textMapSector:  background.Foreground(mapColors.EmptySectorFg),
+	}
+
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			screen.SetContent(x+col, y+row, ' ', nil, background)
+		}
+	}
+
+	for row, line := range gsm.textLines {
+		if row >= height {
+			break
+		}
+		col := 0
+		for _, char := range line.text {
+			if col >= width {
+				break
+			}
+			screen.SetContent(x+col, y+row, char, nil, styles[line.kind])
+			col++
+		}
+	}
+}
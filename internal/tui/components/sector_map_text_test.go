@@ -0,0 +1,67 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTextMapLines(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, Warps: []int{2, 3}}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, Warps: []int{1, 4}, HasPort: true, HasTraders: 2}
+	gsm.sectorData[3] = api.SectorInfo{Number: 3, Warps: []int{1}, Avoided: true}
+	gsm.sectorData[4] = api.SectorInfo{Number: 4}
+	gsm.sectorLevels = map[int]int{1: 0, 3: 1, 2: 1, 4: 2}
+
+	want := []textMapLine{
+		{"Here", textMapHeading},
+		{"     1    > 2 3", textMapCurrent},
+		{"1 hop", textMapHeading},
+		{"     2 PT > 1 4", textMapPort},
+		{"     3 X  > 1", textMapSector},
+		{"2 hops", textMapHeading},
+		{"     4", textMapSector},
+		{"", textMapHeading},
+		{textMapLegend, textMapHeading},
+	}
+	got := gsm.textMapLines()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d lines, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestTextMapDraw(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("Failed to init screen: %v", err)
+	}
+	defer screen.Fini()
+
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.SetTextMode(true)
+	if !gsm.textMode || !gsm.needsRedraw {
+		t.Fatal("Expected text mode to redraw the map")
+	}
+	gsm.textLines = []textMapLine{{"Here", textMapHeading}, {"     1     > 2 3", textMapCurrent}}
+	gsm.drawTextMap(screen, 0, 0, 8, 1)
+
+	var drawn []rune
+	for col := 0; col < 8; col++ {
+		char, _, _, _ := screen.GetContent(col, 0)
+		drawn = append(drawn, char)
+	}
+	if got := string(drawn); got != "Here    " {
+		t.Errorf("Expected the heading on the first row, got %q", got)
+	}
+	if char, _, _, _ := screen.GetContent(5, 1); char == '1' {
+		t.Error("Expected lines below the panel to be cut off")
+	}
+}
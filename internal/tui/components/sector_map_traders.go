@@ -0,0 +1,33 @@
+package components
+
+import (
+	"github.com/goccy/go-graphviz"
+
+	"twist/internal/api"
+)
+
+// Trader sector styling - the label of a visited sector with traders is colored by the
+// most hostile trader's alignment, so sectors with dangerous players can be routed around.
+// Dark shades stay readable on every theme's light node fills.
+var traderAlignmentFontColors = map[api.Alignment]string{
+	api.AlignmentGood:    "darkgreen",
+	api.AlignmentNeutral: "darkorange3",
+	api.AlignmentEvil:    "red3",
+}
+
+// traderAlignment returns the alignment of the most hostile trader in a visited sector,
+// unknown if there are no traders or the sector shows as the current sector
+func (gsm *GraphvizSectorMap) traderAlignment(sector int) api.Alignment {
+	sectorInfo, exists := gsm.sectorData[sector]
+	if !exists || !sectorInfo.Visited || sectorInfo.HasTraders == 0 || sector == gsm.currentSector {
+		return api.AlignmentUnknown
+	}
+	return sectorInfo.TraderAlignment
+}
+
+// applyTraderNodeStyle colors a node's label by the hostility of the traders in the sector
+func (gsm *GraphvizSectorMap) applyTraderNodeStyle(node *graphviz.Node, sector int) {
+	if color, ok := traderAlignmentFontColors[gsm.traderAlignment(sector)]; ok {
+		node.SetFontColor(color)
+	}
+}
@@ -0,0 +1,28 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/api"
+)
+
+func TestTraderSectorStyling(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.currentSector = 1
+	gsm.sectorData[1] = api.SectorInfo{Number: 1, Visited: true, HasTraders: 1, TraderAlignment: api.AlignmentEvil}
+	gsm.sectorData[2] = api.SectorInfo{Number: 2, Visited: true, HasTraders: 2, TraderAlignment: api.AlignmentEvil}
+	gsm.sectorData[3] = api.SectorInfo{Number: 3, Visited: true, HasTraders: 1, TraderAlignment: api.AlignmentGood}
+	gsm.sectorData[4] = api.SectorInfo{Number: 4, Visited: true}
+
+	if got := gsm.traderAlignment(1); got != api.AlignmentUnknown {
+		t.Errorf("current sector should keep the YOU highlight, got alignment %d", got)
+	}
+	if got := gsm.traderAlignment(2); got != api.AlignmentEvil {
+		t.Errorf("expected sector 2 to be styled as evil, got %d", got)
+	}
+	if got := gsm.traderAlignment(3); got != api.AlignmentGood {
+		t.Errorf("expected sector 3 to be styled as good, got %d", got)
+	}
+	if gsm.traderAlignment(4) != api.AlignmentUnknown || gsm.traderAlignment(5) != api.AlignmentUnknown {
+		t.Error("sectors without traders should not be styled by alignment")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"twist/internal/api"
 	"twist/internal/theme"
+	"unicode/utf8"
 
 	"github.com/rivo/tview"
 )
@@ -15,9 +16,10 @@ type StatusComponent struct {
 	proxyAPI      api.ProxyAPI
 	connected     bool
 	serverAddress string
-	gameInfo      *GameInfo      // Current active game information
-	lastWidth     int            // Track the last known width for padding
-	menuComponent *MenuComponent // Reference to menu component for width coordination
+	connState     api.ConnectionState // Last lifecycle state reported by the proxy, shown as the indicator
+	gameInfo      *GameInfo           // Current active game information
+	lastWidth     int                 // Track the last known width for padding
+	menuComponent *MenuComponent      // Reference to menu component for width coordination
 
 	// Version information
 	version string
@@ -28,6 +30,7 @@ type StatusComponent struct {
 // GameInfo holds information about the currently active game
 type GameInfo struct {
 	GameName   string
+	GameLetter string // Server menu letter, empty if the game wasn't picked from a menu
 	ServerHost string
 	ServerPort string
 	IsLoaded   bool
@@ -75,12 +78,35 @@ func (sc *StatusComponent) SetConnectionStatus(connected bool, serverAddress str
 	sc.UpdateStatus()
 }
 
+// SetConnectionState sets the connection lifecycle state shown by the indicator
+func (sc *StatusComponent) SetConnectionState(state api.ConnectionState) {
+	sc.connState = state
+	sc.UpdateStatus()
+}
+
+// connectionIndicator returns the color-coded mark that leads the status bar: a dot colored
+// by state, or an error-colored "!" when the connection failed or was lost
+func (sc *StatusComponent) connectionIndicator(statusColors theme.StatusColors) string {
+	color := statusColors.DisconnectedFg
+	switch sc.connState {
+	case api.ConnectionStateConnecting, api.ConnectionStateReconnecting:
+		color = statusColors.ConnectingFg
+	case api.ConnectionStateConnected:
+		color = statusColors.ConnectedFg
+	case api.ConnectionStateError:
+		return fmt.Sprintf("[%s:%s]![-:%s] ", statusColors.ErrorFg.String(),
+			statusColors.ErrorBg.String(), statusColors.Background.String())
+	}
+	return fmt.Sprintf("[%s]●[-] ", color.String())
+}
+
 // SetGameInfo sets the active game information
-func (sc *StatusComponent) SetGameInfo(gameName, serverHost, serverPort string, isLoaded bool) {
+func (sc *StatusComponent) SetGameInfo(gameLetter, gameName, serverHost, serverPort string, isLoaded bool) {
 
 	if isLoaded {
 		sc.gameInfo = &GameInfo{
 			GameName:   gameName,
+			GameLetter: gameLetter,
 			ServerHost: serverHost,
 			ServerPort: serverPort,
 			IsLoaded:   isLoaded,
@@ -106,7 +132,12 @@ func (sc *StatusComponent) UpdateStatus() {
 
 	// Build status text with colored connection status
 	statusText.WriteString(" ")
-	if sc.connected {
+	statusText.WriteString(sc.connectionIndicator(statusColors))
+	if sc.connState == api.ConnectionStateReconnecting && sc.serverAddress != "" {
+		// The server dropped and is about to be redialed
+		statusText.WriteString(fmt.Sprintf("[%s]Reconnecting[-] to %s",
+			statusColors.ConnectingFg.String(), sc.serverAddress))
+	} else if sc.connected {
 		// Add green "Connected" part
 		statusText.WriteString(fmt.Sprintf("[%s]Connected[-] to %s",
 			statusColors.ConnectedFg.String(), sc.serverAddress))
@@ -114,6 +145,10 @@ func (sc *StatusComponent) UpdateStatus() {
 		// Add connecting color for server address
 		statusText.WriteString(fmt.Sprintf("[%s]%s[-]",
 			statusColors.ConnectingFg.String(), sc.serverAddress))
+	} else if sc.connState == api.ConnectionStateError {
+		// The server dropped or couldn't be reached
+		statusText.WriteString(fmt.Sprintf("[%s]Connection error[-]",
+			statusColors.DisconnectedFg.String()))
 	} else {
 		// Add red "Disconnected" part
 		statusText.WriteString(fmt.Sprintf("[%s]Disconnected[-]",
@@ -124,7 +159,7 @@ func (sc *StatusComponent) UpdateStatus() {
 	if sc.gameInfo != nil && sc.gameInfo.IsLoaded {
 		statusText.WriteString(" | Game: ")
 		statusText.WriteString(fmt.Sprintf("[%s]%s[-]",
-			statusColors.ConnectedFg.String(), sc.gameInfo.GameName))
+			statusColors.ConnectedFg.String(), sc.gameInfo.displayName()))
 	} else {
 	}
 
@@ -152,7 +187,7 @@ func (sc *StatusComponent) UpdateStatus() {
 
 	// Calculate content length (without color tags) before adding the final space
 	plainTextBeforeSpace := sc.stripColorTags(statusText.String())
-	contentLength := len(plainTextBeforeSpace)
+	contentLength := utf8.RuneCountInString(plainTextBeforeSpace)
 
 	// Always add one space at the end (this is the +1)
 	statusText.WriteString(" ")
@@ -183,6 +218,14 @@ func (sc *StatusComponent) UpdateStatus() {
 	}
 }
 
+// displayName returns the game name prefixed with its menu letter, e.g. "B: Trade Wars 2002"
+func (gi *GameInfo) displayName() string {
+	if gi.GameLetter == "" {
+		return gi.GameName
+	}
+	return gi.GameLetter + ": " + gi.GameName
+}
+
 // stripColorTags removes tview color tags from text to calculate actual display length
 func (sc *StatusComponent) stripColorTags(text string) string {
 	// Simple regex to remove tview color tags like [color], [-], [color:background], etc.
@@ -0,0 +1,43 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"twist/internal/api"
+)
+
+func TestStatusConnectionIndicator(t *testing.T) {
+	sc := NewStatusComponent()
+
+	sc.SetConnectionState(api.ConnectionStateConnecting)
+	if text := sc.stripColorTags(sc.GetWrapper().GetText(false)); !strings.HasPrefix(text, " ● ") {
+		t.Errorf("Expected the status bar to start with the indicator, got %q", text)
+	}
+
+	sc.SetConnectionState(api.ConnectionStateError)
+	text := sc.stripColorTags(sc.GetWrapper().GetText(false))
+	if !strings.HasPrefix(text, " ! Connection error") {
+		t.Errorf("Expected the error indicator, got %q", text)
+	}
+
+	// Connecting again clears the error
+	sc.SetConnectionState(api.ConnectionStateConnecting)
+	if text := sc.stripColorTags(sc.GetWrapper().GetText(false)); strings.Contains(text, "Connection error") {
+		t.Errorf("Expected the error to clear on reconnect, got %q", text)
+	}
+}
+
+func TestStatusReconnecting(t *testing.T) {
+	sc := NewStatusComponent()
+	sc.SetConnectionStatus(true, "example.com:2002")
+
+	sc.SetConnectionState(api.ConnectionStateReconnecting)
+	if text := sc.stripColorTags(sc.GetWrapper().GetText(false)); !strings.HasPrefix(text, " ● Reconnecting to example.com:2002") {
+		t.Errorf("Expected the status bar to show the reconnect, got %q", text)
+	}
+
+	sc.SetConnectionState(api.ConnectionStateConnected)
+	if text := sc.stripColorTags(sc.GetWrapper().GetText(false)); !strings.HasPrefix(text, " ● Connected to example.com:2002") {
+		t.Errorf("Expected the status bar to show the connection again, got %q", text)
+	}
+}
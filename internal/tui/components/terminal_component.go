@@ -142,6 +142,48 @@ func (tc *TerminalComponent) Clear() *TerminalComponent {
 	return tc
 }
 
+// SetScrollbackLimit sets how many lines of output are kept for scrolling back
+func (tc *TerminalComponent) SetScrollbackLimit(lines int) *TerminalComponent {
+	tc.terminalView.SetScrollbackLimit(lines)
+	return tc
+}
+
+// SetTimestampFormat prefixes each line of output with the time in format; empty turns it off
+func (tc *TerminalComponent) SetTimestampFormat(format string) *TerminalComponent {
+	tc.terminalView.SetTimestampFormat(format)
+	return tc
+}
+
+// ScrollPage scrolls the terminal up (negative) or down by pages
+func (tc *TerminalComponent) ScrollPage(pages int) {
+	tc.terminalView.ScrollPage(pages)
+}
+
+// BeginSearch starts a new search through the scrollback from the current view
+func (tc *TerminalComponent) BeginSearch() {
+	tc.terminalView.BeginSearch()
+}
+
+// Search finds and highlights the latest match for the query, returning false if none
+func (tc *TerminalComponent) Search(query string) bool {
+	return tc.terminalView.Search(query)
+}
+
+// SearchNext moves to the next earlier match
+func (tc *TerminalComponent) SearchNext() bool {
+	return tc.terminalView.SearchNext()
+}
+
+// SearchPrevious moves to the next later match
+func (tc *TerminalComponent) SearchPrevious() bool {
+	return tc.terminalView.SearchPrevious()
+}
+
+// ClearSearch removes the search highlight
+func (tc *TerminalComponent) ClearSearch() {
+	tc.terminalView.ClearSearch()
+}
+
 // GetInnerRect returns the terminal's inner drawing area
 func (tc *TerminalComponent) GetInnerRect() (int, int, int, int) {
 	// Delegate to the terminal view which handles padding properly
@@ -0,0 +1,214 @@
+package components
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultScrollbackLines is how many lines of game output the terminal keeps for scrolling
+// back and searching
+const DefaultScrollbackLines = 5000
+
+// scrollbackTrimPercent is how much of the limit is kept when the scrollback overflows.
+// Trimming below the limit means the buffer is copied once per batch of lines, not per write.
+const scrollbackTrimPercent = 90
+
+// terminalSearch is an incremental search through the scrollback. The match is highlighted
+// until the search is cleared or scrolls out of the buffer.
+type terminalSearch struct {
+	query    string
+	origin   int // First row below the view when the search began; matches above it come first
+	row, col int // Position of the current match, row -1 if there is none
+}
+
+// SetScrollbackLimit sets how many lines the terminal keeps, dropping the oldest beyond it.
+// Zero or less uses DefaultScrollbackLines.
+func (tv *TerminalView) SetScrollbackLimit(lines int) *TerminalView {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+
+	if lines <= 0 {
+		lines = DefaultScrollbackLines
+	}
+	tv.scrollbackLimit = lines
+	_, _, _, height := tv.GetInnerRect()
+	tv.trimScrollback(height)
+	return tv
+}
+
+// trimScrollback drops the oldest lines once the scrollback limit is passed, down to
+// scrollbackTrimPercent of it but at least a screen's worth, and moves the cursor, view and
+// search match up with the remaining lines
+func (tv *TerminalView) trimScrollback(height int) {
+	limit := max(tv.scrollbackLimit, height)
+	if tv.scrollbackLimit <= 0 || len(tv.lines) <= limit {
+		return
+	}
+	drop := len(tv.lines) - max(limit*scrollbackTrimPercent/100, height)
+
+	tv.lines = append(tv.lines[:0:0], tv.lines[drop:]...)
+	tv.colors = append(tv.colors[:0:0], tv.colors[drop:]...)
+	tv.cursorY = max(tv.cursorY-drop, 0)
+	tv.scrollOffsetRow = max(tv.scrollOffsetRow-drop, 0)
+	tv.search.origin = max(tv.search.origin-drop, 0)
+	if tv.search.row >= 0 {
+		tv.search.row -= drop
+		if tv.search.row < 0 {
+			tv.search.row = -1
+		}
+	}
+}
+
+// ScrollPage scrolls the view by two-thirds of its height, up for negative pages and down
+// for positive ones
+func (tv *TerminalView) ScrollPage(pages int) {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+	tv.scrollPage(pages)
+}
+
+// scrollPage scrolls the view by pages of two-thirds of its height
+func (tv *TerminalView) scrollPage(pages int) {
+	_, _, _, height := tv.GetInnerRect()
+	scrollAmount := max(height*2/3, 1)
+	tv.scrollOffsetRow = tv.clampScroll(tv.scrollOffsetRow+pages*scrollAmount, height)
+}
+
+// clampScroll limits a scroll row to the buffer, so the view never runs past the last line
+func (tv *TerminalView) clampScroll(row, height int) int {
+	return max(min(row, len(tv.lines)-height), 0)
+}
+
+// BeginSearch starts a new search from the bottom of the current view and clears any
+// highlighted match
+func (tv *TerminalView) BeginSearch() {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+
+	_, _, _, height := tv.GetInnerRect()
+	tv.search = terminalSearch{origin: min(tv.scrollOffsetRow+height, len(tv.lines)), row: -1}
+}
+
+// Search finds the latest line above where the search began that contains the query,
+// ignoring case, and scrolls to it, falling back to lines below. Called as the query is
+// typed, so each change starts over from the same place. Returns false if nothing matches.
+func (tv *TerminalView) Search(query string) bool {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+
+	tv.search.query = strings.ToLower(query)
+	tv.search.row = -1
+	if tv.search.query == "" {
+		return false
+	}
+	return tv.findMatch(tv.search.origin-1, math.MaxInt, true) || tv.findMatch(tv.search.origin, -1, false)
+}
+
+// SearchNext moves to the next match further back in the scrollback. Returns false if
+// there is no search or no earlier match.
+func (tv *TerminalView) SearchNext() bool {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+
+	if tv.search.query == "" {
+		return false
+	}
+	if tv.search.row < 0 {
+		return tv.findMatch(tv.search.origin-1, math.MaxInt, true)
+	}
+	return tv.findMatch(tv.search.row, tv.search.col, true)
+}
+
+// SearchPrevious moves to the previous match, nearer the latest output. Returns false if
+// there is no search or no later match.
+func (tv *TerminalView) SearchPrevious() bool {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+
+	if tv.search.query == "" {
+		return false
+	}
+	if tv.search.row < 0 {
+		return tv.findMatch(tv.search.origin, -1, false)
+	}
+	return tv.findMatch(tv.search.row, tv.search.col, false)
+}
+
+// ClearSearch removes the search and its highlight
+func (tv *TerminalView) ClearSearch() {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
+	tv.search = terminalSearch{row: -1}
+}
+
+// findMatch looks for the query from a position, back towards older lines or forward
+// towards newer ones, and scrolls the first match into the middle of the view. On the
+// starting row only matches before (back) or after col count, so repeating a search moves
+// past the current match.
+func (tv *TerminalView) findMatch(row, col int, back bool) bool {
+	step := 1
+	if back {
+		step = -1
+	}
+	for r := row; r >= 0 && r < len(tv.lines); r += step {
+		c, ok := tv.matchInLine(r, col, r == row, back)
+		if !ok {
+			continue
+		}
+		tv.search.row, tv.search.col = r, c
+		_, _, _, height := tv.GetInnerRect()
+		tv.scrollOffsetRow = tv.clampScroll(r-height/2, height)
+		return true
+	}
+	return false
+}
+
+// matchInLine returns the column of the last (back) or first match in a line, limited to
+// before or after col on the starting row
+func (tv *TerminalView) matchInLine(row, col int, startRow, back bool) (int, bool) {
+	text := lineText(tv.lines[row])
+	found := -1
+	for offset := 0; ; {
+		index := strings.Index(text[offset:], tv.search.query)
+		if index < 0 {
+			break
+		}
+		start := offset + index
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+
+		column := utf8.RuneCountInString(text[:start])
+		if back {
+			if startRow && column >= col {
+				break
+			}
+			found = column
+		} else if !startRow || column > col {
+			return column, true
+		}
+	}
+	return found, found >= 0
+}
+
+// lineText returns a line's text in lower case for searching, with blank cells as spaces
+func lineText(line []rune) string {
+	var sb strings.Builder
+	for _, char := range line {
+		if char == 0 {
+			char = ' '
+		}
+		sb.WriteRune(char)
+	}
+	return strings.ToLower(sb.String())
+}
+
+// searchHighlight returns the style for a cell, reversed if it is part of the current match
+func (tv *TerminalView) searchHighlight(row, col int, style tcell.Style) tcell.Style {
+	if row != tv.search.row || col < tv.search.col || col >= tv.search.col+utf8.RuneCountInString(tv.search.query) {
+		return style
+	}
+	return style.Reverse(true)
+}
@@ -0,0 +1,101 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTerminalViewScrollbackLimit(t *testing.T) {
+	tv := NewTerminalView()
+	tv.SetRect(0, 0, 40, 7) // Five rows inside the padding
+	tv.SetScrollbackLimit(20)
+
+	for i := 0; i < 50; i++ {
+		tv.Write([]byte(fmt.Sprintf("\x1b[32mLine %d\x1b[0m\r\n", i)))
+	}
+
+	if got := tv.GetLineCount(); got != 20 {
+		t.Fatalf("Expected 20 lines kept, got %d", got)
+	}
+	if got := strings.TrimRight(lineText(tv.lines[0]), " "); got != "line 30" {
+		t.Errorf("Expected the oldest lines dropped, first line is %q", got)
+	}
+	if _, y := tv.GetCursor(); y != 20 {
+		t.Errorf("Expected the cursor below the last line, got row %d", y)
+	}
+	if row, _ := tv.GetScrollOffset(); row != 15 {
+		t.Errorf("Expected the view at the bottom, got row %d", row)
+	}
+	if tv.colors[0][0] == tv.colors[0][10] {
+		t.Error("Expected kept lines to keep their color")
+	}
+}
+
+func TestTerminalViewScrollbackTrimsInBatches(t *testing.T) {
+	tv := NewTerminalView()
+	tv.SetRect(0, 0, 40, 7)
+	tv.SetScrollbackLimit(20)
+
+	for i := 0; i < 21; i++ {
+		tv.Write([]byte(fmt.Sprintf("Line %d\r\n", i)))
+	}
+	if got := tv.GetLineCount(); got != 18 {
+		t.Fatalf("Expected overflowing the limit to trim to 90%% of it, got %d lines", got)
+	}
+
+	// The lines freed by the trim are filled before the buffer is copied again
+	first := tv.lines[0]
+	tv.Write([]byte("Line 21\r\nLine 22\r\n"))
+	if got := tv.GetLineCount(); got != 20 {
+		t.Errorf("Expected 20 lines before the next trim, got %d", got)
+	}
+	if &tv.lines[0][0] != &first[0] {
+		t.Error("Expected writes below the limit not to trim")
+	}
+}
+
+func TestTerminalViewSearch(t *testing.T) {
+	tv := NewTerminalView()
+	tv.SetRect(0, 0, 40, 7)
+
+	for i := 0; i < 30; i++ {
+		text := fmt.Sprintf("Line %d", i)
+		if i == 5 || i == 12 {
+			text += " You have 100 Credits"
+		}
+		tv.Write([]byte(text + "\r\n"))
+	}
+
+	tv.BeginSearch()
+	if !tv.Search("credits") {
+		t.Fatal("Expected to find credits")
+	}
+	if tv.search.row != 12 || tv.search.col != 21 {
+		t.Errorf("Expected the latest match at 12,21, got %d,%d", tv.search.row, tv.search.col)
+	}
+	if row, _ := tv.GetScrollOffset(); row != 10 {
+		t.Errorf("Expected the match scrolled into view, got row %d", row)
+	}
+	if style := tv.searchHighlight(12, 21, tv.colors[12][21]); style == tv.colors[12][21] {
+		t.Error("Expected the match to be highlighted")
+	}
+
+	if !tv.SearchNext() || tv.search.row != 5 {
+		t.Errorf("Expected the next match back on line 5, got %d", tv.search.row)
+	}
+	if tv.SearchNext() || tv.search.row != 5 {
+		t.Error("Expected no match before line 5")
+	}
+	if !tv.SearchPrevious() || tv.search.row != 12 {
+		t.Errorf("Expected the previous match on line 12, got %d", tv.search.row)
+	}
+
+	if tv.Search("nothing here") {
+		t.Error("Expected no match")
+	}
+	tv.ClearSearch()
+	if tv.SearchNext() {
+		t.Error("Expected no search after clearing")
+	}
+}
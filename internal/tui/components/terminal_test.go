@@ -1,7 +1,10 @@
 package components
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 func TestTerminalViewANSIColors(t *testing.T) {
@@ -110,3 +113,28 @@ func TestTerminalViewInitialization(t *testing.T) {
 		t.Errorf("Invalid cursor position: (%d, %d)", x, y)
 	}
 }
+
+func TestTerminalViewWrapsAtPanelWidth(t *testing.T) {
+	tv := NewTerminalView()
+
+	// Draw into a panel 10 columns wide inside its padding
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("Failed to init screen: %v", err)
+	}
+	defer screen.Fini()
+	tv.SetRect(0, 0, 12, 10)
+	tv.Draw(screen)
+
+	tv.Write([]byte("\x1b[31m" + strings.Repeat("x", 15) + "\x1b[0m\r\n"))
+
+	if got := string(tv.lines[0][:10]); got != strings.Repeat("x", 10) {
+		t.Errorf("Expected 10 columns on the first line, got %q", got)
+	}
+	if got := string(tv.lines[1][:5]); got != strings.Repeat("x", 5) {
+		t.Errorf("Expected the rest wrapped to the second line, got %q", got)
+	}
+	if tv.colors[1][0] != tv.colors[0][0] {
+		t.Error("Expected the wrapped text to keep its color")
+	}
+}
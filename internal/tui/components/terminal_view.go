@@ -4,6 +4,7 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"twist/internal/ansi"
 	"twist/internal/theme"
 	"unicode/utf8"
@@ -35,10 +36,22 @@ type TerminalView struct {
 	scrollOffsetRow int
 	scrollOffsetCol int
 
+	// Scrollback - lines beyond the limit are dropped oldest first
+	scrollbackLimit int
+	search          terminalSearch
+
 	// ANSI sequence buffering
 	buffer    [8192]byte
 	bufferLen int
 
+	// Wraps incoming text at the panel width without splitting escape sequences. The
+	// width is recorded by Draw; until the first draw, lines wrap at the buffer width.
+	lineWrapper *ansi.Wrapper
+	drawnWidth  atomic.Int32
+
+	// Prefixes each line of incoming text with the time; nil when timestamps are off
+	lineStamper *ansi.LineStamper
+
 	// Synchronization
 	mutex sync.RWMutex
 
@@ -60,6 +73,10 @@ func NewTerminalView() *TerminalView {
 		height:        24,
 		scrollable:    true,
 		ansiConverter: ansi.NewColorConverter(),
+		lineWrapper:   ansi.NewWrapper(0),
+
+		scrollbackLimit: DefaultScrollbackLines,
+		search:          terminalSearch{row: -1},
 	}
 
 	// Apply theme colors
@@ -140,12 +157,19 @@ func (tv *TerminalView) Write(p []byte) (n int, err error) {
 	tv.mutex.Lock()
 	defer tv.mutex.Unlock()
 
-	// Process the data through ANSI sequence handling instead of just appending
-	tv.processDataWithANSI(p)
+	// Stamp line starts and wrap long lines at the panel width, then process the data
+	// through ANSI sequence handling instead of just appending
+	text := string(p)
+	if tv.lineStamper != nil {
+		text = tv.lineStamper.StampChunk(text)
+	}
+	tv.lineWrapper.SetWidth(tv.wrapWidth())
+	tv.processDataWithANSI([]byte(tv.lineWrapper.WrapChunk(text)))
+	_, _, _, height := tv.GetInnerRect()
+	tv.trimScrollback(height)
 
 	// Auto-scroll to bottom when new content is added (but only if not positioned elsewhere)
 	// This should happen during content addition, not during drawing
-	_, _, _, height := tv.GetInnerRect()
 	if len(tv.lines) > height {
 		// Original logic: cursor near bottom
 		// Additional fix: also autoscroll if we're already viewing near the bottom,
@@ -392,6 +416,7 @@ func (tv *TerminalView) Draw(screen tcell.Screen) {
 
 	tv.Box.DrawForSubclass(screen, tv)
 	x, y, width, height := tv.GetInnerRect()
+	tv.drawnWidth.Store(int32(width))
 
 	// If we have no content, just show empty terminal
 	if len(tv.lines) == 0 {
@@ -432,7 +457,7 @@ func (tv *TerminalView) Draw(screen tcell.Screen) {
 
 			screenX := x + (col - startCol)
 			char := line[col]
-			style := colors[col]
+			style := tv.searchHighlight(row, col, colors[col])
 
 			if char == 0 {
 				char = ' '
@@ -762,46 +787,49 @@ func (tv *TerminalView) Clear() *TerminalView {
 	tv.cursorY = 0
 	tv.scrollOffsetRow = 0
 	tv.scrollOffsetCol = 0
+	tv.search = terminalSearch{row: -1}
+	tv.lineWrapper.Reset()
+	if tv.lineStamper != nil {
+		tv.lineStamper.Reset()
+	}
+
+	return tv
+}
+
+// SetTimestampFormat prefixes each line of output written from now on with the local time
+// in format, a time layout such as "15:04:05". An empty format turns timestamps off.
+func (tv *TerminalView) SetTimestampFormat(format string) *TerminalView {
+	tv.mutex.Lock()
+	defer tv.mutex.Unlock()
 
+	tv.lineStamper = nil
+	if format != "" {
+		tv.lineStamper = ansi.NewLineStamper(format)
+	}
 	return tv
 }
 
+// wrapWidth returns the column incoming lines wrap at: the drawn panel width, capped at
+// the buffer width
+func (tv *TerminalView) wrapWidth() int {
+	if drawn := int(tv.drawnWidth.Load()); drawn > 0 && drawn < tv.width {
+		return drawn
+	}
+	return tv.width
+}
+
 // InputHandler handles key events for terminal scrolling
 func (tv *TerminalView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
 	return tv.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
 		tv.mutex.Lock()
 		defer tv.mutex.Unlock()
 
-		_, _, _, height := tv.GetInnerRect()
-		totalLines := len(tv.lines)
-
 		switch event.Key() {
 		case tcell.KeyPgUp:
-			// Page up - scroll up by two-thirds of page height
-			scrollAmount := height * 2 / 3
-			if scrollAmount < 1 {
-				scrollAmount = 1
-			}
-			newRow := tv.scrollOffsetRow - scrollAmount
-			if newRow < 0 {
-				newRow = 0
-			}
-			tv.scrollOffsetRow = newRow
+			tv.scrollPage(-1)
 
 		case tcell.KeyPgDn:
-			// Page down - scroll down by two-thirds of page height
-			scrollAmount := height * 2 / 3
-			if scrollAmount < 1 {
-				scrollAmount = 1
-			}
-			newRow := tv.scrollOffsetRow + scrollAmount
-			if newRow+height > totalLines {
-				newRow = totalLines - height
-				if newRow < 0 {
-					newRow = 0
-				}
-			}
-			tv.scrollOffsetRow = newRow
+			tv.scrollPage(1)
 
 		default:
 			// Give parent key handler a chance to handle the key first
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"strings"
+	"twist/internal/theme"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// TextInputDialog is a dialog that asks for a single line of text
+type TextInputDialog struct {
+	form           *tview.Form
+	callback       func(string)
+	cancelCallback func()
+}
+
+// NewTextInputDialog creates a dialog with one input field. callback receives the trimmed
+// text when OK is pressed with a non-empty value.
+func NewTextInputDialog(title, label string, callback func(string), cancelCallback func()) *TextInputDialog {
+	tid := &TextInputDialog{
+		callback:       callback,
+		cancelCallback: cancelCallback,
+	}
+
+	tid.form = theme.NewForm()
+	tid.form.SetTitle(" " + title + " ")
+	tid.form.SetTitleAlign(tview.AlignCenter)
+	tid.form.SetBorder(true)
+	tid.form.SetBorderPadding(1, 1, 2, 2)
+
+	tid.form.AddInputField(label, "", 40, nil, nil)
+
+	tid.form.AddButton("OK", func() {
+		text := strings.TrimSpace(tid.form.GetFormItem(0).(*tview.InputField).GetText())
+		if text != "" && tid.callback != nil {
+			tid.callback(text)
+		}
+	})
+
+	tid.form.AddButton("Cancel", func() {
+		if tid.cancelCallback != nil {
+			tid.cancelCallback()
+		}
+	})
+
+	tid.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if tid.cancelCallback != nil {
+				tid.cancelCallback()
+			}
+			return nil
+		}
+		return event
+	})
+
+	return tid
+}
+
+// SetChangedFunc sets a function called with the text each time it is edited
+func (tid *TextInputDialog) SetChangedFunc(handler func(string)) *TextInputDialog {
+	tid.form.GetFormItem(0).(*tview.InputField).SetChangedFunc(handler)
+	return tid
+}
+
+// SetDoneFunc sets a function to call when the dialog should be closed
+func (tid *TextInputDialog) SetDoneFunc(handler func()) InputDialog {
+	tid.form.SetCancelFunc(handler)
+	return tid
+}
+
+// GetView returns the main view component
+func (tid *TextInputDialog) GetView() tview.Primitive {
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tid.form, 60, 0, true).
+			AddItem(nil, 0, 1, false), 7, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	currentTheme := theme.Current()
+	flex.SetBackgroundColor(currentTheme.DialogColors().Background)
+
+	return flex
+}
+
+// GetForm returns the underlying tview.Form for display
+func (tid *TextInputDialog) GetForm() *tview.Form {
+	return tid.form
+}
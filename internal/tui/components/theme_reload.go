@@ -0,0 +1,83 @@
+package components
+
+import (
+	"container/list"
+	"twist/internal/log"
+	"twist/internal/theme"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Clear removes every cached item
+func (c *LRUCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// ApplyTheme recolors the map from the current theme. Cached images have the old theme's
+// background baked in, so the cache is dropped and the map redrawn.
+func (gsm *GraphvizSectorMap) ApplyTheme() {
+	currentTheme := theme.Current()
+	panelColors := currentTheme.PanelColors()
+
+	gsm.SetBackgroundColor(currentTheme.DefaultColors().Background)
+	gsm.SetBorderColor(panelColors.Border)
+	gsm.SetTitleColor(panelColors.Title)
+
+	gsm.graphCache.Clear()
+	gsm.currentHashKey = ""
+	gsm.needsRedraw = true
+	gsm.themeChanges++
+	if gsm.sixelLayer != nil {
+		gsm.sixelLayer.ClearRegion(gsm.regionID)
+		gsm.sixelLayer.SetRegionVisible(gsm.regionID, false)
+	}
+	log.Info("GraphvizSectorMap: Theme applied, graph cache cleared", "theme", currentTheme.Name())
+}
+
+// finishGeneration marks an async map generation complete. An image started before a theme
+// change has the old colors, so it is dropped and the map generated again.
+func (gsm *GraphvizSectorMap) finishGeneration(themeChanges int) {
+	gsm.isGenerating = false
+	if themeChanges != gsm.themeChanges {
+		gsm.graphCache.Clear()
+		gsm.currentHashKey = ""
+		return
+	}
+	gsm.needsRedraw = false
+	gsm.pendingRedraw = false
+}
+
+// lineColor returns the graphviz color for warps and node borders: white on dark
+// backgrounds and black on light ones
+func lineColor(background tcell.Color) string {
+	r, g, b := background.RGB()
+	if r*299+g*587+b*114 > 128*1000 {
+		return "black"
+	}
+	return "white"
+}
+
+// ApplyTheme recolors the panels and their map from the current theme
+func (pc *PanelComponent) ApplyTheme() {
+	panelColors := theme.Current().PanelColors()
+
+	pc.leftView.SetBackgroundColor(panelColors.Background)
+	pc.leftView.SetTextColor(panelColors.Foreground)
+	pc.leftView.SetBorderColor(panelColors.Border)
+	pc.leftView.SetTitleColor(panelColors.Title)
+	pc.leftWrapper.SetBackgroundColor(panelColors.Background)
+	pc.rightWrapper.SetBackgroundColor(panelColors.Background)
+
+	if pc.graphvizMap != nil {
+		pc.graphvizMap.ApplyTheme()
+	}
+}
+
+// ApplyTheme recolors the terminal's surroundings from the current theme
+func (tc *TerminalComponent) ApplyTheme() {
+	tc.wrapper.SetBackgroundColor(theme.Current().DefaultColors().Background)
+}
@@ -0,0 +1,66 @@
+package components
+
+import (
+	"testing"
+	"twist/internal/theme"
+)
+
+func TestGraphvizSectorMap_ApplyThemeClearsCache(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+	gsm.graphCache.Put("hash", &CachedGraphData{ImageData: []byte("png")})
+	gsm.currentHashKey = "hash"
+	gsm.needsRedraw = false
+
+	gsm.ApplyTheme()
+
+	if _, found := gsm.graphCache.Get("hash"); found {
+		t.Error("Expected cached images to be dropped after a theme change")
+	}
+	if gsm.currentHashKey != "" || !gsm.needsRedraw {
+		t.Error("Expected the map to be redrawn after a theme change")
+	}
+
+	// The cleared cache is still usable
+	gsm.graphCache.Put("next", &CachedGraphData{})
+	if _, found := gsm.graphCache.Get("next"); !found {
+		t.Error("Expected the cache to accept items after being cleared")
+	}
+}
+
+func TestGraphvizSectorMap_ThemeChangeDuringGeneration(t *testing.T) {
+	gsm := NewGraphvizSectorMap(nil, nil)
+
+	// A generation finishing in the same theme completes the redraw
+	gsm.isGenerating = true
+	gsm.finishGeneration(gsm.themeChanges)
+	if gsm.isGenerating || gsm.needsRedraw {
+		t.Error("Expected the redraw to be complete")
+	}
+
+	// One started before a theme change is redrawn in the new colors
+	started := gsm.themeChanges
+	gsm.isGenerating = true
+	gsm.ApplyTheme()
+	gsm.graphCache.Put("old-theme", &CachedGraphData{})
+	gsm.currentHashKey = "old-theme"
+	gsm.finishGeneration(started)
+
+	if gsm.isGenerating {
+		t.Error("Expected the generation to be marked complete")
+	}
+	if !gsm.needsRedraw || gsm.currentHashKey != "" {
+		t.Error("Expected the map to be generated again in the new theme")
+	}
+	if _, found := gsm.graphCache.Get("old-theme"); found {
+		t.Error("Expected the old theme's image to be dropped")
+	}
+}
+
+func TestLineColor(t *testing.T) {
+	if got := lineColor(theme.NewTelixTheme().DefaultColors().Background); got != "white" {
+		t.Errorf("Expected white lines on the dark theme, got %s", got)
+	}
+	if got := lineColor(theme.NewLightTheme().DefaultColors().Background); got != "black" {
+		t.Errorf("Expected black lines on the light theme, got %s", got)
+	}
+}
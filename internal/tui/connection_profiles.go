@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	coreapi "twist/internal/api"
+	"twist/internal/log"
+	"twist/internal/tui/components"
+	"twist/internal/tui/profiles"
+)
+
+// Entries added to the profile lists after the profiles themselves
+const (
+	otherServerItem = "Other Server..."
+	addProfileItem  = "Add Profile..."
+)
+
+// loadProfiles returns the user's connection profiles, or nil if the file is broken, so
+// saving can't overwrite it
+func loadProfiles() *profiles.Profiles {
+	ps, err := profiles.Load(profiles.DefaultFile)
+	if err != nil {
+		log.Warn("TwistApp: Could not load connection profiles", "file", profiles.DefaultFile, "error", err)
+		return nil
+	}
+	log.Info("TwistApp: Connection profiles loaded", "file", profiles.DefaultFile, "count", len(ps.All()))
+	return ps
+}
+
+// profileLabel is how a profile is listed
+func profileLabel(p profiles.Profile) string {
+	return fmt.Sprintf("%s (%s)", p.Name, p.Address())
+}
+
+// profileItems lists the profiles, returning their labels and the profile for each label
+func (ta *TwistApp) profileItems() ([]string, map[string]profiles.Profile) {
+	var all []profiles.Profile
+	if ta.profiles != nil {
+		all = ta.profiles.All()
+	}
+	items := make([]string, 0, len(all)+1)
+	byLabel := make(map[string]profiles.Profile, len(all))
+	for _, p := range all {
+		label := profileLabel(p)
+		items = append(items, label)
+		byLabel[label] = p
+	}
+	return items, byLabel
+}
+
+// showStartupDialog offers the saved profiles, or the connection dialog if there are none.
+// A server given on the command line is connected to instead.
+func (ta *TwistApp) showStartupDialog() {
+	if ta.startupAddress != "" {
+		log.Info("TwistApp: Connecting to the server given on the command line", "address", ta.startupAddress)
+		ta.connect(ta.startupAddress)
+		return
+	}
+	if items, _ := ta.profileItems(); len(items) == 0 {
+		ta.showConnectionDialog()
+		return
+	}
+	ta.showProfilePicker()
+}
+
+// showProfilePicker lists the profiles to connect to, switching servers if connected. With
+// no profiles yet it opens the profile manager to add one.
+func (ta *TwistApp) showProfilePicker() {
+	items, byLabel := ta.profileItems()
+	if len(items) == 0 {
+		ta.showProfileManager()
+		return
+	}
+	items = append(items, otherServerItem)
+
+	ta.ShowListModal("Connect to Profile", items, func(selected string) {
+		if selected == otherServerItem {
+			ta.showConnectionDialog()
+			return
+		}
+		ta.connectProfile(byLabel[selected])
+	})
+}
+
+// showProfileManager lists the profiles to connect to, edit or delete, and adds new ones
+func (ta *TwistApp) showProfileManager() {
+	if ta.profiles == nil {
+		ta.showMessage(fmt.Sprintf("Could not read %s.\n\nFix or remove it to manage profiles; the log has details.",
+			profiles.DefaultFile), "Connection Profiles")
+		return
+	}
+
+	items, byLabel := ta.profileItems()
+	items = append(items, addProfileItem)
+
+	ta.ShowListModal("Connection Profiles", items, func(selected string) {
+		if selected == addProfileItem {
+			ta.showProfileDialog("Add Profile", profiles.Profile{}, "")
+			return
+		}
+		ta.showProfileActions(byLabel[selected])
+	})
+}
+
+// showProfileActions asks what to do with a profile
+func (ta *TwistApp) showProfileActions(p profiles.Profile) {
+	game := p.Game
+	if game == "" {
+		game = "detected from the server's menus"
+	}
+	details := fmt.Sprintf("%s\n\nGame database: %s", p.Address(), game)
+	if p.Transport != "" && p.Transport != coreapi.TransportTCP {
+		details += "\nTransport: " + strings.ToUpper(p.Transport)
+	}
+	if p.Script != "" {
+		details += "\nScript: " + p.Script
+	}
+
+	ta.ShowModal(p.Name, details, []string{"Connect", "Edit", "Delete", "Cancel"},
+		func(buttonIndex int, buttonLabel string) {
+			ta.closeModal()
+			switch buttonLabel {
+			case "Connect":
+				ta.connectProfile(p)
+			case "Edit":
+				ta.showProfileDialog("Edit Profile", p, p.Name)
+			case "Delete":
+				ta.confirmDeleteProfile(p)
+			}
+		})
+}
+
+// confirmDeleteProfile deletes a profile once the user confirms. Its game database is kept.
+func (ta *TwistApp) confirmDeleteProfile(p profiles.Profile) {
+	ta.ShowModal("Delete Profile", fmt.Sprintf("Delete profile %s?\n\nIts game database is kept.", p.Name),
+		[]string{"Delete", "Cancel"},
+		func(buttonIndex int, buttonLabel string) {
+			ta.closeModal()
+			if buttonLabel != "Delete" {
+				return
+			}
+			if err := ta.profiles.Delete(p.Name); err != nil {
+				ta.showMessage(fmt.Sprintf("Could not delete profile: %v", err), "Connection Profiles")
+				return
+			}
+			log.Info("TwistApp: Connection profile deleted", "profile", p.Name)
+		})
+}
+
+// showProfileDialog edits a profile and saves it. previousName is the profile being edited,
+// empty when adding. If saving fails the error is shown and the dialog reopens.
+func (ta *TwistApp) showProfileDialog(title string, p profiles.Profile, previousName string) {
+	dialog := components.NewProfileDialog(title, p,
+		func(edited profiles.Profile) {
+			ta.closeModal()
+			if err := ta.profiles.Save(edited, previousName); err != nil {
+				ta.ShowModal(title, fmt.Sprintf("Could not save profile: %v", err), []string{"OK"},
+					func(buttonIndex int, buttonLabel string) {
+						ta.closeModal()
+						ta.showProfileDialog(title, edited, previousName)
+					})
+				return
+			}
+			log.Info("TwistApp: Connection profile saved", "profile", edited.Name)
+		},
+		func() {
+			ta.closeModal()
+		})
+	ta.ShowInputDialog("profile-dialog", dialog)
+}
+
+// connectProfile connects to a profile's server with its game database and script. When
+// already connected it disconnects first and connects once the old connection is down.
+func (ta *TwistApp) connectProfile(p profiles.Profile) {
+	if ta.proxyClient.IsConnected() {
+		log.Info("TwistApp: Switching to connection profile", "profile", p.Name, "from", ta.serverAddress)
+		ta.pendingProfile = &p
+		ta.disconnect()
+		return
+	}
+
+	log.Info("TwistApp: Connecting to profile", "profile", p.Name, "address", p.Address())
+	opts := ta.connectOptions()
+	// Only a profile naming its game pins the database; otherwise the game is detected
+	opts.GameName = p.Game
+	opts.Transport = p.TransportOptions()
+	if p.Script != "" {
+		opts.ScriptName = p.Script
+	}
+	ta.connectWithOptions(p.Address(), opts)
+}
+
+// connectPendingProfile connects to the profile a switch was waiting on, once disconnected
+func (ta *TwistApp) connectPendingProfile() {
+	p := ta.pendingProfile
+	if p == nil {
+		return
+	}
+	ta.pendingProfile = nil
+	ta.connectProfile(*p)
+}
+
+// ShowProfileManager displays the connection profiles to connect to, add, edit or delete
+func (ta *TwistApp) ShowProfileManager() {
+	ta.showProfileManager()
+}
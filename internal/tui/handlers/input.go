@@ -104,32 +104,6 @@ func (ih *InputHandler) HandleKeyEvent(event *tcell.EventKey) *tcell.EventKey {
 
 // handleMenuInput handles input in menu mode
 func (ih *InputHandler) handleMenuInput(event *tcell.EventKey) *tcell.EventKey {
-	// Handle Alt+Letter combinations
-	if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 {
-		switch event.Rune() {
-		case 's', 'S':
-			ih.showSessionMenu()
-			return nil
-		case 'v', 'V':
-			ih.showViewMenu()
-			return nil
-		case 't', 'T':
-			ih.showTerminalMenu()
-			return nil
-		case 'h', 'H':
-			ih.showHelpMenu()
-			return nil
-		case 'c', 'C':
-			ih.showConnectDialog()
-			return nil
-		case 'd', 'D':
-			if ih.onDisconnect != nil {
-				ih.onDisconnect()
-			}
-			return nil
-		}
-	}
-
 	switch event.Key() {
 	case tcell.KeyTab:
 		ih.SetInputMode(InputModeTerminal)
@@ -141,32 +115,6 @@ func (ih *InputHandler) handleMenuInput(event *tcell.EventKey) *tcell.EventKey {
 
 // handleTerminalInput handles input in terminal mode
 func (ih *InputHandler) handleTerminalInput(event *tcell.EventKey) *tcell.EventKey {
-	// Handle Alt+Letter combinations for menu access
-	if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 {
-		switch event.Rune() {
-		case 's', 'S':
-			ih.showSessionMenu()
-			return nil
-		case 'v', 'V':
-			ih.showViewMenu()
-			return nil
-		case 't', 'T':
-			ih.showTerminalMenu()
-			return nil
-		case 'h', 'H':
-			ih.showHelpMenu()
-			return nil
-		case 'c', 'C':
-			ih.showConnectDialog()
-			return nil
-		case 'd', 'D':
-			if ih.onDisconnect != nil {
-				ih.onDisconnect()
-			}
-			return nil
-		}
-	}
-
 	// Don't send Control key combinations (except let tview handle them)
 	if event.Modifiers()&tcell.ModCtrl != 0 {
 		return event
@@ -218,42 +166,3 @@ func (ih *InputHandler) handleModalInput(event *tcell.EventKey) *tcell.EventKey
 	// This allows Enter, Tab, typing, etc. to work in modal dialogs
 	return event
 }
-
-// Menu display functions
-func (ih *InputHandler) showSessionMenu() {
-	ih.showMenu("Session")
-}
-
-func (ih *InputHandler) showViewMenu() {
-	ih.showMenu("View")
-}
-
-func (ih *InputHandler) showTerminalMenu() {
-	ih.showMenu("Terminal")
-}
-
-func (ih *InputHandler) showHelpMenu() {
-	ih.showMenu("Help")
-}
-
-// showMenu displays any menu using the centralized menu system
-func (ih *InputHandler) showMenu(menuName string) {
-	if ih.onShowDropdown != nil {
-		// Use empty options - showDropdownMenu in app.go will get the real items from the menu manager
-		ih.onShowDropdown(menuName, []string{}, func(selected string) {
-			// This callback is not used - showDropdownMenu handles everything
-		})
-	}
-}
-
-func (ih *InputHandler) showConnectDialog() {
-	if ih.onShowConnectionDialog != nil {
-		ih.onShowConnectionDialog()
-	} else {
-		// Fallback to direct connection
-		if ih.onConnect != nil {
-			ih.onConnect("twgs.geekm0nkey.com:23")
-		} else {
-		}
-	}
-}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"twist/internal/tui/keymap"
+)
+
+// scriptingHelp covers the basics of running scripts beyond the terminal menus, shown
+// under its own heading
+var scriptingHelp = []string{
+	"? = Show help for the open terminal menu",
+	"\\ = Cancel typing a value in the terminal menu and go back to it",
+	"Scripts > List = See the running scripts",
+	"ESC = Stop all scripts when no dialog is open",
+}
+
+// helpLine is one line of the help overlay. Headings stay above their lines when
+// searching.
+type helpLine struct {
+	text    string
+	heading bool
+}
+
+// helpLines builds the help overlay from the bound keys and registered menu items, so it
+// always matches what the application does
+func (ta *TwistApp) helpLines() []helpLine {
+	lines := []helpLine{{text: "Keys", heading: true}}
+	for _, text := range strings.Split(ta.keymap.Help(), "\n") {
+		lines = append(lines, helpLine{text: text})
+	}
+	lines = append(lines,
+		helpLine{text: "ESC = Close dialogs or stop all scripts"},
+		helpLine{text: "Ctrl+C = Exit"},
+		helpLine{text: "Keys can be changed in " + keymap.DefaultFile})
+
+	for _, name := range ta.menuManager.GetMenuNames() {
+		lines = append(lines, helpLine{text: name + " menu", heading: true})
+		for _, item := range ta.menuManager.GetMenuItems(name) {
+			text := item.Label
+			if item.Shortcut != "" {
+				text += " = " + item.Shortcut
+			}
+			lines = append(lines, helpLine{text: text})
+		}
+	}
+
+	// Terminal menus open with keys typed while connected
+	for _, menu := range ta.proxyClient.TerminalMenus() {
+		lines = append(lines, helpLine{text: menu.Title + " (" + menu.Keys + ")", heading: true})
+		for _, item := range menu.Items {
+			lines = append(lines, helpLine{text: menu.Keys + " " + item.Hotkey + " = " + item.Description})
+		}
+	}
+
+	lines = append(lines, helpLine{text: "Scripting", heading: true})
+	for _, text := range scriptingHelp {
+		lines = append(lines, helpLine{text: text})
+	}
+	return lines
+}
+
+// filterHelpLines keeps the lines containing the query, ignoring case, under their
+// headings. A heading that matches keeps all of its lines.
+func filterHelpLines(lines []helpLine, query string) []helpLine {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return lines
+	}
+
+	var filtered []helpLine
+	var heading *helpLine
+	headingMatches := false
+	for i, line := range lines {
+		matches := strings.Contains(strings.ToLower(line.text), query)
+		if line.heading {
+			heading = &lines[i]
+			headingMatches = matches
+			if matches {
+				filtered = append(filtered, line)
+			}
+			continue
+		}
+		if !matches && !headingMatches {
+			continue
+		}
+		if heading != nil && !headingMatches {
+			filtered = append(filtered, *heading)
+			heading = nil
+		}
+		filtered = append(filtered, line)
+	}
+	return filtered
+}
+
+// helpText formats help lines for a text view, with a blank line before each heading
+func helpText(lines []helpLine) string {
+	var text strings.Builder
+	for i, line := range lines {
+		if line.heading {
+			if i > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString("[::b]" + tview.Escape(line.text) + "[::-]\n")
+			continue
+		}
+		text.WriteString("  " + tview.Escape(line.text) + "\n")
+	}
+	if len(lines) == 0 {
+		text.WriteString("No help matches the search.")
+	}
+	return text.String()
+}
+
+// showHelpOverlay shows the keys, menus and scripting basics full screen, filtered as a
+// search is typed. ESC closes it.
+func (ta *TwistApp) showHelpOverlay() {
+	// Close any existing dropdown menus before showing help
+	ta.pages.RemovePage("dropdown-menu")
+	if ta.menuComponent.IsDropdownVisible() {
+		ta.menuComponent.HideDropdown()
+	}
+
+	lines := ta.helpLines()
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(helpText(lines))
+	view.SetBorder(true).SetTitle(" TWIST Help - ESC to close ")
+
+	search := tview.NewInputField().SetLabel("Search: ")
+	search.SetChangedFunc(func(query string) {
+		view.SetText(helpText(filterHelpLines(lines, query)))
+		view.ScrollToBeginning()
+	})
+	// The search field keeps focus, so it passes scrolling keys on to the text
+	search.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			view.InputHandler()(event, func(tview.Primitive) {})
+			return nil
+		}
+		return event
+	})
+
+	overlay := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, false).
+		AddItem(search, 1, 0, true)
+
+	ta.pages.AddPage("help-modal", overlay, true, true)
+	ta.modalVisible = true
+	ta.app.SetFocus(search)
+}
+
+// ShowHelp opens the help overlay, for the Help menu
+func (ta *TwistApp) ShowHelp() {
+	ta.showHelpOverlay()
+}
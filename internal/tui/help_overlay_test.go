@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"twist/internal/tui/keymap"
+	"twist/internal/tui/menus"
+)
+
+func TestHelpLinesFromKeymapAndMenus(t *testing.T) {
+	ta := &TwistApp{keymap: keymap.Default(), menuManager: menus.NewMenuManager()}
+	text := helpText(ta.helpLines())
+
+	for _, want := range []string{"F1 = Help", "[::b]Session menu[::-]", "Connect", "[::b]TWX Data Menu ($ V)[::-]", "$ V N = Sector note", "$ L = Load Script", "[::b]Scripting[::-]"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected help to contain %q", want)
+		}
+	}
+}
+
+func TestFilterHelpLines(t *testing.T) {
+	lines := []helpLine{
+		{text: "Keys", heading: true},
+		{text: "F1 = Help"},
+		{text: "Ctrl+F = Search terminal"},
+		{text: "Scripts menu", heading: true},
+		{text: "List"},
+		{text: "Stop All Scripts = Esc"},
+	}
+
+	got := filterHelpLines(lines, "SEARCH")
+	if len(got) != 2 || got[0].text != "Keys" || got[1].text != "Ctrl+F = Search terminal" {
+		t.Errorf("Expected the match under its heading, got %+v", got)
+	}
+
+	got = filterHelpLines(lines, "scripts")
+	if len(got) != 3 || got[0].text != "Scripts menu" {
+		t.Errorf("Expected a matching heading to keep its lines, got %+v", got)
+	}
+
+	if got = filterHelpLines(lines, "  "); len(got) != len(lines) {
+		t.Errorf("Expected a blank search to keep every line, got %d", len(got))
+	}
+	if got = filterHelpLines(lines, "nothing"); len(got) != 0 {
+		t.Errorf("Expected no lines, got %+v", got)
+	}
+	if text := helpText(nil); text != "No help matches the search." {
+		t.Errorf("Expected the no-match note, got %q", text)
+	}
+}
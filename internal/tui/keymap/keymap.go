@@ -0,0 +1,303 @@
+// Package keymap maps keys to TUI actions, with defaults users can override from a file
+package keymap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// DefaultFile is the keybinding file read from the working directory at startup, if present
+const DefaultFile = "twist_keys.json"
+
+// Action names a TUI command that can be bound to a key
+type Action string
+
+// Actions that can be bound to keys
+const (
+	SessionMenu            Action = "session_menu"
+	ViewMenu               Action = "view_menu"
+	ScriptsMenu            Action = "scripts_menu"
+	TerminalMenu           Action = "terminal_menu"
+	HelpMenu               Action = "help_menu"
+	Connect                Action = "connect"
+	Profiles               Action = "profiles"
+	Disconnect             Action = "disconnect"
+	Quit                   Action = "quit"
+	Help                   Action = "help"
+	ShowSector             Action = "show_sector"
+	TogglePanels           Action = "toggle_panels"
+	NextTheme              Action = "next_theme"
+	MapDepthDown           Action = "map_depth_down"
+	MapDepthUp             Action = "map_depth_up"
+	ToggleSessionHighlight Action = "toggle_session_highlight"
+	FocusMapSector         Action = "focus_map_sector"
+	ScrollUp               Action = "scroll_up"
+	ScrollDown             Action = "scroll_down"
+	SearchTerminal         Action = "search_terminal"
+	SearchNext             Action = "search_next"
+	SearchPrevious         Action = "search_previous"
+)
+
+// binding is an action's default key and the description shown in help
+type binding struct {
+	action      Action
+	key         string
+	description string
+}
+
+// bindings lists every action in the order help shows them
+var bindings = []binding{
+	{SessionMenu, "Alt+S", "Session menu"},
+	{ViewMenu, "Alt+V", "View menu"},
+	{ScriptsMenu, "Alt+R", "Scripts menu"},
+	{TerminalMenu, "Alt+T", "Terminal menu"},
+	{HelpMenu, "Alt+H", "Help menu"},
+	{Connect, "Alt+C", "Connect"},
+	{Profiles, "F8", "Connect to a profile"},
+	{Disconnect, "Alt+D", "Disconnect"},
+	{Quit, "Alt+Q", "Quit"},
+	{Help, "F1", "Help (this screen)"},
+	{ShowSector, "Ctrl+S", "Show current sector"},
+	{TogglePanels, "F2", "Show or hide panels"},
+	{MapDepthDown, "F5", "Show fewer hops on the map"},
+	{MapDepthUp, "F6", "Show more hops on the map"},
+	{ToggleSessionHighlight, "F7", "Highlight sectors new this session"},
+	{FocusMapSector, "Ctrl+G", "Center the map on a sector"},
+	{NextTheme, "F12", "Switch theme"},
+	{ScrollUp, "PgUp", "Scroll the terminal back"},
+	{ScrollDown, "PgDn", "Scroll the terminal forward"},
+	{SearchTerminal, "Ctrl+F", "Search the terminal's scrollback"},
+	{SearchNext, "F3", "Find the next earlier match"},
+	{SearchPrevious, "F4", "Find the next later match"},
+}
+
+// reservedKeys can't be bound: Ctrl+C always quits, and the others are the terminal's
+// backspace, tab and enter
+var reservedKeys = map[string]bool{"ctrl+c": true, "ctrl+h": true, "ctrl+i": true, "ctrl+m": true}
+
+// Keymap holds the active key for each action
+type Keymap struct {
+	keys    map[Action]string // Display form, e.g. "Alt+S"; empty if unbound
+	actions map[string]Action // Normalized key, e.g. "alt+s", to its action
+}
+
+// Default returns the built-in keybindings
+func Default() *Keymap {
+	km := &Keymap{keys: make(map[Action]string), actions: make(map[string]Action)}
+	for _, b := range bindings {
+		normalized, display, _ := parseKey(b.key)
+		km.keys[b.action] = display
+		km.actions[normalized] = b.action
+	}
+	return km
+}
+
+// Load reads a keybinding file and applies it over the defaults. The file maps action
+// names to keys; an empty key unbinds the action:
+//
+//	{"show_sector": "F3", "toggle_panels": "Alt+P", "next_theme": ""}
+//
+// Unknown actions, keys that can't be bound and keys bound to two actions are rejected.
+func Load(path string) (*Keymap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse keybindings %s: %w", path, err)
+	}
+
+	km, err := apply(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keybindings %s: %w", path, err)
+	}
+	return km, nil
+}
+
+// apply builds a keymap from the defaults with the overrides applied
+func apply(overrides map[string]string) (*Keymap, error) {
+	known := make(map[Action]binding, len(bindings))
+	for _, b := range bindings {
+		known[b.action] = b
+	}
+
+	var errs []error
+	keys := make(map[Action]string, len(bindings))
+	for _, b := range bindings {
+		keys[b.action] = b.key
+	}
+	for _, name := range sortedKeys(overrides) {
+		if _, ok := known[Action(name)]; !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q", name))
+			continue
+		}
+		keys[Action(name)] = overrides[name]
+	}
+
+	km := &Keymap{keys: make(map[Action]string), actions: make(map[string]Action)}
+	for _, b := range bindings {
+		if keys[b.action] == "" {
+			km.keys[b.action] = ""
+			continue
+		}
+		normalized, display, err := parseKey(keys[b.action])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", b.action, err))
+			continue
+		}
+		if other, taken := km.actions[normalized]; taken {
+			errs = append(errs, fmt.Errorf("%s is bound to both %s and %s", display, other, b.action))
+			continue
+		}
+		km.keys[b.action] = display
+		km.actions[normalized] = b.action
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// Key returns the key bound to an action, such as "Alt+S", or "" if it is unbound
+func (km *Keymap) Key(action Action) string {
+	return km.keys[action]
+}
+
+// ActionFor returns the action bound to a key event
+func (km *Keymap) ActionFor(event *tcell.EventKey) (Action, bool) {
+	key := eventKey(event)
+	if key == "" {
+		return "", false
+	}
+	action, ok := km.actions[key]
+	return action, ok
+}
+
+// Help lists the bound keys and their actions, one "Key = description" per line
+func (km *Keymap) Help() string {
+	var lines []string
+	for _, b := range bindings {
+		if key := km.keys[b.action]; key != "" {
+			lines = append(lines, key+" = "+b.description)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// modifierOrder is the order modifiers appear in normalized and display keys
+var modifierOrder = []string{"ctrl", "alt", "shift"}
+
+// pageKeys are the paging keys that can be bound, by name with their display form
+var pageKeys = map[string]string{"pgup": "PgUp", "pgdn": "PgDn"}
+
+// parseKey checks a key such as "Alt+S" or "Shift+F5" can be bound, returning its
+// normalized form for matching events and its display form for help and menus.
+// Letters and digits need Ctrl or Alt so typing still reaches the game.
+func parseKey(key string) (normalized, display string, err error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(key)), "+")
+	name := parts[len(parts)-1]
+
+	modifiers := make(map[string]bool)
+	for _, modifier := range parts[:len(parts)-1] {
+		if modifier != "ctrl" && modifier != "alt" && modifier != "shift" {
+			return "", "", fmt.Errorf("unknown modifier %q in %q", modifier, key)
+		}
+		modifiers[modifier] = true
+	}
+
+	switch {
+	case isFunctionKey(name), pageKeys[name] != "":
+	case len(name) == 1 && name[0] >= 'a' && name[0] <= 'z':
+		if modifiers["shift"] || modifiers["ctrl"] == modifiers["alt"] {
+			return "", "", fmt.Errorf("%q: letters need exactly one of Ctrl or Alt", key)
+		}
+	case len(name) == 1 && name[0] >= '0' && name[0] <= '9':
+		if len(modifiers) != 1 || !modifiers["alt"] {
+			return "", "", fmt.Errorf("%q: digits need Alt", key)
+		}
+	default:
+		return "", "", fmt.Errorf("unknown key %q", key)
+	}
+
+	var normalizedParts, displayParts []string
+	for _, modifier := range modifierOrder {
+		if modifiers[modifier] {
+			normalizedParts = append(normalizedParts, modifier)
+			displayParts = append(displayParts, strings.ToUpper(modifier[:1])+modifier[1:])
+		}
+	}
+	normalized = strings.Join(append(normalizedParts, name), "+")
+	displayName := strings.ToUpper(name)
+	if pageKey, ok := pageKeys[name]; ok {
+		displayName = pageKey
+	}
+	display = strings.Join(append(displayParts, displayName), "+")
+	if reservedKeys[normalized] {
+		return "", "", fmt.Errorf("%s is reserved", display)
+	}
+	return normalized, display, nil
+}
+
+// isFunctionKey returns true for "f1" through "f12"
+func isFunctionKey(name string) bool {
+	for i := 1; i <= 12; i++ {
+		if name == fmt.Sprintf("f%d", i) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventKey returns a key event in the normalized form parseKey produces, or "" for keys
+// that can't be bound
+func eventKey(event *tcell.EventKey) string {
+	var name string
+	modifiers := event.Modifiers()
+	switch {
+	case event.Key() >= tcell.KeyF1 && event.Key() <= tcell.KeyF12:
+		name = fmt.Sprintf("f%d", event.Key()-tcell.KeyF1+1)
+	case event.Key() == tcell.KeyPgUp:
+		name = "pgup"
+	case event.Key() == tcell.KeyPgDn:
+		name = "pgdn"
+	case event.Key() >= tcell.KeyCtrlA && event.Key() <= tcell.KeyCtrlZ:
+		// Control letters arrive as their own keys; any other modifiers are ignored
+		return "ctrl+" + string(rune('a'+event.Key()-tcell.KeyCtrlA))
+	case event.Key() == tcell.KeyRune && modifiers&tcell.ModAlt != 0:
+		name = strings.ToLower(string(event.Rune()))
+		modifiers &^= tcell.ModShift // Alt+Shift+S is Alt+S
+	default:
+		return ""
+	}
+
+	var parts []string
+	if modifiers&tcell.ModCtrl != 0 {
+		parts = append(parts, "ctrl")
+	}
+	if modifiers&tcell.ModAlt != 0 {
+		parts = append(parts, "alt")
+	}
+	if modifiers&tcell.ModShift != 0 {
+		parts = append(parts, "shift")
+	}
+	return strings.Join(append(parts, name), "+")
+}
+
+// sortedKeys returns the map's keys in order, so errors are reported consistently
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package keymap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func writeKeysFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write keybindings: %v", err)
+	}
+	return path
+}
+
+func TestDefaultBindings(t *testing.T) {
+	km := Default()
+
+	tests := []struct {
+		event *tcell.EventKey
+		want  Action
+	}{
+		{tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt), SessionMenu},
+		{tcell.NewEventKey(tcell.KeyRune, 'S', tcell.ModAlt|tcell.ModShift), SessionMenu},
+		{tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl), ShowSector},
+		{tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone), Help},
+		{tcell.NewEventKey(tcell.KeyF12, 0, tcell.ModNone), NextTheme},
+		{tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone), ScrollUp},
+		{tcell.NewEventKey(tcell.KeyCtrlF, 0, tcell.ModCtrl), SearchTerminal},
+	}
+	for _, tt := range tests {
+		if action, ok := km.ActionFor(tt.event); !ok || action != tt.want {
+			t.Errorf("%s: expected %s, got %q", tt.event.Name(), tt.want, action)
+		}
+	}
+
+	// Typing reaches the game
+	for _, event := range []*tcell.EventKey{
+		tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModShift),
+	} {
+		if action, ok := km.ActionFor(event); ok {
+			t.Errorf("%s: expected no action, got %s", event.Name(), action)
+		}
+	}
+
+	if km.Key(ShowSector) != "Ctrl+S" {
+		t.Errorf("Expected Ctrl+S for show_sector, got %q", km.Key(ShowSector))
+	}
+	if km.Key(ScrollDown) != "PgDn" {
+		t.Errorf("Expected PgDn for scroll_down, got %q", km.Key(ScrollDown))
+	}
+	if !strings.Contains(km.Help(), "Alt+S = Session menu\n") {
+		t.Errorf("Expected the session menu in help, got:\n%s", km.Help())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	km, err := Load(writeKeysFile(t, `{"show_sector": "shift+f3", "session_menu": "ctrl+s", "next_theme": ""}`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if action, _ := km.ActionFor(tcell.NewEventKey(tcell.KeyF3, 0, tcell.ModShift)); action != ShowSector {
+		t.Errorf("Expected Shift+F3 to show the sector, got %q", action)
+	}
+	if action, _ := km.ActionFor(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)); action != SessionMenu {
+		t.Errorf("Expected Ctrl+S to open the session menu, got %q", action)
+	}
+	if _, ok := km.ActionFor(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt)); ok {
+		t.Error("Expected the old session menu key to be free")
+	}
+	if _, ok := km.ActionFor(tcell.NewEventKey(tcell.KeyF12, 0, tcell.ModNone)); ok || km.Key(NextTheme) != "" {
+		t.Error("Expected next_theme to be unbound")
+	}
+	if km.Key(ShowSector) != "Shift+F3" || km.Key(Quit) != "Alt+Q" {
+		t.Errorf("Unexpected keys %q and %q", km.Key(ShowSector), km.Key(Quit))
+	}
+	if strings.Contains(km.Help(), "Switch theme") {
+		t.Error("Unbound actions should be left out of help")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown action", `{"warp_drive": "F3"}`, `unknown action "warp_drive"`},
+		{"conflict with default", `{"show_sector": "Alt+S"}`, "Alt+S is bound to both session_menu and show_sector"},
+		{"conflict", `{"help": "F3", "quit": "f3"}`, "F3 is bound to both quit and help"},
+		{"bare letter", `{"help": "h"}`, "letters need exactly one of Ctrl or Alt"},
+		{"reserved", `{"quit": "Ctrl+C"}`, "Ctrl+C is reserved"},
+		{"unknown key", `{"quit": "Alt+Space"}`, `unknown key "Alt+Space"`},
+		{"unknown modifier", `{"quit": "Meta+Q"}`, `unknown modifier "meta"`},
+		{"not json", "{", "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		_, err := Load(writeKeysFile(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), DefaultFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file to report not exist, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"twist/internal/log"
+	"twist/internal/tui/keymap"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// loadKeymap returns the user's keybindings file applied over the defaults. A missing or
+// broken file leaves the defaults in place.
+func loadKeymap() *keymap.Keymap {
+	km, err := keymap.Load(keymap.DefaultFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("TwistApp: Could not load keybindings", "file", keymap.DefaultFile, "error", err)
+		}
+		return keymap.Default()
+	}
+	log.Info("TwistApp: Keybindings loaded", "file", keymap.DefaultFile)
+	return km
+}
+
+// handleBoundKey runs the action bound to a key event, returning true if it was handled.
+// Nothing runs while a modal is open, so keys typed into dialogs stay with the dialog.
+func (ta *TwistApp) handleBoundKey(event *tcell.EventKey) bool {
+	if ta.modalVisible {
+		return false
+	}
+	action, ok := ta.keymap.ActionFor(event)
+	if !ok {
+		return false
+	}
+
+	switch action {
+	case keymap.Quit:
+		ta.exit()
+	case keymap.Help:
+		ta.showHelpOverlay()
+	case keymap.NextTheme:
+		ta.CycleTheme()
+	case keymap.TogglePanels:
+		if ta.panelsVisible {
+			ta.hidePanels()
+		} else {
+			ta.showPanels()
+		}
+	case keymap.MapDepthDown, keymap.MapDepthUp:
+		delta := 1
+		if action == keymap.MapDepthDown {
+			delta = -1
+		}
+		ta.panelComponent.ChangeMapDepth(delta)
+	case keymap.ToggleSessionHighlight:
+		ta.panelComponent.ToggleSessionHighlight()
+	case keymap.SessionMenu:
+		ta.showMenu("Session")
+	case keymap.ViewMenu:
+		ta.showMenu("View")
+	case keymap.ScriptsMenu:
+		ta.showMenu("Scripts")
+	case keymap.TerminalMenu:
+		ta.showMenu("Terminal")
+	case keymap.HelpMenu:
+		ta.showMenu("Help")
+	case keymap.Connect:
+		ta.showConnectionDialog()
+	case keymap.Profiles:
+		ta.showProfilePicker()
+	case keymap.Disconnect:
+		ta.disconnect()
+	case keymap.ShowSector:
+		ta.showCurrentSector()
+	case keymap.FocusMapSector:
+		ta.showMapFocus()
+	case keymap.ScrollUp:
+		ta.terminalComponent.ScrollPage(-1)
+	case keymap.ScrollDown:
+		ta.terminalComponent.ScrollPage(1)
+	case keymap.SearchTerminal:
+		ta.showTerminalSearch()
+	case keymap.SearchNext:
+		ta.terminalComponent.SearchNext()
+	case keymap.SearchPrevious:
+		ta.terminalComponent.SearchPrevious()
+	}
+	return true
+}
+
+// showMenu opens a menu's dropdown; its items come from the menu manager
+func (ta *TwistApp) showMenu(menuName string) {
+	ta.showDropdownMenu(menuName, nil, func(string) {})
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"strconv"
+
+	"twist/internal/tui/components"
+)
+
+// Titles for the map focus dialog, depending on whether the text is a sector number
+const (
+	mapFocusTitle        = " Center Map "
+	mapFocusInvalidTitle = " Center Map - not a sector "
+)
+
+// showMapFocus asks for a sector to center the sector map on, to look around it without
+// moving there. 0 centers the map on the player again.
+func (ta *TwistApp) showMapFocus() {
+	var dialog *components.TextInputDialog
+	dialog = components.NewTextInputDialog("Center Map", "Sector (0 for yours):",
+		func(text string) {
+			sector, err := strconv.Atoi(text)
+			if err != nil || sector < 0 {
+				dialog.GetForm().SetTitle(mapFocusInvalidTitle)
+				return
+			}
+			ta.panelComponent.FocusMapSector(sector)
+			ta.closeModal()
+		},
+		func() {
+			ta.closeModal()
+		})
+	dialog.SetChangedFunc(func(string) {
+		dialog.GetForm().SetTitle(mapFocusTitle)
+	})
+	ta.ShowInputDialog("text-input-dialog", dialog)
+}
@@ -0,0 +1,56 @@
+package menus
+
+import (
+	"fmt"
+	"strings"
+	coreapi "twist/internal/api"
+)
+
+// explorationBarWidth is how many cells the exploration progress bar fills at 100%
+const explorationBarWidth = 30
+
+// handleExploration shows how much of the universe has been mapped
+func (v *ViewMenu) handleExploration(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showExplorationMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	stats, err := proxyAPI.GetExplorationStats()
+	if err != nil {
+		showExplorationMessage(app, fmt.Sprintf("Error reading exploration progress: %v", err))
+		return nil
+	}
+	if stats.TotalSectors == 0 {
+		showExplorationMessage(app, "No sectors seen yet in this game.")
+		return nil
+	}
+
+	showExplorationMessage(app, explorationSummary(stats))
+	return nil
+}
+
+// explorationSummary draws a progress bar of the sectors visited or holo scanned, followed by
+// the count for each exploration status
+func explorationSummary(stats coreapi.ExplorationStats) string {
+	percent := stats.PercentHolo()
+	filled := int(percent * explorationBarWidth / 100)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", explorationBarWidth-filled)
+
+	return fmt.Sprintf("%s %.1f%%\n\n"+
+		"Visited or holo scanned: %d\n"+
+		"Density scanned: %d\n"+
+		"Warps calculated: %d\n"+
+		"Unexplored: %d\n"+
+		"Total sectors: %d",
+		bar, percent, stats.Holo, stats.Density, stats.Calculated, stats.Unexplored, stats.TotalSectors)
+}
+
+// showExplorationMessage shows the exploration progress or an error
+func showExplorationMessage(app AppInterface, message string) {
+	app.ShowModal("Exploration", message, []string{"OK"},
+		func(buttonIndex int, buttonLabel string) {
+			app.CloseModal()
+		})
+}
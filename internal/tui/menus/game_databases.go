@@ -0,0 +1,79 @@
+package menus
+
+import (
+	"fmt"
+	"twist/internal/log"
+	"twist/internal/tui/components"
+)
+
+// activeDatabaseSuffix marks the loaded database in the game database list
+const activeDatabaseSuffix = " (active)"
+
+// handleGameDatabases lists this server's game databases and switches to the one picked
+func (s *SessionMenu) handleGameDatabases(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showGameDatabaseMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	databases, err := proxyAPI.ListGameDatabases()
+	if err != nil {
+		showGameDatabaseMessage(app, fmt.Sprintf("Error listing game databases: %v", err))
+		return nil
+	}
+	if len(databases) == 0 {
+		showGameDatabaseMessage(app, "No game databases for this server yet.\n\nUse New Game Database to create one.")
+		return nil
+	}
+
+	items := make([]string, len(databases))
+	names := make(map[string]string, len(databases))
+	for i, db := range databases {
+		items[i] = db.Name
+		if db.IsActive {
+			items[i] += activeDatabaseSuffix
+		}
+		names[items[i]] = db.Name
+	}
+
+	app.ShowListModal("Game Databases", items, func(selected string) {
+		name := names[selected]
+		log.Info("SessionMenu: opening game database", "name", name)
+		if err := proxyAPI.OpenGameDatabase(name); err != nil {
+			showGameDatabaseMessage(app, fmt.Sprintf("Could not open %s: %v", name, err))
+		}
+	})
+	return nil
+}
+
+// handleNewGameDatabase asks for a game name and creates a database for it
+func (s *SessionMenu) handleNewGameDatabase(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showGameDatabaseMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	dialog := components.NewTextInputDialog("New Game Database", "Game name:",
+		func(gameName string) {
+			app.CloseModal()
+			log.Info("SessionMenu: creating game database", "game", gameName)
+			if err := proxyAPI.CreateGameDatabase(gameName); err != nil {
+				showGameDatabaseMessage(app, fmt.Sprintf("Could not create game database: %v", err))
+			}
+		},
+		func() {
+			app.CloseModal()
+		})
+	app.ShowInputDialog("text-input-dialog", dialog)
+	return nil
+}
+
+// showGameDatabaseMessage shows a game database result or error
+func showGameDatabaseMessage(app AppInterface, message string) {
+	app.ShowModal("Game Databases", message, []string{"OK"},
+		func(buttonIndex int, buttonLabel string) {
+			app.CloseModal()
+		})
+}
@@ -53,28 +53,9 @@ func (h *HelpMenu) HandleMenuAction(action string, app AppInterface) error {
 	}
 }
 
-// handleKeyboardShortcuts shows the keyboard shortcuts help
+// handleKeyboardShortcuts shows the help overlay, which starts with the keyboard shortcuts
 func (h *HelpMenu) handleKeyboardShortcuts(app AppInterface) error {
-	helpText := "TWIST Terminal Interface - Keyboard Shortcuts\n\n" +
-		"Menu Navigation:\n" +
-		"Alt+S = Session menu\n" +
-		"Alt+V = View menu\n" +
-		"Alt+T = Terminal menu\n" +
-		"Alt+H = Help menu\n\n" +
-		"Quick Actions:\n" +
-		"Alt+C = Connect to server\n" +
-		"Alt+D = Disconnect from server\n" +
-		"Alt+Q = Quit application\n\n" +
-		"Function Keys:\n" +
-		"F1 = Show this help screen\n" +
-		"ESC = Close dialogs and menus\n\n" +
-		"Global Keys:\n" +
-		"Ctrl+C = Exit application"
-
-	app.ShowModal("Keyboard Shortcuts", helpText, []string{"Close"},
-		func(buttonIndex int, buttonLabel string) {
-			app.CloseModal()
-		})
+	app.ShowHelp()
 	return nil
 }
 
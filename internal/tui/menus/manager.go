@@ -27,6 +27,7 @@ type AppInterface interface {
 	Disconnect()
 	Exit()
 	ShowConnectionDialog()
+	ShowProfileManager() // Connection profiles to connect to, add, edit or delete
 
 	// Panel management
 	ShowPanels()
@@ -35,10 +36,19 @@ type AppInterface interface {
 
 	// Terminal operations
 	ClearTerminal()
+	ReplayTerminal(lines []string) // Clears the terminal and shows the lines, which may hold ANSI codes
+
+	// Theme
+	ReloadTheme() error // Re-reads the user's theme file and recolors the UI
+	CycleTheme() string // Switches to the next theme and returns its name
+
+	// Help
+	ShowHelp() // Shows the searchable help for keys, menus and scripting
 
 	// Modal management
 	ShowModal(title, text string, buttons []string, callback func(int, string))
-	ShowInputDialog(pageName string, dialog interface{}) // For showing custom input dialogs
+	ShowInputDialog(pageName string, dialog interface{})               // For showing custom input dialogs
+	ShowListModal(title string, items []string, callback func(string)) // Modal closes before callback runs
 	CloseModal()
 
 	// Terminal info for dynamic sizing
@@ -89,6 +99,12 @@ func (mm *MenuManager) HandleMenuAction(menuName, action string, app AppInterfac
 	return handler.HandleMenuAction(action, app)
 }
 
+// SetShortcut sets the shortcut shown for a menu, or for one of its items if itemLabel is
+// not empty
+func (mm *MenuManager) SetShortcut(menuName, itemLabel, shortcut string) {
+	mm.registry.SetShortcut(menuName, itemLabel, shortcut)
+}
+
 // GetMenuItems returns menu items for a specific menu
 func (mm *MenuManager) GetMenuItems(menuName string) []twistComponents.MenuItem {
 	return mm.registry.GetMenuItems(menuName)
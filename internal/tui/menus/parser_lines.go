@@ -0,0 +1,65 @@
+package menus
+
+import (
+	"fmt"
+	"strconv"
+	coreapi "twist/internal/api"
+)
+
+// parserLineWidth is how wide the display state column is in the parser lines list
+const parserLineWidth = 8
+
+// handleParserLines lists the last lines the parser handled with the display state each left
+// it in, newest first; picking one shows the line as received, ANSI codes and all
+func (t *TerminalMenu) handleParserLines(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showParserLinesMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	lines, err := proxyAPI.GetRecentParsedLines()
+	if err != nil {
+		showParserLinesMessage(app, fmt.Sprintf("Error reading parser lines: %v", err))
+		return nil
+	}
+	if len(lines) == 0 {
+		showParserLinesMessage(app, "No lines parsed yet.")
+		return nil
+	}
+
+	items := make([]string, len(lines))
+	byItem := make(map[string]coreapi.ParsedLineInfo, len(lines))
+	for i, line := range lines {
+		// Numbered so identical lines stay distinct in the list
+		item := formatParserLine(len(lines)-i, line)
+		items[len(lines)-1-i] = item
+		byItem[item] = line
+	}
+
+	app.ShowListModal("Parser Lines", items, func(selected string) {
+		if line, ok := byItem[selected]; ok {
+			showParserLinesMessage(app, parserLineDetail(line))
+		}
+	})
+	return nil
+}
+
+// formatParserLine renders a parsed line for the list as "age  display  text"
+func formatParserLine(age int, line coreapi.ParsedLineInfo) string {
+	return fmt.Sprintf("%2d  %-*s  %s", age, parserLineWidth, line.Display, line.Stripped)
+}
+
+// parserLineDetail shows a parsed line as received, with escapes visible, and as matched
+func parserLineDetail(line coreapi.ParsedLineInfo) string {
+	return fmt.Sprintf("Display: %s\n\nStripped (%d chars):\n%s\n\nRaw:\n%s",
+		line.Display, len(line.Stripped), strconv.Quote(line.Stripped), strconv.Quote(line.Raw))
+}
+
+// showParserLinesMessage shows a parsed line's detail or an error
+func showParserLinesMessage(app AppInterface, message string) {
+	app.ShowModal("Parser Lines", message, []string{"OK"},
+		func(buttonIndex int, buttonLabel string) {
+			app.CloseModal()
+		})
+}
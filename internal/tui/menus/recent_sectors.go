@@ -0,0 +1,61 @@
+package menus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"twist/internal/log"
+)
+
+// currentSectorSuffix marks the sector the player is in at the top of the recent sectors list
+const currentSectorSuffix = " (current)"
+
+// handleRecentSectors lists the sectors the player was last in and moves back to the one picked
+// by typing its number at the Command prompt; the game asks before engaging the autopilot
+func (v *ViewMenu) handleRecentSectors(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showRecentSectorsMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	sectors, err := proxyAPI.GetRecentSectors()
+	if err != nil {
+		showRecentSectorsMessage(app, fmt.Sprintf("Error reading recent sectors: %v", err))
+		return nil
+	}
+	if len(sectors) == 0 {
+		showRecentSectorsMessage(app, "No sectors visited yet this session.")
+		return nil
+	}
+
+	items := make([]string, len(sectors))
+	for i, sector := range sectors {
+		items[i] = strconv.Itoa(sector)
+	}
+	items[0] += currentSectorSuffix
+
+	app.ShowListModal("Recent Sectors", items, func(selected string) {
+		if strings.HasSuffix(selected, currentSectorSuffix) {
+			return
+		}
+		// Anywhere else the number would be typed into an unrelated game prompt
+		if !proxyAPI.IsAtCommandPrompt() {
+			showRecentSectorsMessage(app, "Go to the Command prompt to move back to a recent sector.")
+			return
+		}
+		log.Info("ViewMenu: moving back to recent sector", "sector", selected)
+		if err := proxyAPI.SendData([]byte(selected + "\r")); err != nil {
+			showRecentSectorsMessage(app, fmt.Sprintf("Could not move to sector %s: %v", selected, err))
+		}
+	})
+	return nil
+}
+
+// showRecentSectorsMessage shows a recent sectors result or error
+func showRecentSectorsMessage(app AppInterface, message string) {
+	app.ShowModal("Recent Sectors", message, []string{"OK"},
+		func(buttonIndex int, buttonLabel string) {
+			app.CloseModal()
+		})
+}
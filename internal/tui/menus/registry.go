@@ -52,11 +52,17 @@ func (mr *MenuRegistry) initializeMenus() {
 			Items: []twistComponents.MenuItem{
 				{Label: "Connect", Shortcut: "Alt+C", CreatesModal: true},
 				{Label: "Disconnect", Shortcut: "Alt+D"},
+				{Label: "Connection Profiles", Shortcut: "", CreatesModal: true},
+				{Label: "Game Databases", Shortcut: "", CreatesModal: true},
+				{Label: "New Game Database", Shortcut: "", CreatesModal: true},
 				{Label: "Quit", Shortcut: "Alt+Q"},
 			},
 			ItemEnabledChecks: []MenuItemEnabledChecker{
 				isNotConnectedCheck, // Connect enabled when not connected
 				isConnectedCheck,    // Disconnect enabled when connected
+				alwaysEnabled,       // Profiles can be managed, or switched to, at any time
+				isConnectedCheck,    // Game databases belong to the connected server
+				isConnectedCheck,    // New game database is created for the connected server
 				alwaysEnabled,       // Quit always enabled
 			},
 			Handler: NewSessionMenu(),
@@ -66,9 +72,19 @@ func (mr *MenuRegistry) initializeMenus() {
 			Shortcut: "Alt+V",
 			Items: []twistComponents.MenuItem{
 				{Label: "Panels", Shortcut: ""},
+				{Label: "Recent Sectors", Shortcut: "", CreatesModal: true},
+				{Label: "Exploration", Shortcut: "", CreatesModal: true},
+				{Label: "Session Stats", Shortcut: "", CreatesModal: true},
+				{Label: "Reload Theme", Shortcut: ""},
+				{Label: "Next Theme", Shortcut: "F12"},
 			},
 			ItemEnabledChecks: []MenuItemEnabledChecker{
 				isConnectedCheck, // Panels only make sense when connected
+				isConnectedCheck, // Recent sectors are this connection's
+				isConnectedCheck, // Exploration is counted in the connected game's database
+				isConnectedCheck, // Session stats are this connection's
+				alwaysEnabled,    // Theme file can be reloaded any time
+				alwaysEnabled,    // Themes can be switched any time
 			},
 			Handler: NewViewMenu(),
 		},
@@ -92,9 +108,13 @@ func (mr *MenuRegistry) initializeMenus() {
 			Shortcut: "Alt+T",
 			Items: []twistComponents.MenuItem{
 				{Label: "Clear", Shortcut: ""},
+				{Label: "Redraw Recent Output", Shortcut: ""},
+				{Label: "Parser Lines", Shortcut: "", CreatesModal: true},
 			},
 			ItemEnabledChecks: []MenuItemEnabledChecker{
-				alwaysEnabled, // Terminal clear always works
+				alwaysEnabled,    // Terminal clear always works
+				isConnectedCheck, // Recent output is kept by the connected proxy
+				isConnectedCheck, // Parsed lines are kept by the connected proxy's parser
 			},
 			Handler: NewTerminalMenu(),
 		},
@@ -138,6 +158,24 @@ func (mr *MenuRegistry) GetMenuConfig(name string) *MenuConfig {
 	return nil
 }
 
+// SetShortcut sets the shortcut shown for a menu, or for one of its items if itemLabel is
+// not empty
+func (mr *MenuRegistry) SetShortcut(menuName, itemLabel, shortcut string) {
+	config := mr.GetMenuConfig(menuName)
+	if config == nil {
+		return
+	}
+	if itemLabel == "" {
+		config.Shortcut = shortcut
+		return
+	}
+	for i := range config.Items {
+		if config.Items[i].Label == itemLabel {
+			config.Items[i].Shortcut = shortcut
+		}
+	}
+}
+
 // GetMenuHandler returns the handler for a specific menu
 func (mr *MenuRegistry) GetMenuHandler(name string) MenuHandler {
 	config := mr.GetMenuConfig(name)
@@ -16,7 +16,7 @@ func NewSessionMenu() *SessionMenu {
 // ActionCreatesModal implements ModalAwareMenuHandler
 func (s *SessionMenu) ActionCreatesModal(action string) bool {
 	switch action {
-	case "Connect", "Recent Connections", "Save Session":
+	case "Connect", "Recent Connections", "Save Session", "Game Databases", "New Game Database":
 		return true // These actions create modal dialogs
 	default:
 		return false
@@ -29,6 +29,9 @@ func (s *SessionMenu) GetMenuItems() []twistComponents.MenuItem {
 		{Label: "Connect", Shortcut: ""},
 		{Label: "Recent Connections", Shortcut: ""},
 		{Label: "Disconnect", Shortcut: ""},
+		{Label: "Connection Profiles", Shortcut: ""},
+		{Label: "Game Databases", Shortcut: ""},
+		{Label: "New Game Database", Shortcut: ""},
 		{Label: "Save Session", Shortcut: ""},
 		{Label: "Quit", Shortcut: "Alt+Q"},
 	}
@@ -49,6 +52,12 @@ func (s *SessionMenu) HandleMenuAction(action string, app AppInterface) error {
 		return s.handleRecentConnections(app)
 	case "Disconnect":
 		return s.handleDisconnect(app)
+	case "Connection Profiles":
+		return s.handleConnectionProfiles(app)
+	case "Game Databases":
+		return s.handleGameDatabases(app)
+	case "New Game Database":
+		return s.handleNewGameDatabase(app)
 	case "Save Session":
 		return s.handleSaveSession(app)
 	case "Quit":
@@ -82,6 +91,12 @@ func (s *SessionMenu) handleDisconnect(app AppInterface) error {
 	return nil
 }
 
+// handleConnectionProfiles shows the saved connection profiles
+func (s *SessionMenu) handleConnectionProfiles(app AppInterface) error {
+	app.ShowProfileManager()
+	return nil
+}
+
 // handleSaveSession saves the current session (not implemented yet)
 func (s *SessionMenu) handleSaveSession(app AppInterface) error {
 	app.ShowModal("Save Session",
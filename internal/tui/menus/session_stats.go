@@ -0,0 +1,41 @@
+package menus
+
+import (
+	"fmt"
+	coreapi "twist/internal/api"
+)
+
+// handleSessionStats shows what the player has done since connecting or switching games
+func (v *ViewMenu) handleSessionStats(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		showSessionStatsMessage(app, "Not connected to proxy. Please connect first.")
+		return nil
+	}
+
+	stats, err := proxyAPI.GetSessionStats()
+	if err != nil {
+		showSessionStatsMessage(app, fmt.Sprintf("Error reading session stats: %v", err))
+		return nil
+	}
+
+	showSessionStatsMessage(app, sessionStatsSummary(stats))
+	return nil
+}
+
+// sessionStatsSummary lists the session's counts, with credits signed so a loss reads as one
+func sessionStatsSummary(stats coreapi.SessionStats) string {
+	return fmt.Sprintf("Turns spent: %d\n"+
+		"Credits: %+d\n"+
+		"Sectors explored: %d\n"+
+		"Ports discovered: %d",
+		stats.TurnsSpent, stats.CreditsChange, stats.SectorsExplored, stats.PortsDiscovered)
+}
+
+// showSessionStatsMessage shows the session stats or an error
+func showSessionStatsMessage(app AppInterface, message string) {
+	app.ShowModal("Session Stats", message, []string{"OK"},
+		func(buttonIndex int, buttonLabel string) {
+			app.CloseModal()
+		})
+}
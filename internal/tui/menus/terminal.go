@@ -17,6 +17,8 @@ func NewTerminalMenu() *TerminalMenu {
 func (t *TerminalMenu) GetMenuItems() []twistComponents.MenuItem {
 	return []twistComponents.MenuItem{
 		{Label: "Clear", Shortcut: ""},
+		{Label: "Redraw Recent Output", Shortcut: ""},
+		{Label: "Parser Lines", Shortcut: ""},
 		{Label: "Scroll Up", Shortcut: ""},
 		{Label: "Scroll Down", Shortcut: ""},
 		{Label: "Copy Selection", Shortcut: ""},
@@ -34,6 +36,10 @@ func (t *TerminalMenu) HandleMenuAction(action string, app AppInterface) error {
 	switch action {
 	case "Clear":
 		return t.handleClear(app)
+	case "Redraw Recent Output":
+		return t.handleRedraw(app)
+	case "Parser Lines":
+		return t.handleParserLines(app)
 	case "Scroll Up":
 		return t.handleScrollUp(app)
 	case "Scroll Down":
@@ -53,6 +59,23 @@ func (t *TerminalMenu) handleClear(app AppInterface) error {
 	return nil
 }
 
+// handleRedraw clears the terminal and shows the last lines from the server again in their
+// original colors, for when the screen has been cleared or garbled
+func (t *TerminalMenu) handleRedraw(app AppInterface) error {
+	proxyAPI := app.GetProxyAPI()
+	if proxyAPI == nil {
+		return nil
+	}
+	lines, err := proxyAPI.GetRecentANSILines()
+	if err != nil {
+		log.Warn("TerminalMenu: Could not read recent output", "error", err)
+		return nil
+	}
+	app.ReplayTerminal(lines)
+	log.Info("TerminalMenu: Redrew recent output", "lines", len(lines))
+	return nil
+}
+
 // handleScrollUp scrolls terminal up (not implemented yet)
 func (t *TerminalMenu) handleScrollUp(app AppInterface) error {
 	app.ShowModal("Scroll Up",
@@ -1,8 +1,10 @@
 package menus
 
 import (
+	"fmt"
 	twistComponents "twist/internal/components"
 	"twist/internal/log"
+	"twist/internal/theme"
 )
 
 // ViewMenu handles View menu actions
@@ -21,6 +23,11 @@ func (v *ViewMenu) GetMenuItems() []twistComponents.MenuItem {
 		{Label: "Zoom Out", Shortcut: ""},
 		{Label: "Full Screen", Shortcut: ""},
 		{Label: "Panels", Shortcut: ""},
+		{Label: "Recent Sectors", Shortcut: ""},
+		{Label: "Exploration", Shortcut: ""},
+		{Label: "Session Stats", Shortcut: ""},
+		{Label: "Reload Theme", Shortcut: ""},
+		{Label: "Next Theme", Shortcut: "F12"},
 	}
 }
 
@@ -43,6 +50,16 @@ func (v *ViewMenu) HandleMenuAction(action string, app AppInterface) error {
 		return v.handleFullScreen(app)
 	case "Panels":
 		return v.handlePanels(app)
+	case "Recent Sectors":
+		return v.handleRecentSectors(app)
+	case "Exploration":
+		return v.handleExploration(app)
+	case "Session Stats":
+		return v.handleSessionStats(app)
+	case "Reload Theme":
+		return v.handleReloadTheme(app)
+	case "Next Theme":
+		return v.handleNextTheme(app)
 	default:
 		log.Info("ViewMenu: Unknown action", "action", action)
 		return nil
@@ -106,3 +123,23 @@ func (v *ViewMenu) handlePanels(app AppInterface) error {
 	}
 	return nil
 }
+
+// handleReloadTheme re-reads the theme file so color changes show without a restart
+func (v *ViewMenu) handleReloadTheme(app AppInterface) error {
+	if err := app.ReloadTheme(); err != nil {
+		app.ShowModal("Reload Theme",
+			fmt.Sprintf("Could not load %s:\n\n%v", theme.DefaultThemeFile, err),
+			[]string{"OK"},
+			func(buttonIndex int, buttonLabel string) {
+				app.CloseModal()
+			})
+	}
+	return nil
+}
+
+// handleNextTheme switches to the next bundled or user theme
+func (v *ViewMenu) handleNextTheme(app AppInterface) error {
+	name := app.CycleTheme()
+	log.Info("ViewMenu: Theme switched", "theme", name)
+	return nil
+}
@@ -0,0 +1,201 @@
+// Package profiles stores the servers a user connects to, each with its own game database
+package profiles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"twist/internal/api"
+)
+
+// DefaultFile is the profiles file read from and saved to the working directory
+const DefaultFile = "twist_profiles.json"
+
+// fileMode keeps the file private to the user, since profiles hold passwords
+const fileMode = 0600
+
+// Default ports of profiles that don't give one: telnet, which TLS usually fronts, or SSH
+const (
+	DefaultPort    = 23
+	DefaultSSHPort = 22
+)
+
+// Profile is a saved server connection
+type Profile struct {
+	Name   string `json:"name"`
+	Host   string `json:"host"`
+	Port   int    `json:"port,omitempty"`
+	Game   string `json:"game,omitempty"`   // Game database to use; empty detects the game from the server's menus
+	Script string `json:"script,omitempty"` // Script to run once connected
+
+	// Transport is "tcp" (the default), "tls" or "ssh". SSH logs in as User with Password,
+	// KeyFile, or both, the password unlocking the key.
+	Transport  string `json:"transport,omitempty"`
+	User       string `json:"user,omitempty"`
+	Password   string `json:"password,omitempty"`
+	KeyFile    string `json:"key_file,omitempty"`
+	SkipVerify bool   `json:"skip_verify,omitempty"` // Accept any TLS certificate or SSH host key
+}
+
+// Address returns the host:port to connect to
+func (p Profile) Address() string {
+	port := p.Port
+	if port == 0 {
+		port = DefaultPort
+		if p.Transport == api.TransportSSH {
+			port = DefaultSSHPort
+		}
+	}
+	return net.JoinHostPort(p.Host, strconv.Itoa(port))
+}
+
+// Validate checks that the profile can be saved and connected to
+func (p Profile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("profile name is empty")
+	}
+	if strings.TrimSpace(p.Host) == "" {
+		return fmt.Errorf("profile %q has no host", p.Name)
+	}
+	if p.Port < 0 || p.Port > 65535 {
+		return fmt.Errorf("profile %q has invalid port %d", p.Name, p.Port)
+	}
+	switch p.Transport {
+	case "", api.TransportTCP, api.TransportTLS:
+	case api.TransportSSH:
+		if p.User == "" || (p.Password == "" && p.KeyFile == "") {
+			return fmt.Errorf("profile %q needs a user and a password or key file for SSH", p.Name)
+		}
+	default:
+		return fmt.Errorf("profile %q has unknown transport %q", p.Name, p.Transport)
+	}
+	return nil
+}
+
+// TransportOptions returns how to connect to the profile's server
+func (p Profile) TransportOptions() api.TransportOptions {
+	return api.TransportOptions{
+		Kind:       p.Transport,
+		SkipVerify: p.SkipVerify,
+		User:       p.User,
+		Password:   p.Password,
+		KeyFile:    p.KeyFile,
+	}
+}
+
+// Profiles is the list of saved profiles and the file they are kept in
+type Profiles struct {
+	path     string
+	profiles []Profile
+}
+
+// Load reads a profiles file. A missing file gives an empty list that is created on the
+// first save.
+func Load(path string) (*Profiles, error) {
+	ps := &Profiles{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ps, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Tighten a file written before profiles were kept private
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != fileMode {
+		if err := os.Chmod(path, fileMode); err != nil {
+			return nil, fmt.Errorf("failed to restrict profiles %s: %w", path, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, &ps.profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles %s: %w", path, err)
+	}
+	for _, p := range ps.profiles {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid profiles %s: %w", path, err)
+		}
+	}
+	return ps, nil
+}
+
+// All returns the profiles in the order they were added
+func (ps *Profiles) All() []Profile {
+	return append([]Profile(nil), ps.profiles...)
+}
+
+// Get returns the named profile
+func (ps *Profiles) Get(name string) (Profile, bool) {
+	if i := ps.index(name); i >= 0 {
+		return ps.profiles[i], true
+	}
+	return Profile{}, false
+}
+
+// Save adds a profile, or replaces the one named previousName when editing, and writes the
+// file. Names must be unique.
+func (ps *Profiles) Save(p Profile, previousName string) error {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Host = strings.TrimSpace(p.Host)
+	p.Game = strings.TrimSpace(p.Game)
+	p.Script = strings.TrimSpace(p.Script)
+	p.User = strings.TrimSpace(p.User)
+	p.KeyFile = strings.TrimSpace(p.KeyFile)
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	existing := ps.index(previousName)
+	if i := ps.index(p.Name); i >= 0 && i != existing {
+		return fmt.Errorf("a profile named %q already exists", p.Name)
+	}
+
+	profiles := ps.All()
+	if existing >= 0 {
+		profiles[existing] = p
+	} else {
+		profiles = append(profiles, p)
+	}
+	return ps.write(profiles)
+}
+
+// Delete removes the named profile and writes the file
+func (ps *Profiles) Delete(name string) error {
+	i := ps.index(name)
+	if i < 0 {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	profiles := append(ps.All()[:i], ps.profiles[i+1:]...)
+	return ps.write(profiles)
+}
+
+// index returns the position of the named profile, or -1
+func (ps *Profiles) index(name string) int {
+	for i, p := range ps.profiles {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// write saves profiles to the file and makes them the current list once written
+func (ps *Profiles) write(profiles []Profile) error {
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ps.path, append(data, '\n'), fileMode); err != nil {
+		return fmt.Errorf("failed to save profiles %s: %w", ps.path, err)
+	}
+	// WriteFile only applies the mode when it creates the file
+	if err := os.Chmod(ps.path, fileMode); err != nil {
+		return fmt.Errorf("failed to restrict profiles %s: %w", ps.path, err)
+	}
+	ps.profiles = profiles
+	return nil
+}
@@ -0,0 +1,153 @@
+package profiles
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	p := Profile{Name: "Home", Host: "twgs.example.com"}
+	if p.Address() != "twgs.example.com:23" {
+		t.Errorf("Expected the default port, got %s", p.Address())
+	}
+
+	p.Port = 2002
+	if p.Address() != "twgs.example.com:2002" {
+		t.Errorf("Unexpected address %s", p.Address())
+	}
+
+	ssh := Profile{Name: "Hosted", Host: "ssh.example.com", Transport: "ssh", User: "trader", KeyFile: "id_ed25519"}
+	if err := ssh.Validate(); err != nil {
+		t.Errorf("Expected the SSH profile to be valid: %v", err)
+	}
+	if ssh.Address() != "ssh.example.com:22" {
+		t.Errorf("Expected the SSH port, got %s", ssh.Address())
+	}
+	if opts := ssh.TransportOptions(); opts.Kind != "ssh" || opts.User != "trader" || opts.KeyFile != "id_ed25519" {
+		t.Errorf("Unexpected transport options %+v", opts)
+	}
+
+	for _, invalid := range []Profile{
+		{Host: "twgs.example.com"},
+		{Name: "Home"},
+		{Name: "Home", Host: "twgs.example.com", Port: 70000},
+		{Name: "Home", Host: "twgs.example.com", Transport: "udp"},
+		{Name: "Home", Host: "twgs.example.com", Transport: "ssh", User: "trader"},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
+
+func TestLoadSaveDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+
+	ps, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of a missing file failed: %v", err)
+	}
+	if len(ps.All()) != 0 {
+		t.Fatalf("Expected no profiles, got %+v", ps.All())
+	}
+
+	if err := ps.Save(Profile{Name: " Home ", Host: "home.example.com", Script: "login.ts"}, ""); err != nil {
+		t.Fatalf("Save(Home) failed: %v", err)
+	}
+	if err := ps.Save(Profile{Name: "Away", Host: "away.example.com", Port: 2002}, ""); err != nil {
+		t.Fatalf("Save(Away) failed: %v", err)
+	}
+	if err := ps.Save(Profile{Name: "Away", Host: "other.example.com"}, ""); err == nil {
+		t.Error("Expected a duplicate name to be rejected")
+	}
+	if err := ps.Save(Profile{Name: "Away", Host: "other.example.com"}, "Home"); err == nil {
+		t.Error("Expected renaming onto another profile to be rejected")
+	}
+
+	// Editing replaces the profile in place, even when renamed
+	if err := ps.Save(Profile{Name: "House", Host: "home.example.com", Game: "Main"}, "Home"); err != nil {
+		t.Fatalf("Save(House) failed: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	all := reloaded.All()
+	if len(all) != 2 || all[0].Name != "House" || all[0].Game != "Main" || all[1].Port != 2002 {
+		t.Fatalf("Unexpected profiles after reload: %+v", all)
+	}
+	if _, ok := reloaded.Get("Home"); ok {
+		t.Error("Expected the old name to be gone")
+	}
+
+	if err := reloaded.Delete("House"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := reloaded.Delete("House"); err == nil {
+		t.Error("Expected deleting a missing profile to fail")
+	}
+	if away, ok := reloaded.Get("Away"); !ok || len(reloaded.All()) != 1 || away.Host != "away.example.com" {
+		t.Errorf("Expected only Away to remain, got %+v", reloaded.All())
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"broken.json": `[{"name": "Home"`,
+		"nohost.json": `[{"name": "Home"}]`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write profiles: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: expected Load to fail", name)
+		}
+	}
+}
+
+func TestFileIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+	dir := t.TempDir()
+
+	// New files are created private
+	created := filepath.Join(dir, "new.json")
+	ps, err := Load(created)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := ps.Save(Profile{Name: "Home", Host: "home.example.com", Password: "secret"}, ""); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	assertMode(t, created, 0600)
+
+	// An existing world-readable file is tightened when loaded
+	existing := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(existing, []byte(`[{"name": "Home", "host": "home.example.com"}]`), 0644); err != nil {
+		t.Fatalf("Failed to write profiles: %v", err)
+	}
+	if err := os.Chmod(existing, 0644); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if _, err := Load(existing); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	assertMode(t, existing, 0600)
+}
+
+func assertMode(t *testing.T, path string, want os.FileMode) {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if got := info.Mode().Perm(); got != want {
+		t.Errorf("Expected %s to have mode %o, got %o", filepath.Base(path), want, got)
+	}
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"sync"
+	"time"
+	coreapi "twist/internal/api"
+)
+
+// DefaultSectorUpdateCoalesceInterval is how long sector events are batched before the panels update
+const DefaultSectorUpdateCoalesceInterval = 100 * time.Millisecond
+
+// sectorUpdateBatch holds the coalesced events from one window
+type sectorUpdateBatch struct {
+	current *coreapi.SectorInfo  // Latest current sector info, nil if none arrived
+	sectors []coreapi.SectorInfo // Latest info per updated sector
+	warps   map[int][6]int       // Latest warps per sector from warp-only updates
+	events  int                  // Number of events folded into this batch
+}
+
+// sectorUpdateCoalescer collects sector events and flushes them once per interval.
+// A change of current sector flushes immediately so movement never feels delayed.
+type sectorUpdateCoalescer struct {
+	mu            sync.Mutex
+	interval      time.Duration
+	flushFunc     func(sectorUpdateBatch)
+	timer         *time.Timer
+	currentSector int
+	ready         []sectorUpdateBatch // Batches taken but not yet handed to flushFunc
+	delivering    bool                // A goroutine is draining ready
+
+	current *coreapi.SectorInfo
+	sectors map[int]coreapi.SectorInfo
+	warps   map[int][6]int
+	events  int
+}
+
+// newSectorUpdateCoalescer creates a coalescer that hands each batch to flushFunc
+func newSectorUpdateCoalescer(interval time.Duration, flushFunc func(sectorUpdateBatch)) *sectorUpdateCoalescer {
+	return &sectorUpdateCoalescer{
+		interval:  interval,
+		flushFunc: flushFunc,
+		sectors:   make(map[int]coreapi.SectorInfo),
+		warps:     make(map[int][6]int),
+	}
+}
+
+// SetInterval changes the coalescing window. Zero or negative disables coalescing.
+func (c *sectorUpdateCoalescer) SetInterval(interval time.Duration) {
+	c.mu.Lock()
+	c.interval = interval
+	c.mu.Unlock()
+}
+
+// AddCurrentSector records a current sector event, flushing immediately if the sector changed
+func (c *sectorUpdateCoalescer) AddCurrentSector(sectorInfo coreapi.SectorInfo) {
+	c.mu.Lock()
+	changed := sectorInfo.Number != c.currentSector
+	c.currentSector = sectorInfo.Number
+	c.current = &sectorInfo
+	c.events++
+	c.scheduleLocked(changed)
+}
+
+// AddSectorUpdate records a sector data event
+func (c *sectorUpdateCoalescer) AddSectorUpdate(sectorInfo coreapi.SectorInfo) {
+	c.mu.Lock()
+	c.sectors[sectorInfo.Number] = sectorInfo
+	c.events++
+	c.scheduleLocked(false)
+}
+
+// AddSectorWarps records a warp-only sector event
+func (c *sectorUpdateCoalescer) AddSectorWarps(sector int, warps [6]int) {
+	c.mu.Lock()
+	c.warps[sector] = warps
+	c.events++
+	c.scheduleLocked(false)
+}
+
+// scheduleLocked starts the window timer or flushes now. Must be called with mu held; releases it.
+func (c *sectorUpdateCoalescer) scheduleLocked(immediate bool) {
+	if immediate || c.interval <= 0 {
+		if c.timer != nil {
+			c.timer.Stop()
+			c.timer = nil
+		}
+		c.deliverLocked(c.takeLocked())
+		return
+	}
+
+	// The window starts at the first event, so a continuous stream still flushes every interval
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.interval, c.flush)
+	}
+	c.mu.Unlock()
+}
+
+// flush applies everything collected in the current window
+func (c *sectorUpdateCoalescer) flush() {
+	c.mu.Lock()
+	c.timer = nil
+	if c.events == 0 {
+		c.mu.Unlock()
+		return
+	}
+	c.deliverLocked(c.takeLocked())
+}
+
+// deliverLocked queues batch and hands queued batches to flushFunc outside mu, so flushFunc
+// may block (QueueUpdateDraw) or call back into the coalescer. Only one goroutine drains
+// at a time, which keeps batches in order. Must be called with mu held; releases it.
+func (c *sectorUpdateCoalescer) deliverLocked(batch sectorUpdateBatch) {
+	c.ready = append(c.ready, batch)
+	if c.delivering {
+		// The goroutine already draining picks this batch up
+		c.mu.Unlock()
+		return
+	}
+	c.delivering = true
+
+	for len(c.ready) > 0 {
+		batches := c.ready
+		c.ready = nil
+		c.mu.Unlock()
+
+		for _, b := range batches {
+			c.flushFunc(b)
+		}
+
+		c.mu.Lock()
+	}
+	c.delivering = false
+	c.mu.Unlock()
+}
+
+// takeLocked moves the pending events into a batch. Must be called with mu held.
+func (c *sectorUpdateCoalescer) takeLocked() sectorUpdateBatch {
+	batch := sectorUpdateBatch{
+		current: c.current,
+		sectors: make([]coreapi.SectorInfo, 0, len(c.sectors)),
+		warps:   c.warps,
+		events:  c.events,
+	}
+	for _, sectorInfo := range c.sectors {
+		batch.sectors = append(batch.sectors, sectorInfo)
+	}
+
+	c.current = nil
+	c.sectors = make(map[int]coreapi.SectorInfo)
+	c.warps = make(map[int][6]int)
+	c.events = 0
+	return batch
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"sync"
+	"testing"
+	"time"
+	coreapi "twist/internal/api"
+)
+
+// recordBatches returns a flush function that records every batch it receives
+func recordBatches() (func(sectorUpdateBatch), func() []sectorUpdateBatch) {
+	var mu sync.Mutex
+	var batches []sectorUpdateBatch
+	record := func(batch sectorUpdateBatch) {
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}
+	get := func() []sectorUpdateBatch {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]sectorUpdateBatch(nil), batches...)
+	}
+	return record, get
+}
+
+func TestSectorUpdateCoalescerBatchesBurst(t *testing.T) {
+	record, batches := recordBatches()
+	coalescer := newSectorUpdateCoalescer(20*time.Millisecond, record)
+
+	// Simulate a CIM dump: many sector and warp events in quick succession
+	for i := 1; i <= 200; i++ {
+		coalescer.AddSectorUpdate(coreapi.SectorInfo{Number: i})
+		coalescer.AddSectorWarps(i, [6]int{i + 1})
+	}
+	coalescer.AddSectorUpdate(coreapi.SectorInfo{Number: 5, Beacon: "latest"})
+
+	time.Sleep(60 * time.Millisecond)
+
+	got := batches()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 coalesced batch, got %d", len(got))
+	}
+	if got[0].events != 401 {
+		t.Errorf("Expected 401 events in batch, got %d", got[0].events)
+	}
+	if len(got[0].sectors) != 200 || len(got[0].warps) != 200 {
+		t.Errorf("Expected 200 sectors and 200 warp updates, got %d and %d", len(got[0].sectors), len(got[0].warps))
+	}
+	for _, sectorInfo := range got[0].sectors {
+		if sectorInfo.Number == 5 && sectorInfo.Beacon != "latest" {
+			t.Errorf("Expected latest info for sector 5 to win, got beacon %q", sectorInfo.Beacon)
+		}
+	}
+}
+
+func TestSectorUpdateCoalescerFlushesOnSectorChange(t *testing.T) {
+	record, batches := recordBatches()
+	coalescer := newSectorUpdateCoalescer(time.Hour, record)
+
+	coalescer.AddSectorUpdate(coreapi.SectorInfo{Number: 10})
+	coalescer.AddCurrentSector(coreapi.SectorInfo{Number: 1})
+
+	got := batches()
+	if len(got) != 1 {
+		t.Fatalf("Expected sector change to flush immediately, got %d batches", len(got))
+	}
+	if got[0].current == nil || got[0].current.Number != 1 || len(got[0].sectors) != 1 {
+		t.Errorf("Expected batch with current sector 1 and pending sector 10, got %+v", got[0])
+	}
+
+	// Repeated events for the same current sector are held for the window
+	coalescer.AddCurrentSector(coreapi.SectorInfo{Number: 1})
+	if len(batches()) != 1 {
+		t.Error("Expected same-sector event to be coalesced, not flushed")
+	}
+}
+
+func TestSectorUpdateCoalescerFlushesOutsideLock(t *testing.T) {
+	var coalescer *sectorUpdateCoalescer
+	var order []int
+	coalescer = newSectorUpdateCoalescer(time.Hour, func(batch sectorUpdateBatch) {
+		order = append(order, batch.current.Number)
+		// A flush that raises another sector change must not deadlock; its batch follows this one
+		if batch.current.Number == 1 {
+			coalescer.AddCurrentSector(coreapi.SectorInfo{Number: 2})
+			if len(order) != 1 {
+				t.Error("Expected nested flush to be delivered after the current one returns")
+			}
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		coalescer.AddCurrentSector(coreapi.SectorInfo{Number: 1})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Flush deadlocked calling back into the coalescer")
+	}
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Errorf("Expected batches for sectors 1 then 2, got %v", order)
+	}
+}
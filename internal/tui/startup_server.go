@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"twist/internal/tui/profiles"
+)
+
+// SetStartupServer connects to host and port as soon as the TUI starts, instead of offering
+// the profiles or connect dialog. An empty host leaves the dialog; port 0 uses the telnet port.
+func (ta *TwistApp) SetStartupServer(host string, port int) error {
+	address, err := startupAddress(host, port)
+	if err != nil {
+		return err
+	}
+	ta.startupAddress = address
+	return nil
+}
+
+// startupAddress checks the server given on the command line and returns its host:port,
+// or an empty address if no host was given
+func startupAddress(host string, port int) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		if port != 0 {
+			return "", errors.New("-port needs -host")
+		}
+		return "", nil
+	}
+	if strings.ContainsAny(host, " \t/") || strings.Count(host, ":") == 1 {
+		return "", fmt.Errorf("invalid host %q: give the port with -port", host)
+	}
+	if port < 0 || port > 65535 {
+		return "", fmt.Errorf("invalid port %d: use 1 to 65535", port)
+	}
+	if port == 0 {
+		port = profiles.DefaultPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
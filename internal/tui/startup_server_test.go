@@ -0,0 +1,31 @@
+package tui
+
+import "testing"
+
+func TestStartupAddress(t *testing.T) {
+	for _, tt := range []struct {
+		host    string
+		port    int
+		want    string
+		wantErr bool
+	}{
+		{host: "", port: 0, want: ""},
+		{host: "twgs.example.com", port: 2002, want: "twgs.example.com:2002"},
+		{host: " twgs.example.com ", port: 0, want: "twgs.example.com:23"},
+		{host: "::1", port: 2002, want: "[::1]:2002"},
+		{host: "", port: 2002, wantErr: true},
+		{host: "twgs.example.com:2002", port: 0, wantErr: true},
+		{host: "twgs example", port: 23, wantErr: true},
+		{host: "twgs.example.com", port: 70000, wantErr: true},
+		{host: "twgs.example.com", port: -1, wantErr: true},
+	} {
+		got, err := startupAddress(tt.host, tt.port)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q port %d: expected error %v, got %v", tt.host, tt.port, tt.wantErr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q port %d: expected %q, got %q", tt.host, tt.port, tt.want, got)
+		}
+	}
+}
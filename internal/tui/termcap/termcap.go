@@ -0,0 +1,99 @@
+// Package termcap detects what the terminal can display, so components ask one place
+// instead of each guessing
+package termcap
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/BourgeoisBear/rasterm"
+
+	"twist/internal/log"
+)
+
+// Capabilities are what the terminal was found to support
+type Capabilities struct {
+	Sixel      bool   // Can draw sixel graphics
+	Source     string // How it was decided, for the log: "TERM", "device attributes" or "default"
+	CellWidth  int    // Pixels per character cell as the terminal reported them, 0 if unknown
+	CellHeight int
+}
+
+var (
+	mutex   sync.RWMutex
+	current = Capabilities{Sixel: true, Source: "default"}
+)
+
+// Current returns the detected capabilities. Until Detect runs, sixels are assumed, as
+// they were before detection existed.
+func Current() Capabilities {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// Detect probes the terminal, logs what it supports and stores it for Current. It must run
+// before the screen starts, as the terminal's answer is read from its input.
+func Detect() Capabilities {
+	caps := probe(os.Getenv("TERM"), rasterm.IsSixelCapable, requestCellSize)
+	mutex.Lock()
+	current = caps
+	mutex.Unlock()
+	return caps
+}
+
+// probe works out the capabilities for a TERM value, asking the terminal for its device
+// attributes unless TERM names one known to lack sixels, and for its cell size if it can
+// draw sixels
+func probe(term string, askSixel func() (bool, error), askCellSize func() (int, int, error)) Capabilities {
+	switch term {
+	case "", "dumb", "linux", "vt100":
+		log.Info("Terminal: Sixels not supported", "term", term, "source", "TERM")
+		return Capabilities{Source: "TERM"}
+	}
+
+	sixel, err := askSixel()
+	if err != nil {
+		log.Info("Terminal: Could not ask the terminal about sixel support", "term", term, "error", err)
+		return Capabilities{Source: "device attributes"}
+	}
+	caps := Capabilities{Sixel: sixel, Source: "device attributes"}
+	if sixel {
+		if width, height, err := askCellSize(); err == nil {
+			caps.CellWidth, caps.CellHeight = width, height
+		} else {
+			log.Info("Terminal: Cell size not reported, estimating it", "term", term, "error", err)
+		}
+	}
+	log.Info("Terminal: Capabilities detected", "term", term, "sixel", sixel, "cell_width", caps.CellWidth, "cell_height", caps.CellHeight, "source", "device attributes")
+	return caps
+}
+
+// cellSizeReport matches the answer to CSI 16 t: CSI 6 ; height ; width t
+var cellSizeReport = regexp.MustCompile(`\x1b\[6;(\d+);(\d+)t`)
+
+// requestCellSize asks the terminal how many pixels a character cell is
+func requestCellSize() (int, int, error) {
+	response, err := rasterm.TermRequestResponse(os.Stdin, os.Stdout, "\x1b[16t")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseCellSizeReport(response)
+}
+
+// parseCellSizeReport reads the cell width and height from the terminal's answer to CSI 16 t
+func parseCellSizeReport(response []byte) (int, int, error) {
+	match := cellSizeReport.FindSubmatch(response)
+	if match == nil {
+		return 0, 0, fmt.Errorf("unexpected cell size report %q", response)
+	}
+	height, _ := strconv.Atoi(string(match[1]))
+	width, _ := strconv.Atoi(string(match[2]))
+	if width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("empty cell size report %q", response)
+	}
+	return width, height, nil
+}
@@ -0,0 +1,55 @@
+package termcap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+	asked := false
+	yes := func() (bool, error) { asked = true; return true, nil }
+	noCellSize := func() (int, int, error) { return 0, 0, errors.New("timed out") }
+
+	if caps := probe("linux", yes, noCellSize); caps.Sixel || asked {
+		t.Error("Expected the Linux console to have no sixels without asking")
+	}
+	if caps := probe("xterm-256color", yes, noCellSize); !caps.Sixel || caps.Source != "device attributes" {
+		t.Errorf("Expected sixels from the device attributes, got %+v", caps)
+	}
+	if caps := probe("xterm", func() (bool, error) { return false, nil }, noCellSize); caps.Sixel {
+		t.Error("Expected no sixels when the terminal doesn't report them")
+	}
+	if caps := probe("xterm", func() (bool, error) { return true, errors.New("timed out") }, noCellSize); caps.Sixel {
+		t.Error("Expected no sixels when the terminal doesn't answer")
+	}
+}
+
+func TestProbeCellSize(t *testing.T) {
+	yes := func() (bool, error) { return true, nil }
+	cellSize := func() (int, int, error) { return 10, 20, nil }
+
+	if caps := probe("xterm-256color", yes, cellSize); caps.CellWidth != 10 || caps.CellHeight != 20 {
+		t.Errorf("Expected a 10x20 cell, got %+v", caps)
+	}
+	if caps := probe("xterm", func() (bool, error) { return false, nil }, cellSize); caps.CellWidth != 0 {
+		t.Errorf("Expected no cell size without sixels, got %+v", caps)
+	}
+
+	for response, want := range map[string][2]int{
+		"\x1b[6;20;10t":           {10, 20},
+		"\x1b[?62;4c\x1b[6;17;8t": {8, 17},
+		"\x1b[6;0;0t":             {0, 0},
+		"\x1b[4;600;800t":         {0, 0},
+	} {
+		width, height, err := parseCellSizeReport([]byte(response))
+		if width != want[0] || height != want[1] || (err == nil) != (want[0] > 0) {
+			t.Errorf("%q: expected %v, got %dx%d (%v)", response, want, width, height, err)
+		}
+	}
+}
+
+func TestCurrentDefaultsToSixel(t *testing.T) {
+	if caps := Current(); !caps.Sixel || caps.Source != "default" {
+		t.Errorf("Expected sixels assumed before detection, got %+v", caps)
+	}
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"twist/internal/tui/components"
+)
+
+// Titles for the terminal search dialog, depending on whether the text was found
+const (
+	searchTitle         = " Search Terminal "
+	searchNotFoundTitle = " Search Terminal - not found "
+)
+
+// SetScrollbackLines sets how many lines of game output the terminal keeps for scrolling
+// back and searching. Zero or less uses the default.
+func (ta *TwistApp) SetScrollbackLines(lines int) {
+	ta.terminalComponent.SetScrollbackLimit(lines)
+}
+
+// SetTerminalTimestamps prefixes each line of game output with the local time in format,
+// a Go time layout such as "15:04:05". An empty format leaves lines unstamped.
+func (ta *TwistApp) SetTerminalTimestamps(format string) {
+	ta.terminalComponent.SetTimestampFormat(format)
+}
+
+// showTerminalSearch asks for text to find in the terminal's scrollback, jumping to the
+// latest match as it is typed. OK keeps the match highlighted for search next and previous;
+// cancelling clears it.
+func (ta *TwistApp) showTerminalSearch() {
+	ta.terminalComponent.BeginSearch()
+
+	dialog := components.NewTextInputDialog("Search Terminal", "Find:",
+		func(string) {
+			ta.closeModal()
+		},
+		func() {
+			ta.terminalComponent.ClearSearch()
+			ta.closeModal()
+		})
+	dialog.SetChangedFunc(func(text string) {
+		title := searchTitle
+		if !ta.terminalComponent.Search(text) && text != "" {
+			title = searchNotFoundTitle
+		}
+		dialog.GetForm().SetTitle(title)
+	})
+	ta.ShowInputDialog("text-input-dialog", dialog)
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"twist/internal/log"
+	"twist/internal/theme"
+)
+
+// loadUserTheme switches to the user's theme file, if there is one, then to the theme
+// chosen last time. A broken file leaves the built-in theme in place.
+func loadUserTheme() {
+	manager := theme.GetThemeManager()
+	if err := manager.LoadThemeFile(theme.DefaultThemeFile); err != nil {
+		log.Warn("TwistApp: Could not load user theme", "file", theme.DefaultThemeFile, "error", err)
+	}
+
+	name, err := theme.LoadChoice(theme.DefaultChoiceFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("TwistApp: Could not read theme choice", "file", theme.DefaultChoiceFile, "error", err)
+		}
+	} else if err := manager.SetTheme(name); err != nil {
+		log.Warn("TwistApp: Chosen theme is no longer available", "theme", name, "error", err)
+	}
+	log.Info("TwistApp: Theme loaded", "theme", theme.Current().Name())
+}
+
+// ReloadTheme re-reads the user's theme file and recolors the UI with it, so colors can be
+// tweaked without restarting. On error the current theme is kept.
+func (ta *TwistApp) ReloadTheme() error {
+	if err := theme.GetThemeManager().LoadThemeFile(theme.DefaultThemeFile); err != nil {
+		log.Warn("TwistApp: Could not reload theme", "file", theme.DefaultThemeFile, "error", err)
+		return err
+	}
+
+	ta.applyTheme()
+	log.Info("TwistApp: Theme reloaded", "theme", theme.Current().Name())
+	return nil
+}
+
+// CycleTheme switches to the next theme (telix, light, high-contrast, then the user's theme
+// file if loaded) and remembers it for the next launch. Returns the new theme's name.
+func (ta *TwistApp) CycleTheme() string {
+	name := theme.GetThemeManager().CycleTheme().Name()
+	ta.applyTheme()
+
+	if err := theme.SaveChoice(theme.DefaultChoiceFile, name); err != nil {
+		log.Warn("TwistApp: Could not save theme choice", "file", theme.DefaultChoiceFile, "error", err)
+	}
+	log.Info("TwistApp: Theme switched", "theme", name)
+	return name
+}
+
+// applyTheme recolors the UI from the current theme. The sector map drops its images,
+// which have the old background baked in, and is generated again.
+func (ta *TwistApp) applyTheme() {
+	background := theme.Current().DefaultColors().Background
+	ta.pages.SetBackgroundColor(background)
+	ta.mainGrid.SetBackgroundColor(background)
+	ta.terminalComponent.ApplyTheme()
+	ta.statusComponent.UpdateStatus()
+	ta.panelComponent.ApplyTheme()
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -20,11 +22,19 @@ var (
 	date    = "unknown"
 )
 
+// runningApp is the application once it is created, for the signal handler to close its
+// database before exiting
+var runningApp atomic.Pointer[tui.TwistApp]
+
 func main() {
-	// Set up global panic handler first
+	// Set up global panic handler first. It only sees panics on the main goroutine; one on
+	// another goroutine ends the process without closing the database.
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error("GLOBAL PANIC recovered", "error", r, "stack", string(debug.Stack()))
+			if app := runningApp.Load(); app != nil {
+				app.CloseDatabase()
+			}
 			fmt.Fprintf(os.Stderr, "Application crashed. See twist_debug.log for details.\n")
 			os.Exit(1)
 		}
@@ -42,6 +52,9 @@ func main() {
 		sig := <-signalChan
 		log.Error("SIGNAL RECEIVED", "signal", sig.String(), "stack", string(debug.Stack()))
 		fmt.Fprintf(os.Stderr, "Application received signal %s. See twist_debug.log for details.\n", sig.String())
+		if app := runningApp.Load(); app != nil {
+			app.CloseDatabase()
+		}
 		os.Exit(1)
 	}()
 
@@ -53,6 +66,37 @@ func main() {
 		}
 	}()
 
+	serverDB := flag.Bool("server-db", false, "start one game database per server if no game is detected by the game's command prompt")
+	staleDays := flag.Int("stale-days", 7, "flag sectors whose data is older than this many days on the map and sector display (0 to disable)")
+	pruneHours := flag.Int("prune-hours", 0, "clear traders, ships and foreign fighters from sectors not seen for this many hours when a game loads (0 to keep them)")
+	scrollback := flag.Int("scrollback", 5000, "lines of game output the terminal keeps for scrolling back and searching")
+	timestamps := flag.Bool("timestamps", false, "prefix each line of game output in the terminal with the local time")
+	timestampFormat := flag.String("timestamp-format", "15:04:05", "Go time layout of the -timestamps prefix")
+	tradePricing := flag.String("trade-pricing", "", "JSON file of base prices and margin for trade profit estimates, for servers with a tweaked economy (default twist_trade_pricing.json if present)")
+	portClasses := flag.String("port-classes", "", "JSON file of trade pattern to port class, for servers with custom port classes (default twist_port_classes.json if present)")
+	unrecognizedPrompts := flag.String("unrecognized-prompts", "", "file to append prompts no parser or game detection pattern recognizes to, as samples for custom detection patterns (default off)")
+	mapMode := flag.String("map", "auto", "how to draw the sector map: sixel, text, or auto to use sixels if the terminal supports them")
+	mapCoalesce := flag.Duration("map-coalesce", tui.DefaultSectorUpdateCoalesceInterval, "how long sector updates from scans and probes are collected before the map is redrawn once for all of them (0 to redraw on every update)")
+	checkpointInterval := flag.Duration("checkpoint-interval", 5*time.Second, "how often to copy the game database's write-ahead log into the database file (negative to leave it to SQLite)")
+	displayWatchdog := flag.Int("display-watchdog", 500, "lines the parser lets a screen such as a CIM report run without saving anything before it stops treating lines as part of it (0 to disable)")
+	host := flag.String("host", "", "server to connect to at startup instead of showing the connect dialog")
+	port := flag.Int("port", 0, "port of the -host server (default 23)")
+	gameLetter := flag.String("game", "", "letter of the game to select at the TWGS game selection prompt")
+	reconnect := flag.Int("reconnect", 0, "times to try reconnecting when the server drops the connection, running the login script again (0 to disable)")
+	reconnectInterval := flag.Duration("reconnect-interval", 2*time.Second, "wait before the first reconnect attempt, doubling after each failed one up to a minute")
+	parseSession := flag.String("parse", "", "parse a captured session file (- for standard input) into a game database and exit, without the TUI")
+	parseDB := flag.String("db", "", "database -parse writes to (default the session file's name with .db, or parsed.db)")
+	flag.Parse()
+
+	// Parse a captured session headless, which needs no terminal
+	if *parseSession != "" {
+		if err := runParse(*parseSession, *parseDB); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Check if we have a proper TTY
 	if !isatty.IsTerminal(os.Stdout.Fd()) {
 		fmt.Println("Trade Wars 2002 Client")
@@ -62,15 +106,36 @@ func main() {
 	}
 
 	// Get script name from command line arguments (default to empty string)
-	var scriptName string
-	if len(os.Args) > 1 {
-		scriptName = os.Args[1]
-	}
+	scriptName := flag.Arg(0)
 
 	// Initialize and run the tview application
 	app := tui.NewApplication()
 	app.SetVersionInfo(version, commit, date)
 	app.SetInitialScript(scriptName)
+	app.SetServerDatabaseFallback(*serverDB)
+	app.SetStaleSectorThreshold(time.Duration(*staleDays) * 24 * time.Hour)
+	app.SetCheckpointInterval(*checkpointInterval)
+	app.SetPruneVolatileAfter(time.Duration(*pruneHours) * time.Hour)
+	app.SetDisplayWatchdogLines(*displayWatchdog)
+	app.SetGameLetter(*gameLetter)
+	app.SetReconnect(*reconnect, *reconnectInterval)
+	app.SetScrollbackLines(*scrollback)
+	app.SetTradePricingFile(*tradePricing)
+	app.SetPortClassesFile(*portClasses)
+	app.SetUnrecognizedPromptsFile(*unrecognizedPrompts)
+	app.SetSectorUpdateCoalesceInterval(*mapCoalesce)
+	if *timestamps {
+		app.SetTerminalTimestamps(*timestampFormat)
+	}
+	if err := app.SetSectorMapMode(*mapMode); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := app.SetStartupServer(*host, *port); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	runningApp.Store(app)
 	if err := app.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"twist/internal/proxy/database"
+	"twist/internal/proxy/streaming"
+)
+
+// parseDatabasePath returns the database -parse writes to: dbPath if given, otherwise the
+// session file's name with a .db extension, or parsed.db for standard input
+func parseDatabasePath(sessionPath, dbPath string) string {
+	if dbPath != "" {
+		return dbPath
+	}
+	if sessionPath == "-" {
+		return "parsed.db"
+	}
+	return strings.TrimSuffix(sessionPath, filepath.Ext(sessionPath)) + ".db"
+}
+
+// runParse parses a captured session, or standard input if sessionPath is "-", into a game
+// database without starting the TUI, and reports what the database holds afterwards
+func runParse(sessionPath, dbPath string) error {
+	var input io.Reader = os.Stdin
+	if sessionPath != "-" {
+		file, err := os.Open(sessionPath)
+		if err != nil {
+			return fmt.Errorf("failed to open session: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	dbPath = parseDatabasePath(sessionPath, dbPath)
+	db := database.NewDatabase()
+	if err := db.CreateDatabase(dbPath); err != nil {
+		if err := db.OpenDatabase(dbPath); err != nil {
+			return fmt.Errorf("failed to open database %s: %w", dbPath, err)
+		}
+	}
+	defer db.CloseDatabase()
+
+	bytesRead, err := streaming.ParseLog(input, db)
+	if err != nil {
+		return err
+	}
+
+	sectors, _ := db.CountSectors()
+	ports, _ := db.CountPorts()
+	fmt.Printf("Parsed %d bytes into %s: %d sectors, %d ports\n", bytesRead, dbPath, sectors, ports)
+	return nil
+}